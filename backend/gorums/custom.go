@@ -0,0 +1,86 @@
+package gorums
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/relab/gorums"
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/internal/proto/hotstuffpb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// MessageHandler handles a custom message that was sent by the replica with the given ID.
+type MessageHandler func(sender hotstuff.ID, msg proto.Message)
+
+// RegisterMessageHandler registers a handler for custom messages with the same type as msg.
+// The handler is executed by the event loop, like the handlers of the consensus messages.
+// Only one handler can be registered per message type, and the handlers must be registered before the server is started.
+func (srv *Server) RegisterMessageHandler(msg proto.Message, handler MessageHandler) {
+	srv.handlers[msg.ProtoReflect().Descriptor().FullName()] = handler
+}
+
+// Custom handles an incoming custom message.
+func (srv *Server) Custom(ctx gorums.ServerCtx, pb *hotstuffpb.CustomMessage) {
+	id, err := srv.getClientID(ctx)
+	if err != nil {
+		srv.mods.Logger().Infof("Failed to get client ID: %v", err)
+		return
+	}
+	srv.markSeen(id)
+
+	msg, err := pb.GetMessage().UnmarshalNew()
+	if err != nil {
+		srv.mods.Logger().Infof("Failed to unmarshal custom message from replica %d: %v", id, err)
+		return
+	}
+
+	handler, ok := srv.handlers[msg.ProtoReflect().Descriptor().FullName()]
+	if !ok {
+		srv.mods.Logger().Infof("No handler for custom message of type %s from replica %d", msg.ProtoReflect().Descriptor().FullName(), id)
+		return
+	}
+	srv.mods.EventLoop().AddEvent(func() { handler(id, msg) })
+}
+
+// SendMessage sends a custom message to the replica with the given ID.
+// The message type must have a handler registered on the server of the receiving replica.
+func (cfg *Config) SendMessage(id hotstuff.ID, msg proto.Message) error {
+	replica, ok := cfg.Replicas()[id].(*gorumsReplica)
+	if !ok || replica.node == nil {
+		return fmt.Errorf("replica %d is not connected", id)
+	}
+	pb, err := customMessage(msg)
+	if err != nil {
+		return err
+	}
+	replica.node.Custom(context.Background(), pb, gorums.WithNoSendWaiting())
+	return nil
+}
+
+// Broadcast sends a custom message to all other replicas in the configuration.
+func (cfg *Config) Broadcast(msg proto.Message) error {
+	pb, err := customMessage(msg)
+	if err != nil {
+		return err
+	}
+	for _, replica := range cfg.Replicas() {
+		if r, ok := replica.(*gorumsReplica); ok && r.node != nil {
+			r.node.Custom(context.Background(), pb, gorums.WithNoSendWaiting())
+		}
+	}
+	return nil
+}
+
+func customMessage(msg proto.Message) (*hotstuffpb.CustomMessage, error) {
+	wrapped, err := anypb.New(msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode custom message: %w", err)
+	}
+	return &hotstuffpb.CustomMessage{Message: wrapped}, nil
+}
+
+// messageHandlers maps the names of custom message types to their handlers.
+type messageHandlers map[protoreflect.FullName]MessageHandler
@@ -6,6 +6,7 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"net"
+	"strconv"
 	"sync"
 	"testing"
 	"time"
@@ -15,11 +16,17 @@ import (
 	"github.com/relab/hotstuff"
 	"github.com/relab/hotstuff/config"
 	"github.com/relab/hotstuff/consensus"
+	ecdsacrypto "github.com/relab/hotstuff/crypto/ecdsa"
 	"github.com/relab/hotstuff/crypto/keygen"
 	"github.com/relab/hotstuff/eventloop"
+	"github.com/relab/hotstuff/internal/proto/hotstuffpb"
 	"github.com/relab/hotstuff/internal/testutil"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 func TestConnect(t *testing.T) {
@@ -28,7 +35,7 @@ func TestConnect(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		td := setup(t, ctrl, n)
 		builder := testutil.TestModules(t, ctrl, 1, td.keys[0])
-		teardown := createServers(t, td, ctrl)
+		_, teardown := createServers(t, td, ctrl)
 		defer teardown()
 		td.builders.Build()
 
@@ -46,6 +53,150 @@ func TestConnect(t *testing.T) {
 	runBoth(t, run)
 }
 
+// TestWitness checks that witnesses are not counted in the quorum size.
+func TestWitness(t *testing.T) {
+	const n = 4
+	ctrl := gomock.NewController(t)
+	td := setupReplicas(t, ctrl, n)
+	td.cfg.Replicas[4].Witness = true
+	cfg, teardown := createConfig(t, td, ctrl)
+	defer teardown()
+	td.builders[0].Register(cfg)
+	td.builders.Build()
+
+	// the three replicas that vote form a quorum on their own
+	if got, want := cfg.QuorumSize(), hotstuff.QuorumSize(n-1); got != want {
+		t.Errorf("wrong quorum size: got: %d, want: %d", got, want)
+	}
+	for id, replica := range cfg.Replicas() {
+		if consensus.IsWitness(replica) != (id == 4) {
+			t.Errorf("replica %d: got witness: %v, want: %v", id, consensus.IsWitness(replica), id == 4)
+		}
+	}
+}
+
+// TestWitnessSignatures checks that the signatures of witnesses do not count toward the quorum
+// when certificates are verified.
+func TestWitnessSignatures(t *testing.T) {
+	const n = 4
+	ctrl := gomock.NewController(t)
+	td := setupReplicas(t, ctrl, n)
+	td.cfg.Replicas[4].Witness = true
+	cfg, teardown := createConfig(t, td, ctrl)
+	defer teardown()
+	td.builders[0].Register(cfg)
+	hl := td.builders.Build()
+	signers := hl.Signers()
+
+	block := consensus.NewBlock(consensus.GetGenesis().Hash(), consensus.QuorumCert{}, "foo", 1, 1)
+	qc := func(ids ...hotstuff.ID) consensus.QuorumCert {
+		return signedQC(t, cfg, block, signers, ids...)
+	}
+
+	if !hl[0].Crypto().VerifyQuorumCert(qc(1, 2, 3)) {
+		t.Error("a QC signed by a quorum of voters was invalid")
+	}
+	if hl[0].Crypto().VerifyQuorumCert(qc(1, 2, 4)) {
+		t.Error("a QC that needs the signature of a witness to reach a quorum was valid")
+	}
+	if !hl[0].Crypto().VerifyQuorumCert(qc(1, 2, 3, 4)) {
+		t.Error("a QC signed by a quorum of voters and a witness was invalid")
+	}
+}
+
+// TestFaultModel checks that the quorum size follows the configured fault model.
+func TestFaultModel(t *testing.T) {
+	const n = 5
+	for _, tt := range []struct {
+		model hotstuff.FaultModel
+		want  int
+	}{{hotstuff.Byzantine, 4}, {hotstuff.CrashOnly, 3}} {
+		ctrl := gomock.NewController(t)
+		td := setupReplicas(t, ctrl, n)
+		cfg, teardown := createConfig(t, td, ctrl)
+		td.builders[0].Options().SetFaultModel(tt.model)
+		td.builders[0].Register(cfg)
+		td.builders.Build()
+		if got := cfg.QuorumSize(); got != tt.want {
+			t.Errorf("fault model %d: wrong quorum size: got: %d, want: %d", tt.model, got, tt.want)
+		}
+		teardown()
+	}
+}
+
+// TestFetchSubset checks that FetchFrom reuses the configuration for a subset of replicas, regardless of their order.
+func TestFetchSubset(t *testing.T) {
+	const n = 4
+	ctrl := gomock.NewController(t)
+	td := setupReplicas(t, ctrl, n)
+	cfg, teardown := createConfig(t, td, ctrl)
+	defer teardown()
+	td.builders[0].Register(cfg)
+	td.builders.Build()
+
+	first, err := cfg.subset([]hotstuff.ID{3, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := cfg.subset([]hotstuff.ID{2, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first != second {
+		t.Error("a new configuration was created for the same subset of replicas")
+	}
+	if got := first.Size(); got != 2 {
+		t.Errorf("wrong configuration size: got: %d, want: 2", got)
+	}
+	wider, err := cfg.subset([]hotstuff.ID{2, 3, 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wider == first {
+		t.Error("the same configuration was used for different subsets of replicas")
+	}
+}
+
+func TestKeepalive(t *testing.T) {
+	params := keepaliveParams(15*time.Second, 5*time.Second)
+	if params.Time != 15*time.Second || params.Timeout != 5*time.Second || !params.PermitWithoutStream {
+		t.Errorf("wrong keepalive parameters: %+v", params)
+	}
+	policy := keepalivePolicy(15 * time.Second)
+	// the server must permit pings before the interval has passed, such that early pings are not rejected.
+	if policy.MinTime >= params.Time || !policy.PermitWithoutStream {
+		t.Errorf("wrong keepalive policy: %+v", policy)
+	}
+
+	run := func(t *testing.T, setup setupFunc) {
+		const n = 4
+		ctrl := gomock.NewController(t)
+		td := setup(t, ctrl, n)
+		builder := testutil.TestModules(t, ctrl, 1, td.keys[0])
+		servers := make([]*Server, td.n)
+		for i := range servers {
+			servers[i] = NewServer(
+				gorums.WithGRPCServerOptions(grpc.Creds(td.cfg.Creds)),
+				WithKeepalivePolicy(15*time.Second),
+			)
+			servers[i].StartOnListener(td.listeners[i])
+			td.builders[i].Register(servers[i])
+			defer servers[i].Stop()
+		}
+		td.builders.Build()
+
+		cfg := NewConfig(td.cfg.ID, td.cfg.Creds, gorums.WithDialTimeout(time.Second), WithKeepalive(15*time.Second, 5*time.Second))
+		builder.Register(cfg)
+		builder.Build()
+
+		if err := cfg.Connect(&td.cfg); err != nil {
+			t.Error(err)
+		}
+		cfg.Close()
+	}
+	runBoth(t, run)
+}
+
 // testBase is a generic test for a unicast/multicast call
 func testBase(t *testing.T, typ interface{}, send func(consensus.Configuration), handle eventloop.EventHandler) {
 	run := func(t *testing.T, setup setupFunc) {
@@ -119,6 +270,145 @@ func TestTimeout(t *testing.T) {
 
 }
 
+func TestAckProposal(t *testing.T) {
+	var wg sync.WaitGroup
+	want := consensus.ProposalAckMsg{ID: 1, BlockHash: consensus.GetGenesis().Hash()}
+	testBase(t, want, func(cfg consensus.Configuration) {
+		wg.Add(3)
+		for id, replica := range cfg.Replicas() {
+			if id != want.ID {
+				replica.AckProposal(want.BlockHash)
+			}
+		}
+		wg.Wait()
+	}, func(event interface{}) {
+		got := event.(consensus.ProposalAckMsg)
+		if got.ID != want.ID {
+			t.Errorf("wrong id in acknowledgment: got: %d, want: %d", got.ID, want.ID)
+		}
+		if got.BlockHash != want.BlockHash {
+			t.Error("block hashes do not match")
+		}
+		wg.Done()
+	})
+}
+
+// TestCustomMessage checks that custom messages are delivered to the handlers registered for their type.
+func TestCustomMessage(t *testing.T) {
+	run := func(t *testing.T, setup setupFunc) {
+		const n = 4
+		ctrl := gomock.NewController(t)
+		td := setup(t, ctrl, n)
+		servers, serverTeardown := createServers(t, td, ctrl)
+		defer serverTeardown()
+
+		var wg sync.WaitGroup
+		want := "foo"
+		for _, srv := range servers[1:] {
+			srv.RegisterMessageHandler(&wrapperspb.StringValue{}, func(sender hotstuff.ID, msg proto.Message) {
+				if sender != 1 {
+					t.Errorf("wrong sender: got: %d, want: 1", sender)
+				}
+				if got := msg.(*wrapperspb.StringValue).GetValue(); got != want {
+					t.Errorf("wrong message: got: %q, want: %q", got, want)
+				}
+				wg.Done()
+			})
+		}
+
+		cfg := NewConfig(td.cfg.ID, td.cfg.Creds, gorums.WithDialTimeout(time.Second))
+		if err := cfg.Connect(&td.cfg); err != nil {
+			t.Fatal(err)
+		}
+		defer cfg.Close()
+		td.builders[0].Register(cfg)
+		hl := td.builders.Build()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		for _, hs := range hl[1:] {
+			go hs.Run(ctx)
+		}
+
+		// messages without a handler are ignored
+		if err := cfg.Broadcast(&wrapperspb.Int64Value{Value: 1}); err != nil {
+			t.Fatal(err)
+		}
+
+		wg.Add(n - 1)
+		if err := cfg.Broadcast(&wrapperspb.StringValue{Value: want}); err != nil {
+			t.Fatal(err)
+		}
+		wg.Wait()
+
+		wg.Add(1)
+		if err := cfg.SendMessage(2, &wrapperspb.StringValue{Value: want}); err != nil {
+			t.Fatal(err)
+		}
+		wg.Wait()
+
+		if err := cfg.SendMessage(1, &wrapperspb.StringValue{Value: want}); err == nil {
+			t.Error("expected an error when sending a message to the local replica")
+		}
+	}
+	runBoth(t, run)
+}
+
+// TestLiveness checks that the configuration records the messages received by the server from each replica,
+// and that replicas are no longer responsive once they have been silent for longer than the threshold.
+func TestLiveness(t *testing.T) {
+	const threshold = 50 * time.Millisecond
+	ctrl := gomock.NewController(t)
+	builder := testutil.TestModules(t, ctrl, 1, testutil.GenerateECDSAKey(t))
+	builder.Options().SetResponsiveThreshold(threshold)
+	cfg := NewConfig(1, nil)
+	srv := NewServer()
+	builder.Register(cfg, srv)
+	mods := builder.Build()
+
+	// from returns the context of a message from the replica with the given ID.
+	from := func(id hotstuff.ID) gorums.ServerCtx {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("id", strconv.Itoa(int(id))))
+		return gorums.ServerCtx{Context: peer.NewContext(ctx, &peer.Peer{})}
+	}
+	// drain handles the events that the server added to the event loop.
+	drain := func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		mods.EventLoop().Run(ctx)
+	}
+
+	// replicas 2 and 3 send messages, but replica 4 is silent.
+	srv.NewView(from(2), &hotstuffpb.SyncInfo{})
+	srv.AckProposal(from(3), &hotstuffpb.BlockHash{})
+	drain()
+
+	for id, want := range map[hotstuff.ID]bool{1: true, 2: true, 3: true, 4: false} {
+		if got := cfg.IsResponsive(id); got != want {
+			t.Errorf("IsResponsive(%d) = %v, want %v", id, got, want)
+		}
+	}
+	if !cfg.LastSeen(4).IsZero() {
+		t.Errorf("LastSeen(4) = %v, want the zero time", cfg.LastSeen(4))
+	}
+
+	// replica 3 keeps sending messages, but replica 2 stops.
+	seen := cfg.LastSeen(2)
+	time.Sleep(2 * threshold)
+	srv.Abstain(from(3), &hotstuffpb.AbstainMsg{})
+	drain()
+
+	if cfg.IsResponsive(2) {
+		t.Error("replica 2 is responsive after being silent for longer than the threshold")
+	}
+	if !cfg.IsResponsive(3) {
+		t.Error("replica 3 is not responsive after sending a message")
+	}
+	if got := cfg.LastSeen(2); !got.Equal(seen) {
+		t.Errorf("LastSeen(2) changed without any messages: got %v, want %v", got, seen)
+	}
+}
+
 type testData struct {
 	n         int
 	cfg       config.ReplicaConfig
@@ -129,6 +419,18 @@ type testData struct {
 
 type setupFunc func(t *testing.T, ctrl *gomock.Controller, n int) testData
 
+// signedQC creates a QC for the block signed by the given replicas, regardless of the quorum size.
+// The signers are identified by their position among the replicas that the configuration has in the view of the block.
+func signedQC(t *testing.T, cfg consensus.Configuration, block *consensus.Block, signers []consensus.Crypto, ids ...hotstuff.ID) consensus.QuorumCert {
+	t.Helper()
+	members := consensus.SortedReplicas(consensus.MembershipAt(cfg, block.View()))
+	sigs := make([]*ecdsacrypto.Signature, 0, len(ids))
+	for _, id := range ids {
+		sigs = append(sigs, testutil.CreatePC(t, block, signers[id-1]).Signature().(*ecdsacrypto.Signature))
+	}
+	return consensus.NewQuorumCert(ecdsacrypto.RestoreThresholdSignature(members, sigs), block.View(), block.Hash())
+}
+
 func setupReplicas(t *testing.T, ctrl *gomock.Controller, n int) testData {
 	t.Helper()
 
@@ -200,9 +502,9 @@ func runBoth(t *testing.T, run func(*testing.T, setupFunc)) {
 	t.Run("WithTLS", func(t *testing.T) { run(t, setupTLS) })
 }
 
-func createServers(t *testing.T, td testData, ctrl *gomock.Controller) (teardown func()) {
+func createServers(t *testing.T, td testData, ctrl *gomock.Controller) (servers []*Server, teardown func()) {
 	t.Helper()
-	servers := make([]*Server, td.n)
+	servers = make([]*Server, td.n)
 	for i := range servers {
 		cfg := td.cfg
 		cfg.ID = hotstuff.ID(i + 1)
@@ -211,7 +513,7 @@ func createServers(t *testing.T, td testData, ctrl *gomock.Controller) (teardown
 		servers[i].StartOnListener(td.listeners[i])
 		td.builders[i].Register(servers[i])
 	}
-	return func() {
+	return servers, func() {
 		for _, srv := range servers {
 			srv.Stop()
 		}
@@ -220,7 +522,7 @@ func createServers(t *testing.T, td testData, ctrl *gomock.Controller) (teardown
 
 func createConfig(t *testing.T, td testData, ctrl *gomock.Controller) (cfg *Config, teardown func()) {
 	t.Helper()
-	serverTeardown := createServers(t, td, ctrl)
+	_, serverTeardown := createServers(t, td, ctrl)
 	cfg = NewConfig(td.cfg.ID, td.cfg.Creds, gorums.WithDialTimeout(time.Second))
 	err := cfg.Connect(&td.cfg)
 	if err != nil {
@@ -8,6 +8,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
+	"sync"
+	"time"
 
 	"github.com/relab/gorums"
 	"github.com/relab/hotstuff"
@@ -26,6 +29,7 @@ type gorumsReplica struct {
 	voteCancel    context.CancelFunc
 	newviewCancel context.CancelFunc
 	reputation    float64
+	witness       bool
 }
 
 // ID returns the replica's ID.
@@ -61,6 +65,32 @@ func (r *gorumsReplica) NewView(msg consensus.SyncInfo) {
 	r.node.NewView(ctx, hotstuffpb.SyncInfoToProto(msg), gorums.WithNoSendWaiting())
 }
 
+// AckProposal tells the other replica that the proposal of the block was received.
+func (r *gorumsReplica) AckProposal(hash consensus.Hash) {
+	if r.node == nil {
+		return
+	}
+	r.node.AckProposal(context.Background(), &hotstuffpb.BlockHash{Hash: hash[:]}, gorums.WithNoSendWaiting())
+}
+
+// Abstain tells the other replica that the proposal of the block was received, but not voted for.
+func (r *gorumsReplica) Abstain(view consensus.View, hash consensus.Hash) {
+	if r.node == nil {
+		return
+	}
+	r.node.Abstain(context.Background(), &hotstuffpb.AbstainMsg{View: uint64(view), Hash: hash[:]}, gorums.WithNoSendWaiting())
+}
+
+// Witness returns true if the replica is a witness, which does not vote.
+func (r *gorumsReplica) Witness() bool {
+	return r.witness
+}
+
+// Connected returns true if the last attempt to communicate with the replica succeeded.
+func (r *gorumsReplica) Connected() bool {
+	return r.node != nil && r.node.LastErr() == nil
+}
+
 func (r *gorumsReplica) UpdateRep(rep float64) {
 	prevRep := r.GetRep()
 	updated := prevRep + rep
@@ -77,10 +107,24 @@ type Config struct {
 	mods *consensus.Modules
 
 	mgr           *hotstuffpb.Manager
-	cfg           *hotstuffpb.Configuration
-	replicas      map[hotstuff.ID]consensus.Replica
+	mgrMut        sync.Mutex                           // serializes the creation of gorums configurations, which is not safe for concurrent use
+	subsets       map[string]*hotstuffpb.Configuration // the configurations used by FetchFrom, protected by mgrMut
 	proposeCancel context.CancelFunc
 	timeoutCancel context.CancelFunc
+
+	// mut protects the set of replicas, which changes when a reconfiguration takes effect.
+	// The replicas map is replaced rather than modified, such that it can be used after the lock is released.
+	mut       sync.RWMutex
+	cfg       *hotstuffpb.Configuration
+	replicas  map[hotstuff.ID]consensus.Replica
+	witnesses int
+	start     consensus.View  // the view in which the current set of replicas took effect
+	history   []membership    // the previous sets of replicas, oldest first
+	view      consensus.View  // the current view of the local replica
+	pending   []pendingChange // reconfigurations that take effect in a later view
+
+	seenMut  sync.Mutex
+	lastSeen map[hotstuff.ID]time.Time // the time that the last message from each replica was received
 }
 
 // InitConsensusModule gives the module a reference to the Modules object.
@@ -93,6 +137,8 @@ func (cfg *Config) InitConsensusModule(mods *consensus.Modules, _ *consensus.Opt
 func NewConfig(id hotstuff.ID, creds credentials.TransportCredentials, opts ...gorums.ManagerOption) *Config {
 	cfg := &Config{
 		replicas:      make(map[hotstuff.ID]consensus.Replica),
+		lastSeen:      make(map[hotstuff.ID]time.Time),
+		subsets:       make(map[string]*hotstuffpb.Configuration),
 		proposeCancel: func() {},
 		timeoutCancel: func() {},
 	}
@@ -121,6 +167,9 @@ func NewConfig(id hotstuff.ID, creds credentials.TransportCredentials, opts ...g
 
 // Connect opens connections to the replicas in the configuration.
 func (cfg *Config) Connect(replicaCfg *config.ReplicaConfig) (err error) {
+	cfg.mut.Lock()
+	defer cfg.mut.Unlock()
+
 	idMapping := make(map[string]uint32, len(replicaCfg.Replicas)-1)
 	for _, replica := range replicaCfg.Replicas {
 		cfg.replicas[replica.ID] = &gorumsReplica{
@@ -129,13 +178,17 @@ func (cfg *Config) Connect(replicaCfg *config.ReplicaConfig) (err error) {
 			newviewCancel: func() {},
 			voteCancel:    func() {},
 			reputation: 	float64(replica.ID),
+			witness:       replica.Witness,
+		}
+		if replica.Witness {
+			cfg.witnesses++
 		}
 		if replica.ID != replicaCfg.ID {
 			idMapping[replica.Address] = uint32(replica.ID)
 		}
 	}
 
-	cfg.cfg, err = cfg.mgr.NewConfiguration(qspec{}, gorums.WithNodeMap(idMapping))
+	cfg.cfg, err = cfg.newConfiguration(gorums.WithNodeMap(idMapping))
 	if err != nil {
 		return fmt.Errorf("failed to create configuration: %w", err)
 	}
@@ -149,58 +202,161 @@ func (cfg *Config) Connect(replicaCfg *config.ReplicaConfig) (err error) {
 	return nil
 }
 
+// newConfiguration creates a gorums configuration with the given nodes.
+// Nodes that the manager does not know are connected to before the configuration is returned.
+func (cfg *Config) newConfiguration(nodes gorums.NodeListOption) (*hotstuffpb.Configuration, error) {
+	cfg.mgrMut.Lock()
+	defer cfg.mgrMut.Unlock()
+	return cfg.mgr.NewConfiguration(qspec{members: hotstuffpb.ConfigurationMembers(cfg)}, nodes)
+}
+
+// subset returns a gorums configuration with the replicas with the given IDs.
+// The configurations are cached, as each block fetch asks the same subsets of replicas.
+func (cfg *Config) subset(ids []hotstuff.ID) (*hotstuffpb.Configuration, error) {
+	nodeIDs := make([]uint32, 0, len(ids))
+	for _, id := range ids {
+		nodeIDs = append(nodeIDs, uint32(id))
+	}
+	sort.Slice(nodeIDs, func(i, j int) bool { return nodeIDs[i] < nodeIDs[j] })
+	key := fmt.Sprint(nodeIDs)
+
+	cfg.mgrMut.Lock()
+	defer cfg.mgrMut.Unlock()
+	if subset, ok := cfg.subsets[key]; ok {
+		return subset, nil
+	}
+	subset, err := cfg.mgr.NewConfiguration(qspec{members: hotstuffpb.ConfigurationMembers(cfg)}, gorums.WithNodeIDs(nodeIDs))
+	if err != nil {
+		return nil, err
+	}
+	cfg.subsets[key] = subset
+	return subset, nil
+}
+
+// markSeen records that a message was received from the replica.
+func (cfg *Config) markSeen(id hotstuff.ID) {
+	cfg.seenMut.Lock()
+	cfg.lastSeen[id] = time.Now()
+	cfg.seenMut.Unlock()
+}
+
+// LastSeen returns the time that the last message from the replica was received,
+// or the zero time if no message has been received from the replica.
+func (cfg *Config) LastSeen(id hotstuff.ID) time.Time {
+	cfg.seenMut.Lock()
+	defer cfg.seenMut.Unlock()
+	return cfg.lastSeen[id]
+}
+
+// IsResponsive returns true if a message has been received from the replica within the ResponsiveThreshold.
+// The local replica is always responsive.
+func (cfg *Config) IsResponsive(id hotstuff.ID) bool {
+	if id == cfg.mods.ID() {
+		return true
+	}
+	lastSeen := cfg.LastSeen(id)
+	if lastSeen.IsZero() {
+		return false
+	}
+	threshold := cfg.mods.Options().ResponsiveThreshold()
+	return threshold <= 0 || time.Since(lastSeen) <= threshold
+}
+
 // Replicas returns all of the replicas in the configuration.
+// The returned map must not be modified.
 func (cfg *Config) Replicas() map[hotstuff.ID]consensus.Replica {
+	cfg.mut.RLock()
+	defer cfg.mut.RUnlock()
 	return cfg.replicas
 }
 
 // Replica returns a replica if it is present in the configuration.
 func (cfg *Config) Replica(id hotstuff.ID) (replica consensus.Replica, ok bool) {
+	cfg.mut.RLock()
+	defer cfg.mut.RUnlock()
 	replica, ok = cfg.replicas[id]
 	return
 }
 
 // Len returns the number of replicas in the configuration.
 func (cfg *Config) Len() int {
+	cfg.mut.RLock()
+	defer cfg.mut.RUnlock()
 	return len(cfg.replicas)
 }
 
-// QuorumSize returns the size of a quorum
+// QuorumSize returns the size of a quorum under the configured fault model.
+// Witnesses do not vote, so they are not counted.
 func (cfg *Config) QuorumSize() int {
-	return hotstuff.QuorumSize(cfg.Len())
+	cfg.mut.RLock()
+	defer cfg.mut.RUnlock()
+	return cfg.quorumSize(cfg.replicas, cfg.witnesses)
+}
+
+// quorumSize returns the size of a quorum of the replicas under the configured fault model.
+func (cfg *Config) quorumSize(replicas map[hotstuff.ID]consensus.Replica, witnesses int) int {
+	return cfg.mods.Options().FaultModel().QuorumSize(len(replicas) - witnesses)
+}
+
+// configuration returns the gorums configuration of the other replicas.
+func (cfg *Config) configuration() *hotstuffpb.Configuration {
+	cfg.mut.RLock()
+	defer cfg.mut.RUnlock()
+	return cfg.cfg
 }
 
 // Propose sends the block to all replicas in the configuration
 func (cfg *Config) Propose(proposal consensus.ProposeMsg) {
-	if cfg.cfg == nil {
+	gorumsCfg := cfg.configuration()
+	if gorumsCfg == nil {
 		return
 	}
 	var ctx context.Context
 	cfg.proposeCancel()
 	ctx, cfg.proposeCancel = context.WithCancel(context.Background())
 	p := hotstuffpb.ProposalToProto(proposal)
-	cfg.cfg.Propose(ctx, p, gorums.WithNoSendWaiting())
+	gorumsCfg.Propose(ctx, p, gorums.WithNoSendWaiting())
 }
 
 // Timeout sends the timeout message to all replicas.
 func (cfg *Config) Timeout(msg consensus.TimeoutMsg) {
-	if cfg.cfg == nil {
+	gorumsCfg := cfg.configuration()
+	if gorumsCfg == nil {
 		return
 	}
 	var ctx context.Context
 	cfg.timeoutCancel()
 	ctx, cfg.timeoutCancel = context.WithCancel(context.Background())
-	cfg.cfg.Timeout(ctx, hotstuffpb.TimeoutMsgToProto(msg), gorums.WithNoSendWaiting())
+	gorumsCfg.Timeout(ctx, hotstuffpb.TimeoutMsgToProto(msg), gorums.WithNoSendWaiting())
 }
 
 // Fetch requests a block from all the replicas in the configuration
 func (cfg *Config) Fetch(ctx context.Context, hash consensus.Hash) (*consensus.Block, bool) {
-	protoBlock, err := cfg.cfg.Fetch(ctx, &hotstuffpb.BlockHash{Hash: hash[:]})
-	if err != nil && !errors.Is(err, context.Canceled) {
-		cfg.mods.Logger().Infof("Failed to fetch block: %v", err)
+	protoBlock, err := cfg.configuration().Fetch(ctx, &hotstuffpb.BlockHash{Hash: hash[:]})
+	if err != nil {
+		if !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded) {
+			cfg.mods.Logger().Infof("Failed to fetch block: %v", err)
+		}
+		return nil, false
+	}
+	return hotstuffpb.BlockFromProto(protoBlock, hotstuffpb.ConfigurationMembers(cfg)), true
+}
+
+// FetchFrom requests a block from the replicas with the given IDs.
+func (cfg *Config) FetchFrom(ctx context.Context, hash consensus.Hash, ids []hotstuff.ID) (*consensus.Block, bool) {
+	subset, err := cfg.subset(ids)
+	if err != nil {
+		cfg.mods.Logger().Infof("Failed to create configuration for fetching block: %v", err)
 		return nil, false
 	}
-	return hotstuffpb.BlockFromProto(protoBlock), true
+	protoBlock, err := subset.Fetch(ctx, &hotstuffpb.BlockHash{Hash: hash[:]})
+	if err != nil {
+		if !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded) {
+			cfg.mods.Logger().Infof("Failed to fetch block: %v", err)
+		}
+		return nil, false
+	}
+	return hotstuffpb.BlockFromProto(protoBlock, hotstuffpb.ConfigurationMembers(cfg)), true
 }
 
 // Close closes all connections made by this configuration.
@@ -208,9 +364,16 @@ func (cfg *Config) Close() {
 	cfg.mgr.Close()
 }
 
-var _ consensus.Configuration = (*Config)(nil)
+var (
+	_ consensus.Configuration   = (*Config)(nil)
+	_ consensus.SubsetFetcher   = (*Config)(nil)
+	_ consensus.LivenessTracker = (*Config)(nil)
+	_ consensus.Reconfigurable  = (*Config)(nil)
+)
 
-type qspec struct{}
+type qspec struct {
+	members hotstuffpb.Members
+}
 
 // FetchQF is the quorum function for the Fetch quorum call method.
 // It simply returns true if one of the replies matches the requested block.
@@ -218,7 +381,7 @@ func (q qspec) FetchQF(in *hotstuffpb.BlockHash, replies map[uint32]*hotstuffpb.
 	var h consensus.Hash
 	copy(h[:], in.GetHash())
 	for _, b := range replies {
-		block := hotstuffpb.BlockFromProto(b)
+		block := hotstuffpb.BlockFromProto(b, q.members)
 		if h == block.Hash() {
 			return b, true
 		}
@@ -23,6 +23,7 @@ import (
 type Server struct {
 	mods      *consensus.Modules
 	gorumsSrv *gorums.Server
+	handlers  messageHandlers
 }
 
 // InitConsensusModule gives the module a reference to the Modules object.
@@ -33,7 +34,7 @@ func (srv *Server) InitConsensusModule(mods *consensus.Modules, _ *consensus.Opt
 
 // NewServer creates a new Server.
 func NewServer(opts ...gorums.ServerOption) *Server {
-	srv := &Server{}
+	srv := &Server{handlers: make(messageHandlers)}
 
 	grpcServerOpts := []grpc.ServerOption{}
 
@@ -106,6 +107,13 @@ func (srv *Server) getClientID(ctx context.Context) (hotstuff.ID, error) {
 	return hotstuff.ID(id), nil
 }
 
+// markSeen records that a message was received from the replica, if the configuration tracks the liveness of replicas.
+func (srv *Server) markSeen(id hotstuff.ID) {
+	if cfg, ok := srv.mods.Configuration().(*Config); ok {
+		cfg.markSeen(id)
+	}
+}
+
 // Stop stops the server.
 func (srv *Server) Stop() {
 	srv.gorumsSrv.Stop()
@@ -118,9 +126,15 @@ func (srv *Server) Propose(ctx gorums.ServerCtx, proposal *hotstuffpb.Proposal)
 		srv.mods.Logger().Infof("Failed to get client ID: %v", err)
 		return
 	}
+	srv.markSeen(id)
+
+	if id == srv.mods.ID() {
+		// the proposal was already handled locally
+		return
+	}
 
 	proposal.Block.Proposer = uint32(id)
-	proposeMsg := hotstuffpb.ProposalFromProto(proposal)
+	proposeMsg := hotstuffpb.ProposalFromProto(proposal, hotstuffpb.ConfigurationMembers(srv.mods.Configuration()))
 	proposeMsg.ID = id
 
 	srv.mods.EventLoop().AddEvent(proposeMsg)
@@ -133,6 +147,12 @@ func (srv *Server) Vote(ctx gorums.ServerCtx, cert *hotstuffpb.PartialCert) {
 		srv.mods.Logger().Infof("Failed to get client ID: %v", err)
 		return
 	}
+	srv.markSeen(id)
+
+	if id == srv.mods.ID() {
+		// our own votes are delivered locally
+		return
+	}
 
 	srv.mods.EventLoop().AddEvent(consensus.VoteMsg{
 		ID:          id,
@@ -147,13 +167,44 @@ func (srv *Server) NewView(ctx gorums.ServerCtx, msg *hotstuffpb.SyncInfo) {
 		srv.mods.Logger().Infof("Failed to get client ID: %v", err)
 		return
 	}
+	srv.markSeen(id)
 
 	srv.mods.EventLoop().AddEvent(consensus.NewViewMsg{
 		ID:       id,
-		SyncInfo: hotstuffpb.SyncInfoFromProto(msg),
+		SyncInfo: hotstuffpb.SyncInfoFromProto(msg, hotstuffpb.ConfigurationMembers(srv.mods.Configuration())),
 	})
 }
 
+// AckProposal handles an acknowledgment of a proposal from a replica.
+func (srv *Server) AckProposal(ctx gorums.ServerCtx, pb *hotstuffpb.BlockHash) {
+	id, err := srv.getClientID(ctx)
+	if err != nil {
+		srv.mods.Logger().Infof("Failed to get client ID: %v", err)
+		return
+	}
+	srv.markSeen(id)
+
+	var hash consensus.Hash
+	copy(hash[:], pb.GetHash())
+
+	srv.mods.EventLoop().AddEvent(consensus.ProposalAckMsg{ID: id, BlockHash: hash})
+}
+
+// Abstain handles a message from a replica that received a proposal, but did not vote for it.
+func (srv *Server) Abstain(ctx gorums.ServerCtx, pb *hotstuffpb.AbstainMsg) {
+	id, err := srv.getClientID(ctx)
+	if err != nil {
+		srv.mods.Logger().Infof("Failed to get client ID: %v", err)
+		return
+	}
+	srv.markSeen(id)
+
+	var hash consensus.Hash
+	copy(hash[:], pb.GetHash())
+
+	srv.mods.EventLoop().AddEvent(consensus.AbstainMsg{ID: id, View: consensus.View(pb.GetView()), BlockHash: hash})
+}
+
 // Fetch handles an incoming fetch request.
 func (srv *Server) Fetch(ctx gorums.ServerCtx, pb *hotstuffpb.BlockHash) (*hotstuffpb.Block, error) {
 	var hash consensus.Hash
@@ -172,10 +223,12 @@ func (srv *Server) Fetch(ctx gorums.ServerCtx, pb *hotstuffpb.BlockHash) (*hotst
 // Timeout handles an incoming TimeoutMsg.
 func (srv *Server) Timeout(ctx gorums.ServerCtx, msg *hotstuffpb.TimeoutMsg) {
 	var err error
-	timeoutMsg := hotstuffpb.TimeoutMsgFromProto(msg)
+	timeoutMsg := hotstuffpb.TimeoutMsgFromProto(msg, hotstuffpb.ConfigurationMembers(srv.mods.Configuration()))
 	timeoutMsg.ID, err = srv.getClientID(ctx)
 	if err != nil {
 		srv.mods.Logger().Infof("Could not get ID of replica: %v", err)
+	} else {
+		srv.markSeen(timeoutMsg.ID)
 	}
 	srv.mods.EventLoop().AddEvent(timeoutMsg)
 }
@@ -0,0 +1,45 @@
+package gorums
+
+import (
+	"time"
+
+	"github.com/relab/gorums"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+)
+
+// keepaliveParams returns the client-side keepalive parameters for the given ping interval and timeout.
+func keepaliveParams(interval, timeout time.Duration) keepalive.ClientParameters {
+	return keepalive.ClientParameters{
+		Time:    interval,
+		Timeout: timeout,
+		// the connections between replicas may be idle for long periods, so we must ping them anyway.
+		PermitWithoutStream: true,
+	}
+}
+
+// keepalivePolicy returns the server-side keepalive enforcement policy for clients that ping every interval.
+// The minimum time between pings is half the interval, such that clients are not disconnected when a ping
+// arrives slightly early because of scheduling jitter or network delays.
+func keepalivePolicy(interval time.Duration) keepalive.EnforcementPolicy {
+	return keepalive.EnforcementPolicy{
+		MinTime:             interval / 2,
+		PermitWithoutStream: true,
+	}
+}
+
+// WithKeepalive returns a ManagerOption that makes the connections to the other replicas send a keepalive ping
+// after being idle for the given interval. If a ping is not acknowledged within the timeout,
+// the connection is closed and gRPC will attempt to reconnect.
+//
+// Note that gRPC will not send pings more often than every 10 seconds.
+// The servers must also be configured with WithKeepalivePolicy to allow pings at this rate.
+func WithKeepalive(interval, timeout time.Duration) gorums.ManagerOption {
+	return gorums.WithGrpcDialOptions(grpc.WithKeepaliveParams(keepaliveParams(interval, timeout)))
+}
+
+// WithKeepalivePolicy returns a ServerOption that permits clients to send keepalive pings every interval.
+// Clients that send pings more often than every half interval will be disconnected.
+func WithKeepalivePolicy(interval time.Duration) gorums.ServerOption {
+	return gorums.WithGRPCServerOptions(grpc.KeepaliveEnforcementPolicy(keepalivePolicy(interval)))
+}
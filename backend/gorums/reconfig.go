@@ -0,0 +1,277 @@
+package gorums
+
+import (
+	"fmt"
+
+	"github.com/relab/gorums"
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/crypto/keygen"
+	"github.com/relab/hotstuff/internal/proto/hotstuffpb"
+)
+
+// pendingChange is a reconfiguration that takes effect when the local replica enters the view.
+type pendingChange struct {
+	change consensus.Reconfiguration
+	view   consensus.View
+	pubKey consensus.PublicKey // the parsed public key of a replica that is added
+}
+
+// Reconfigure schedules the change to take effect in the given view.
+// The connection to a replica that is added is opened in the background, such that it is likely to be ready
+// when the change takes effect. If the local replica has already entered the view, the change takes effect immediately.
+func (cfg *Config) Reconfigure(change consensus.Reconfiguration, view consensus.View) error {
+	p := pendingChange{change: change, view: view}
+	if !change.Remove {
+		if change.ID == 0 {
+			return fmt.Errorf("invalid replica ID: %d", change.ID)
+		}
+		pubKey, err := keygen.ParsePublicKey(change.PublicKey)
+		if err != nil {
+			return fmt.Errorf("invalid public key for replica %d: %w", change.ID, err)
+		}
+		p.pubKey = pubKey
+		if change.ID != cfg.mods.ID() && change.Address != "" {
+			go cfg.connectNode(change.ID, change.Address)
+		}
+	}
+
+	cfg.mut.Lock()
+	cfg.pending = append(cfg.pending, p)
+	view = cfg.view
+	cfg.mut.Unlock()
+
+	cfg.EnterView(view)
+	return nil
+}
+
+// connectNode opens a connection to the replica. The node is added to the configuration by EnterView.
+// While the connection is opened, the creation of other gorums configurations waits for at most the dial timeout.
+func (cfg *Config) connectNode(id hotstuff.ID, address string) {
+	_, err := cfg.newConfiguration(gorums.WithNodeMap(map[string]uint32{address: uint32(id)}))
+	if err != nil {
+		cfg.mods.Logger().Infof("Failed to connect to replica %d: %v", id, err)
+	}
+}
+
+// EnterView applies the changes that take effect in or before the given view.
+// It also adds the connections to added replicas that were not ready when the change took effect.
+func (cfg *Config) EnterView(view consensus.View) {
+	cfg.mut.Lock()
+	defer cfg.mut.Unlock()
+
+	if view > cfg.view {
+		cfg.view = view
+	}
+	if !cfg.needsUpdate() {
+		return
+	}
+
+	replicas := copyReplicas(cfg.replicas)
+	start := cfg.start
+	changed := false      // the set of replicas changed
+	epochChanged := false // the set of replicas from the start view changed
+	remaining := cfg.pending[:0]
+	for _, p := range cfg.pending {
+		if p.view > cfg.view {
+			remaining = append(remaining, p)
+			continue
+		}
+		if p.view > start {
+			// the previous changes took effect in an earlier view, so they form an epoch of their own.
+			if epochChanged {
+				cfg.startEpoch(start, replicas)
+				replicas = copyReplicas(replicas)
+				epochChanged = false
+			}
+			start = p.view
+		}
+		if cfg.apply(replicas, p) {
+			cfg.mods.Logger().Infof("Reconfiguration in view %d: %v", p.view, p.change)
+			changed, epochChanged = true, true
+		}
+	}
+	cfg.pending = remaining
+
+	if cfg.attachNodes(replicas) {
+		changed = true
+	}
+	if !changed {
+		return
+	}
+	if epochChanged {
+		cfg.startEpoch(start, replicas)
+	} else {
+		cfg.replicas = replicas
+	}
+
+	ids := make([]uint32, 0, len(replicas))
+	for id, replica := range replicas {
+		if r, ok := replica.(*gorumsReplica); ok && r.node != nil {
+			ids = append(ids, uint32(id))
+		}
+	}
+	if len(ids) == 0 {
+		cfg.cfg = nil
+		return
+	}
+	gorumsCfg, err := cfg.newConfiguration(gorums.WithNodeIDs(ids))
+	if err != nil {
+		cfg.mods.Logger().Warnf("Failed to create configuration after reconfiguration: %v", err)
+		return
+	}
+	cfg.cfg = gorumsCfg
+}
+
+// startEpoch makes the replicas the current set of replicas from the start view.
+// The previous set of replicas is kept, such that the certificates that it created can still be verified.
+func (cfg *Config) startEpoch(start consensus.View, replicas map[hotstuff.ID]consensus.Replica) {
+	if start > cfg.start {
+		cfg.history = append(cfg.history, membership{
+			start:    cfg.start,
+			replicas: cfg.replicas,
+			quorum:   cfg.quorumSize(cfg.replicas, cfg.witnesses),
+		})
+		cfg.start = start
+	}
+	cfg.replicas = replicas
+	cfg.witnesses = countWitnesses(replicas)
+}
+
+// MembershipAt returns the replicas that participate in the given view.
+// For a view that the local replica has not yet entered, this includes the changes that are scheduled to take effect
+// in or before that view.
+func (cfg *Config) MembershipAt(view consensus.View) consensus.Membership {
+	cfg.mut.RLock()
+	defer cfg.mut.RUnlock()
+
+	if view < cfg.start {
+		for i := len(cfg.history) - 1; i >= 0; i-- {
+			if cfg.history[i].start <= view {
+				return cfg.history[i]
+			}
+		}
+	}
+
+	current := membership{start: cfg.start, replicas: cfg.replicas, quorum: cfg.quorumSize(cfg.replicas, cfg.witnesses)}
+	scheduled := false
+	for _, p := range cfg.pending {
+		if p.view > view {
+			continue
+		}
+		if !scheduled {
+			current.replicas = copyReplicas(current.replicas)
+			scheduled = true
+		}
+		if p.view > current.start {
+			current.start = p.view
+		}
+		cfg.apply(current.replicas, p)
+	}
+	if scheduled {
+		current.quorum = cfg.quorumSize(current.replicas, countWitnesses(current.replicas))
+	}
+	return current
+}
+
+// needsUpdate returns true if a change takes effect in the current view, or if an added replica was connected.
+func (cfg *Config) needsUpdate() bool {
+	for _, p := range cfg.pending {
+		if p.view <= cfg.view {
+			return true
+		}
+	}
+	for id, replica := range cfg.replicas {
+		if r, ok := replica.(*gorumsReplica); ok && r.node == nil && id != cfg.mods.ID() {
+			if _, ok := cfg.mgr.Node(uint32(id)); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// apply adds or removes the replica, and returns true if the set of replicas changed.
+func (cfg *Config) apply(replicas map[hotstuff.ID]consensus.Replica, p pendingChange) bool {
+	_, exists := replicas[p.change.ID]
+	if p.change.Remove {
+		if !exists {
+			return false
+		}
+		delete(replicas, p.change.ID)
+		return true
+	}
+	if exists {
+		return false
+	}
+	replicas[p.change.ID] = &gorumsReplica{
+		id:            p.change.ID,
+		pubKey:        p.pubKey,
+		newviewCancel: func() {},
+		voteCancel:    func() {},
+		reputation:    float64(p.change.ID),
+	}
+	return true
+}
+
+// attachNodes sets the node of the other replicas that are not yet connected, if the manager has connected to them.
+// The replicas are replaced rather than modified, as they may be in use by other goroutines.
+func (cfg *Config) attachNodes(replicas map[hotstuff.ID]consensus.Replica) (attached bool) {
+	for id, replica := range replicas {
+		r, ok := replica.(*gorumsReplica)
+		if !ok || r.node != nil || id == cfg.mods.ID() {
+			continue
+		}
+		node, ok := cfg.mgr.Node(uint32(id))
+		if !ok {
+			continue
+		}
+		connected := *r
+		connected.node = &hotstuffpb.Node{Node: node}
+		replicas[id] = &connected
+		attached = true
+	}
+	return attached
+}
+
+// membership is the set of replicas that participate in the protocol from the start view.
+type membership struct {
+	start    consensus.View
+	replicas map[hotstuff.ID]consensus.Replica
+	quorum   int
+}
+
+// Replicas returns all of the members.
+func (m membership) Replicas() map[hotstuff.ID]consensus.Replica {
+	return m.replicas
+}
+
+// Replica returns a replica if it is a member.
+func (m membership) Replica(id hotstuff.ID) (replica consensus.Replica, ok bool) {
+	replica, ok = m.replicas[id]
+	return replica, ok
+}
+
+// QuorumSize returns the size of a quorum of the members.
+func (m membership) QuorumSize() int {
+	return m.quorum
+}
+
+func copyReplicas(replicas map[hotstuff.ID]consensus.Replica) map[hotstuff.ID]consensus.Replica {
+	c := make(map[hotstuff.ID]consensus.Replica, len(replicas)+1)
+	for id, replica := range replicas {
+		c[id] = replica
+	}
+	return c
+}
+
+func countWitnesses(replicas map[hotstuff.ID]consensus.Replica) (n int) {
+	for _, replica := range replicas {
+		if consensus.IsWitness(replica) {
+			n++
+		}
+	}
+	return n
+}
+
+var _ consensus.MembershipHistory = (*Config)(nil)
@@ -0,0 +1,132 @@
+package gorums
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/crypto/keygen"
+	"github.com/relab/hotstuff/internal/testutil"
+)
+
+// TestReconfiguration checks that an added replica participates in quorums only after the activation view,
+// and that each certificate is verified against the replicas that participated in the view that it is from.
+func TestReconfiguration(t *testing.T) {
+	const (
+		n          = 5
+		activation = 10
+	)
+	ctrl := gomock.NewController(t)
+	td := setupReplicas(t, ctrl, n)
+	added := td.cfg.Replicas[n]
+	delete(td.cfg.Replicas, n)
+	cfg, teardown := createConfig(t, td, ctrl)
+	defer teardown()
+	td.builders[0].Register(cfg)
+	hl := td.builders.Build()
+	signers := hl.Signers()
+
+	// before is a block from before the activation view, and after is a block from the activation view.
+	before := consensus.NewBlock(consensus.GetGenesis().Hash(), consensus.QuorumCert{}, "foo", 1, 1)
+	after := consensus.NewBlock(before.Hash(), consensus.QuorumCert{}, "bar", activation, 1)
+	valid := func(block *consensus.Block, ids ...hotstuff.ID) bool {
+		return hl[0].Crypto().VerifyQuorumCert(signedQC(t, cfg, block, signers, ids...))
+	}
+
+	pubKey, err := keygen.PublicKeyToPEM(added.PubKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	change := consensus.Reconfiguration{ID: added.ID, Address: added.Address, PublicKey: pubKey}
+	if err := cfg.Reconfigure(change, activation); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg.EnterView(activation - 1)
+	if _, ok := cfg.Replica(added.ID); ok {
+		t.Error("the added replica is in the configuration before the activation view")
+	}
+	if got, want := cfg.QuorumSize(), hotstuff.QuorumSize(n-1); got != want {
+		t.Errorf("wrong quorum size before the activation view: got: %d, want: %d", got, want)
+	}
+	if valid(before, 1, 2, 5) {
+		t.Error("a QC signed by the added replica was valid for a view before the activation view")
+	}
+	if !valid(before, 1, 2, 3) {
+		t.Error("a QC signed by a quorum of the old replicas was invalid before the activation view")
+	}
+	// a replica that lags behind must be able to verify QCs from after the activation view.
+	if !valid(after, 1, 2, 3, 5) {
+		t.Error("a QC from the activation view was invalid before the activation view")
+	}
+
+	cfg.EnterView(activation)
+	replica, ok := cfg.Replica(added.ID)
+	if !ok {
+		t.Fatal("the added replica is not in the configuration after the activation view")
+	}
+	if got, want := cfg.QuorumSize(), hotstuff.QuorumSize(n); got != want {
+		t.Errorf("wrong quorum size after the activation view: got: %d, want: %d", got, want)
+	}
+	if !valid(after, 1, 2, 3, 5) {
+		t.Error("a QC signed by the added replica was invalid after the activation view")
+	}
+	if valid(after, 1, 3, 4) {
+		t.Error("a QC signed by a quorum of the old replicas was valid for the activation view")
+	}
+	// the QC that the first proposal of the activation view extends was created by the old replicas.
+	if !valid(before, 1, 3, 4) {
+		t.Error("a QC from before the activation view was invalid after the activation view")
+	}
+
+	// the connection to the added replica is opened in the background.
+	deadline := time.Now().Add(2 * time.Second)
+	for !consensus.IsConnected(replica) && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+		cfg.EnterView(activation)
+		replica, _ = cfg.Replica(added.ID)
+	}
+	if !consensus.IsConnected(replica) {
+		t.Error("the added replica was not connected")
+	}
+}
+
+// TestReconfigurationCachedCertificate checks that a certificate that was verified for one view is verified again
+// for a view with other members, instead of being accepted from the signature cache.
+func TestReconfigurationCachedCertificate(t *testing.T) {
+	const (
+		n          = 4
+		activation = 10
+	)
+	ctrl := gomock.NewController(t)
+	td := setupReplicas(t, ctrl, n)
+	cfg, teardown := createConfig(t, td, ctrl)
+	defer teardown()
+	td.builders[0].Register(cfg)
+	hl := td.builders.Build()
+	signers := hl.Signers()
+
+	// replica 4 replaces its key in the activation view, so its old signatures are not valid from that view.
+	pubKey, err := keygen.PublicKeyToPEM(testutil.GenerateECDSAKey(t).Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.Reconfigure(consensus.Reconfiguration{ID: n, Remove: true}, activation); err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.Reconfigure(consensus.Reconfiguration{ID: n, PublicKey: pubKey}, activation); err != nil {
+		t.Fatal(err)
+	}
+
+	block := consensus.NewBlock(consensus.GetGenesis().Hash(), consensus.QuorumCert{}, "foo", activation-1, 1)
+	qc := signedQC(t, cfg, block, signers, 1, 2, n)
+	if !hl[0].Crypto().VerifyQuorumCert(qc) {
+		t.Fatal("a QC signed with the old key was invalid before the activation view")
+	}
+	replayed := consensus.NewQuorumCert(qc.Signature(), activation, qc.BlockHash())
+	if hl[0].Crypto().VerifyQuorumCert(replayed) {
+		t.Error("a QC signed with the old key was valid for the activation view after it was verified for an earlier view")
+	}
+}
@@ -0,0 +1,114 @@
+package blockchain
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/modules"
+)
+
+// auditEvent is sent on the metrics event loop by the ticker of the auditor.
+type auditEvent struct{}
+
+// Auditor periodically re-verifies the QCs and the parent links of the most recently committed blocks.
+// Inconsistencies should never occur, but can be caused by a corrupted store.
+// Each inconsistency is logged and raised as a ChainInconsistencyEvent on the metrics event loop.
+type Auditor struct {
+	mods     *consensus.Modules
+	interval time.Duration
+	depth    int
+	running  int32 // set while an audit is in progress, to avoid overlapping audits.
+}
+
+// NewAuditor returns an auditor that checks up to depth committed blocks every interval.
+// If depth is zero, every committed block that is available locally is checked.
+func NewAuditor(interval time.Duration, depth int) *Auditor {
+	return &Auditor{
+		interval: interval,
+		depth:    depth,
+	}
+}
+
+// InitConsensusModule gives the module a reference to the Modules object.
+// It also allows the module to set module options using the OptionsBuilder.
+func (a *Auditor) InitConsensusModule(mods *consensus.Modules, _ *consensus.OptionsBuilder) {
+	a.mods = mods
+}
+
+// InitModule gives the module access to the other modules.
+func (a *Auditor) InitModule(mods *modules.Modules) {
+	mods.MetricsEventLoop().RegisterHandler(auditEvent{}, func(_ interface{}) {
+		// the audit may have to verify many certificates, so it must not hold up the event loop.
+		if atomic.CompareAndSwapInt32(&a.running, 0, 1) {
+			go func() {
+				defer atomic.StoreInt32(&a.running, 0)
+				a.Audit()
+			}()
+		}
+	})
+	mods.MetricsEventLoop().AddTicker(a.interval, func(_ time.Time) interface{} {
+		return auditEvent{}
+	})
+}
+
+// Audit checks the committed blocks, starting at the last committed block, and returns the number of
+// inconsistent blocks that were found. The audit stops at the genesis block, at the configured depth,
+// or at the first block that is not available locally.
+// It must not be called from the metrics event loop.
+func (a *Auditor) Audit() (inconsistent int) {
+	hash := a.mods.Consensus().CommittedBlock().Hash()
+	for i := 0; (a.depth == 0 || i < a.depth) && hash != a.mods.Genesis().Hash(); i++ {
+		block, ok := a.mods.BlockChain().LocalGet(hash)
+		if !ok {
+			break
+		}
+		if reason := a.check(hash, block); reason != "" {
+			inconsistent++
+			a.mods.Logger().Errorf("Audit of block %.8s in view %d failed: %s", hash, block.View(), reason)
+			a.mods.MetricsEventLoop().AddEvent(consensus.ChainInconsistencyEvent{
+				Block:  hash,
+				View:   block.View(),
+				Reason: reason,
+			})
+		}
+		hash = block.Parent()
+	}
+	return inconsistent
+}
+
+// check returns a description of the problem with the block that was stored under the given hash,
+// or the empty string if the block is consistent.
+func (a *Auditor) check(hash consensus.Hash, block *consensus.Block) string {
+	if block.Hash() != hash {
+		return "block does not match its hash"
+	}
+	qc := block.QuorumCert()
+	if !a.mods.Crypto().VerifyQuorumCert(qc) {
+		return "invalid quorum certificate"
+	}
+	parent, ok := a.mods.BlockChain().LocalGet(block.Parent())
+	if !ok {
+		// the parent may have been evicted.
+		return ""
+	}
+	if parent.View() >= block.View() {
+		return "parent is not from an earlier view"
+	}
+	if qc.BlockHash() == parent.Hash() {
+		return ""
+	}
+	certified, ok := a.mods.BlockChain().LocalGet(qc.BlockHash())
+	if !ok {
+		return ""
+	}
+	if certified.View() != qc.View() || !a.mods.BlockChain().Extends(parent, certified) {
+		return "quorum certificate is not for an ancestor"
+	}
+	return ""
+}
+
+var (
+	_ consensus.Module = (*Auditor)(nil)
+	_ modules.Module   = (*Auditor)(nil)
+)
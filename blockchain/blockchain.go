@@ -4,6 +4,7 @@ package blockchain
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/relab/hotstuff/consensus"
 )
@@ -17,22 +18,56 @@ type blockChain struct {
 	blocks        map[consensus.Hash]*consensus.Block
 	blockAtHeight map[consensus.View]*consensus.Block
 	pendingFetch  map[consensus.Hash]context.CancelFunc // allows a pending fetch operation to be cancelled
+	retention     int                                   // number of committed blocks to keep when pruning (0 = keep all)
+	retained      []*consensus.Block                    // the committed blocks that are retained, oldest first
+
+	fetchTimeout    time.Duration // the timeout of the first fetch attempt, or 0 to fetch without retrying
+	fetchMaxTimeout time.Duration // upper bound on the timeout of a fetch attempt (0 = no bound)
+}
+
+// Option configures the blockchain.
+type Option func(*blockChain)
+
+// WithFetchRetention makes the blockchain remove the blocks that are no longer needed when it is pruned,
+// except for the given number of the most recently committed blocks, which are retained such that they can be served
+// to lagging replicas that fetch them. The forked blocks are removed, and the blocks that are not yet committed are kept.
+// The committed block is always kept.
+//
+// Without this option, blocks are never removed. Leader rotations that walk the committed chain,
+// such as the liveness-based rotation, need the retention to cover their window.
+func WithFetchRetention(blocks int) Option {
+	return func(chain *blockChain) {
+		if blocks < 1 {
+			blocks = 1
+		}
+		chain.retention = blocks
+	}
 }
 
 // InitConsensusModule gives the module a reference to the Modules object.
 // It also allows the module to set module options using the OptionsBuilder.
 func (chain *blockChain) InitConsensusModule(mods *consensus.Modules, _ *consensus.OptionsBuilder) {
 	chain.mods = mods
+	if genesis := mods.Genesis(); genesis.Hash() != consensus.GetGenesis().Hash() {
+		// replace the default genesis block, which was stored by New.
+		chain.mut.Lock()
+		delete(chain.blocks, consensus.GetGenesis().Hash())
+		chain.mut.Unlock()
+		chain.Store(genesis)
+	}
 }
 
 // New creates a new blockChain with a maximum size.
 // Blocks are dropped in least recently used order.
-func New() consensus.BlockChain {
+func New(opts ...Option) consensus.BlockChain {
 	bc := &blockChain{
 		blocks:        make(map[consensus.Hash]*consensus.Block),
 		blockAtHeight: make(map[consensus.View]*consensus.Block),
 		pendingFetch:  make(map[consensus.Hash]context.CancelFunc),
 	}
+	for _, opt := range opts {
+		opt(bc)
+	}
 	bc.Store(consensus.GetGenesis())
 	return bc
 }
@@ -84,7 +119,7 @@ func (chain *blockChain) Get(hash consensus.Hash) (block *consensus.Block, ok bo
 
 	chain.mut.Unlock()
 	chain.mods.Logger().Debugf("Attempting to fetch block: %.8s", hash)
-	block, ok = chain.mods.Configuration().Fetch(ctx, hash)
+	block, ok = chain.fetch(ctx, hash)
 	chain.mut.Lock()
 
 	delete(chain.pendingFetch, hash)
@@ -126,11 +161,15 @@ func (chain *blockChain) PruneToHeight(height consensus.View) (forkedBlocks []*c
 	committedHeight := chain.mods.Consensus().CommittedBlock().View()
 	committedViews := make(map[consensus.View]bool)
 	committedViews[committedHeight] = true
+	var newlyCommitted []*consensus.Block // the blocks committed since the last time the chain was pruned, newest first
 	for h := committedHeight; h >= chain.pruneHeight; {
 		block, ok := chain.blockAtHeight[h]
 		if !ok {
 			break
 		}
+		if h > chain.pruneHeight {
+			newlyCommitted = append(newlyCommitted, block)
+		}
 		parent, ok := chain.blocks[block.Parent()]
 		if !ok || parent.View() < chain.pruneHeight {
 			break
@@ -145,12 +184,45 @@ func (chain *blockChain) PruneToHeight(height consensus.View) (forkedBlocks []*c
 			if ok {
 				chain.mods.Logger().Debugf("PruneToHeight: found forked block: %v", block)
 				forkedBlocks = append(forkedBlocks, block)
+				if chain.retention > 0 {
+					delete(chain.blocks, block.Hash())
+				}
 			}
 		}
 		delete(chain.blockAtHeight, h)
 	}
 	chain.pruneHeight = height
+	chain.retain(newlyCommitted)
 	return forkedBlocks
 }
 
+// retain adds the newly committed blocks to the retained blocks,
+// and removes the oldest retained blocks that exceed the retention.
+func (chain *blockChain) retain(newlyCommitted []*consensus.Block) {
+	if chain.retention == 0 {
+		return
+	}
+	for i := len(newlyCommitted) - 1; i >= 0; i-- {
+		chain.retained = append(chain.retained, newlyCommitted[i])
+	}
+	for len(chain.retained) > chain.retention {
+		delete(chain.blocks, chain.retained[0].Hash())
+		chain.retained[0] = nil
+		chain.retained = chain.retained[1:]
+	}
+}
+
+// fetch requests the block from the other replicas, retrying according to the WithFetchRetry option.
+func (chain *blockChain) fetch(ctx context.Context, hash consensus.Hash) (*consensus.Block, bool) {
+	if chain.fetchTimeout <= 0 {
+		return chain.mods.Configuration().Fetch(ctx, hash)
+	}
+	block, err := Fetch(ctx, chain.mods, hash, chain.fetchTimeout, chain.fetchMaxTimeout)
+	if err != nil {
+		chain.mods.Logger().Debug(err)
+		return nil, false
+	}
+	return block, true
+}
+
 var _ consensus.BlockChain = (*blockChain)(nil)
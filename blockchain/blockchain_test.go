@@ -0,0 +1,124 @@
+package blockchain_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/relab/hotstuff/blockchain"
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/internal/mocks"
+	"github.com/relab/hotstuff/internal/testutil"
+)
+
+func TestFetchRetention(t *testing.T) {
+	const (
+		numBlocks = 20
+		retention = 5
+	)
+	ctrl := gomock.NewController(t)
+
+	// the up-to-date replica has committed a chain of blocks, and keeps the most recent of them
+	cs := mocks.NewMockConsensus(ctrl)
+	builder := consensus.NewBuilder(1, testutil.GenerateECDSAKey(t))
+	builder.Register(cs, blockchain.New(blockchain.WithFetchRetention(retention)))
+	mods := builder.Build()
+
+	// the chain is pruned after each commit, such that the retained blocks are updated incrementally
+	blocks := make([]*consensus.Block, 0, numBlocks)
+	parent := consensus.GetGenesis()
+	cs.EXPECT().CommittedBlock().AnyTimes().DoAndReturn(func() *consensus.Block { return parent })
+	for v := consensus.View(1); v <= numBlocks; v++ {
+		block := consensus.NewBlock(parent.Hash(), consensus.NewQuorumCert(nil, parent.View(), parent.Hash()), "foo", v, 1)
+		mods.BlockChain().Store(block)
+		blocks = append(blocks, block)
+		parent = block
+		mods.BlockChain().PruneToHeight(parent.View())
+	}
+
+	// the laggard fetches the blocks from the up-to-date replica
+	cfg := mocks.NewMockConfiguration(ctrl)
+	cfg.EXPECT().Fetch(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(_ context.Context, hash consensus.Hash) (*consensus.Block, bool) {
+		return mods.BlockChain().LocalGet(hash)
+	})
+	sync := mocks.NewMockSynchronizer(ctrl)
+	sync.EXPECT().ViewContext().AnyTimes().Return(context.Background())
+	laggardBuilder := consensus.NewBuilder(2, testutil.GenerateECDSAKey(t))
+	laggardBuilder.Register(cfg, sync, blockchain.New())
+	laggard := laggardBuilder.Build()
+
+	for i, block := range blocks {
+		_, ok := laggard.BlockChain().Get(block.Hash())
+		if retained := i >= numBlocks-retention; ok != retained {
+			t.Errorf("block in view %d: fetched: %v, want: %v", block.View(), ok, retained)
+		}
+	}
+	if _, ok := mods.BlockChain().LocalGet(consensus.GetGenesis().Hash()); !ok {
+		t.Error("the genesis block was evicted")
+	}
+}
+
+func TestPruneToHeight(t *testing.T) {
+	const (
+		numCommitted = 30
+		retention    = 5
+		forkView     = 15
+	)
+	ctrl := gomock.NewController(t)
+	cs := mocks.NewMockConsensus(ctrl)
+	cfg := mocks.NewMockConfiguration(ctrl)
+	cfg.EXPECT().Fetch(gomock.Any(), gomock.Any()).AnyTimes().Return(nil, false)
+	sync := mocks.NewMockSynchronizer(ctrl)
+	sync.EXPECT().ViewContext().AnyTimes().Return(context.Background())
+	builder := consensus.NewBuilder(1, testutil.GenerateECDSAKey(t))
+	builder.Register(cs, cfg, sync, blockchain.New(blockchain.WithFetchRetention(retention)))
+	mods := builder.Build()
+	chain := mods.BlockChain()
+
+	extend := func(parent *consensus.Block, view consensus.View, cmd consensus.Command) *consensus.Block {
+		block := consensus.NewBlock(parent.Hash(), consensus.NewQuorumCert(nil, parent.View(), parent.Hash()), cmd, view, 1)
+		chain.Store(block)
+		return block
+	}
+
+	// the committed chain skips the view of the forked block, which extends the committed block before it.
+	var committed, old []*consensus.Block
+	parent := consensus.GetGenesis()
+	for v := consensus.View(1); v <= numCommitted; v++ {
+		if v == forkView {
+			old = append(old, extend(parent, v, "fork"))
+			continue
+		}
+		parent = extend(parent, v, "foo")
+		committed = append(committed, parent)
+	}
+	head := parent
+	// the uncommitted descendants of the committed block
+	recent := []*consensus.Block{extend(head, numCommitted+1, "foo")}
+	recent = append(recent, extend(recent[0], numCommitted+2, "foo"))
+
+	cs.EXPECT().CommittedBlock().AnyTimes().Return(head)
+	forked := chain.PruneToHeight(head.View())
+
+	if len(forked) != 1 || forked[0] != old[0] {
+		t.Errorf("expected the forked block to be returned, got %v", forked)
+	}
+	old = append(old, committed[:len(committed)-retention]...)
+	recent = append(recent, committed[len(committed)-retention:]...)
+	for _, block := range old {
+		if _, ok := chain.LocalGet(block.Hash()); ok {
+			t.Errorf("block in view %d was not pruned", block.View())
+		}
+		if b, ok := chain.Get(block.Hash()); ok || b != nil {
+			t.Errorf("Get returned the pruned block in view %d", block.View())
+		}
+	}
+	for _, block := range recent {
+		if _, ok := chain.LocalGet(block.Hash()); !ok {
+			t.Errorf("block in view %d was pruned", block.View())
+		}
+	}
+	if !chain.Extends(recent[1], head) {
+		t.Error("the uncommitted descendants no longer extend the committed block")
+	}
+}
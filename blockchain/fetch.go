@@ -0,0 +1,75 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/consensus"
+)
+
+// WithFetchRetry makes the blockchain retry fetching a block that did not arrive within the timeout.
+// See Fetch for the retry schedule.
+func WithFetchRetry(timeout, maxTimeout time.Duration) Option {
+	return func(chain *blockChain) {
+		chain.fetchTimeout = timeout
+		chain.fetchMaxTimeout = maxTimeout
+	}
+}
+
+// Fetch requests the block with the given hash from the other replicas until it arrives or the context is cancelled.
+//
+// The first attempt asks f+1 replicas, such that at least one correct replica is asked, and waits for the timeout.
+// Each subsequent attempt asks twice as many replicas and waits twice as long, up to all replicas and maxTimeout.
+// If maxTimeout is 0, there is no bound on the time to wait. If the configuration does not implement
+// consensus.SubsetFetcher, all replicas are asked in each attempt.
+// The attempts continue until the context is cancelled, in which case the context's error is returned.
+func Fetch(ctx context.Context, mods *consensus.Modules, hash consensus.Hash, timeout, maxTimeout time.Duration) (*consensus.Block, error) {
+	cfg := mods.Configuration()
+	subsetFetcher, canFetchSubset := cfg.(consensus.SubsetFetcher)
+
+	ids := make([]hotstuff.ID, 0, cfg.Len())
+	for id := range cfg.Replicas() {
+		if id != mods.ID() {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	size := mods.Options().FaultModel().NumFaulty(cfg.Len()) + 1
+	for attempt := 1; ; attempt++ {
+		if size > len(ids) {
+			size = len(ids)
+		}
+
+		attemptCtx, cancel := context.WithTimeout(ctx, timeout)
+		var (
+			block *consensus.Block
+			ok    bool
+		)
+		if canFetchSubset {
+			block, ok = subsetFetcher.FetchFrom(attemptCtx, hash, ids[:size])
+		} else {
+			block, ok = cfg.Fetch(attemptCtx, hash)
+		}
+		if ok && block.Hash() == hash {
+			cancel()
+			return block, nil
+		}
+		// wait for the attempt to time out, such that replicas that fail quickly are not asked repeatedly.
+		<-attemptCtx.Done()
+		cancel()
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("failed to fetch block %.8s after %d attempts: %w", hash, attempt, err)
+		}
+		mods.Logger().Debugf("Attempt %d to fetch block %.8s from %d replicas failed", attempt, hash, size)
+
+		size *= 2
+		timeout *= 2
+		if maxTimeout > 0 && timeout > maxTimeout {
+			timeout = maxTimeout
+		}
+	}
+}
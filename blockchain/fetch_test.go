@@ -0,0 +1,104 @@
+package blockchain_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/blockchain"
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/internal/mocks"
+	"github.com/relab/hotstuff/internal/testutil"
+)
+
+// subsetConfig is a configuration where only the responders reply to fetch requests.
+type subsetConfig struct {
+	*mocks.MockConfiguration
+	block      *consensus.Block
+	responders map[hotstuff.ID]bool
+
+	mut   sync.Mutex
+	asked [][]hotstuff.ID
+}
+
+func (c *subsetConfig) FetchFrom(ctx context.Context, hash consensus.Hash, ids []hotstuff.ID) (*consensus.Block, bool) {
+	c.mut.Lock()
+	c.asked = append(c.asked, append([]hotstuff.ID(nil), ids...))
+	c.mut.Unlock()
+	for _, id := range ids {
+		if c.responders[id] && hash == c.block.Hash() {
+			return c.block, true
+		}
+	}
+	<-ctx.Done()
+	return nil, false
+}
+
+func newSubsetConfig(t *testing.T, ctrl *gomock.Controller, n int, block *consensus.Block, responders ...hotstuff.ID) *subsetConfig {
+	cfg, replicas := testutil.CreateMockConfigurationWithReplicas(t, ctrl, n)
+	replicaMap := make(map[hotstuff.ID]consensus.Replica)
+	for _, replica := range replicas {
+		replicaMap[replica.ID()] = replica
+	}
+	cfg.EXPECT().Replicas().AnyTimes().Return(replicaMap)
+	c := &subsetConfig{MockConfiguration: cfg, block: block, responders: make(map[hotstuff.ID]bool)}
+	for _, id := range responders {
+		c.responders[id] = true
+	}
+	return c
+}
+
+func TestFetchRetry(t *testing.T) {
+	const n = 7
+	ctrl := gomock.NewController(t)
+	genesis := consensus.GetGenesis()
+	block := consensus.NewBlock(genesis.Hash(), consensus.NewQuorumCert(nil, 0, genesis.Hash()), "foo", 1, 2)
+
+	// replicas 2, 3, and 4 never respond.
+	cfg := newSubsetConfig(t, ctrl, n, block, 5, 6, 7)
+	builder := consensus.NewBuilder(1, testutil.GenerateECDSAKey(t))
+	builder.Register(cfg)
+	mods := builder.Build()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	got, err := blockchain.Fetch(ctx, mods, block.Hash(), 10*time.Millisecond, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Hash() != block.Hash() {
+		t.Errorf("fetched the wrong block: %v", got)
+	}
+	// the first attempt asks f+1 = 3 replicas, and the second attempt asks all other replicas.
+	want := [][]hotstuff.ID{{2, 3, 4}, {2, 3, 4, 5, 6, 7}}
+	if !reflect.DeepEqual(cfg.asked, want) {
+		t.Errorf("wrong replicas were asked: got %v, want %v", cfg.asked, want)
+	}
+}
+
+func TestFetchRetryDeadline(t *testing.T) {
+	const n = 4
+	ctrl := gomock.NewController(t)
+	genesis := consensus.GetGenesis()
+	block := consensus.NewBlock(genesis.Hash(), consensus.NewQuorumCert(nil, 0, genesis.Hash()), "foo", 1, 2)
+
+	cfg := newSubsetConfig(t, ctrl, n, block)
+	builder := consensus.NewBuilder(1, testutil.GenerateECDSAKey(t))
+	builder.Register(cfg)
+	mods := builder.Build()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := blockchain.Fetch(ctx, mods, block.Hash(), 5*time.Millisecond, 10*time.Millisecond)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the fetch to fail with the deadline of the context, got: %v", err)
+	}
+	if len(cfg.asked) < 3 {
+		t.Errorf("expected several attempts before the deadline, got %d", len(cfg.asked))
+	}
+}
@@ -0,0 +1,94 @@
+package persistent
+
+import (
+	"fmt"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// boltBucket is the bucket that holds the values of a BoltStore.
+var boltBucket = []byte("blocks")
+
+// BoltStore is a Store that keeps the values in a bbolt database file.
+// Unlike FileStore, the values are read from the file when they are needed, rather than being kept in memory.
+type BoltStore struct {
+	db *bolt.DB
+}
+
+// OpenBoltStore opens the BoltStore at the given path, creating the database if it does not exist.
+func OpenBoltStore(path string) (*BoltStore, error) {
+	db, err := bolt.Open(path, 0600, nil)
+	if err != nil {
+		return nil, fmt.Errorf("persistent: failed to open store: %w", err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(boltBucket)
+		return err
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("persistent: failed to create bucket: %w", err)
+	}
+	return &BoltStore{db: db}, nil
+}
+
+// Get returns the value stored for the key. If there is no such value, ok is false.
+func (s *BoltStore) Get(key []byte) (value []byte, ok bool, err error) {
+	err = s.db.View(func(tx *bolt.Tx) error {
+		// the value is only valid during the transaction, so it must be copied.
+		if v := tx.Bucket(boltBucket).Get(key); v != nil {
+			value, ok = append([]byte(nil), v...), true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("persistent: failed to read store: %w", err)
+	}
+	return value, ok, nil
+}
+
+// Put stores the value for the key, replacing any existing value.
+func (s *BoltStore) Put(key, value []byte) error {
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(boltBucket).Put(key, value)
+	})
+	if err != nil {
+		return fmt.Errorf("persistent: failed to write store: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the value stored for the key, if any.
+func (s *BoltStore) Delete(key []byte) error {
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(boltBucket).Delete(key)
+	})
+	if err != nil {
+		return fmt.Errorf("persistent: failed to write store: %w", err)
+	}
+	return nil
+}
+
+// Iterate calls f for each key and value in the store, in key order, until f returns false.
+func (s *BoltStore) Iterate(f func(key, value []byte) bool) error {
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(boltBucket).Cursor()
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			if !f(k, v) {
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("persistent: failed to read store: %w", err)
+	}
+	return nil
+}
+
+// Close closes the database.
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
+
+var _ Store = (*BoltStore)(nil)
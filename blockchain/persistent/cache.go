@@ -0,0 +1,69 @@
+package persistent
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/relab/hotstuff/consensus"
+)
+
+// DefaultCacheSize is the number of blocks loaded from the store that are kept in memory,
+// if the cache size is not given.
+const DefaultCacheSize = 1000
+
+// blockCache keeps a limited number of blocks in memory. Blocks are evicted in LRU order.
+type blockCache struct {
+	mut         sync.Mutex
+	capacity    int
+	entries     map[consensus.Hash]*list.Element
+	accessOrder list.List
+}
+
+func newBlockCache(capacity int) *blockCache {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &blockCache{
+		capacity: capacity,
+		entries:  make(map[consensus.Hash]*list.Element, capacity),
+	}
+}
+
+func (cache *blockCache) insert(block *consensus.Block) {
+	cache.mut.Lock()
+	defer cache.mut.Unlock()
+	if elem, ok := cache.entries[block.Hash()]; ok {
+		cache.accessOrder.MoveToFront(elem)
+		return
+	}
+	cache.evict()
+	cache.entries[block.Hash()] = cache.accessOrder.PushFront(block)
+}
+
+func (cache *blockCache) get(hash consensus.Hash) (*consensus.Block, bool) {
+	cache.mut.Lock()
+	defer cache.mut.Unlock()
+	elem, ok := cache.entries[hash]
+	if !ok {
+		return nil, false
+	}
+	cache.accessOrder.MoveToFront(elem)
+	return elem.Value.(*consensus.Block), true
+}
+
+func (cache *blockCache) remove(hash consensus.Hash) {
+	cache.mut.Lock()
+	defer cache.mut.Unlock()
+	if elem, ok := cache.entries[hash]; ok {
+		cache.accessOrder.Remove(elem)
+		delete(cache.entries, hash)
+	}
+}
+
+func (cache *blockCache) evict() {
+	if len(cache.entries) < cache.capacity {
+		return
+	}
+	block := cache.accessOrder.Remove(cache.accessOrder.Back()).(*consensus.Block)
+	delete(cache.entries, block.Hash())
+}
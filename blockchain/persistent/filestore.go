@@ -0,0 +1,284 @@
+package persistent
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const (
+	opPut byte = iota + 1
+	opDelete
+)
+
+// compactMinRecords is the number of records that the log must contain before it is compacted.
+const compactMinRecords = 1024
+
+// FileStore is an embedded Store that keeps the values in memory and persists every change to an append-only log file.
+// Each change is synced to stable storage before it is applied. When the file is opened, the log is replayed to restore
+// the values. The log is compacted when most of its records have been overwritten or deleted.
+type FileStore struct {
+	mut     sync.Mutex
+	path    string
+	file    *os.File
+	records int // the number of records in the log
+	entries map[string][]byte
+}
+
+// OpenFileStore opens the FileStore at the given path, creating the file if it does not exist.
+// If the log ends with an incomplete record, for example after a crash, the incomplete record is discarded.
+func OpenFileStore(path string) (*FileStore, error) {
+	// a compacted log that was not completely written before a crash is not used.
+	if err := os.Remove(compactPath(path)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("persistent: failed to remove incomplete compaction: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("persistent: failed to open store: %w", err)
+	}
+	s := &FileStore{
+		path:    path,
+		file:    file,
+		entries: make(map[string][]byte),
+	}
+	if err := s.replay(); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// replay reads the log from the start of the file and truncates any incomplete record at the end.
+func (s *FileStore) replay() error {
+	r := &countingReader{r: bufio.NewReader(s.file)}
+	var valid int64
+	for {
+		op, key, value, err := readRecord(r)
+		if err == io.EOF {
+			break
+		}
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			// the last record was not completely written
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("persistent: failed to read store: %w", err)
+		}
+		switch op {
+		case opPut:
+			s.entries[string(key)] = value
+		case opDelete:
+			delete(s.entries, string(key))
+		default:
+			return fmt.Errorf("persistent: failed to read store: unknown operation %d", op)
+		}
+		s.records++
+		valid = r.n
+	}
+	if err := s.file.Truncate(valid); err != nil {
+		return fmt.Errorf("persistent: failed to truncate store: %w", err)
+	}
+	if _, err := s.file.Seek(valid, io.SeekStart); err != nil {
+		return fmt.Errorf("persistent: failed to seek store: %w", err)
+	}
+	return nil
+}
+
+// readRecord reads a record consisting of the operation, the length of the key, the length of the value,
+// the key, and the value.
+func readRecord(r *countingReader) (op byte, key, value []byte, err error) {
+	op, err = r.ReadByte()
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	keyLen, err := binary.ReadUvarint(r)
+	if err != nil {
+		return 0, nil, nil, unexpectedEOF(err)
+	}
+	valueLen, err := binary.ReadUvarint(r)
+	if err != nil {
+		return 0, nil, nil, unexpectedEOF(err)
+	}
+	buf := make([]byte, keyLen+valueLen)
+	if _, err = io.ReadFull(r, buf); err != nil {
+		return 0, nil, nil, unexpectedEOF(err)
+	}
+	return op, buf[:keyLen], buf[keyLen:], nil
+}
+
+// unexpectedEOF converts io.EOF to io.ErrUnexpectedEOF, as EOF is only expected at the start of a record.
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// write appends a record to the log, and syncs it to stable storage.
+func (s *FileStore) write(op byte, key, value []byte) error {
+	if _, err := s.file.Write(encodeRecord(op, key, value)); err != nil {
+		return fmt.Errorf("persistent: failed to write store: %w", err)
+	}
+	if err := s.file.Sync(); err != nil {
+		return fmt.Errorf("persistent: failed to sync store: %w", err)
+	}
+	s.records++
+	return nil
+}
+
+// encodeRecord encodes a record in the format that is read by readRecord.
+func encodeRecord(op byte, key, value []byte) []byte {
+	var length [binary.MaxVarintLen64]byte
+	buf := make([]byte, 1, 1+2*binary.MaxVarintLen64+len(key)+len(value))
+	buf[0] = op
+	n := binary.PutUvarint(length[:], uint64(len(key)))
+	buf = append(buf, length[:n]...)
+	n = binary.PutUvarint(length[:], uint64(len(value)))
+	buf = append(buf, length[:n]...)
+	buf = append(buf, key...)
+	buf = append(buf, value...)
+	return buf
+}
+
+// maybeCompact compacts the log if less than half of its records are needed to restore the values.
+// A failure to compact is not an error, as the log is still valid.
+func (s *FileStore) maybeCompact() {
+	if s.records >= compactMinRecords && s.records > 2*len(s.entries) {
+		_ = s.compact()
+	}
+}
+
+// Compact rewrites the log such that it only contains one record for each value.
+// The compacted log is written to a separate file that replaces the log once it has been synced to stable storage.
+func (s *FileStore) Compact() error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	return s.compact()
+}
+
+func (s *FileStore) compact() error {
+	file, err := os.OpenFile(compactPath(s.path), os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("persistent: failed to compact store: %w", err)
+	}
+	w := bufio.NewWriter(file)
+	iterate(s.entries, func(key, value []byte) bool {
+		_, err = w.Write(encodeRecord(opPut, key, value))
+		return err == nil
+	})
+	if err == nil {
+		err = w.Flush()
+	}
+	if err == nil {
+		err = file.Sync()
+	}
+	if err == nil {
+		err = os.Rename(compactPath(s.path), s.path)
+	}
+	if err != nil {
+		_ = file.Close()
+		_ = os.Remove(compactPath(s.path))
+		return fmt.Errorf("persistent: failed to compact store: %w", err)
+	}
+	syncDir(filepath.Dir(s.path))
+	_ = s.file.Close()
+	s.file = file
+	s.records = len(s.entries)
+	return nil
+}
+
+// compactPath returns the path of the file that a compacted log is written to.
+func compactPath(path string) string {
+	return path + ".compact"
+}
+
+// syncDir syncs the directory, such that a renamed file is found after a crash.
+// Not all platforms support syncing a directory, so errors are ignored.
+func syncDir(path string) {
+	dir, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	_ = dir.Sync()
+	_ = dir.Close()
+}
+
+// Get returns the value stored for the key. If there is no such value, ok is false.
+func (s *FileStore) Get(key []byte) (value []byte, ok bool, err error) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	value, ok = s.entries[string(key)]
+	return value, ok, nil
+}
+
+// Put stores the value for the key, replacing any existing value.
+func (s *FileStore) Put(key, value []byte) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	if err := s.write(opPut, key, value); err != nil {
+		return err
+	}
+	s.entries[string(key)] = append([]byte(nil), value...)
+	s.maybeCompact()
+	return nil
+}
+
+// Delete removes the value stored for the key, if any.
+func (s *FileStore) Delete(key []byte) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	if _, ok := s.entries[string(key)]; !ok {
+		return nil
+	}
+	if err := s.write(opDelete, key, nil); err != nil {
+		return err
+	}
+	delete(s.entries, string(key))
+	s.maybeCompact()
+	return nil
+}
+
+// Iterate calls f for each key and value in the store, in key order, until f returns false.
+func (s *FileStore) Iterate(f func(key, value []byte) bool) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	iterate(s.entries, f)
+	return nil
+}
+
+// Close flushes the log to stable storage and closes the file.
+func (s *FileStore) Close() error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	if err := s.file.Sync(); err != nil {
+		return fmt.Errorf("persistent: failed to sync store: %w", err)
+	}
+	return s.file.Close()
+}
+
+// countingReader counts the number of bytes read.
+type countingReader struct {
+	r *bufio.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+func (c *countingReader) ReadByte() (byte, error) {
+	b, err := c.r.ReadByte()
+	if err == nil {
+		c.n++
+	}
+	return b, err
+}
+
+var _ Store = (*FileStore)(nil)
@@ -0,0 +1,139 @@
+package persistent
+
+import (
+	"io"
+
+	"github.com/relab/hotstuff/blockchain"
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/internal/proto/hotstuffpb"
+)
+
+// persistentChain is a blockchain that writes the blocks to a Store.
+// The blocks that are stored or fetched are kept in an in-memory blockchain, which is pruned as blocks are committed,
+// and the blocks that are loaded from the store are kept in a bounded LRU cache.
+type persistentChain struct {
+	mods   *consensus.Modules
+	cache  consensus.BlockChain
+	loaded *blockCache
+	store  Store
+}
+
+// New returns a new blockchain that stores the blocks in the given store.
+// Blocks written to the store by an earlier instance are available to the new instance.
+// At most DefaultCacheSize of the blocks that are loaded from the store are kept in memory.
+// The options configure the in-memory blockchain.
+func New(store Store, opts ...blockchain.Option) consensus.BlockChain {
+	return NewWithCacheSize(store, DefaultCacheSize, opts...)
+}
+
+// NewWithCacheSize returns a new blockchain that stores the blocks in the given store,
+// and keeps at most cacheSize of the blocks that are loaded from the store in memory.
+func NewWithCacheSize(store Store, cacheSize int, opts ...blockchain.Option) consensus.BlockChain {
+	return &persistentChain{
+		cache:  blockchain.New(opts...),
+		loaded: newBlockCache(cacheSize),
+		store:  store,
+	}
+}
+
+// NewPersistentBlockChain returns a new blockchain that stores the blocks in a BoltStore at the given path.
+// The returned Closer closes the store, and must be called when the blockchain is no longer used.
+func NewPersistentBlockChain(path string, opts ...blockchain.Option) (consensus.BlockChain, io.Closer, error) {
+	store, err := OpenBoltStore(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return New(store, opts...), store, nil
+}
+
+// InitConsensusModule gives the module a reference to the Modules object.
+// It also allows the module to set module options using the OptionsBuilder.
+func (chain *persistentChain) InitConsensusModule(mods *consensus.Modules, opts *consensus.OptionsBuilder) {
+	chain.mods = mods
+	if mod, ok := chain.cache.(consensus.Module); ok {
+		mod.InitConsensusModule(mods, opts)
+	}
+}
+
+// Store stores a block in the blockchain.
+func (chain *persistentChain) Store(block *consensus.Block) {
+	chain.cache.Store(block)
+	chain.persist(block)
+}
+
+// persist writes the block to the store.
+func (chain *persistentChain) persist(block *consensus.Block) {
+	b, err := hotstuffpb.MarshalBlock(block)
+	if err != nil {
+		chain.mods.Logger().Errorf("Failed to marshal block: %v", err)
+		return
+	}
+	hash := block.Hash()
+	if err := chain.store.Put(hash[:], b); err != nil {
+		chain.mods.Logger().Errorf("Failed to store block: %v", err)
+	}
+}
+
+// LocalGet retrieves a block given its hash, without fetching it from other replicas.
+func (chain *persistentChain) LocalGet(hash consensus.Hash) (*consensus.Block, bool) {
+	if block, ok := chain.cache.LocalGet(hash); ok {
+		return block, true
+	}
+	if block, ok := chain.loaded.get(hash); ok {
+		return block, true
+	}
+	b, ok, err := chain.store.Get(hash[:])
+	if err != nil {
+		chain.mods.Logger().Errorf("Failed to load block: %v", err)
+		return nil, false
+	}
+	if !ok {
+		return nil, false
+	}
+	block, err := hotstuffpb.UnmarshalBlock(b, hotstuffpb.ConfigurationMembers(chain.mods.Configuration()))
+	if err != nil {
+		chain.mods.Logger().Errorf("Failed to unmarshal block: %v", err)
+		return nil, false
+	}
+	chain.loaded.insert(block)
+	return block, true
+}
+
+// Get retrieves a block given its hash, attempting to fetch it from other replicas if necessary.
+func (chain *persistentChain) Get(hash consensus.Hash) (*consensus.Block, bool) {
+	if block, ok := chain.LocalGet(hash); ok {
+		return block, true
+	}
+	block, ok := chain.cache.Get(hash)
+	if !ok {
+		return nil, false
+	}
+	chain.persist(block)
+	return block, true
+}
+
+// Extends checks if the given block extends the branch of the target block.
+func (chain *persistentChain) Extends(block, target *consensus.Block) bool {
+	current := block
+	ok := true
+	for ok && current.View() > target.View() {
+		current, ok = chain.Get(current.Parent())
+	}
+	return ok && current.Hash() == target.Hash()
+}
+
+// PruneToHeight prunes blocks from the in-memory cache up to the specified height,
+// and removes the forked blocks from the store.
+func (chain *persistentChain) PruneToHeight(height consensus.View) (forkedBlocks []*consensus.Block) {
+	forkedBlocks = chain.cache.PruneToHeight(height)
+	for _, block := range forkedBlocks {
+		hash := block.Hash()
+		chain.loaded.remove(hash)
+		if err := chain.store.Delete(hash[:]); err != nil {
+			chain.mods.Logger().Errorf("Failed to delete block: %v", err)
+		}
+	}
+	return forkedBlocks
+}
+
+var _ consensus.BlockChain = (*persistentChain)(nil)
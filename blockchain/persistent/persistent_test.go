@@ -0,0 +1,392 @@
+package persistent_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/relab/hotstuff/blockchain"
+	"github.com/relab/hotstuff/blockchain/persistent"
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/internal/mocks"
+	"github.com/relab/hotstuff/internal/proto/hotstuffpb"
+	"github.com/relab/hotstuff/internal/testutil"
+)
+
+// openFunc returns a new, empty store, and a function that reopens the store with the same contents.
+type openFunc func(t *testing.T) (store persistent.Store, reopen func() persistent.Store)
+
+func openMemStore(_ *testing.T) (persistent.Store, func() persistent.Store) {
+	store := persistent.NewMemStore()
+	return store, func() persistent.Store { return store }
+}
+
+func openFileStore(t *testing.T) (persistent.Store, func() persistent.Store) {
+	path := filepath.Join(t.TempDir(), "blocks")
+	open := func() persistent.Store {
+		store, err := persistent.OpenFileStore(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { _ = store.Close() })
+		return store
+	}
+	store := open()
+	current := store
+	return store, func() persistent.Store {
+		if err := current.(*persistent.FileStore).Close(); err != nil {
+			t.Fatal(err)
+		}
+		current = open()
+		return current
+	}
+}
+
+func openBoltStore(t *testing.T) (persistent.Store, func() persistent.Store) {
+	path := filepath.Join(t.TempDir(), "blocks.db")
+	open := func() *persistent.BoltStore {
+		store, err := persistent.OpenBoltStore(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return store
+	}
+	current := open()
+	t.Cleanup(func() { _ = current.Close() })
+	return current, func() persistent.Store {
+		if err := current.Close(); err != nil {
+			t.Fatal(err)
+		}
+		current = open()
+		return current
+	}
+}
+
+func runAll(t *testing.T, run func(*testing.T, openFunc)) {
+	t.Helper()
+	t.Run("MemStore", func(t *testing.T) { run(t, openMemStore) })
+	t.Run("FileStore", func(t *testing.T) { run(t, openFileStore) })
+	t.Run("BoltStore", func(t *testing.T) { run(t, openBoltStore) })
+}
+
+// newChain returns the test modules of a replica with a persistent blockchain that uses the given store,
+// and the signers of all replicas.
+func newChain(t *testing.T, store persistent.Store) (*consensus.Modules, []consensus.Crypto) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	builders := testutil.CreateBuilders(t, ctrl, 4)
+	builders[0].Register(persistent.New(store))
+	hl := builders.Build()
+	return hl[0], hl.Signers()
+}
+
+// createChain stores a chain of blocks for the views 1 through numViews and returns the blocks.
+func createChain(t *testing.T, mods *consensus.Modules, signers []consensus.Crypto, numViews int) []*consensus.Block {
+	t.Helper()
+	var blocks []*consensus.Block
+	parent := consensus.GetGenesis()
+	qc := consensus.NewQuorumCert(nil, 0, parent.Hash())
+	for v := consensus.View(1); v <= consensus.View(numViews); v++ {
+		block := consensus.NewBlock(parent.Hash(), qc, "foo", v, 1)
+		mods.BlockChain().Store(block)
+		blocks = append(blocks, block)
+		qc = testutil.CreateQC(t, block, signers)
+		parent = block
+	}
+	return blocks
+}
+
+func TestStore(t *testing.T) {
+	runAll(t, func(t *testing.T, open openFunc) {
+		store, reopen := open(t)
+
+		for _, key := range []string{"c", "a", "b"} {
+			if err := store.Put([]byte(key), []byte("value "+key)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := store.Put([]byte("b"), []byte("new value b")); err != nil {
+			t.Fatal(err)
+		}
+		if err := store.Delete([]byte("c")); err != nil {
+			t.Fatal(err)
+		}
+
+		store = reopen()
+
+		if _, ok, err := store.Get([]byte("c")); err != nil || ok {
+			t.Errorf("deleted key was found (err: %v)", err)
+		}
+		value, ok, err := store.Get([]byte("b"))
+		if err != nil || !ok {
+			t.Fatalf("key was not found (err: %v)", err)
+		}
+		if !bytes.Equal(value, []byte("new value b")) {
+			t.Errorf("wrong value: got: %q, want: %q", value, "new value b")
+		}
+
+		var keys []string
+		err = store.Iterate(func(key, _ []byte) bool {
+			keys = append(keys, string(key))
+			return true
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(keys) != 2 || keys[0] != "a" || keys[1] != "b" {
+			t.Errorf("wrong keys: got: %v, want: [a b]", keys)
+		}
+	})
+}
+
+func TestStoreAndGet(t *testing.T) {
+	runAll(t, func(t *testing.T, open openFunc) {
+		store, _ := open(t)
+		mods, signers := newChain(t, store)
+		chain := mods.BlockChain()
+		blocks := createChain(t, mods, signers, 5)
+
+		for _, block := range blocks {
+			got, ok := chain.LocalGet(block.Hash())
+			if !ok {
+				t.Fatalf("block %v was not found", block)
+			}
+			if got.Hash() != block.Hash() {
+				t.Errorf("got wrong block: got: %v, want: %v", got, block)
+			}
+		}
+
+		if !chain.Extends(blocks[4], blocks[1]) {
+			t.Error("block does not extend its ancestor")
+		}
+		if chain.Extends(blocks[1], blocks[4]) {
+			t.Error("block extends its descendant")
+		}
+	})
+}
+
+func TestReopen(t *testing.T) {
+	runAll(t, func(t *testing.T, open openFunc) {
+		store, reopen := open(t)
+		mods, signers := newChain(t, store)
+		blocks := createChain(t, mods, signers, 5)
+
+		// a new blockchain using the same store must find the blocks without fetching them.
+		mods, _ = newChain(t, reopen())
+		chain := mods.BlockChain()
+		for _, block := range blocks {
+			got, ok := chain.LocalGet(block.Hash())
+			if !ok {
+				t.Fatalf("block %v was not restored", block)
+			}
+			if got.Hash() != block.Hash() {
+				t.Errorf("got wrong block: got: %v, want: %v", got, block)
+			}
+		}
+		if !chain.Extends(blocks[4], blocks[0]) {
+			t.Error("restored block does not extend its ancestor")
+		}
+	})
+}
+
+// TestAuditCorruptedQC checks that the auditor detects a block whose QC was corrupted in the store.
+// TestLoadedBlocksAreBounded checks that only the most recently loaded blocks are kept in memory.
+func TestLoadedBlocksAreBounded(t *testing.T) {
+	store := persistent.NewMemStore()
+	mods, signers := newChain(t, store)
+	blocks := createChain(t, mods, signers, 5)
+
+	ctrl := gomock.NewController(t)
+	builders := testutil.CreateBuilders(t, ctrl, 4)
+	builders[0].Register(persistent.NewWithCacheSize(store, 2))
+	chain := builders.Build()[0].BlockChain()
+	for _, block := range blocks {
+		if _, ok := chain.LocalGet(block.Hash()); !ok {
+			t.Fatalf("block %v was not restored", block)
+		}
+	}
+	for _, block := range blocks {
+		hash := block.Hash()
+		if err := store.Delete(hash[:]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i, block := range blocks {
+		_, ok := chain.LocalGet(block.Hash())
+		if want := i >= len(blocks)-2; ok != want {
+			t.Errorf("block %d in memory: got: %v, want: %v", i, ok, want)
+		}
+	}
+}
+
+// TestNewPersistentBlockChain checks that a blockchain that is reopened from the same path finds the stored blocks.
+func TestNewPersistentBlockChain(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "blocks.db")
+	open := func() (*consensus.Modules, []consensus.Crypto, io.Closer) {
+		chain, closer, err := persistent.NewPersistentBlockChain(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ctrl := gomock.NewController(t)
+		builders := testutil.CreateBuilders(t, ctrl, 4)
+		builders[0].Register(chain)
+		hl := builders.Build()
+		return hl[0], hl.Signers(), closer
+	}
+
+	mods, signers, closer := open()
+	blocks := createChain(t, mods, signers, 5)
+	if err := closer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	mods, _, closer = open()
+	defer closer.Close()
+	for _, block := range blocks {
+		if _, ok := mods.BlockChain().LocalGet(block.Hash()); !ok {
+			t.Errorf("block %v was not restored", block)
+		}
+	}
+}
+
+func TestAuditCorruptedQC(t *testing.T) {
+	runAll(t, func(t *testing.T, open openFunc) {
+		store, reopen := open(t)
+		keys := make([]consensus.PrivateKey, 4)
+		for i := range keys {
+			keys[i] = testutil.GenerateECDSAKey(t)
+		}
+		builders := testutil.CreateBuilders(t, gomock.NewController(t), len(keys), keys...)
+		builders[0].Register(persistent.New(store))
+		hl := builders.Build()
+		blocks := createChain(t, hl[0], hl.Signers(), 5)
+
+		// audit opens a new blockchain using the store, such that the blocks are loaded from the store,
+		// and returns the inconsistencies that the auditor found.
+		audit := func() []consensus.ChainInconsistencyEvent {
+			t.Helper()
+			ctrl := gomock.NewController(t)
+			cs := mocks.NewMockConsensus(ctrl)
+			cs.EXPECT().CommittedBlock().AnyTimes().Return(blocks[len(blocks)-1])
+			builders := testutil.CreateBuilders(t, ctrl, len(keys), keys...)
+			auditor := blockchain.NewAuditor(time.Hour, 0)
+			store = reopen()
+			builders[0].Register(persistent.New(store), cs, auditor)
+			mods := builders.Build()[0]
+
+			var events []consensus.ChainInconsistencyEvent
+			mods.MetricsEventLoop().RegisterHandler(consensus.ChainInconsistencyEvent{}, func(event interface{}) {
+				events = append(events, event.(consensus.ChainInconsistencyEvent))
+			})
+			n := auditor.Audit()
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			mods.MetricsEventLoop().Run(ctx)
+			if n != len(events) {
+				t.Errorf("audit returned %d, but %d events were raised", n, len(events))
+			}
+			return events
+		}
+
+		if events := audit(); len(events) != 0 {
+			t.Fatalf("audit of a consistent chain raised events: %v", events)
+		}
+
+		// replace the QC of the third block with the QC of another block, under the original hash.
+		corrupted := blocks[2].Hash()
+		b, ok, err := store.Get(corrupted[:])
+		if err != nil || !ok {
+			t.Fatalf("block was not found in the store (err: %v)", err)
+		}
+		block, err := hotstuffpb.UnmarshalBlock(b, hotstuffpb.ConfigurationMembers(hl[0].Configuration()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		block = consensus.NewBlock(block.Parent(), blocks[4].QuorumCert(), block.Command(), block.View(), block.Proposer())
+		if b, err = hotstuffpb.MarshalBlock(block); err != nil {
+			t.Fatal(err)
+		}
+		if err := store.Put(corrupted[:], b); err != nil {
+			t.Fatal(err)
+		}
+
+		events := audit()
+		if len(events) != 1 {
+			t.Fatalf("wrong number of events: got: %d, want: 1", len(events))
+		}
+		if events[0].Block != corrupted || events[0].View != blocks[2].View() {
+			t.Errorf("wrong block in event: got: %.8s in view %d, want: %.8s in view %d",
+				events[0].Block, events[0].View, corrupted, blocks[2].View())
+		}
+	})
+}
+
+// TestFileStoreCompaction checks that the log is compacted when most of its records have been overwritten,
+// and that the compacted log restores the latest values.
+func TestFileStoreCompaction(t *testing.T) {
+	const (
+		numKeys   = 10
+		numRounds = 200
+	)
+	path := filepath.Join(t.TempDir(), "blocks")
+	store, err := persistent.OpenFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	value := func(key, round int) []byte {
+		return []byte(fmt.Sprintf("value %d in round %d", key, round))
+	}
+	for round := 0; round < numRounds; round++ {
+		for key := 0; key < numKeys; key++ {
+			if err := store.Put([]byte{byte(key)}, value(key, round)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	size := func() int64 {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return info.Size()
+	}
+	// without compaction, the log would contain one record for each Put.
+	uncompacted := int64(numKeys * numRounds * len(value(0, 0)))
+	if size() >= uncompacted*3/4 {
+		t.Errorf("the log was not compacted: size: %d, uncompacted: %d", size(), uncompacted)
+	}
+	if err := store.Compact(); err != nil {
+		t.Fatal(err)
+	}
+	if max := int64(numKeys * (len(value(0, numRounds-1)) + 4)); size() > max {
+		t.Errorf("the log contains overwritten values after compaction: size: %d, want at most %d", size(), max)
+	}
+	if err := store.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// an incomplete compaction from a crash must not be used.
+	if err := os.WriteFile(path+".compact", []byte("garbage"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	store, err = persistent.OpenFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	for key := 0; key < numKeys; key++ {
+		got, ok, err := store.Get([]byte{byte(key)})
+		if err != nil || !ok {
+			t.Fatalf("key %d was not found (err: %v)", key, err)
+		}
+		if want := value(key, numRounds-1); !bytes.Equal(got, want) {
+			t.Errorf("wrong value for key %d: got: %q, want: %q", key, got, want)
+		}
+	}
+}
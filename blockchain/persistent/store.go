@@ -0,0 +1,79 @@
+// Package persistent provides an implementation of the consensus.BlockChain interface
+// that stores blocks in a pluggable key-value store.
+package persistent
+
+import (
+	"sort"
+	"sync"
+)
+
+// Store is a key-value store that is used by the persistent blockchain.
+// Implementations must be safe for concurrent use.
+type Store interface {
+	// Get returns the value stored for the key. If there is no such value, ok is false.
+	Get(key []byte) (value []byte, ok bool, err error)
+	// Put stores the value for the key, replacing any existing value.
+	Put(key, value []byte) error
+	// Delete removes the value stored for the key, if any.
+	Delete(key []byte) error
+	// Iterate calls f for each key and value in the store, in key order, until f returns false.
+	// The store must not be modified by f.
+	Iterate(f func(key, value []byte) bool) error
+}
+
+// memStore is a Store that keeps the values in a map.
+type memStore struct {
+	mut     sync.Mutex
+	entries map[string][]byte
+}
+
+// NewMemStore returns a new Store that keeps the values in memory.
+func NewMemStore() Store {
+	return &memStore{entries: make(map[string][]byte)}
+}
+
+// Get returns the value stored for the key. If there is no such value, ok is false.
+func (s *memStore) Get(key []byte) (value []byte, ok bool, err error) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	value, ok = s.entries[string(key)]
+	return value, ok, nil
+}
+
+// Put stores the value for the key, replacing any existing value.
+func (s *memStore) Put(key, value []byte) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	s.entries[string(key)] = append([]byte(nil), value...)
+	return nil
+}
+
+// Delete removes the value stored for the key, if any.
+func (s *memStore) Delete(key []byte) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	delete(s.entries, string(key))
+	return nil
+}
+
+// Iterate calls f for each key and value in the store, in key order, until f returns false.
+func (s *memStore) Iterate(f func(key, value []byte) bool) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	iterate(s.entries, f)
+	return nil
+}
+
+// iterate calls f for each entry in key order, until f returns false.
+func iterate(entries map[string][]byte, f func(key, value []byte) bool) {
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !f([]byte(key), entries[key]) {
+			return
+		}
+	}
+}
@@ -4,16 +4,17 @@
 package client
 
 import (
+	"bytes"
 	"context"
 	"crypto/x509"
 	"errors"
+	"fmt"
 	"io"
 	"math"
 	"strconv"
 	"sync"
 	"time"
 
-	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/relab/gorums"
 	"github.com/relab/hotstuff"
 	"github.com/relab/hotstuff/config"
@@ -23,23 +24,54 @@ import (
 	"golang.org/x/time/rate"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type qspec struct {
 	faulty int
 }
 
-func (q *qspec) ExecCommandQF(_ *clientpb.Command, signatures map[uint32]*empty.Empty) (*empty.Empty, bool) {
-	if len(signatures) < q.faulty+1 {
+// ExecCommandQF returns a result once f+1 replicas have replied with the same result.
+func (q *qspec) ExecCommandQF(_ *clientpb.Command, replies map[uint32]*clientpb.Result) (*clientpb.Result, bool) {
+	if len(replies) < q.faulty+1 {
 		return nil, false
 	}
-	return &empty.Empty{}, true
+	for _, reply := range replies {
+		matching := 0
+		for _, other := range replies {
+			if bytes.Equal(reply.GetData(), other.GetData()) && bytes.Equal(reply.GetCommandHash(), other.GetCommandHash()) {
+				matching++
+			}
+		}
+		if matching >= q.faulty+1 {
+			return reply, true
+		}
+	}
+	return nil, false
+}
+
+// AwaitProposalQF returns a reply once f+1 replicas have accepted the command in a proposed block.
+func (q *qspec) AwaitProposalQF(cmd *clientpb.Command, replies map[uint32]*clientpb.Accepted) (*clientpb.Accepted, bool) {
+	hash := clientpb.CommandHash(cmd)
+	matching := 0
+	for _, reply := range replies {
+		if bytes.Equal(reply.GetCommandHash(), hash) {
+			matching++
+		}
+		if matching >= q.faulty+1 {
+			return reply, true
+		}
+	}
+	return nil, false
 }
 
 type pendingCmd struct {
 	sequenceNumber uint64
 	sendTime       time.Time
-	promise        *clientpb.AsyncEmpty
+	accepted       *clientpb.AsyncAccepted // nil if the client does not wait for the command to be accepted
+	promise        *clientpb.AsyncResult
+	cmd            *clientpb.Command
 }
 
 // Config contains config options for a client.
@@ -54,8 +86,18 @@ type Config struct {
 	RateLimit        float64       // initial rate limit
 	RateStep         float64       // rate limit step up
 	RateStepInterval time.Duration // step up interval
+	MaxCommandSize   uint32        // maximum size in bytes of an encoded command (0 = no limit)
+	Priority         uint32        // the priority of the commands, for replicas that order commands by priority
+	// OnResult is called with the result of each command that was executed by the replicas. May be nil.
+	OnResult func(cmd *clientpb.Command, result []byte)
+	// OnAccepted is called when a command has been accepted in a proposed block by the replicas,
+	// before it is committed and executed. May be nil, in which case the client only waits for execution.
+	OnAccepted func(cmd *clientpb.Command)
 }
 
+// ErrCommandTooLarge is returned when a command is larger than the maximum command size.
+var ErrCommandTooLarge = errors.New("command too large")
+
 // Client is a hotstuff client.
 type Client struct {
 	mut              sync.Mutex
@@ -72,6 +114,10 @@ type Client struct {
 	limiter          *rate.Limiter
 	stepUp           float64
 	stepUpInterval   time.Duration
+	maxCommandSize   uint32
+	priority         uint32
+	onResult         func(cmd *clientpb.Command, result []byte)
+	onAccepted       func(cmd *clientpb.Command)
 }
 
 // New returns a new Client.
@@ -89,6 +135,10 @@ func New(conf Config, builder modules.Builder) (client *Client) {
 		limiter:          rate.NewLimiter(rate.Limit(conf.RateLimit), 1),
 		stepUp:           conf.RateStep,
 		stepUpInterval:   conf.RateStepInterval,
+		maxCommandSize:   conf.MaxCommandSize,
+		priority:         conf.Priority,
+		onResult:         conf.OnResult,
+		onAccepted:       conf.OnAccepted,
 	}
 
 	grpcOpts := []grpc.DialOption{grpc.WithBlock()}
@@ -220,12 +270,22 @@ func (c *Client) sendCommands(ctx context.Context) error {
 			ClientID:       uint32(c.id),
 			SequenceNumber: num,
 			Data:           data[:n],
+			Timestamp:      timestamppb.Now(),
+			Priority:       c.priority,
 		}
 
-		promise := c.gorumsConfig.ExecCommand(ctx, cmd)
+		var accepted *clientpb.AsyncAccepted
+		if c.onAccepted != nil {
+			// the replicas must be waiting for the command to be accepted before they receive the command.
+			accepted = c.gorumsConfig.AwaitProposal(ctx, cmd)
+		}
+		promise, err := c.submit(ctx, cmd)
+		if err != nil {
+			return err
+		}
 
 		num++
-		c.pendingCmds <- pendingCmd{sequenceNumber: num, sendTime: time.Now(), promise: promise}
+		c.pendingCmds <- pendingCmd{sequenceNumber: num, sendTime: time.Now(), accepted: accepted, promise: promise, cmd: cmd}
 
 		if num%100 == 0 {
 			c.mods.Logger().Infof("%d commands sent", num)
@@ -235,6 +295,15 @@ func (c *Client) sendCommands(ctx context.Context) error {
 	return nil
 }
 
+// submit sends the command to the replicas.
+// Commands that exceed the maximum command size are rejected without being sent.
+func (c *Client) submit(ctx context.Context, cmd *clientpb.Command) (*clientpb.AsyncResult, error) {
+	if size := proto.Size(cmd); c.maxCommandSize > 0 && size > int(c.maxCommandSize) {
+		return nil, fmt.Errorf("%w: %d bytes exceeds the limit of %d bytes", ErrCommandTooLarge, size, c.maxCommandSize)
+	}
+	return c.gorumsConfig.ExecCommand(ctx, cmd), nil
+}
+
 // handleCommands will get pending commands from the pendingCmds channel and then
 // handle them as they become acknowledged by the replicas. We expect the commands to be
 // acknowledged in the order that they were sent.
@@ -252,15 +321,28 @@ func (c *Client) handleCommands(ctx context.Context) (executed, failed int) {
 		case <-ctx.Done():
 			return
 		}
-		_, err := cmd.promise.Get()
+		if cmd.accepted != nil {
+			if _, err := cmd.accepted.Get(); err != nil {
+				c.mods.Logger().Debugf("Command was not accepted by enough replicas: %v\n", err)
+			} else {
+				c.onAccepted(cmd.cmd)
+			}
+		}
+		result, err := cmd.promise.Get()
 		if err != nil {
 			qcError, ok := err.(gorums.QuorumCallError)
 			if !ok || qcError.Reason != context.Canceled.Error() {
 				c.mods.Logger().Debugf("Did not get enough replies for command: %v\n", err)
 				failed++
 			}
+		} else if !bytes.Equal(result.GetCommandHash(), clientpb.CommandHash(cmd.cmd)) {
+			c.mods.Logger().Warnf("Got result for the wrong command (sequence number: %d)", cmd.cmd.GetSequenceNumber())
+			failed++
 		} else {
 			executed++
+			if c.onResult != nil {
+				c.onResult(cmd.cmd, result.GetData())
+			}
 		}
 		c.mut.Lock()
 		if cmd.sequenceNumber > c.highestCommitted {
@@ -0,0 +1,67 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/relab/hotstuff/internal/proto/clientpb"
+	"github.com/relab/hotstuff/modules"
+)
+
+func TestSubmitOversizedCommand(t *testing.T) {
+	c := New(Config{ID: 1, MaxConcurrent: 1, MaxCommandSize: 64}, modules.NewBuilder(1))
+	defer c.mgr.Close()
+
+	cmd := &clientpb.Command{ClientID: 1, SequenceNumber: 1, Data: make([]byte, 100)}
+	// the client is not connected, so the command would fail if it was sent.
+	promise, err := c.submit(context.Background(), cmd)
+	if !errors.Is(err, ErrCommandTooLarge) {
+		t.Fatalf("expected ErrCommandTooLarge, got: %v", err)
+	}
+	if promise != nil {
+		t.Error("oversized command was sent")
+	}
+}
+
+func TestExecCommandQFMatchingResults(t *testing.T) {
+	q := &qspec{faulty: 1}
+	cmd := &clientpb.Command{ClientID: 1, SequenceNumber: 1, Data: []byte("foo")}
+	hash := clientpb.CommandHash(cmd)
+
+	replies := map[uint32]*clientpb.Result{
+		1: {CommandHash: hash, Data: []byte("FOO")},
+		2: {CommandHash: hash, Data: []byte("BAR")},
+	}
+	if _, ok := q.ExecCommandQF(cmd, replies); ok {
+		t.Error("expected no result when the replies differ")
+	}
+
+	replies[3] = &clientpb.Result{CommandHash: hash, Data: []byte("FOO")}
+	result, ok := q.ExecCommandQF(cmd, replies)
+	if !ok {
+		t.Fatal("expected a result from f+1 matching replies")
+	}
+	if string(result.GetData()) != "FOO" {
+		t.Errorf("wrong result: got: %q, want: %q", result.GetData(), "FOO")
+	}
+}
+
+func TestAwaitProposalQF(t *testing.T) {
+	q := &qspec{faulty: 1}
+	cmd := &clientpb.Command{ClientID: 1, SequenceNumber: 1, Data: []byte("foo")}
+	other := &clientpb.Command{ClientID: 1, SequenceNumber: 2, Data: []byte("foo")}
+
+	replies := map[uint32]*clientpb.Accepted{
+		1: {CommandHash: clientpb.CommandHash(cmd)},
+		2: {CommandHash: clientpb.CommandHash(other)},
+	}
+	if _, ok := q.AwaitProposalQF(cmd, replies); ok {
+		t.Error("expected no reply when only one replica accepted the command")
+	}
+
+	replies[3] = &clientpb.Accepted{CommandHash: clientpb.CommandHash(cmd)}
+	if _, ok := q.AwaitProposalQF(cmd, replies); !ok {
+		t.Error("expected a reply once f+1 replicas accepted the command")
+	}
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"sort"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/consensus"
+)
+
+// CommittedCommand is a command that a replica reported as committed at the given index in the total order.
+type CommittedCommand struct {
+	Index          uint64
+	Hash           consensus.Hash // the hash of the command
+	ClientID       hotstuff.ID    // the client that sent the command
+	SequenceNumber uint64         // the sequence number that the client assigned to the command
+}
+
+// VerifyTotalOrder checks that the committed commands reported by several replicas agree on the total order,
+// that is, no two replicas report different commands at the same index.
+// It also checks that the commands reported by each replica form a prefix of the total order without gaps:
+// the indexes must be contiguous from 0, and the sequence numbers of the commands from each client must be contiguous.
+// The reports from each replica do not need to have the same length, or be sorted.
+// If the reports are consistent, ok is true. Otherwise, the lowest index at which the reports diverge, or at which
+// a report has a gap, is returned.
+func VerifyTotalOrder(reports map[hotstuff.ID][]CommittedCommand) (divergence uint64, ok bool) {
+	order := make(map[uint64]consensus.Hash)
+	var conflicts []uint64
+	for _, commands := range reports {
+		if index, ok := findGap(commands); !ok {
+			conflicts = append(conflicts, index)
+		}
+		for _, cmd := range commands {
+			hash, seen := order[cmd.Index]
+			if !seen {
+				order[cmd.Index] = cmd.Hash
+				continue
+			}
+			if hash != cmd.Hash {
+				conflicts = append(conflicts, cmd.Index)
+			}
+		}
+	}
+	if len(conflicts) == 0 {
+		return 0, true
+	}
+	sort.Slice(conflicts, func(i, j int) bool { return conflicts[i] < conflicts[j] })
+	return conflicts[0], false
+}
+
+// findGap returns the lowest index at which the commands reported by a replica are not contiguous,
+// either because an index is missing or repeated, or because a command from a client does not have the sequence number
+// that follows the previous command from the same client. It returns true if there is no gap.
+func findGap(commands []CommittedCommand) (index uint64, ok bool) {
+	sorted := make([]CommittedCommand, len(commands))
+	copy(sorted, commands)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Index < sorted[j].Index })
+
+	lastSeq := make(map[hotstuff.ID]uint64)
+	for i, cmd := range sorted {
+		if cmd.Index != uint64(i) {
+			return uint64(i), false
+		}
+		if last, seen := lastSeq[cmd.ClientID]; seen && cmd.SequenceNumber != last+1 {
+			return cmd.Index, false
+		}
+		lastSeq[cmd.ClientID] = cmd.SequenceNumber
+	}
+	return 0, true
+}
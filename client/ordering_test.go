@@ -0,0 +1,118 @@
+package client_test
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/client"
+)
+
+// committed returns the committed commands with the given names, starting at index 0.
+// The commands are sent by client 1 with consecutive sequence numbers, starting at 1.
+func committed(names ...string) []client.CommittedCommand {
+	cmds := make([]client.CommittedCommand, len(names))
+	for i, name := range names {
+		cmds[i] = client.CommittedCommand{
+			Index:          uint64(i),
+			Hash:           sha256.Sum256([]byte(name)),
+			ClientID:       1,
+			SequenceNumber: uint64(i + 1),
+		}
+	}
+	return cmds
+}
+
+// without returns the commands, except for the command at the given index.
+func without(cmds []client.CommittedCommand, index int) []client.CommittedCommand {
+	return append(cmds[:index:index], cmds[index+1:]...)
+}
+
+// interleaved returns commands from two clients, whose sequence numbers are contiguous per client.
+// The sequence number of the last command of client 2 is skipped if skip is true.
+func interleaved(skip bool) []client.CommittedCommand {
+	cmds := []client.CommittedCommand{
+		{ClientID: 1, SequenceNumber: 1},
+		{ClientID: 2, SequenceNumber: 1},
+		{ClientID: 2, SequenceNumber: 2},
+		{ClientID: 1, SequenceNumber: 2},
+		{ClientID: 2, SequenceNumber: 3},
+	}
+	if skip {
+		cmds[4].SequenceNumber = 4
+	}
+	for i := range cmds {
+		cmds[i].Index = uint64(i)
+		cmds[i].Hash = sha256.Sum256([]byte{byte(cmds[i].ClientID), byte(cmds[i].SequenceNumber)})
+	}
+	return cmds
+}
+
+// reversed returns the commands in reverse order.
+func reversed(cmds []client.CommittedCommand) []client.CommittedCommand {
+	r := make([]client.CommittedCommand, len(cmds))
+	for i, cmd := range cmds {
+		r[len(cmds)-1-i] = cmd
+	}
+	return r
+}
+
+func TestVerifyTotalOrder(t *testing.T) {
+	tests := []struct {
+		name       string
+		reports    map[hotstuff.ID][]client.CommittedCommand
+		consistent bool
+		divergence uint64
+	}{
+		{"Empty", map[hotstuff.ID][]client.CommittedCommand{}, true, 0},
+		{"Identical", map[hotstuff.ID][]client.CommittedCommand{
+			1: committed("a", "b", "c"),
+			2: committed("a", "b", "c"),
+		}, true, 0},
+		{"Prefix", map[hotstuff.ID][]client.CommittedCommand{
+			1: committed("a", "b", "c", "d"),
+			2: committed("a", "b"),
+			3: committed(),
+		}, true, 0},
+		{"Divergent", map[hotstuff.ID][]client.CommittedCommand{
+			1: committed("a", "b", "c", "d"),
+			2: committed("a", "b", "x", "y"),
+			3: committed("a", "b", "c"),
+		}, false, 2},
+		{"DivergentFirst", map[hotstuff.ID][]client.CommittedCommand{
+			1: committed("a"),
+			2: committed("b"),
+		}, false, 0},
+		{"Unsorted", map[hotstuff.ID][]client.CommittedCommand{
+			1: reversed(committed("a", "b", "c")),
+			2: committed("a", "b"),
+		}, true, 0},
+		{"MissingIndex", map[hotstuff.ID][]client.CommittedCommand{
+			1: committed("a", "b", "c", "d"),
+			2: without(committed("a", "b", "c", "d"), 1),
+		}, false, 1},
+		{"MissingSequenceNumber", map[hotstuff.ID][]client.CommittedCommand{
+			1: committed("a", "b", "c"),
+			2: append(committed("a"), client.CommittedCommand{Index: 1, Hash: sha256.Sum256([]byte("b")), ClientID: 1, SequenceNumber: 3}),
+		}, false, 1},
+		{"Interleaved", map[hotstuff.ID][]client.CommittedCommand{
+			1: interleaved(false),
+			2: interleaved(false)[:3],
+		}, true, 0},
+		{"InterleavedGap", map[hotstuff.ID][]client.CommittedCommand{
+			1: interleaved(true),
+		}, false, 4},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			divergence, ok := client.VerifyTotalOrder(test.reports)
+			if ok != test.consistent {
+				t.Fatalf("got consistent: %v, want: %v", ok, test.consistent)
+			}
+			if !ok && divergence != test.divergence {
+				t.Errorf("wrong divergence index: got: %d, want: %d", divergence, test.divergence)
+			}
+		})
+	}
+}
@@ -16,6 +16,7 @@ type ReplicaInfo struct {
 	Address string
 	PubKey  consensus.PublicKey
 	Reputation uint64
+	Witness bool
 }
 
 // ReplicaConfig holds information needed by a replica.
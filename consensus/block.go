@@ -1,7 +1,6 @@
 package consensus
 
 import (
-	"crypto/sha256"
 	"encoding/binary"
 	"fmt"
 
@@ -29,7 +28,7 @@ func NewBlock(parent Hash, cert QuorumCert, cmd Command, view View, proposer hot
 		proposer: proposer,
 	}
 	// cache the hash immediately because it is too racy to do it in Hash()
-	b.hash = sha256.Sum256(b.ToBytes())
+	b.hash = hasher.Sum(b.ToBytes())
 	return b
 }
 
@@ -75,6 +74,7 @@ func (b *Block) View() View {
 }
 
 // ToBytes returns the raw byte form of the Block, to be used for hashing, etc.
+// The bytes are deterministic, such that all replicas compute the same hash for the same block.
 func (b *Block) ToBytes() []byte {
 	buf := b.parent[:]
 	var proposerBuf [4]byte
@@ -87,3 +87,5 @@ func (b *Block) ToBytes() []byte {
 	buf = append(buf, b.cert.ToBytes()...)
 	return buf
 }
+
+var _ ToBytes = (*Block)(nil)
@@ -25,6 +25,7 @@ func New() consensus.Rules {
 // It also allows the module to set module options using the OptionsBuilder.
 func (hs *ChainedHotStuff) InitConsensusModule(mods *consensus.Modules, _ *consensus.OptionsBuilder) {
 	hs.mods = mods
+	hs.bLock = mods.Genesis()
 }
 
 func (hs *ChainedHotStuff) qcRef(qc consensus.QuorumCert) (*consensus.Block, bool) {
@@ -70,22 +71,31 @@ func (hs *ChainedHotStuff) CommitRule(block *consensus.Block) *consensus.Block {
 
 // VoteRule decides whether to vote for the proposal or not.
 func (hs *ChainedHotStuff) VoteRule(proposal consensus.ProposeMsg) bool {
-	block := proposal.Block
-
-	qcBlock, haveQCBlock := hs.mods.BlockChain().Get(block.QuorumCert().BlockHash())
-
-	safe := false
-	if haveQCBlock && qcBlock.View() > hs.bLock.View() {
-		safe = true
-	} else {
-		hs.mods.Logger().Debug("OnPropose: liveness condition failed")
-		// check if this block extends bLock
-		if hs.mods.BlockChain().Extends(block, hs.bLock) {
-			safe = true
-		} else {
-			hs.mods.Logger().Debug("OnPropose: safety condition failed")
-		}
+	return hs.safeNode(proposal.Block)
+}
+
+// safeNode implements the safeNode predicate of HotStuff. The block is safe to vote for if it satisfies
+// the liveness rule or the safety rule. The liveness rule allows a replica that is locked on a block
+// that was abandoned by the other replicas to vote for a block that extends a more recent QC.
+func (hs *ChainedHotStuff) safeNode(block *consensus.Block) bool {
+	if hs.livenessRule(block) {
+		return true
+	}
+	hs.mods.Logger().Debug("OnPropose: liveness condition failed")
+	if hs.safetyRule(block) {
+		return true
 	}
+	hs.mods.Logger().Debug("OnPropose: safety condition failed")
+	return false
+}
+
+// livenessRule returns true if the QC of the block certifies a block from a later view than the locked block.
+func (hs *ChainedHotStuff) livenessRule(block *consensus.Block) bool {
+	qcBlock, ok := hs.mods.BlockChain().Get(block.QuorumCert().BlockHash())
+	return ok && qcBlock.View() > hs.bLock.View()
+}
 
-	return safe
+// safetyRule returns true if the block extends the locked block.
+func (hs *ChainedHotStuff) safetyRule(block *consensus.Block) bool {
+	return hs.mods.BlockChain().Extends(block, hs.bLock)
 }
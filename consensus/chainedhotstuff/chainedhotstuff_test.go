@@ -0,0 +1,64 @@
+package chainedhotstuff_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/consensus/chainedhotstuff"
+	"github.com/relab/hotstuff/internal/mocks"
+	"github.com/relab/hotstuff/internal/testutil"
+	"github.com/relab/hotstuff/leaderrotation"
+	"github.com/relab/hotstuff/synchronizer"
+)
+
+// TestCommitLag checks that, on a linear chain where each block certifies its parent,
+// the committed block lags the block referenced by the highQC by exactly two blocks.
+func TestCommitLag(t *testing.T) {
+	const n = 4
+	ctrl := gomock.NewController(t)
+	bl := testutil.CreateBuilders(t, ctrl, n)
+	bl[0].Register(
+		consensus.New(chainedhotstuff.New()),
+		synchronizer.New(testutil.FixedTimeout(1000)),
+		leaderrotation.NewFixed(2),
+	)
+	hl := bl.Build()
+	hs := hl[0]
+	signers := hl.Signers()
+
+	leader, _ := hs.Configuration().Replica(2)
+	leader.(*mocks.MockReplica).EXPECT().Vote(gomock.Any()).AnyTimes()
+	leader.(*mocks.MockReplica).EXPECT().NewView(gomock.Any()).AnyTimes()
+
+	parent := consensus.GetGenesis()
+	qc := consensus.NewQuorumCert(nil, 0, parent.Hash())
+	for view := consensus.View(1); view <= 8; view++ {
+		block := consensus.NewBlock(parent.Hash(), qc, consensus.Command(fmt.Sprintf("cmd%d", view)), view, 2)
+		hs.EventLoop().AddEvent(consensus.ProposeMsg{ID: 2, Block: block})
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		hs.EventLoop().Run(ctx)
+
+		leaf := hs.Synchronizer().LeafBlock()
+		if leaf.View() != view-1 {
+			t.Fatalf("view %d: wrong leaf block: got view %d, want view %d", view, leaf.View(), view-1)
+		}
+		committed := hs.Consensus().CommittedBlock()
+		want := consensus.View(0)
+		if view > 3 {
+			want = view - 3
+		}
+		if committed.View() != want {
+			t.Errorf("view %d: wrong committed block: got view %d, want view %d", view, committed.View(), want)
+		}
+		if view > 3 && leaf.View()-committed.View() != 2 {
+			t.Errorf("view %d: committed block lags the leaf by %d blocks, want 2", view, leaf.View()-committed.View())
+		}
+
+		parent = block
+		qc = testutil.CreateQC(t, block, signers)
+	}
+}
@@ -0,0 +1,81 @@
+package chainedhotstuff
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/internal/mocks"
+	"github.com/relab/hotstuff/internal/testutil"
+)
+
+// TestSafeNode checks each branch of the safeNode predicate with constructed chains and locks.
+//
+// The chains are genesis <- a1 <- a2 <- a3 and genesis <- b1 <- b2 <- b3, where each block certifies its parent,
+// and c2 is a sibling of a2.
+func TestSafeNode(t *testing.T) {
+	genesis := consensus.GetGenesis()
+	certify := func(block *consensus.Block) consensus.QuorumCert {
+		return consensus.NewQuorumCert(nil, block.View(), block.Hash())
+	}
+	extend := func(parent *consensus.Block, cmd consensus.Command) *consensus.Block {
+		return consensus.NewBlock(parent.Hash(), certify(parent), cmd, parent.View()+1, 2)
+	}
+	a1 := extend(genesis, "a1")
+	a2 := extend(a1, "a2")
+	a3 := extend(a2, "a3")
+	b1 := extend(genesis, "b1")
+	b2 := extend(b1, "b2")
+	b3 := extend(b2, "b3")
+	c2 := extend(a1, "c2")
+	unknown := consensus.NewBlock(genesis.Hash(), certify(genesis), "unknown", 5, 2)
+
+	tests := []struct {
+		name     string
+		lock     *consensus.Block
+		block    *consensus.Block
+		liveness bool
+		safety   bool
+	}{
+		{name: "GenesisLock", lock: genesis, block: extend(b1, "x"), liveness: true, safety: true},
+		{name: "ExtendsLockWithNewerQC", lock: a1, block: extend(a2, "x"), liveness: true, safety: true},
+		{name: "ExtendsLockWithLockQC", lock: a2, block: extend(a2, "x"), liveness: false, safety: true},
+		{name: "ExtendsLockWithOlderQC", lock: a2,
+			block: consensus.NewBlock(a3.Hash(), certify(a1), "x", 5, 2), liveness: false, safety: true},
+		{name: "LivenessOverride", lock: a2, block: extend(b3, "x"), liveness: true, safety: false},
+		{name: "ConflictingBranch", lock: a2, block: extend(b1, "x"), liveness: false, safety: false},
+		{name: "SiblingOfLock", lock: a2, block: extend(c2, "x"), liveness: false, safety: false},
+		{name: "ForkBelowLock", lock: a2, block: extend(a1, "x"), liveness: false, safety: false},
+		{name: "UnknownQCBlock", lock: a2,
+			block: consensus.NewBlock(a3.Hash(), certify(unknown), "x", 6, 2), liveness: false, safety: true},
+		{name: "UnknownQCBlockConflicting", lock: a2,
+			block: consensus.NewBlock(b3.Hash(), certify(unknown), "x", 6, 2), liveness: false, safety: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			bl := testutil.CreateBuilders(t, ctrl, 4)
+			hs := New().(*ChainedHotStuff)
+			bl[0].Register(hs)
+			mods := bl.Build()[0]
+			mods.Configuration().(*mocks.MockConfiguration).EXPECT().Fetch(gomock.Any(), unknown.Hash()).AnyTimes().Return(nil, false)
+			for _, block := range []*consensus.Block{a1, a2, a3, b1, b2, b3, c2} {
+				mods.BlockChain().Store(block)
+			}
+			hs.bLock = tt.lock
+
+			if got := hs.livenessRule(tt.block); got != tt.liveness {
+				t.Errorf("livenessRule: got %v, want %v", got, tt.liveness)
+			}
+			if got := hs.safetyRule(tt.block); got != tt.safety {
+				t.Errorf("safetyRule: got %v, want %v", got, tt.safety)
+			}
+			if got, want := hs.safeNode(tt.block), tt.liveness || tt.safety; got != want {
+				t.Errorf("safeNode: got %v, want %v", got, want)
+			}
+			if got, want := hs.VoteRule(consensus.ProposeMsg{ID: 2, Block: tt.block}), tt.liveness || tt.safety; got != want {
+				t.Errorf("VoteRule: got %v, want %v", got, want)
+			}
+		})
+	}
+}
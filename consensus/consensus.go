@@ -1,8 +1,13 @@
 package consensus
 
 import (
+	"context"
 	"fmt"
+	"sort"
 	"sync"
+	"time"
+
+	"github.com/relab/hotstuff"
 )
 
 // Rules is the minimum interface that a consensus implementations must implement.
@@ -25,41 +30,223 @@ type ProposeRuler interface {
 	ProposeRule(cert SyncInfo, cmd Command) (proposal ProposeMsg, ok bool)
 }
 
+// PausePolicy decides what happens to proposals and votes that arrive while consensus is paused.
+type PausePolicy int
+
+const (
+	// PauseBuffer handles the messages that arrived while paused once consensus is resumed.
+	PauseBuffer PausePolicy = iota
+	// PauseDrop discards the messages that arrived while paused.
+	PauseDrop
+)
+
+// PreExecPolicy decides what happens to a committed block when the CommitHook returns an error.
+type PreExecPolicy int
+
+const (
+	// PreExecHalt stops executing blocks. The failed block, and all blocks that are committed after it,
+	// are not executed.
+	PreExecHalt PreExecPolicy = iota
+	// PreExecRetry calls the CommitHook again, up to PreExecRetries times, before halting.
+	// No blocks are executed while waiting to retry.
+	PreExecRetry
+)
+
+// PreExecRetries is the number of times that the CommitHook is called again after an error
+// when using the PreExecRetry policy. The delay between the attempts starts at PreExecRetryDelay and is doubled
+// after each attempt.
+const PreExecRetries = 3
+
+// PreExecRetryDelay is the delay before the first retry of the CommitHook.
+const PreExecRetryDelay = 10 * time.Millisecond
+
+// QuorumWaitStrategy decides what the leader does before proposing when some replicas cannot be reached.
+type QuorumWaitStrategy int
+
+const (
+	// QuorumWaitNone proposes without checking which replicas can be reached.
+	QuorumWaitNone QuorumWaitStrategy = iota
+	// QuorumWaitReachable proposes if a quorum of replicas can be reached, and raises a ReducedResilienceEvent
+	// if no more than a bare quorum can be reached. If a quorum cannot be reached, the leader steps down,
+	// such that the replicas can move on to the next view without waiting for the view to time out.
+	QuorumWaitReachable
+)
+
+// StaleCommandPolicy decides what happens to a proposal whose client commands have all been committed already.
+// Detecting such proposals requires an Acceptor that implements the StaleChecker interface.
+type StaleCommandPolicy int
+
+const (
+	// StaleIgnore does not check the proposals for stale commands.
+	StaleIgnore StaleCommandPolicy = iota
+	// StaleFlag raises a StaleProposalEvent, but still handles the proposal as usual.
+	StaleFlag
+	// StaleReject raises a StaleProposalEvent and does not vote for the proposal,
+	// such that the leader must propose fresher commands to make progress.
+	StaleReject
+)
+
 // consensusBase provides a default implementation of the Consensus interface
 // for implementations of the ConsensusImpl interface.
 type consensusBase struct {
 	impl Rules
 	mods *Modules
 
-	lastVote View
+	lastVote      View
+	lastVoteBlock Hash
+
+	uncommittedProposals int // the number of proposals sent by the local replica since one of its blocks was committed
+
+	mut              sync.Mutex
+	bExec            *Block
+	paused           bool
+	execHalted       bool         // set when the CommitHook failed, after which no more blocks are executed or voted for
+	execRetries      int          // the number of times the CommitHook has failed for the next block to execute
+	retryBlock       *Block       // the block to commit when the CommitHook is retried, or nil if no retry is scheduled
+	pendingProposals []ProposeMsg // proposals received while paused, sorted by view
+	committed        []*Block     // blocks executed by commitInner that have not been passed to the commit handlers
 
-	mut   sync.Mutex
-	bExec *Block
+	commitHandlers []func(*Block)
+
+	proposals map[proposalKey]seenProposal // the first block proposed by each proposer in the recent views
+
+	pendingVotes []*Block // blocks waiting to be signed as a batch
+
+	voteSigners   chan struct{} // limits the number of votes that are signed concurrently by signing workers
+	lastSentVote  View          // the view of the most recent vote that was sent after being signed outside of OnPropose
+	stoppedVoting View          // the most recent view passed to StopVoting
 }
 
 // New returns a new Consensus instance based on the given Rules implementation.
 func New(impl Rules) Consensus {
 	return &consensusBase{
-		impl:     impl,
-		lastVote: 0,
-		bExec:    GetGenesis(),
+		impl:      impl,
+		lastVote:  0,
+		bExec:     GetGenesis(),
+		proposals: make(map[proposalKey]seenProposal),
 	}
 }
 
+// proposalKey identifies the proposals of a proposer in a view.
+type proposalKey struct {
+	view     View
+	proposer hotstuff.ID
+}
+
+// seenProposal is the first block that a proposer proposed in a view.
+type seenProposal struct {
+	block       Hash
+	equivocated bool // set when the proposer has proposed a conflicting block in the same view
+}
+
 func (cs *consensusBase) CommittedBlock() *Block {
 	cs.mut.Lock()
 	defer cs.mut.Unlock()
 	return cs.bExec
 }
 
+// RegisterCommitHandler registers a function that is called once for each committed block, in commit order.
+// The handlers run synchronously on the consensus goroutine after the block has been executed,
+// so they should return quickly. Handlers must be registered before the replica is started.
+func (cs *consensusBase) RegisterCommitHandler(handler func(*Block)) {
+	cs.commitHandlers = append(cs.commitHandlers, handler)
+}
+
 func (cs *consensusBase) InitConsensusModule(mods *Modules, opts *OptionsBuilder) {
 	cs.mods = mods
+	cs.bExec = mods.Genesis()
 	if mod, ok := cs.impl.(Module); ok {
 		mod.InitConsensusModule(mods, opts)
 	}
 	cs.mods.EventLoop().RegisterHandler(ProposeMsg{}, func(event interface{}) {
+		if cs.Paused() {
+			cs.holdProposal(event.(ProposeMsg))
+			return
+		}
 		cs.OnPropose(event.(ProposeMsg))
 	})
+	cs.mods.EventLoop().RegisterObserver(ResumeEvent{}, func(_ interface{}) {
+		cs.releaseProposals()
+	})
+}
+
+// Pause stops the processing of proposals and votes until Resume is called.
+// The replica still takes part in view synchronization, but it will not propose or vote.
+func (cs *consensusBase) Pause() {
+	cs.mut.Lock()
+	defer cs.mut.Unlock()
+	cs.paused = true
+}
+
+// Resume continues the processing of proposals and votes after a call to Pause.
+func (cs *consensusBase) Resume() {
+	cs.mut.Lock()
+	wasPaused := cs.paused
+	cs.paused = false
+	cs.mut.Unlock()
+	if wasPaused {
+		cs.mods.EventLoop().AddEvent(ResumeEvent{})
+	}
+}
+
+// Paused returns true if consensus is paused.
+func (cs *consensusBase) Paused() bool {
+	cs.mut.Lock()
+	defer cs.mut.Unlock()
+	return cs.paused
+}
+
+// holdWhilePaused buffers or drops an event that arrived while consensus is paused, according to the pause policy.
+// Buffered events are handled again after the next ResumeEvent.
+func holdWhilePaused(mods *Modules, event interface{}) {
+	if mods.Options().PausePolicy() == PauseDrop {
+		mods.Logger().Debugf("Dropping %T while paused", event)
+		return
+	}
+	mods.EventLoop().DelayUntil(ResumeEvent{}, event)
+}
+
+// holdProposal buffers a proposal that arrived while consensus is paused.
+// If the MaxPendingProposals bound is exceeded, the proposal for the view that is furthest ahead is dropped.
+// This is the only place where proposals are buffered; while not paused, OnPropose handles every proposal directly.
+func (cs *consensusBase) holdProposal(proposal ProposeMsg) {
+	if cs.mods.Options().PausePolicy() == PauseDrop {
+		holdWhilePaused(cs.mods, proposal)
+		return
+	}
+	cs.mut.Lock()
+	i := sort.Search(len(cs.pendingProposals), func(i int) bool {
+		return cs.pendingProposals[i].Block.View() > proposal.Block.View()
+	})
+	cs.pendingProposals = append(cs.pendingProposals, ProposeMsg{})
+	copy(cs.pendingProposals[i+1:], cs.pendingProposals[i:])
+	cs.pendingProposals[i] = proposal
+
+	bound := cs.mods.Options().MaxPendingProposals()
+	if bound == 0 || len(cs.pendingProposals) <= bound {
+		cs.mut.Unlock()
+		return
+	}
+	dropped := cs.pendingProposals[len(cs.pendingProposals)-1]
+	cs.pendingProposals = cs.pendingProposals[:len(cs.pendingProposals)-1]
+	cs.mut.Unlock()
+
+	cs.mods.Logger().Debugf("Dropping proposal for view %d: too many pending proposals", dropped.Block.View())
+	cs.reject(dropped, RejectBufferFull, "")
+}
+
+// releaseProposals handles the proposals that were buffered while consensus was paused.
+func (cs *consensusBase) releaseProposals() {
+	cs.mut.Lock()
+	proposals := cs.pendingProposals
+	cs.pendingProposals = nil
+	cs.mut.Unlock()
+	// must use a goroutine to avoid deadlock
+	go func() {
+		for _, proposal := range proposals {
+			cs.mods.EventLoop().AddEvent(proposal)
+		}
+	}()
 }
 
 // StopVoting ensures that no voting happens in a view earlier than `view`.
@@ -67,12 +254,30 @@ func (cs *consensusBase) StopVoting(view View) {
 	if cs.lastVote < view {
 		cs.lastVote = view
 	}
+	if cs.stoppedVoting < view {
+		cs.stoppedVoting = view
+	}
+}
+
+// pollCommand returns the next command if one is available, without waiting for more commands.
+func (cs *consensusBase) pollCommand() (cmd Command, ok bool) {
+	if poller, ok := cs.mods.CommandQueue().(CommandPoller); ok {
+		return poller.Poll()
+	}
+	ctx, cancel := context.WithCancel(cs.mods.Synchronizer().ViewContext())
+	cancel()
+	return cs.mods.CommandQueue().Get(ctx)
 }
 
 // Propose creates a new proposal.
 func (cs *consensusBase) Propose(cert SyncInfo) {
 	cs.mods.Logger().Debug("Propose")
 
+	if cs.Paused() {
+		cs.mods.Logger().Debug("Propose: consensus is paused")
+		return
+	}
+
 	qc, ok := cert.QC()
 	if ok {
 		// tell the acceptor that the previous proposal succeeded.
@@ -84,11 +289,36 @@ func (cs *consensusBase) Propose(cert SyncInfo) {
 		cs.mods.Acceptor().Proposed(qcBlock.Command())
 	}
 
-	cmd, ok := cs.mods.CommandQueue().Get(cs.mods.Synchronizer().ViewContext())
+	if cs.mods.Options().QuorumWaitStrategy() == QuorumWaitReachable && !cs.quorumReachable() {
+		return
+	}
+
+	if threshold := cs.mods.Options().StepDownThreshold(); threshold > 0 && cs.uncommittedProposals >= threshold {
+		view := cs.mods.Synchronizer().View()
+		cs.mods.Logger().Warnf("Propose: none of the last %d proposals were committed, stepping down as the leader of view %d",
+			cs.uncommittedProposals, view)
+		// give the leader a new chance the next time it leads.
+		cs.uncommittedProposals = 0
+		// must use a goroutine to avoid deadlock
+		go cs.mods.EventLoop().AddEvent(StepDownEvent{View: view})
+		return
+	}
+
+	var cmd Command
+	if cs.mods.Options().AllowEmptyProposals() {
+		// only take the commands that are available already; the view should not wait for commands.
+		cmd, ok = cs.pollCommand()
+	} else {
+		cmd, ok = cs.mods.CommandQueue().Get(cs.mods.Synchronizer().ViewContext())
+	}
 	//fmt.Println("Command", cmd, "Bool", ok)
 	if !ok {
-		cs.mods.Logger().Debug("Propose: No command")
-		return
+		if !cs.mods.Options().AllowEmptyProposals() {
+			cs.mods.Logger().Debug("Propose: No command")
+			return
+		}
+		cs.mods.Logger().Debug("Propose: No command, proposing an empty block")
+		cmd = ""
 	}
 
 	var proposal ProposeMsg
@@ -115,24 +345,102 @@ func (cs *consensusBase) Propose(cert SyncInfo) {
 		}
 	}
 
+	if tc, ok := cert.TC(); ok && cs.mods.Options().ShouldPiggybackTC() && tc.View() == proposal.Block.View()-1 {
+		// replicas that did not receive the timeouts for the previous view can use the TC to catch up.
+		proposal.TimeoutCert = &tc
+	}
+
 	fmt.Println("The proposal: ", proposal)
 
 	cs.mods.BlockChain().Store(proposal.Block)
 
+	proposal.Timestamp = time.Now()
 	cs.mods.Configuration().Propose(proposal)
+	cs.uncommittedProposals++
+	cs.mods.MetricsEventLoop().AddEvent(ProposalSentEvent{BlockHash: proposal.Block.Hash(), Time: time.Now()})
 	// self vote
 	cs.OnPropose(proposal)
 }
 
+// quorumReachable returns true if the local replica can reach a quorum of the voting replicas.
+// If it can only reach a bare quorum, a ReducedResilienceEvent is raised.
+// If it cannot reach a quorum, it steps down as the leader of the current view.
+func (cs *consensusBase) quorumReachable() bool {
+	voters, reachable := 0, 0
+	for id, replica := range cs.mods.Configuration().Replicas() {
+		if IsWitness(replica) {
+			continue
+		}
+		voters++
+		if id == cs.mods.ID() || IsConnected(replica) {
+			reachable++
+		}
+	}
+
+	view := cs.mods.Synchronizer().View()
+	quorum := cs.mods.Configuration().QuorumSize()
+	if reachable < quorum {
+		cs.mods.Logger().Warnf("Propose: only %d replicas are reachable, stepping down as the leader of view %d", reachable, view)
+		// must use a goroutine to avoid deadlock
+		go cs.mods.EventLoop().AddEvent(StepDownEvent{View: view})
+		return false
+	}
+	if reachable == quorum && reachable < voters {
+		cs.mods.Logger().Infof("Propose: only a bare quorum of %d replicas is reachable in view %d", reachable, view)
+		cs.mods.MetricsEventLoop().AddEvent(ReducedResilienceEvent{View: view, Reachable: reachable})
+	}
+	return true
+}
+
 func (cs *consensusBase) OnPropose(proposal ProposeMsg) {
 	cs.mods.Logger().Debugf("OnPropose: %v", proposal.Block)
 
 	block := proposal.Block
 
+	if cs.mods.Options().ShouldAckProposals() && proposal.ID != cs.mods.ID() {
+		if leader, ok := cs.mods.Configuration().Replica(proposal.ID); ok {
+			leader.AckProposal(block.Hash())
+		}
+	}
+
+	// the proposal may be echoed back to us, or delivered more than once.
+	if block.Hash() == cs.lastVoteBlock {
+		cs.mods.Logger().Debugf("OnPropose: already voted for block %.8s", block.Hash())
+		return
+	}
+
+	// a proposal for a view before the last voted view, or that is not above the last committed block,
+	// can never be voted for, so it is dropped before any work is done, such as verifying its certificates.
+	// Proposals for the last voted view are still checked for equivocation below.
+	if committed := cs.CommittedBlock().View(); block.View() < cs.lastVote || block.View() <= committed {
+		cs.reject(proposal, RejectStaleView, "OnPropose: dropping proposal for view %d, last voted view is %d and last committed view is %d",
+			block.View(), cs.lastVote, committed)
+		return
+	}
+
+	if limit := cs.mods.Options().MaxProposalSize(); limit > 0 && len(block.Command()) > limit {
+		cs.reject(proposal, RejectOversized, "OnPropose: dropping proposal for view %d, command of %d bytes exceeds the limit of %d bytes",
+			block.View(), len(block.Command()), limit)
+		return
+	}
+
+	if cs.mods.Options().DropAbandonedProposals() {
+		// the proposal may arrive late, after we have timed out and started collecting timeouts for a later view.
+		if view, ok := cs.mods.Synchronizer().TimedOutView(); ok && block.View() <= view {
+			cs.reject(proposal, RejectStaleView, "OnPropose: dropping proposal for view %d, which was abandoned after a timeout", block.View())
+			return
+		}
+	}
+
+	verifyProposer := cs.mods.Options().ShouldVerifyProposer()
+	if verifyProposer && !cs.checkProposer(proposal) {
+		return
+	}
+
 	if cs.mods.Options().ShouldUseAggQC() && proposal.AggregateQC != nil {
 		ok, highQC := cs.mods.Crypto().VerifyAggregateQC(*proposal.AggregateQC)
 		if !ok {
-			cs.mods.Logger().Warn("OnPropose: failed to verify aggregate QC")
+			cs.reject(proposal, RejectBadSignature, "OnPropose: failed to verify aggregate QC")
 			return
 		}
 		// NOTE: for simplicity, we require that the highQC found in the AggregateQC equals the QC embedded in the block.
@@ -143,20 +451,44 @@ func (cs *consensusBase) OnPropose(proposal ProposeMsg) {
 	}
 
 	if !cs.mods.Crypto().VerifyQuorumCert(block.QuorumCert()) {
-		cs.mods.Logger().Info("OnPropose: invalid QC")
+		cs.reject(proposal, RejectBadSignature, "OnPropose: invalid QC")
+		return
+	}
+
+	if cs.mods.Options().ValidateViewSkips() && !cs.viewJustified(proposal) {
+		cs.reject(proposal, RejectUnjustifiedView, "OnPropose: proposal for view %d skips views without a certificate that justifies it", block.View())
 		return
 	}
 
+	if tc := proposal.TimeoutCert; tc != nil && proposal.ID != cs.mods.ID() {
+		if tc.View() >= block.View() {
+			cs.mods.Logger().Infof("OnPropose: ignoring TC for view %d in proposal for view %d", tc.View(), block.View())
+		} else {
+			// the synchronizer verifies the TC before advancing the view.
+			cs.mods.Synchronizer().AdvanceView(NewSyncInfo().WithTC(*tc))
+		}
+	}
+
 	defer cs.mods.Synchronizer().AdvanceView(NewSyncInfo().WithQC(block.QuorumCert()))
 	// ensure the block came from the leader.
-	if proposal.ID != cs.mods.LeaderRotation().GetLeader(block.View()) {
+	if !verifyProposer && proposal.ID != cs.mods.LeaderRotation().GetLeader(block.View()) {
 		fmt.Println("proposal.ID", proposal.ID, "cs.GetLeader(block.View())", cs.mods.LeaderRotation().GetLeader(block.View()))
 		cs.mods.Logger().Info("OnPropose: block was not proposed by the expected leader")
 		return
 	}
 
+	// a block that does not extend the chain of its QC is not recorded as the proposal of the leader.
+	if !cs.checkAncestors(proposal) {
+		return
+	}
+
+	if !cs.checkEquivocation(proposal) {
+		return
+	}
+
 	if !cs.impl.VoteRule(proposal) {
 		cs.mods.Logger().Info("OnPropose: Block not voted for")
+		cs.abstain(proposal)
 		return
 	}
 
@@ -167,8 +499,14 @@ func (cs *consensusBase) OnPropose(proposal ProposeMsg) {
 		cs.mods.Acceptor().Proposed(qcBlock.Command())
 	}
 
+	if !cs.checkStale(proposal) {
+		cs.abstain(proposal)
+		return
+	}
+
 	if !cs.mods.Acceptor().Accept(block.Command()) {
 		cs.mods.Logger().Info("OnPropose: command not accepted")
+		cs.abstain(proposal)
 		return
 	}
 
@@ -181,8 +519,35 @@ func (cs *consensusBase) OnPropose(proposal ProposeMsg) {
 		}
 	}()
 
+	if cs.mods.Options().IsWitness() {
+		// witnesses store the block such that they can relay it, but they do not vote.
+		return
+	}
+
+	if cs.executionHalted() {
+		// the replica cannot execute the block, so it must not help to commit it.
+		cs.mods.Logger().Info("OnPropose: execution is halted, not voting")
+		return
+	}
+
 	if block.View() <= cs.lastVote {
-		cs.mods.Logger().Info("OnPropose: block view too old")
+		cs.reject(proposal, RejectStaleView, "OnPropose: block view too old")
+		return
+	}
+
+	if window := cs.mods.Options().VoteCoalesceWindow(); window > 0 {
+		if _, ok := cs.mods.Crypto().(BatchSigner); ok {
+			cs.lastVote = block.View()
+			cs.lastVoteBlock = block.Hash()
+			cs.coalesceVote(block, window)
+			return
+		}
+	}
+
+	if workers := cs.mods.Options().VoteSigningWorkers(); workers > 0 {
+		cs.lastVote = block.View()
+		cs.lastVoteBlock = block.Hash()
+		cs.signVoteAsync(block, workers)
 		return
 	}
 
@@ -193,8 +558,163 @@ func (cs *consensusBase) OnPropose(proposal ProposeMsg) {
 	}
 
 	cs.lastVote = block.View()
+	cs.lastVoteBlock = block.Hash()
+
+	cs.sendVote(block.View(), pc)
+}
+
+// checkStale returns false if the proposal should be rejected because all of its client commands
+// have already been committed, according to the StaleCommandPolicy option.
+func (cs *consensusBase) checkStale(proposal ProposeMsg) bool {
+	policy := cs.mods.Options().StaleCommandPolicy()
+	if policy == StaleIgnore {
+		return true
+	}
+	checker, ok := cs.mods.Acceptor().(StaleChecker)
+	if !ok || !checker.Stale(proposal.Block.Command()) {
+		return true
+	}
+	cs.mods.MetricsEventLoop().AddEvent(StaleProposalEvent{Proposer: proposal.ID, View: proposal.Block.View()})
+	if policy == StaleReject {
+		cs.reject(proposal, RejectStaleCommands, "OnPropose: replica %d proposed only committed commands in view %d", proposal.ID, proposal.Block.View())
+		return false
+	}
+	cs.mods.Logger().Warnf("OnPropose: replica %d proposed only committed commands in view %d", proposal.ID, proposal.Block.View())
+	return true
+}
+
+// checkEquivocation returns false if the proposer has proposed a different block for the same view.
+// The first conflicting block raises an EquivocationEvent, and no block from that proposer and view is voted for after that.
+// The proposals for views before the last view that was voted for are forgotten, as they cannot be voted for anymore,
+// such that the state does not grow without bound.
+func (cs *consensusBase) checkEquivocation(proposal ProposeMsg) bool {
+	for key := range cs.proposals {
+		if key.view < cs.lastVote {
+			delete(cs.proposals, key)
+		}
+	}
+
+	block := proposal.Block
+	key := proposalKey{view: block.View(), proposer: proposal.ID}
+	seen, ok := cs.proposals[key]
+	if !ok {
+		cs.proposals[key] = seenProposal{block: block.Hash()}
+		return true
+	}
+	if seen.block == block.Hash() && !seen.equivocated {
+		return true
+	}
+	if !seen.equivocated {
+		seen.equivocated = true
+		cs.proposals[key] = seen
+		cs.mods.MetricsEventLoop().AddEvent(EquivocationEvent{
+			Proposer: proposal.ID,
+			View:     block.View(),
+			First:    seen.block,
+			Second:   block.Hash(),
+		})
+	}
+	cs.reject(proposal, RejectEquivocation, "OnPropose: replica %d proposed conflicting blocks for view %d", proposal.ID, block.View())
+	return false
+}
+
+// checkAncestors returns true if the parent of the proposed block and the block certified by its QC are known,
+// or can be fetched from the other replicas, and the parent extends the certified block.
+// If the parent validation depth is set, the parent must also be the certified block,
+// and the same must hold for the ancestors of the block, up to that depth.
+// Otherwise, the proposal is rejected, as the block cannot be voted for without knowing the chain that it extends,
+// and a leader must not be able to propose atop a QC for a block that is not on that chain.
+func (cs *consensusBase) checkAncestors(proposal ProposeMsg) bool {
+	block := proposal.Block
+	parent, ok := cs.fetchBlock(block.Parent())
+	if !ok {
+		cs.reject(proposal, RejectUnknownBlock, "OnPropose: failed to fetch the parent of block %.8s", block.Hash())
+		return false
+	}
+	qc := block.QuorumCert()
+	certified, ok := cs.fetchBlock(qc.BlockHash())
+	if !ok {
+		cs.reject(proposal, RejectUnknownBlock, "OnPropose: failed to fetch the block certified by the QC of block %.8s", block.Hash())
+		return false
+	}
+	if certified.View() != qc.View() || !cs.mods.BlockChain().Extends(parent, certified) {
+		cs.reject(proposal, RejectBrokenChain, "OnPropose: block %.8s does not extend the block certified by its QC", block.Hash())
+		return false
+	}
+
+	ancestor := block
+	for i := 0; i < cs.mods.Options().ParentValidationDepth() && ancestor.View() > 0; i++ {
+		if ancestor.Parent() != ancestor.QuorumCert().BlockHash() {
+			cs.reject(proposal, RejectBrokenChain, "OnPropose: the parent of block %.8s does not match its QC", ancestor.Hash())
+			return false
+		}
+		next, ok := cs.fetchBlock(ancestor.Parent())
+		if !ok {
+			cs.reject(proposal, RejectUnknownBlock, "OnPropose: failed to fetch the parent of block %.8s", ancestor.Hash())
+			return false
+		}
+		ancestor = next
+	}
+	return true
+}
+
+// fetchBlock returns the block with the given hash, fetching it from the other replicas if it is not known.
+func (cs *consensusBase) fetchBlock(hash Hash) (*Block, bool) {
+	if block, ok := cs.mods.BlockChain().LocalGet(hash); ok {
+		return block, true
+	}
+	cs.mods.Logger().Debugf("OnPropose: block %.8s is unknown, fetching it", hash)
+	return cs.mods.BlockChain().Get(hash)
+}
+
+// abstain tells the leader that the proposal was received, but that the replica does not vote for it,
+// if abstaining is enabled.
+func (cs *consensusBase) abstain(proposal ProposeMsg) {
+	if !cs.mods.Options().ShouldAbstain() || cs.mods.Options().IsWitness() || proposal.ID == cs.mods.ID() {
+		return
+	}
+	if leader, ok := cs.mods.Configuration().Replica(proposal.ID); ok {
+		leader.Abstain(proposal.Block.View(), proposal.Block.Hash())
+	}
+}
+
+// checkProposer returns true if the proposal was sent by the replica that proposed the block,
+// and that replica is the leader of the view of the block according to the leader rotation.
+// Proposals from other replicas are rejected before any of their certificates are used.
+func (cs *consensusBase) checkProposer(proposal ProposeMsg) bool {
+	block := proposal.Block
+	if block.Proposer() != proposal.ID {
+		cs.reject(proposal, RejectWrongProposer, "OnPropose: replica %d sent a block proposed by replica %d", proposal.ID, block.Proposer())
+		return false
+	}
+	if leader := cs.mods.LeaderRotation().GetLeader(block.View()); proposal.ID != leader {
+		cs.reject(proposal, RejectWrongProposer, "OnPropose: replica %d proposed a block for view %d, but the leader is replica %d",
+			proposal.ID, block.View(), leader)
+		return false
+	}
+	return true
+}
+
+// viewJustified returns true if the proposal carries a certificate for the view before the view of the block.
+// This is either the QC in the block, or a valid TC or AggregateQC that shows that the previous view timed out.
+func (cs *consensusBase) viewJustified(proposal ProposeMsg) bool {
+	prev := proposal.Block.View() - 1
+	if proposal.Block.QuorumCert().View() == prev {
+		return true
+	}
+	if tc := proposal.TimeoutCert; tc != nil && tc.View() == prev {
+		return cs.mods.Crypto().VerifyTimeoutCert(*tc)
+	}
+	// the AggregateQC has already been verified by OnPropose.
+	if aggQC := proposal.AggregateQC; aggQC != nil && cs.mods.Options().ShouldUseAggQC() && aggQC.View() == prev {
+		return true
+	}
+	return false
+}
 
-	leaderID := cs.mods.LeaderRotation().GetLeader(cs.lastVote) //removed +1, no difference. Added -1
+// sendVote sends the vote to the leader of the given view.
+func (cs *consensusBase) sendVote(view View, pc PartialCert) {
+	leaderID := cs.mods.LeaderRotation().GetLeader(view) //removed +1, no difference. Added -1
 	if leaderID == cs.mods.ID() {
 		go cs.mods.EventLoop().AddEvent(VoteMsg{ID: cs.mods.ID(), PartialCert: pc})
 		return
@@ -211,11 +731,109 @@ func (cs *consensusBase) OnPropose(proposal ProposeMsg) {
 	leader.Vote(pc)
 }
 
+// signVoteAsync signs the vote for the block on one of the signing workers, such that the event loop
+// can process other events in the meantime. The signed vote is sent from the event loop.
+func (cs *consensusBase) signVoteAsync(block *Block, workers int) {
+	if cs.voteSigners == nil {
+		cs.voteSigners = make(chan struct{}, workers)
+	}
+	go func() {
+		cs.voteSigners <- struct{}{}
+		pc, err := cs.mods.Crypto().CreatePartialCert(block)
+		<-cs.voteSigners
+		if err != nil {
+			cs.mods.Logger().Error("OnPropose: failed to sign vote: ", err)
+			return
+		}
+		cs.mods.EventLoop().AddEvent(func() { cs.sendSignedVote(block.View(), pc) })
+	}()
+}
+
+// sendSignedVote sends a vote that was signed by a signing worker, or as part of a batch of coalesced votes.
+// The workers may finish out of order, so the vote is dropped if a vote for a later view has already been sent.
+// It is also dropped if voting was stopped for its view while it was being signed.
+func (cs *consensusBase) sendSignedVote(view View, pc PartialCert) {
+	if view <= cs.lastSentVote || view <= cs.stoppedVoting {
+		cs.mods.Logger().Debugf("OnPropose: dropping vote for view %d", view)
+		return
+	}
+	cs.lastSentVote = view
+	cs.sendVote(view, pc)
+}
+
+// coalesceVote delays the signing of the vote for the block until the window has passed,
+// such that it can be signed together with the votes for any other proposals that arrive in the meantime.
+func (cs *consensusBase) coalesceVote(block *Block, window time.Duration) {
+	cs.pendingVotes = append(cs.pendingVotes, block)
+	if len(cs.pendingVotes) > 1 {
+		// already waiting for the window to pass
+		return
+	}
+	time.AfterFunc(window, func() {
+		cs.mods.EventLoop().AddEvent(func() { cs.flushVotes() })
+	})
+}
+
+// flushVotes signs the pending votes as a batch and sends them.
+// Votes for views where voting was stopped while they were pending are dropped without being signed.
+func (cs *consensusBase) flushVotes() {
+	blocks := cs.pendingVotes[:0]
+	for _, block := range cs.pendingVotes {
+		if block.View() <= cs.lastSentVote || block.View() <= cs.stoppedVoting {
+			cs.mods.Logger().Debugf("OnPropose: dropping vote for view %d", block.View())
+			continue
+		}
+		blocks = append(blocks, block)
+	}
+	cs.pendingVotes = nil
+	if len(blocks) == 0 {
+		return
+	}
+
+	pcs, err := cs.mods.Crypto().(BatchSigner).CreatePartialCerts(blocks)
+	if err != nil {
+		cs.mods.Logger().Error("OnPropose: failed to sign votes: ", err)
+		return
+	}
+	for i, pc := range pcs {
+		cs.sendSignedVote(blocks[i].View(), pc)
+	}
+}
+
+// reject reports that the proposal was rejected for the given reason, and logs the message (see Modules.ReportRejection).
+func (cs *consensusBase) reject(proposal ProposeMsg, reason RejectReason, template string, args ...interface{}) {
+	cs.mods.ReportRejection(MessageRejectedEvent{Reason: reason, Type: "ProposeMsg", Sender: proposal.ID}, template, args...)
+}
+
 func (cs *consensusBase) commit(block *Block) {
 	cs.mut.Lock()
-	// can't recurse due to requiring the mutex, so we use a helper instead.
-	cs.commitInner(block)
+	locked := time.Now()
+	if cs.retryBlock == nil {
+		// can't recurse due to requiring the mutex, so we use a helper instead.
+		cs.commitInner(block)
+	}
+	if cs.retryBlock != nil && cs.retryBlock.View() < block.View() {
+		// the block is committed once the retry succeeds.
+		cs.retryBlock = block
+	}
+	halted := cs.execHalted || cs.retryBlock != nil
+	committed := cs.committed
+	cs.committed = nil
+	held := time.Since(locked)
 	cs.mut.Unlock()
+	cs.mods.MetricsEventLoop().AddEvent(LockHeldEvent{Duration: held})
+
+	// the handlers are called without holding the lock, such that they may use the consensus module.
+	for _, b := range committed {
+		for _, handler := range cs.commitHandlers {
+			handler(b)
+		}
+	}
+
+	if halted {
+		// the blocks that were not executed must be kept.
+		return
+	}
 
 	// prune the blockchain and handle forked blocks
 	forkedBlocks := cs.mods.BlockChain().PruneToHeight(block.View())
@@ -230,8 +848,83 @@ func (cs *consensusBase) commitInner(block *Block) {
 		if parent, ok := cs.mods.BlockChain().Get(block.Parent()); ok {
 			cs.commitInner(parent)
 		}
-		cs.mods.Logger().Debug("EXEC: ", block)
-		cs.mods.Executor().Exec(block)
+		if cs.execHalted || cs.retryBlock != nil || !cs.preExecCommit(block) {
+			return
+		}
+		if change, ok := ReconfigurationFromCommand(block.Command()); ok {
+			cs.reconfigure(block, change)
+		} else {
+			cs.mods.Logger().Debug("EXEC: ", block)
+			cs.mods.Executor().Exec(block)
+		}
 		cs.bExec = block
+		cs.committed = append(cs.committed, block)
+		if block.Proposer() == cs.mods.ID() {
+			cs.uncommittedProposals = 0
+		}
+	}
+}
+
+// reconfigure schedules the reconfiguration that was committed by the block.
+// The change takes effect ReconfigurationDelay views after the view of the block,
+// which is the same view at all replicas. Reconfigurations are not passed to the executor.
+func (cs *consensusBase) reconfigure(block *Block, change Reconfiguration) {
+	cfg, ok := cs.mods.Configuration().(Reconfigurable)
+	if !ok {
+		cs.mods.Logger().Warnf("Configuration does not support reconfiguration, ignoring %v", change)
+		return
+	}
+	view := block.View() + cs.mods.Options().ReconfigurationDelay()
+	if err := cfg.Reconfigure(change, view); err != nil {
+		cs.mods.Logger().Warnf("Failed to %v: %v", change, err)
+		return
+	}
+	cs.mods.Logger().Infof("Scheduled to %v in view %d", change, view)
+}
+
+// preExecCommit passes the block to the commit hook, if any, and returns true if the block may be executed.
+// If the hook fails, a retry is scheduled or execution is halted according to the PreExecPolicy option.
+// A scheduled retry is indicated by setting retryBlock, which must be set to the committed block by the caller.
+func (cs *consensusBase) preExecCommit(block *Block) bool {
+	hook := cs.mods.CommitHook()
+	if hook == nil {
+		return true
 	}
+	err := hook.PreExecCommit(block)
+	if err == nil {
+		cs.execRetries = 0
+		return true
+	}
+	if cs.mods.Options().PreExecPolicy() == PreExecRetry && cs.execRetries < PreExecRetries {
+		delay := PreExecRetryDelay << cs.execRetries
+		cs.execRetries++
+		cs.mods.Logger().Warnf("PreExecCommit failed for %v, retrying in %v: %v", block, delay, err)
+		cs.retryBlock = block
+		// the retry is handled by the event loop, such that the lock is not held while waiting.
+		time.AfterFunc(delay, func() {
+			cs.mods.EventLoop().AddEvent(func() { cs.retryCommit() })
+		})
+		return false
+	}
+	cs.mods.Logger().Errorf("PreExecCommit failed for %v, halting execution: %v", block, err)
+	cs.execHalted = true
+	return false
+}
+
+// retryCommit commits the blocks that were waiting for the CommitHook to be retried.
+func (cs *consensusBase) retryCommit() {
+	cs.mut.Lock()
+	block := cs.retryBlock
+	cs.retryBlock = nil
+	cs.mut.Unlock()
+	if block != nil {
+		cs.commit(block)
+	}
+}
+
+// executionHalted returns true if execution was halted because the CommitHook failed.
+func (cs *consensusBase) executionHalted() bool {
+	cs.mut.Lock()
+	defer cs.mut.Unlock()
+	return cs.execHalted
 }
@@ -2,12 +2,25 @@ package consensus_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/relab/hotstuff"
 	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/consensus/chainedhotstuff"
+	"github.com/relab/hotstuff/crypto"
+	"github.com/relab/hotstuff/crypto/bls12"
+	"github.com/relab/hotstuff/crypto/ecdsa"
+	"github.com/relab/hotstuff/internal/logging"
+	"github.com/relab/hotstuff/internal/mocks"
 	"github.com/relab/hotstuff/internal/testutil"
+	"github.com/relab/hotstuff/leaderrotation"
 	"github.com/relab/hotstuff/synchronizer"
 )
 
@@ -51,3 +64,1891 @@ func TestVote(t *testing.T) {
 		t.Error("No new view event happened")
 	}
 }
+
+// acceptAll is a consensus.Rules implementation that votes for every block and never commits.
+type acceptAll struct{}
+
+func (acceptAll) VoteRule(_ consensus.ProposeMsg) bool           { return true }
+func (acceptAll) CommitRule(_ *consensus.Block) *consensus.Block { return nil }
+
+func TestOnProposeParentValidation(t *testing.T) {
+	const n = 4
+	ctrl := gomock.NewController(t)
+	bl := testutil.CreateBuilders(t, ctrl, n)
+	bl[0].Options().SetParentValidationDepth(2)
+	sync := mocks.NewMockSynchronizer(ctrl)
+	sync.EXPECT().AdvanceView(gomock.Any()).AnyTimes()
+	bl[0].Register(consensus.New(acceptAll{}), sync)
+	hl := bl.Build()
+	hs := hl[0]
+	signers := hl.Signers()
+
+	// propose processes the proposal on the event loop and returns when it has been handled.
+	propose := func(block *consensus.Block) {
+		hs.EventLoop().AddEvent(consensus.ProposeMsg{ID: 1, Block: block})
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		hs.EventLoop().Run(ctx)
+	}
+
+	genesis := consensus.GetGenesis()
+	b1 := consensus.NewBlock(genesis.Hash(), consensus.NewQuorumCert(nil, 0, genesis.Hash()), "foo", 1, 1)
+	hs.BlockChain().Store(b1)
+	qc1 := testutil.CreateQC(t, b1, signers)
+
+	// b2 points to b1, but its QC references the genesis block
+	mismatched := consensus.NewBlock(b1.Hash(), consensus.NewQuorumCert(nil, 0, genesis.Hash()), "bar", 2, 1)
+	propose(mismatched)
+	if _, ok := hs.BlockChain().LocalGet(mismatched.Hash()); ok {
+		t.Error("block with mismatched parent and QC was accepted")
+	}
+
+	consistent := consensus.NewBlock(b1.Hash(), qc1, "bar", 2, 1)
+	propose(consistent)
+	if _, ok := hs.BlockChain().LocalGet(consistent.Hash()); !ok {
+		t.Error("block with matching parent and QC was rejected")
+	}
+}
+
+// TestProposalEcho checks that a leader whose proposal is echoed back by the configuration only votes once.
+func TestProposalEcho(t *testing.T) {
+	const n = 4
+	ctrl := gomock.NewController(t)
+	bl := testutil.CreateBuilders(t, ctrl, n)
+	sync := mocks.NewMockSynchronizer(ctrl)
+	sync.EXPECT().AdvanceView(gomock.Any()).AnyTimes()
+	sync.EXPECT().View().AnyTimes().Return(consensus.View(1))
+	sync.EXPECT().LeafBlock().AnyTimes().Return(consensus.GetGenesis())
+	sync.EXPECT().ViewContext().AnyTimes().Return(context.Background())
+	signer := &countingCrypto{Crypto: crypto.NewCache(ecdsa.New(), 10)}
+	bl[0].Register(consensus.New(acceptAll{}), sync, signer)
+	hl := bl.Build()
+	hs := hl[0]
+
+	cfg := hs.Configuration().(*mocks.MockConfiguration)
+	cfg.EXPECT().Propose(gomock.Any()).Do(func(proposal consensus.ProposeMsg) {
+		// the configuration includes the leader, so the proposal comes back to it
+		hs.EventLoop().AddEvent(proposal)
+	})
+
+	proposals := 0
+	hs.EventLoop().RegisterObserver(consensus.ProposeMsg{}, func(_ interface{}) {
+		proposals++
+	})
+
+	hs.Consensus().Propose(consensus.NewSyncInfo().WithQC(consensus.NewQuorumCert(nil, 0, consensus.GetGenesis().Hash())))
+
+	// the echoed proposal is already in the event queue, and the votes are signed while the proposal is handled.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	hs.EventLoop().Run(ctx)
+
+	if proposals != 1 {
+		t.Fatalf("expected the proposal to be echoed once, got %d", proposals)
+	}
+	if votes := atomic.LoadInt64(&signer.signed); votes != 1 {
+		t.Errorf("leader voted %d times for its own proposal", votes)
+	}
+}
+
+// TestPauseResume checks that a paused replica does not vote, and that buffered proposals are handled safely after resuming.
+func TestPauseResume(t *testing.T) {
+	const n = 4
+	ctrl := gomock.NewController(t)
+	bl := testutil.CreateBuilders(t, ctrl, n)
+	sync := mocks.NewMockSynchronizer(ctrl)
+	sync.EXPECT().AdvanceView(gomock.Any()).AnyTimes()
+	bl[0].Register(consensus.New(acceptAll{}), sync, testutil.NewLeaderRotation(t, 2, 2, 2))
+	hl := bl.Build()
+	hs := hl[0]
+
+	votes := make(chan consensus.PartialCert, 4)
+	leader, _ := hs.Configuration().Replica(2)
+	leader.(*mocks.MockReplica).EXPECT().Vote(gomock.Any()).AnyTimes().Do(func(pc consensus.PartialCert) {
+		votes <- pc
+	})
+
+	// the three proposals are observed once while paused, and once more when they are released after resuming.
+	const proposals = 6
+	// handled is closed when the event loop has handled all of the proposals.
+	handled := make(chan struct{})
+	seen := 0
+	hs.EventLoop().RegisterObserver(consensus.ProposeMsg{}, func(_ interface{}) {
+		seen++
+		if seen == proposals {
+			// observers run before the handler, so the channel is closed by an event that is queued after the proposal.
+			go hs.EventLoop().AddEvent(func() { close(handled) })
+		}
+	})
+
+	// received returns the votes that have been cast so far.
+	received := func() (got []consensus.PartialCert) {
+		for {
+			select {
+			case pc := <-votes:
+				got = append(got, pc)
+			default:
+				return got
+			}
+		}
+	}
+
+	genesis := consensus.GetGenesis()
+	genesisQC := consensus.NewQuorumCert(nil, 0, genesis.Hash())
+	b1 := consensus.NewBlock(genesis.Hash(), genesisQC, "foo", 1, 2)
+	// two conflicting proposals for the same view
+	b2 := consensus.NewBlock(b1.Hash(), genesisQC, "bar", 2, 2)
+	b2Fork := consensus.NewBlock(b1.Hash(), genesisQC, "baz", 2, 2)
+
+	hs.Consensus().Pause()
+	hs.EventLoop().AddEvent(consensus.ProposeMsg{ID: 2, Block: b1})
+	hs.EventLoop().AddEvent(consensus.ProposeMsg{ID: 2, Block: b2})
+	hs.EventLoop().AddEvent(consensus.ProposeMsg{ID: 2, Block: b2Fork})
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	hs.EventLoop().Run(cancelled)
+	if got := received(); len(got) != 0 {
+		t.Fatalf("paused replica voted %d times", len(got))
+	}
+
+	hs.Consensus().Resume()
+	if hs.Consensus().Paused() {
+		t.Fatal("consensus is still paused after Resume")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	done := make(chan struct{})
+	go func() {
+		hs.EventLoop().Run(ctx)
+		close(done)
+	}()
+	select {
+	case <-handled:
+	case <-ctx.Done():
+		t.Error("timed out waiting for the buffered proposals")
+	}
+	cancel()
+	<-done
+
+	got := received()
+	if len(got) != 2 {
+		t.Fatalf("expected 2 votes after resuming, got %d", len(got))
+	}
+	if got[0].BlockHash() != b1.Hash() {
+		t.Errorf("first vote was not for the first proposal")
+	}
+	if got[1].BlockHash() == got[0].BlockHash() {
+		t.Errorf("voted twice for the same block")
+	}
+}
+
+// TestMaxPendingProposals checks that a paused replica flooded with proposals for future views
+// buffers no more than the configured number of proposals, and drops those that are furthest ahead.
+func TestMaxPendingProposals(t *testing.T) {
+	const (
+		n       = 4
+		bound   = 3
+		flooded = 20
+	)
+	ctrl := gomock.NewController(t)
+	bl := testutil.CreateBuilders(t, ctrl, n)
+	bl[0].Options().SetMaxPendingProposals(bound)
+	sync := mocks.NewMockSynchronizer(ctrl)
+	sync.EXPECT().AdvanceView(gomock.Any()).AnyTimes()
+	bl[0].Register(consensus.New(acceptAll{}), sync, leaderrotation.NewFixed(2))
+	hl := bl.Build()
+	hs := hl[0]
+
+	var votes []consensus.PartialCert
+	leader, _ := hs.Configuration().Replica(2)
+	leader.(*mocks.MockReplica).EXPECT().Vote(gomock.Any()).AnyTimes().Do(func(pc consensus.PartialCert) {
+		votes = append(votes, pc)
+	})
+
+	drops := 0
+	hs.MetricsEventLoop().RegisterHandler(consensus.MessageRejectedEvent{}, func(event interface{}) {
+		if event.(consensus.MessageRejectedEvent).Reason == consensus.RejectBufferFull {
+			drops++
+		}
+	})
+
+	genesis := consensus.GetGenesis()
+	genesisQC := consensus.NewQuorumCert(nil, 0, genesis.Hash())
+	blocks := make([]*consensus.Block, 0, flooded)
+	parent := genesis
+	for view := consensus.View(1); view <= flooded; view++ {
+		block := consensus.NewBlock(parent.Hash(), genesisQC, "foo", view, 2)
+		blocks = append(blocks, block)
+		parent = block
+	}
+
+	hs.Consensus().Pause()
+	// deliver the proposals in reverse order, such that the proposals that are furthest ahead arrive first.
+	for i := len(blocks) - 1; i >= 0; i-- {
+		hs.EventLoop().AddEvent(consensus.ProposeMsg{ID: 2, Block: blocks[i]})
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	hs.EventLoop().Run(ctx)
+	hs.MetricsEventLoop().Run(ctx)
+
+	if len(votes) != 0 {
+		t.Fatalf("paused replica voted %d times", len(votes))
+	}
+	if drops != flooded-bound {
+		t.Errorf("wrong number of dropped proposals: got: %d, want: %d", drops, flooded-bound)
+	}
+
+	hs.Consensus().Resume()
+	ctx, cancel = context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	hs.EventLoop().Run(ctx)
+
+	if len(votes) != bound {
+		t.Fatalf("expected %d votes after resuming, got %d", bound, len(votes))
+	}
+	for i, vote := range votes {
+		if vote.BlockHash() != blocks[i].Hash() {
+			t.Errorf("vote %d was not for the proposal in view %d", i, blocks[i].View())
+		}
+	}
+}
+
+// batchSigner is a Crypto implementation that records the sizes of the batches passed to CreatePartialCerts.
+type batchSigner struct {
+	consensus.Crypto
+	batches []int
+}
+
+func (bs *batchSigner) InitConsensusModule(mods *consensus.Modules, opts *consensus.OptionsBuilder) {
+	if mod, ok := bs.Crypto.(consensus.Module); ok {
+		mod.InitConsensusModule(mods, opts)
+	}
+}
+
+func (bs *batchSigner) CreatePartialCerts(blocks []*consensus.Block) (certs []consensus.PartialCert, err error) {
+	bs.batches = append(bs.batches, len(blocks))
+	for _, block := range blocks {
+		pc, err := bs.CreatePartialCert(block)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, pc)
+	}
+	return certs, nil
+}
+
+// TestVoteCoalescing checks that the votes for proposals that arrive within the coalescing window are signed together.
+func TestVoteCoalescing(t *testing.T) {
+	const n = 4
+	ctrl := gomock.NewController(t)
+	bl := testutil.CreateBuilders(t, ctrl, n)
+	bl[0].Options().SetVoteCoalesceWindow(5 * time.Millisecond)
+	sync := mocks.NewMockSynchronizer(ctrl)
+	sync.EXPECT().AdvanceView(gomock.Any()).AnyTimes()
+	signer := &batchSigner{Crypto: crypto.NewCache(ecdsa.New(), 10)}
+	bl[0].Register(consensus.New(acceptAll{}), sync, signer, testutil.NewLeaderRotation(t, 2, 2, 2))
+	hl := bl.Build()
+	hs := hl[0]
+
+	const proposals = 3
+	votes := make(chan consensus.PartialCert, proposals)
+	leader, _ := hs.Configuration().Replica(2)
+	leader.(*mocks.MockReplica).EXPECT().Vote(gomock.Any()).Times(proposals).Do(func(pc consensus.PartialCert) {
+		votes <- pc
+	})
+
+	genesisQC := consensus.NewQuorumCert(nil, 0, consensus.GetGenesis().Hash())
+	parent := consensus.GetGenesis()
+	var blocks []*consensus.Block
+	for view := consensus.View(1); view <= proposals; view++ {
+		block := consensus.NewBlock(parent.Hash(), genesisQC, "foo", view, 2)
+		hs.EventLoop().AddEvent(consensus.ProposeMsg{ID: 2, Block: block})
+		blocks = append(blocks, block)
+		parent = block
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	go hs.EventLoop().Run(ctx)
+
+	for i := 0; i < proposals; i++ {
+		select {
+		case pc := <-votes:
+			if pc.BlockHash() != blocks[i].Hash() {
+				t.Errorf("vote %d was for the wrong block", i)
+			}
+		case <-ctx.Done():
+			t.Fatalf("timed out waiting for votes: got %d, want %d", i, proposals)
+		}
+	}
+	cancel()
+
+	if len(signer.batches) != 1 || signer.batches[0] != proposals {
+		t.Errorf("expected the votes to be signed in a single batch of %d, got batches: %v", proposals, signer.batches)
+	}
+}
+
+// TestVoteCoalescingStopVoting checks that coalesced votes for views where voting was stopped
+// while the votes were pending are neither signed nor sent.
+func TestVoteCoalescingStopVoting(t *testing.T) {
+	const n = 4
+	ctrl := gomock.NewController(t)
+	bl := testutil.CreateBuilders(t, ctrl, n)
+	bl[0].Options().SetVoteCoalesceWindow(5 * time.Millisecond)
+	sync := mocks.NewMockSynchronizer(ctrl)
+	sync.EXPECT().AdvanceView(gomock.Any()).AnyTimes()
+	signer := &batchSigner{Crypto: crypto.NewCache(ecdsa.New(), 10)}
+	bl[0].Register(consensus.New(acceptAll{}), sync, signer, testutil.NewLeaderRotation(t, 2, 2, 2))
+	hl := bl.Build()
+	hs := hl[0]
+
+	const (
+		proposals = 3
+		stopped   = 2 // the view that the replica times out of while the votes are pending
+	)
+	votes := make(chan consensus.PartialCert, proposals)
+	leader, _ := hs.Configuration().Replica(2)
+	leader.(*mocks.MockReplica).EXPECT().Vote(gomock.Any()).AnyTimes().Do(func(pc consensus.PartialCert) {
+		votes <- pc
+	})
+
+	genesisQC := consensus.NewQuorumCert(nil, 0, consensus.GetGenesis().Hash())
+	parent := consensus.GetGenesis()
+	var blocks []*consensus.Block
+	for view := consensus.View(1); view <= proposals; view++ {
+		block := consensus.NewBlock(parent.Hash(), genesisQC, "foo", view, 2)
+		hs.EventLoop().AddEvent(consensus.ProposeMsg{ID: 2, Block: block})
+		blocks = append(blocks, block)
+		parent = block
+	}
+	// handle the proposals, such that their votes are pending when voting is stopped.
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	hs.EventLoop().Run(cancelled)
+	hs.Consensus().StopVoting(stopped)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	done := make(chan struct{})
+	go func() {
+		hs.EventLoop().Run(ctx)
+		close(done)
+	}()
+	// the pending votes are flushed together, so the vote for the last view is the only vote that is sent.
+	select {
+	case pc := <-votes:
+		if pc.BlockHash() != blocks[proposals-1].Hash() {
+			t.Errorf("sent a vote for a view where voting was stopped")
+		}
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for the vote for the last view")
+	}
+	cancel()
+	<-done
+
+	if len(votes) != 0 {
+		t.Errorf("sent %d votes for views where voting was stopped", len(votes))
+	}
+	if len(signer.batches) != 1 || signer.batches[0] != 1 {
+		t.Errorf("expected only the vote for the last view to be signed, got batches: %v", signer.batches)
+	}
+}
+
+// TestOnProposeAbandonedView checks that a proposal that arrives after the replica has timed out in its view is dropped.
+func TestOnProposeAbandonedView(t *testing.T) {
+	const n = 4
+	ctrl := gomock.NewController(t)
+	bl := testutil.CreateBuilders(t, ctrl, n)
+	bl[0].Options().SetDropAbandonedProposals()
+	sync := mocks.NewMockSynchronizer(ctrl)
+	sync.EXPECT().AdvanceView(gomock.Any()).AnyTimes()
+	// the replica timed out in view 2, and is collecting timeouts to move on to view 3
+	sync.EXPECT().TimedOutView().AnyTimes().Return(consensus.View(2), true)
+	bl[0].Register(consensus.New(acceptAll{}), sync, testutil.NewLeaderRotation(t, 1, 1, 1))
+	hl := bl.Build()
+	hs := hl[0]
+
+	propose := func(block *consensus.Block) {
+		hs.EventLoop().AddEvent(consensus.ProposeMsg{ID: 1, Block: block})
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		hs.EventLoop().Run(ctx)
+	}
+
+	genesis := consensus.GetGenesis()
+	genesisQC := consensus.NewQuorumCert(nil, 0, genesis.Hash())
+
+	late := consensus.NewBlock(genesis.Hash(), genesisQC, "foo", 2, 1)
+	propose(late)
+	if _, ok := hs.BlockChain().LocalGet(late.Hash()); ok {
+		t.Error("late proposal for an abandoned view was accepted")
+	}
+
+	current := consensus.NewBlock(genesis.Hash(), genesisQC, "bar", 3, 1)
+	propose(current)
+	if _, ok := hs.BlockChain().LocalGet(current.Hash()); !ok {
+		t.Error("proposal for the next view was rejected")
+	}
+}
+
+// TestOnProposeAck checks that a replica acknowledges a proposal, even if it does not vote for it.
+func TestOnProposeAck(t *testing.T) {
+	const n = 4
+	ctrl := gomock.NewController(t)
+	bl := testutil.CreateBuilders(t, ctrl, n)
+	bl[0].Options().SetShouldAckProposals()
+	sync := mocks.NewMockSynchronizer(ctrl)
+	sync.EXPECT().AdvanceView(gomock.Any()).AnyTimes()
+	bl[0].Register(consensus.New(acceptAll{}), sync, testutil.NewLeaderRotation(t, 2, 2))
+	hl := bl.Build()
+	hs := hl[0]
+
+	genesis := consensus.GetGenesis()
+	// the block has a QC without signatures, so it will not be voted for
+	b1 := consensus.NewBlock(genesis.Hash(), consensus.NewQuorumCert(nil, 0, genesis.Hash()), "foo", 1, 2)
+	block := consensus.NewBlock(b1.Hash(), consensus.NewQuorumCert(nil, 1, b1.Hash()), "bar", 2, 2)
+
+	leader, _ := hs.Configuration().Replica(2)
+	leader.(*mocks.MockReplica).EXPECT().AckProposal(block.Hash())
+	leader.(*mocks.MockReplica).EXPECT().Vote(gomock.Any()).Times(0)
+
+	hs.EventLoop().AddEvent(consensus.ProposeMsg{ID: 2, Block: block})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	hs.EventLoop().Run(ctx)
+}
+
+// rejectAll is a consensus.Rules implementation that never votes and never commits.
+type rejectAll struct{}
+
+func (rejectAll) VoteRule(_ consensus.ProposeMsg) bool           { return false }
+func (rejectAll) CommitRule(_ *consensus.Block) *consensus.Block { return nil }
+
+// TestOnProposeAbstain checks that a replica that does not vote for a proposal tells the leader that it abstains.
+func TestOnProposeAbstain(t *testing.T) {
+	const n = 4
+	ctrl := gomock.NewController(t)
+	bl := testutil.CreateBuilders(t, ctrl, n)
+	bl[0].Options().SetShouldAbstain()
+	sync := mocks.NewMockSynchronizer(ctrl)
+	sync.EXPECT().AdvanceView(gomock.Any()).AnyTimes()
+	bl[0].Register(consensus.New(rejectAll{}), sync, testutil.NewLeaderRotation(t, 2, 2))
+	hl := bl.Build()
+	hs := hl[0]
+
+	genesis := consensus.GetGenesis()
+	block := consensus.NewBlock(genesis.Hash(), testutil.CreateQC(t, genesis, hl.Signers()), "foo", 1, 2)
+
+	leader, _ := hs.Configuration().Replica(2)
+	leader.(*mocks.MockReplica).EXPECT().Abstain(block.View(), block.Hash())
+	leader.(*mocks.MockReplica).EXPECT().Vote(gomock.Any()).Times(0)
+
+	hs.EventLoop().AddEvent(consensus.ProposeMsg{ID: 2, Block: block})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	hs.EventLoop().Run(ctx)
+}
+
+// TestOnProposeWitness checks that a witness stores the proposed block, such that it can relay it, but does not vote.
+func TestOnProposeWitness(t *testing.T) {
+	const n = 4
+	ctrl := gomock.NewController(t)
+	bl := testutil.CreateBuilders(t, ctrl, n)
+	bl[0].Options().SetWitness()
+	sync := mocks.NewMockSynchronizer(ctrl)
+	sync.EXPECT().AdvanceView(gomock.Any()).AnyTimes()
+	bl[0].Register(consensus.New(acceptAll{}), sync, testutil.NewLeaderRotation(t, 2, 2))
+	hl := bl.Build()
+	hs := hl[0]
+
+	genesis := consensus.GetGenesis()
+	block := consensus.NewBlock(genesis.Hash(), consensus.NewQuorumCert(nil, 0, genesis.Hash()), "foo", 1, 2)
+
+	leader, _ := hs.Configuration().Replica(2)
+	leader.(*mocks.MockReplica).EXPECT().Vote(gomock.Any()).Times(0)
+
+	hs.EventLoop().AddEvent(consensus.ProposeMsg{ID: 2, Block: block})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	hs.EventLoop().Run(ctx)
+
+	if _, ok := hs.BlockChain().LocalGet(block.Hash()); !ok {
+		t.Error("witness did not store the proposed block")
+	}
+}
+
+// TestQCAtQuorum checks that the leader creates a QC as soon as it has collected exactly a quorum of votes,
+// and that late votes do not create another QC.
+func TestQCAtQuorum(t *testing.T) {
+	const n = 4
+	ctrl := gomock.NewController(t)
+	bl := testutil.CreateBuilders(t, ctrl, n)
+	sync := mocks.NewMockSynchronizer(ctrl)
+	sync.EXPECT().LeafBlock().AnyTimes().Return(consensus.GetGenesis())
+	bl[0].Register(sync)
+	hl := bl.Build()
+	hs := hl[0]
+	signers := hl.Signers()
+
+	genesis := consensus.GetGenesis()
+	block := consensus.NewBlock(genesis.Hash(), consensus.NewQuorumCert(nil, 0, genesis.Hash()), "foo", 1, 1)
+	hs.BlockChain().Store(block)
+
+	var qcs []consensus.QuorumCert
+	created := make(chan struct{}, n)
+	hs.EventLoop().RegisterObserver(consensus.NewViewMsg{}, func(event interface{}) {
+		if qc, ok := event.(consensus.NewViewMsg).SyncInfo.QC(); ok {
+			qcs = append(qcs, qc)
+			created <- struct{}{}
+		}
+	})
+
+	// vote adds the votes from the given replicas and runs the event loop until a QC is created or the timeout expires.
+	vote := func(timeout time.Duration, ids ...hotstuff.ID) {
+		for _, id := range ids {
+			pc := testutil.CreatePC(t, block, signers[id-1])
+			hs.EventLoop().AddEvent(consensus.VoteMsg{ID: id, PartialCert: pc})
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		go func() {
+			select {
+			case <-created:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+		hs.EventLoop().Run(ctx)
+	}
+
+	vote(50*time.Millisecond, 1, 2)
+	if len(qcs) != 0 {
+		t.Fatal("QC was created before a quorum of votes was collected")
+	}
+
+	vote(time.Second, 3)
+	if len(qcs) != 1 {
+		t.Fatalf("expected a QC when a quorum of votes was collected, got %d", len(qcs))
+	}
+	signed := 0
+	qcs[0].Signature().Participants().ForEach(func(_ hotstuff.ID) { signed++ })
+	if signed != hotstuff.QuorumSize(n) {
+		t.Errorf("QC has %d signers, want %d", signed, hotstuff.QuorumSize(n))
+	}
+
+	vote(50*time.Millisecond, 4)
+	if len(qcs) != 1 {
+		t.Errorf("late vote created another QC: got %d QCs", len(qcs))
+	}
+}
+
+// TestAbstainNotCounted checks that the leader records abstain messages, but does not count them towards a QC.
+func TestAbstainNotCounted(t *testing.T) {
+	const n = 4
+	ctrl := gomock.NewController(t)
+	bl := testutil.CreateBuilders(t, ctrl, n)
+	sync := mocks.NewMockSynchronizer(ctrl)
+	sync.EXPECT().LeafBlock().AnyTimes().Return(consensus.GetGenesis())
+	bl[0].Register(sync)
+	hl := bl.Build()
+	hs := hl[0]
+	signers := hl.Signers()
+
+	genesis := consensus.GetGenesis()
+	block := consensus.NewBlock(genesis.Hash(), consensus.NewQuorumCert(nil, 0, genesis.Hash()), "foo", 1, 1)
+	hs.BlockChain().Store(block)
+
+	qcs := 0
+	hs.EventLoop().RegisterObserver(consensus.NewViewMsg{}, func(event interface{}) {
+		if _, ok := event.(consensus.NewViewMsg).SyncInfo.QC(); ok {
+			qcs++
+		}
+	})
+	var abstains []consensus.AbstainEvent
+	hs.MetricsEventLoop().RegisterHandler(consensus.AbstainEvent{}, func(event interface{}) {
+		abstains = append(abstains, event.(consensus.AbstainEvent))
+	})
+
+	for _, id := range []hotstuff.ID{1, 2} {
+		hs.EventLoop().AddEvent(consensus.VoteMsg{ID: id, PartialCert: testutil.CreatePC(t, block, signers[id-1])})
+	}
+	for _, id := range []hotstuff.ID{3, 4, 4} {
+		hs.EventLoop().AddEvent(consensus.AbstainMsg{ID: id, View: block.View(), BlockHash: block.Hash()})
+	}
+
+	// the votes are verified asynchronously, so we give them some time to complete.
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	hs.EventLoop().Run(ctx)
+	hs.MetricsEventLoop().Run(ctx)
+
+	if qcs != 0 {
+		t.Error("abstain messages were counted towards a QC")
+	}
+	// the duplicate abstain message is not recorded.
+	if len(abstains) != 2 {
+		t.Fatalf("expected 2 recorded abstain messages, got %d", len(abstains))
+	}
+	if abstains[1].Abstained != 2 {
+		t.Errorf("wrong number of abstains for the block: got %d, want %d", abstains[1].Abstained, 2)
+	}
+}
+
+// TestPiggybackTC checks that a leader that was moved to a new view by a TC includes the TC in its proposal,
+// and that a replica that did not receive the TC catches up from the proposal.
+// ValidateViewSkips implies PiggybackTC, such that the proposal is not rejected for skipping views.
+func TestPiggybackTC(t *testing.T) {
+	tests := []struct {
+		name   string
+		enable func(*consensus.OptionsBuilder)
+	}{
+		{name: "PiggybackTC", enable: (*consensus.OptionsBuilder).SetShouldPiggybackTC},
+		{name: "ValidateViewSkips", enable: (*consensus.OptionsBuilder).SetValidateViewSkips},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testPiggybackTC(t, tt.enable)
+		})
+	}
+}
+
+func testPiggybackTC(t *testing.T, enable func(*consensus.OptionsBuilder)) {
+	const n = 4
+	ctrl := gomock.NewController(t)
+	bl := testutil.CreateBuilders(t, ctrl, n)
+	for _, builder := range bl[:2] {
+		enable(builder.Options())
+		builder.Register(
+			consensus.New(acceptAll{}),
+			synchronizer.New(testutil.FixedTimeout(1000)),
+			testutil.NewLeaderRotation(t, 1, 1, 1, 1),
+		)
+	}
+	hl := bl.Build()
+	leader, replica := hl[0], hl[1]
+
+	for _, hs := range hl[:2] {
+		peer, _ := hs.Configuration().Replica(1)
+		peer.(*mocks.MockReplica).EXPECT().Vote(gomock.Any()).AnyTimes()
+		peer.(*mocks.MockReplica).EXPECT().NewView(gomock.Any()).AnyTimes()
+	}
+
+	// the replicas timed out in views 1 and 2, but only the leader received the TC for view 2
+	tc := testutil.CreateTC(t, 2, hl.Signers())
+	var proposal consensus.ProposeMsg
+	leader.Configuration().(*mocks.MockConfiguration).EXPECT().Propose(gomock.Any()).Do(func(p consensus.ProposeMsg) {
+		proposal = p
+	})
+	leader.Synchronizer().AdvanceView(consensus.NewSyncInfo().WithQC(leader.Synchronizer().HighQC()).WithTC(tc))
+	if leader.Synchronizer().View() != 3 {
+		t.Fatalf("leader is in view %d, want 3", leader.Synchronizer().View())
+	}
+	if proposal.TimeoutCert == nil || proposal.TimeoutCert.View() != tc.View() {
+		t.Fatalf("proposal does not include the TC: %v", proposal.TimeoutCert)
+	}
+
+	if replica.Synchronizer().View() != 1 {
+		t.Fatalf("replica is in view %d, want 1", replica.Synchronizer().View())
+	}
+	proposal.ID = leader.ID()
+	replica.EventLoop().AddEvent(proposal)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	replica.EventLoop().Run(ctx)
+
+	if replica.Synchronizer().View() != 3 {
+		t.Errorf("replica did not catch up from the proposal: in view %d, want 3", replica.Synchronizer().View())
+	}
+	if _, ok := replica.BlockChain().LocalGet(proposal.Block.Hash()); !ok {
+		t.Error("replica did not accept the proposal")
+	}
+}
+
+// reachableReplica is a replica that reports whether it is connected.
+type reachableReplica struct {
+	*mocks.MockReplica
+	connected *bool
+}
+
+func (r reachableReplica) Connected() bool {
+	return *r.connected
+}
+
+func TestQuorumWaitReachable(t *testing.T) {
+	const n = 4
+	ctrl := gomock.NewController(t)
+	builder := testutil.TestModules(t, ctrl, 1, testutil.GenerateECDSAKey(t))
+	builder.Options().SetQuorumWaitStrategy(consensus.QuorumWaitReachable)
+
+	connected := make(map[hotstuff.ID]*bool)
+	replicas := make(map[hotstuff.ID]consensus.Replica)
+	cfg := mocks.NewMockConfiguration(ctrl)
+	for id := hotstuff.ID(1); id <= n; id++ {
+		connected[id] = new(bool)
+		*connected[id] = id != 1 // the local replica does not report as connected to itself
+		replicas[id] = reachableReplica{testutil.CreateMockReplica(t, ctrl, id, testutil.GenerateECDSAKey(t).Public()), connected[id]}
+	}
+	cfg.EXPECT().Len().AnyTimes().Return(n)
+	cfg.EXPECT().QuorumSize().AnyTimes().Return(hotstuff.QuorumSize(n))
+	cfg.EXPECT().Replicas().AnyTimes().Return(replicas)
+	cfg.EXPECT().Replica(gomock.Any()).AnyTimes().DoAndReturn(func(id hotstuff.ID) (consensus.Replica, bool) {
+		replica, ok := replicas[id]
+		return replica, ok
+	})
+	proposals := 0
+	cfg.EXPECT().Propose(gomock.Any()).AnyTimes().Do(func(_ consensus.ProposeMsg) { proposals++ })
+	var timeouts []consensus.TimeoutMsg
+	cfg.EXPECT().Timeout(gomock.Any()).AnyTimes().Do(func(msg consensus.TimeoutMsg) { timeouts = append(timeouts, msg) })
+
+	builder.Register(cfg, consensus.New(acceptAll{}), synchronizer.New(testutil.FixedTimeout(1000)))
+	hs := builder.Build()
+
+	var reduced []consensus.ReducedResilienceEvent
+	hs.MetricsEventLoop().RegisterObserver(consensus.ReducedResilienceEvent{}, func(event interface{}) {
+		reduced = append(reduced, event.(consensus.ReducedResilienceEvent))
+	})
+	run := func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		hs.EventLoop().Run(ctx)
+		hs.MetricsEventLoop().Run(ctx)
+	}
+	propose := func() {
+		hs.Consensus().Propose(consensus.NewSyncInfo().WithQC(hs.Synchronizer().HighQC()))
+		run()
+	}
+
+	// all replicas are reachable
+	propose()
+	if proposals != 1 || len(reduced) != 0 {
+		t.Fatalf("with all replicas reachable: got %d proposals and %d reduced resilience events, want 1 and 0", proposals, len(reduced))
+	}
+
+	// a bare quorum is reachable
+	*connected[4] = false
+	propose()
+	if proposals != 2 {
+		t.Fatalf("leader did not propose with a bare quorum reachable")
+	}
+	if len(reduced) != 1 || reduced[0].Reachable != hotstuff.QuorumSize(n) || reduced[0].View != 1 {
+		t.Fatalf("wrong reduced resilience events: %v", reduced)
+	}
+
+	// only a minority is reachable
+	*connected[3] = false
+	propose()
+	if proposals != 2 {
+		t.Fatalf("leader proposed without a quorum reachable")
+	}
+	// the step down is delivered to the event loop asynchronously
+	deadline := time.Now().Add(time.Second)
+	for len(timeouts) == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+		run()
+	}
+	if len(timeouts) != 1 || timeouts[0].View != 1 {
+		t.Errorf("leader did not time out the view after stepping down: %v", timeouts)
+	}
+}
+
+// TestVoteSigningWorkers checks that votes signed by the signing workers are sent to the leader in the order of their views.
+func TestVoteSigningWorkers(t *testing.T) {
+	const (
+		n         = 4
+		proposals = 20
+	)
+	ctrl := gomock.NewController(t)
+	bl := testutil.CreateBuilders(t, ctrl, n)
+	bl[0].Options().SetVoteSigningWorkers(4)
+	sync := mocks.NewMockSynchronizer(ctrl)
+	sync.EXPECT().AdvanceView(gomock.Any()).AnyTimes()
+	bl[0].Register(consensus.New(acceptAll{}), sync, leaderrotation.NewFixed(2))
+	hl := bl.Build()
+	hs := hl[0]
+
+	var votes []consensus.PartialCert
+	leader, _ := hs.Configuration().Replica(2)
+	leader.(*mocks.MockReplica).EXPECT().Vote(gomock.Any()).AnyTimes().Do(func(pc consensus.PartialCert) {
+		votes = append(votes, pc)
+	})
+
+	genesisQC := consensus.NewQuorumCert(nil, 0, consensus.GetGenesis().Hash())
+	parent := consensus.GetGenesis()
+	blocks := make(map[consensus.Hash]*consensus.Block)
+	var last *consensus.Block
+	for view := consensus.View(1); view <= proposals; view++ {
+		block := consensus.NewBlock(parent.Hash(), genesisQC, "foo", view, 2)
+		hs.EventLoop().AddEvent(consensus.ProposeMsg{ID: 2, Block: block})
+		blocks[block.Hash()] = block
+		parent, last = block, block
+	}
+
+	// the votes are signed concurrently, and are sent once the event loop handles the signed votes.
+	deadline := time.Now().Add(time.Second)
+	for (len(votes) == 0 || votes[len(votes)-1].BlockHash() != last.Hash()) && time.Now().Before(deadline) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		hs.EventLoop().Run(ctx)
+		time.Sleep(time.Millisecond)
+	}
+	if len(votes) == 0 || votes[len(votes)-1].BlockHash() != last.Hash() {
+		t.Fatalf("did not vote for the last proposal: got %d votes", len(votes))
+	}
+
+	var prev consensus.View
+	for _, pc := range votes {
+		block, ok := blocks[pc.BlockHash()]
+		if !ok {
+			t.Fatalf("vote for unknown block %.8s", pc.BlockHash())
+		}
+		if block.View() <= prev {
+			t.Errorf("vote for view %d was sent after the vote for view %d", block.View(), prev)
+		}
+		prev = block.View()
+		if !hs.Crypto().VerifyPartialCert(pc) {
+			t.Errorf("vote for view %d has an invalid signature", block.View())
+		}
+	}
+}
+
+// commitRecorder records the calls to PreExecCommit and Exec.
+// PreExecCommit fails the first failures times it is called with the block from failView.
+// The done channel is closed once the expected number of calls have been recorded.
+type commitRecorder struct {
+	calls    []string
+	failView consensus.View
+	failures int
+	expected int
+	done     chan struct{}
+}
+
+func (r *commitRecorder) record(call string) {
+	r.calls = append(r.calls, call)
+	if len(r.calls) == r.expected {
+		close(r.done)
+	}
+}
+
+func (r *commitRecorder) PreExecCommit(block *consensus.Block) error {
+	r.record(fmt.Sprintf("commit %d", block.View()))
+	if block.View() == r.failView && r.failures > 0 {
+		r.failures--
+		return errors.New("failed to write to log")
+	}
+	return nil
+}
+
+func (r *commitRecorder) Exec(block *consensus.Block) {
+	r.record(fmt.Sprintf("exec %d", block.View()))
+}
+
+// TestPreExecCommit checks that the commit hook is called before each block is executed, in commit order,
+// and that a failing hook is retried or halts execution according to the policy.
+// The retries are scheduled on the event loop, such that proposals are handled while waiting to retry.
+// Once execution is halted, the replica stops voting.
+func TestPreExecCommit(t *testing.T) {
+	const proposals = 8 // the blocks from view 1 to 5 are committed by the three-chain rule
+	tests := []struct {
+		name      string
+		policy    consensus.PreExecPolicy
+		failView  consensus.View
+		failures  int
+		want      []string
+		committed consensus.View
+		votes     int
+	}{
+		{name: "NoFailures", want: []string{
+			"commit 1", "exec 1", "commit 2", "exec 2", "commit 3", "exec 3", "commit 4", "exec 4", "commit 5", "exec 5",
+		}, committed: 5, votes: proposals},
+		{name: "Retry", policy: consensus.PreExecRetry, failView: 2, failures: 1, want: []string{
+			"commit 1", "exec 1", "commit 2", "commit 2", "exec 2", "commit 3", "exec 3", "commit 4", "exec 4", "commit 5", "exec 5",
+		}, committed: 5, votes: proposals},
+		{name: "RetryExhausted", policy: consensus.PreExecRetry, failView: 2, failures: consensus.PreExecRetries + 1, want: []string{
+			"commit 1", "exec 1", "commit 2", "commit 2", "commit 2", "commit 2",
+		}, committed: 1, votes: proposals},
+		// the block from view 2 is committed by the proposal for view 5, after the vote for it has been sent.
+		{name: "Halt", policy: consensus.PreExecHalt, failView: 2, failures: 1, want: []string{
+			"commit 1", "exec 1", "commit 2",
+		}, committed: 1, votes: 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			const n = 4
+			ctrl := gomock.NewController(t)
+			bl := testutil.CreateBuilders(t, ctrl, n)
+			recorder := &commitRecorder{failView: tt.failView, failures: tt.failures, expected: len(tt.want), done: make(chan struct{})}
+			bl[0].Options().SetPreExecPolicy(tt.policy)
+			bl[0].Register(
+				consensus.New(chainedhotstuff.New()),
+				synchronizer.New(testutil.FixedTimeout(1000)),
+				leaderrotation.NewFixed(2),
+				recorder,
+			)
+			hl := bl.Build()
+			hs := hl[0]
+			signers := hl.Signers()
+
+			votes := 0
+			leader, _ := hs.Configuration().Replica(2)
+			leader.(*mocks.MockReplica).EXPECT().Vote(gomock.Any()).AnyTimes().Do(func(consensus.PartialCert) { votes++ })
+			leader.(*mocks.MockReplica).EXPECT().NewView(gomock.Any()).AnyTimes()
+
+			parent := consensus.GetGenesis()
+			qc := consensus.NewQuorumCert(nil, 0, parent.Hash())
+			for v := consensus.View(1); v <= proposals; v++ {
+				block := consensus.NewBlock(parent.Hash(), qc, "foo", v, 2)
+				hs.EventLoop().AddEvent(consensus.ProposeMsg{ID: 2, Block: block})
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				hs.EventLoop().Run(ctx)
+				qc = testutil.CreateQC(t, block, signers)
+				parent = block
+			}
+			// run the event loop until the retries are done.
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			go func() {
+				select {
+				case <-recorder.done:
+				case <-ctx.Done():
+				}
+				cancel()
+			}()
+			hs.EventLoop().Run(ctx)
+
+			if votes != tt.votes {
+				t.Errorf("wrong number of votes: got: %d, want: %d", votes, tt.votes)
+			}
+			if !reflect.DeepEqual(recorder.calls, tt.want) {
+				t.Errorf("wrong calls:\n got: %v\nwant: %v", recorder.calls, tt.want)
+			}
+			if got := hs.Consensus().CommittedBlock().View(); got != tt.committed {
+				t.Errorf("wrong committed block: got view %d, want view %d", got, tt.committed)
+			}
+		})
+	}
+}
+
+// TestCommitHandler checks that the commit handlers are called once for each committed block, in chain order,
+// also when a three-chain commits several blocks at once.
+func TestCommitHandler(t *testing.T) {
+	const n = 4
+	ctrl := gomock.NewController(t)
+	bl := testutil.CreateBuilders(t, ctrl, n)
+	bl[0].Register(
+		consensus.New(chainedhotstuff.New()),
+		synchronizer.New(testutil.FixedTimeout(1000)),
+		leaderrotation.NewFixed(2),
+	)
+	hl := bl.Build()
+	hs := hl[0]
+	signers := hl.Signers()
+
+	leader, _ := hs.Configuration().Replica(2)
+	leader.(*mocks.MockReplica).EXPECT().Vote(gomock.Any()).AnyTimes()
+	leader.(*mocks.MockReplica).EXPECT().NewView(gomock.Any()).AnyTimes()
+
+	var committed []consensus.View
+	hs.Consensus().RegisterCommitHandler(func(block *consensus.Block) {
+		committed = append(committed, block.View())
+	})
+
+	genesis := consensus.GetGenesis()
+	genesisQC := consensus.NewQuorumCert(nil, 0, genesis.Hash())
+
+	// propose handles a proposal for a new block with the given parent and QC, and returns the block.
+	propose := func(view consensus.View, parent *consensus.Block, qc consensus.QuorumCert) *consensus.Block {
+		block := consensus.NewBlock(parent.Hash(), qc, consensus.Command(fmt.Sprintf("cmd%d", view)), view, 2)
+		hs.EventLoop().AddEvent(consensus.ProposeMsg{ID: 2, Block: block})
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		hs.EventLoop().Run(ctx)
+		return block
+	}
+
+	// the block from view 2 does not carry a QC for its parent, so the block from view 1
+	// is only committed together with the block from view 2.
+	b1 := propose(1, genesis, genesisQC)
+	b2 := propose(2, b1, genesisQC)
+	parent := b2
+	for v := consensus.View(3); v <= 5; v++ {
+		parent = propose(v, parent, testutil.CreateQC(t, parent, signers))
+	}
+	if want := []consensus.View{1, 2}; !reflect.DeepEqual(committed, want) {
+		t.Fatalf("wrong committed blocks after the first three-chain: got %v, want %v", committed, want)
+	}
+
+	propose(6, parent, testutil.CreateQC(t, parent, signers))
+	if want := []consensus.View{1, 2, 3}; !reflect.DeepEqual(committed, want) {
+		t.Errorf("wrong committed blocks: got %v, want %v", committed, want)
+	}
+}
+
+// TestOnProposeViewSkip checks that proposals that skip views are only accepted with a TC for the previous view.
+func TestOnProposeViewSkip(t *testing.T) {
+	const n = 4
+	ctrl := gomock.NewController(t)
+	bl := testutil.CreateBuilders(t, ctrl, n)
+	bl[0].Options().SetValidateViewSkips()
+	sync := mocks.NewMockSynchronizer(ctrl)
+	sync.EXPECT().AdvanceView(gomock.Any()).AnyTimes()
+	// the vote for an accepted proposal may be processed before the event loop stops.
+	sync.EXPECT().LeafBlock().Return(consensus.GetGenesis()).AnyTimes()
+	bl[0].Register(consensus.New(acceptAll{}), sync)
+	hl := bl.Build()
+	hs := hl[0]
+	signers := hl.Signers()
+
+	// propose processes the proposal on the event loop and returns true if the block was accepted.
+	propose := func(block *consensus.Block, tc *consensus.TimeoutCert) bool {
+		hs.EventLoop().AddEvent(consensus.ProposeMsg{ID: 1, Block: block, TimeoutCert: tc})
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		hs.EventLoop().Run(ctx)
+		_, ok := hs.BlockChain().LocalGet(block.Hash())
+		return ok
+	}
+
+	genesis := consensus.GetGenesis()
+	genesisQC := consensus.NewQuorumCert(nil, 0, genesis.Hash())
+
+	// the QC is for the previous view, so no views are skipped
+	if !propose(consensus.NewBlock(genesis.Hash(), genesisQC, "foo", 1, 1), nil) {
+		t.Error("proposal for the view after its QC was rejected")
+	}
+
+	// views 1 and 2 timed out, so the proposal for view 3 skips views
+	if propose(consensus.NewBlock(genesis.Hash(), genesisQC, "bar", 3, 1), nil) {
+		t.Error("proposal that skips views without a TC was accepted")
+	}
+	tc1 := testutil.CreateTC(t, 1, signers)
+	if propose(consensus.NewBlock(genesis.Hash(), genesisQC, "baz", 3, 1), &tc1) {
+		t.Error("proposal that skips views with a TC for an earlier view was accepted")
+	}
+	tc2 := testutil.CreateTC(t, 2, signers)
+	if !propose(consensus.NewBlock(genesis.Hash(), genesisQC, "qux", 3, 1), &tc2) {
+		t.Error("proposal that skips views with a TC for the previous view was rejected")
+	}
+}
+
+// commitAll is a Rules implementation that votes for all blocks and commits each block immediately.
+type commitAll struct{}
+
+func (commitAll) VoteRule(_ consensus.ProposeMsg) bool               { return true }
+func (commitAll) CommitRule(block *consensus.Block) *consensus.Block { return block }
+
+// TestStepDownThreshold checks that a leader steps down after proposing a number of blocks that are not committed,
+// and that it keeps leading while its blocks are committed.
+func TestStepDownThreshold(t *testing.T) {
+	const (
+		n         = 4
+		threshold = 3
+		views     = 6
+	)
+	tests := []struct {
+		name      string
+		rules     consensus.Rules
+		proposals int
+	}{
+		{name: "NoCommits", rules: acceptAll{}, proposals: threshold},
+		{name: "Commits", rules: commitAll{}, proposals: views},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			bl := testutil.CreateBuilders(t, ctrl, n)
+			bl[0].Options().SetStepDownThreshold(threshold)
+			bl[0].Register(consensus.New(tt.rules), synchronizer.New(testutil.FixedTimeout(1000)), leaderrotation.NewFixed(1))
+			hl := bl.Build()
+			hs := hl[0]
+			signers := hl.Signers()
+
+			cfg := hs.Configuration().(*mocks.MockConfiguration)
+			var proposals []consensus.ProposeMsg
+			cfg.EXPECT().Propose(gomock.Any()).AnyTimes().Do(func(p consensus.ProposeMsg) { proposals = append(proposals, p) })
+			var timeouts []consensus.TimeoutMsg
+			cfg.EXPECT().Timeout(gomock.Any()).AnyTimes().Do(func(msg consensus.TimeoutMsg) { timeouts = append(timeouts, msg) })
+
+			run := func() {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				hs.EventLoop().Run(ctx)
+			}
+
+			// the leader proposes in each view, and each proposal is certified by a QC that moves it to the next view.
+			hs.Consensus().Propose(consensus.NewSyncInfo().WithQC(hs.Synchronizer().HighQC()))
+			for view := consensus.View(1); view < views && len(proposals) == int(view); view++ {
+				qc := testutil.CreateQC(t, proposals[view-1].Block, signers)
+				hs.Synchronizer().AdvanceView(consensus.NewSyncInfo().WithQC(qc))
+				run()
+			}
+			if len(proposals) != tt.proposals {
+				t.Fatalf("wrong number of proposals: got: %d, want: %d", len(proposals), tt.proposals)
+			}
+			if tt.proposals == views {
+				if len(timeouts) != 0 {
+					t.Errorf("leader stepped down although its blocks were committed: %v", timeouts)
+				}
+				return
+			}
+
+			// the step down is delivered to the event loop asynchronously
+			deadline := time.Now().Add(time.Second)
+			for len(timeouts) == 0 && time.Now().Before(deadline) {
+				time.Sleep(time.Millisecond)
+				run()
+			}
+			if len(timeouts) != 1 || timeouts[0].View != threshold+1 {
+				t.Errorf("leader did not time out view %d after stepping down: %v", threshold+1, timeouts)
+			}
+		})
+	}
+}
+
+// TestOnProposeWrongProposer checks that proposals from replicas other than the leader are rejected
+// without advancing the view.
+func TestOnProposeWrongProposer(t *testing.T) {
+	const n = 4
+	ctrl := gomock.NewController(t)
+	bl := testutil.CreateBuilders(t, ctrl, n)
+	bl[0].Options().SetShouldVerifyProposer()
+	sync := mocks.NewMockSynchronizer(ctrl)
+	// only the proposal from the leader may advance the view
+	sync.EXPECT().AdvanceView(gomock.Any()).Times(1)
+	bl[0].Register(consensus.New(acceptAll{}), sync, leaderrotation.NewFixed(2))
+	hl := bl.Build()
+	hs := hl[0]
+
+	leader, _ := hs.Configuration().Replica(2)
+	leader.(*mocks.MockReplica).EXPECT().Vote(gomock.Any()).AnyTimes()
+
+	var rejected []consensus.MessageRejectedEvent
+	hs.MetricsEventLoop().RegisterObserver(consensus.MessageRejectedEvent{}, func(event interface{}) {
+		rejected = append(rejected, event.(consensus.MessageRejectedEvent))
+	})
+
+	// propose processes the proposal on the event loops and returns true if the block was accepted.
+	propose := func(sender hotstuff.ID, block *consensus.Block) bool {
+		hs.EventLoop().AddEvent(consensus.ProposeMsg{ID: sender, Block: block})
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		hs.EventLoop().Run(ctx)
+		hs.MetricsEventLoop().Run(ctx)
+		_, ok := hs.BlockChain().LocalGet(block.Hash())
+		return ok
+	}
+
+	genesis := consensus.GetGenesis()
+	genesisQC := consensus.NewQuorumCert(nil, 0, genesis.Hash())
+
+	if propose(3, consensus.NewBlock(genesis.Hash(), genesisQC, "foo", 1, 3)) {
+		t.Error("proposal from a replica that is not the leader was accepted")
+	}
+	if propose(3, consensus.NewBlock(genesis.Hash(), genesisQC, "bar", 1, 2)) {
+		t.Error("proposal with a block from another proposer was accepted")
+	}
+	if len(rejected) != 2 {
+		t.Fatalf("expected 2 rejections, got %d", len(rejected))
+	}
+	for _, event := range rejected {
+		if event.Reason != consensus.RejectWrongProposer || event.Sender != 3 {
+			t.Errorf("wrong rejection: %+v", event)
+		}
+	}
+
+	if !propose(2, consensus.NewBlock(genesis.Hash(), genesisQC, "baz", 1, 2)) {
+		t.Error("proposal from the leader was rejected")
+	}
+}
+
+// TestOnProposeEquivocation checks that a second, different block from the leader of a view is detected,
+// and that the event is raised only once, however many conflicting blocks are received.
+func TestOnProposeEquivocation(t *testing.T) {
+	const n = 4
+	ctrl := gomock.NewController(t)
+	bl := testutil.CreateBuilders(t, ctrl, n)
+	sync := mocks.NewMockSynchronizer(ctrl)
+	sync.EXPECT().AdvanceView(gomock.Any()).AnyTimes()
+	bl[0].Register(consensus.New(acceptAll{}), sync, leaderrotation.NewFixed(2))
+	hl := bl.Build()
+	hs := hl[0]
+
+	leader, _ := hs.Configuration().Replica(2)
+	leader.(*mocks.MockReplica).EXPECT().Vote(gomock.Any()).Times(1)
+
+	var equivocations []consensus.EquivocationEvent
+	hs.MetricsEventLoop().RegisterHandler(consensus.EquivocationEvent{}, func(event interface{}) {
+		equivocations = append(equivocations, event.(consensus.EquivocationEvent))
+	})
+
+	propose := func(block *consensus.Block) {
+		hs.EventLoop().AddEvent(consensus.ProposeMsg{ID: 2, Block: block})
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		hs.EventLoop().Run(ctx)
+		hs.MetricsEventLoop().Run(ctx)
+	}
+
+	genesis := consensus.GetGenesis()
+	genesisQC := consensus.NewQuorumCert(nil, 0, genesis.Hash())
+	first := consensus.NewBlock(genesis.Hash(), genesisQC, "foo", 1, 2)
+	second := consensus.NewBlock(genesis.Hash(), genesisQC, "bar", 1, 2)
+
+	propose(first)
+	propose(second)
+	propose(second)
+	propose(consensus.NewBlock(genesis.Hash(), genesisQC, "baz", 1, 2))
+
+	if len(equivocations) != 1 {
+		t.Fatalf("expected 1 equivocation, got %d", len(equivocations))
+	}
+	want := consensus.EquivocationEvent{Proposer: 2, View: 1, First: first.Hash(), Second: second.Hash()}
+	if equivocations[0] != want {
+		t.Errorf("wrong equivocation: got %+v, want %+v", equivocations[0], want)
+	}
+	if _, ok := hs.BlockChain().LocalGet(second.Hash()); ok {
+		t.Error("the conflicting block was accepted")
+	}
+}
+
+// TestOnProposeStaleView checks that proposals for views before the last voted view are dropped without voting.
+func TestOnProposeStaleView(t *testing.T) {
+	const n = 4
+	ctrl := gomock.NewController(t)
+	bl := testutil.CreateBuilders(t, ctrl, n)
+	sync := mocks.NewMockSynchronizer(ctrl)
+	sync.EXPECT().AdvanceView(gomock.Any()).AnyTimes()
+	bl[0].Register(consensus.New(acceptAll{}), sync, leaderrotation.NewFixed(2))
+	hl := bl.Build()
+	hs := hl[0]
+
+	leader, _ := hs.Configuration().Replica(2)
+	// only the proposals for views 1 and 3 are voted for
+	leader.(*mocks.MockReplica).EXPECT().Vote(gomock.Any()).Times(2)
+
+	var rejected []consensus.MessageRejectedEvent
+	hs.MetricsEventLoop().RegisterObserver(consensus.MessageRejectedEvent{}, func(event interface{}) {
+		rejected = append(rejected, event.(consensus.MessageRejectedEvent))
+	})
+
+	propose := func(block *consensus.Block) {
+		hs.EventLoop().AddEvent(consensus.ProposeMsg{ID: 2, Block: block})
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		hs.EventLoop().Run(ctx)
+		hs.MetricsEventLoop().Run(ctx)
+	}
+
+	genesis := consensus.GetGenesis()
+	genesisQC := consensus.NewQuorumCert(nil, 0, genesis.Hash())
+	propose(consensus.NewBlock(genesis.Hash(), genesisQC, "foo", 1, 2))
+	propose(consensus.NewBlock(genesis.Hash(), genesisQC, "bar", 3, 2))
+
+	stale := consensus.NewBlock(genesis.Hash(), genesisQC, "baz", 2, 2)
+	propose(stale)
+
+	if len(rejected) != 1 || rejected[0].Reason != consensus.RejectStaleView {
+		t.Fatalf("expected the stale proposal to be rejected, got %+v", rejected)
+	}
+	if _, ok := hs.BlockChain().LocalGet(stale.Hash()); ok {
+		t.Error("the stale block was stored")
+	}
+}
+
+// TestOnProposeOversized checks that proposals with commands larger than the limit are rejected without voting.
+func TestOnProposeOversized(t *testing.T) {
+	const n = 4
+	ctrl := gomock.NewController(t)
+	bl := testutil.CreateBuilders(t, ctrl, n)
+	bl[0].Options().SetMaxProposalSize(4)
+	sync := mocks.NewMockSynchronizer(ctrl)
+	sync.EXPECT().AdvanceView(gomock.Any()).AnyTimes()
+	bl[0].Register(consensus.New(acceptAll{}), sync, leaderrotation.NewFixed(2))
+	hl := bl.Build()
+	hs := hl[0]
+
+	leader, _ := hs.Configuration().Replica(2)
+	// only the proposal that is within the limit is voted for
+	leader.(*mocks.MockReplica).EXPECT().Vote(gomock.Any()).Times(1)
+
+	var rejected []consensus.MessageRejectedEvent
+	hs.MetricsEventLoop().RegisterObserver(consensus.MessageRejectedEvent{}, func(event interface{}) {
+		rejected = append(rejected, event.(consensus.MessageRejectedEvent))
+	})
+
+	propose := func(block *consensus.Block) {
+		hs.EventLoop().AddEvent(consensus.ProposeMsg{ID: 2, Block: block})
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		hs.EventLoop().Run(ctx)
+		hs.MetricsEventLoop().Run(ctx)
+	}
+
+	genesis := consensus.GetGenesis()
+	genesisQC := consensus.NewQuorumCert(nil, 0, genesis.Hash())
+	oversized := consensus.NewBlock(genesis.Hash(), genesisQC, "too large", 1, 2)
+	propose(oversized)
+	propose(consensus.NewBlock(genesis.Hash(), genesisQC, "foo", 2, 2))
+
+	if len(rejected) != 1 || rejected[0].Reason != consensus.RejectOversized {
+		t.Fatalf("expected the oversized proposal to be rejected, got %+v", rejected)
+	}
+	if _, ok := hs.BlockChain().LocalGet(oversized.Hash()); ok {
+		t.Error("the oversized block was stored")
+	}
+}
+
+// TestOnProposeMissingParent checks that the parent of a proposed block is fetched if it is unknown,
+// and that the proposal is rejected if the parent cannot be fetched.
+func TestOnProposeMissingParent(t *testing.T) {
+	const n = 4
+	ctrl := gomock.NewController(t)
+	bl := testutil.CreateBuilders(t, ctrl, n)
+	sync := mocks.NewMockSynchronizer(ctrl)
+	sync.EXPECT().AdvanceView(gomock.Any()).AnyTimes()
+	sync.EXPECT().ViewContext().AnyTimes().Return(context.Background())
+	bl[0].Register(consensus.New(acceptAll{}), sync, leaderrotation.NewFixed(2))
+	hl := bl.Build()
+	hs := hl[0]
+
+	leader, _ := hs.Configuration().Replica(2)
+	leader.(*mocks.MockReplica).EXPECT().Vote(gomock.Any()).Times(1)
+
+	var rejected []consensus.MessageRejectedEvent
+	hs.MetricsEventLoop().RegisterObserver(consensus.MessageRejectedEvent{}, func(event interface{}) {
+		rejected = append(rejected, event.(consensus.MessageRejectedEvent))
+	})
+
+	propose := func(block *consensus.Block) bool {
+		hs.EventLoop().AddEvent(consensus.ProposeMsg{ID: 2, Block: block})
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		hs.EventLoop().Run(ctx)
+		hs.MetricsEventLoop().Run(ctx)
+		_, ok := hs.BlockChain().LocalGet(block.Hash())
+		return ok
+	}
+
+	genesis := consensus.GetGenesis()
+	genesisQC := consensus.NewQuorumCert(nil, 0, genesis.Hash())
+	parent := consensus.NewBlock(genesis.Hash(), genesisQC, "foo", 1, 2)
+	lost := consensus.NewBlock(genesis.Hash(), genesisQC, "bar", 2, 2)
+
+	cfg := hs.Configuration().(*mocks.MockConfiguration)
+	cfg.EXPECT().Fetch(gomock.Any(), parent.Hash()).Return(parent, true)
+	cfg.EXPECT().Fetch(gomock.Any(), lost.Hash()).Return(nil, false)
+
+	if !propose(consensus.NewBlock(parent.Hash(), genesisQC, "baz", 2, 2)) {
+		t.Error("proposal with a parent that could be fetched was rejected")
+	}
+	if _, ok := hs.BlockChain().LocalGet(parent.Hash()); !ok {
+		t.Error("the fetched parent was not stored")
+	}
+
+	if propose(consensus.NewBlock(lost.Hash(), genesisQC, "qux", 3, 2)) {
+		t.Error("proposal with a parent that could not be fetched was accepted")
+	}
+	if len(rejected) != 1 || rejected[0].Reason != consensus.RejectUnknownBlock {
+		t.Errorf("expected the proposal to be rejected as unknown, got %+v", rejected)
+	}
+}
+
+// TestOnProposeHighQC checks that a proposal is only voted for if its QC is valid, and the block extends
+// the block certified by the QC, which must be known or fetched from the other replicas.
+func TestOnProposeHighQC(t *testing.T) {
+	genesis := consensus.GetGenesis()
+	genesisQC := consensus.NewQuorumCert(nil, 0, genesis.Hash())
+	certified := consensus.NewBlock(genesis.Hash(), genesisQC, "foo", 1, 2)
+	fork := consensus.NewBlock(genesis.Hash(), genesisQC, "bar", 1, 2)
+	lost := consensus.NewBlock(genesis.Hash(), genesisQC, "baz", 1, 2)
+
+	tests := []struct {
+		name   string
+		block  func(signers []consensus.Crypto) *consensus.Block
+		reason consensus.RejectReason // 0 if the block is voted for
+	}{
+		{name: "Valid", block: func(signers []consensus.Crypto) *consensus.Block {
+			return consensus.NewBlock(certified.Hash(), testutil.CreateQC(t, certified, signers), "qux", 2, 2)
+		}},
+		{name: "InvalidQC", reason: consensus.RejectBadSignature, block: func(signers []consensus.Crypto) *consensus.Block {
+			// the signatures are for the block that is certified, not the fork that the QC claims to certify.
+			qc := testutil.CreateQC(t, certified, signers)
+			forged := consensus.NewQuorumCert(qc.Signature(), fork.View(), fork.Hash())
+			return consensus.NewBlock(fork.Hash(), forged, "qux", 2, 2)
+		}},
+		{name: "UnknownQCBlock", reason: consensus.RejectUnknownBlock, block: func(signers []consensus.Crypto) *consensus.Block {
+			return consensus.NewBlock(certified.Hash(), testutil.CreateQC(t, lost, signers), "qux", 2, 2)
+		}},
+		{name: "UnknownParent", reason: consensus.RejectUnknownBlock, block: func(signers []consensus.Crypto) *consensus.Block {
+			return consensus.NewBlock(lost.Hash(), testutil.CreateQC(t, certified, signers), "qux", 2, 2)
+		}},
+		{name: "NotExtendingQCBlock", reason: consensus.RejectBrokenChain, block: func(signers []consensus.Crypto) *consensus.Block {
+			return consensus.NewBlock(certified.Hash(), testutil.CreateQC(t, fork, signers), "qux", 2, 2)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			const n = 4
+			ctrl := gomock.NewController(t)
+			bl := testutil.CreateBuilders(t, ctrl, n)
+			sync := mocks.NewMockSynchronizer(ctrl)
+			sync.EXPECT().AdvanceView(gomock.Any()).AnyTimes()
+			sync.EXPECT().ViewContext().AnyTimes().Return(context.Background())
+			bl[0].Register(consensus.New(acceptAll{}), sync, leaderrotation.NewFixed(2))
+			hl := bl.Build()
+			hs := hl[0]
+			hs.BlockChain().Store(certified)
+			hs.BlockChain().Store(fork)
+
+			votes := 0
+			if tt.reason == 0 {
+				votes = 1
+			}
+			leader, _ := hs.Configuration().Replica(2)
+			leader.(*mocks.MockReplica).EXPECT().Vote(gomock.Any()).Times(votes)
+			hs.Configuration().(*mocks.MockConfiguration).EXPECT().Fetch(gomock.Any(), lost.Hash()).AnyTimes().Return(nil, false)
+
+			var rejected []consensus.MessageRejectedEvent
+			hs.MetricsEventLoop().RegisterObserver(consensus.MessageRejectedEvent{}, func(event interface{}) {
+				rejected = append(rejected, event.(consensus.MessageRejectedEvent))
+			})
+
+			hs.EventLoop().AddEvent(consensus.ProposeMsg{ID: 2, Block: tt.block(hl.Signers())})
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			hs.EventLoop().Run(ctx)
+			hs.MetricsEventLoop().Run(ctx)
+
+			if tt.reason == 0 {
+				if len(rejected) != 0 {
+					t.Errorf("valid proposal was rejected: %+v", rejected)
+				}
+				return
+			}
+			if len(rejected) != 1 || rejected[0].Reason != tt.reason {
+				t.Errorf("expected the proposal to be rejected with reason %v, got %+v", tt.reason, rejected)
+			}
+		})
+	}
+}
+
+// staleAcceptor is an Acceptor that accepts all commands, and reports that all non-empty commands are stale.
+type staleAcceptor struct{}
+
+func (staleAcceptor) Accept(_ consensus.Command) bool  { return true }
+func (staleAcceptor) Proposed(_ consensus.Command)     {}
+func (staleAcceptor) Stale(cmd consensus.Command) bool { return cmd != "" }
+
+// TestOnProposeStaleCommands checks that a proposal of already committed commands is flagged,
+// and that it is only rejected with the StaleReject policy.
+func TestOnProposeStaleCommands(t *testing.T) {
+	const n = 4
+	tests := []struct {
+		name    string
+		policy  consensus.StaleCommandPolicy
+		flagged int
+		stored  bool
+	}{
+		{name: "Ignore", policy: consensus.StaleIgnore, flagged: 0, stored: true},
+		{name: "Flag", policy: consensus.StaleFlag, flagged: 1, stored: true},
+		{name: "Reject", policy: consensus.StaleReject, flagged: 1, stored: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			bl := testutil.CreateBuilders(t, ctrl, n)
+			bl[0].Options().SetStaleCommandPolicy(tt.policy)
+			sync := mocks.NewMockSynchronizer(ctrl)
+			sync.EXPECT().AdvanceView(gomock.Any()).AnyTimes()
+			bl[0].Register(consensus.New(acceptAll{}), sync, staleAcceptor{})
+			hl := bl.Build()
+			hs := hl[0]
+
+			var flagged []consensus.StaleProposalEvent
+			hs.MetricsEventLoop().RegisterHandler(consensus.StaleProposalEvent{}, func(event interface{}) {
+				flagged = append(flagged, event.(consensus.StaleProposalEvent))
+			})
+
+			genesis := consensus.GetGenesis()
+			block := consensus.NewBlock(genesis.Hash(), testutil.CreateQC(t, genesis, hl.Signers()), "stale", 1, 1)
+			hs.EventLoop().AddEvent(consensus.ProposeMsg{ID: 1, Block: block})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			hs.EventLoop().Run(ctx)
+			hs.MetricsEventLoop().Run(ctx)
+
+			if len(flagged) != tt.flagged {
+				t.Fatalf("expected %d flagged proposals, got %d", tt.flagged, len(flagged))
+			}
+			if tt.flagged > 0 && (flagged[0].Proposer != 1 || flagged[0].View != block.View()) {
+				t.Errorf("wrong flagged proposal: %+v", flagged[0])
+			}
+			if _, ok := hs.BlockChain().LocalGet(block.Hash()); ok != tt.stored {
+				t.Errorf("block stored: got %v, want %v", ok, tt.stored)
+			}
+		})
+	}
+}
+
+// TestEmptyProposal checks that the leader proposes an empty block instead of waiting for commands
+// when AllowEmptyProposals is set, and that the other replicas vote for the empty block.
+func TestEmptyProposal(t *testing.T) {
+	const n = 4
+	ctrl := gomock.NewController(t)
+	bl := testutil.CreateBuilders(t, ctrl, n)
+	bl[0].Options().SetAllowEmptyProposals()
+
+	// the command queue of the leader is empty, and it must not wait for commands.
+	queue := mocks.NewMockCommandQueue(ctrl)
+	queue.EXPECT().Get(gomock.Any()).DoAndReturn(func(ctx context.Context) (consensus.Command, bool) {
+		select {
+		case <-ctx.Done():
+		case <-time.After(time.Second):
+			t.Error("the leader waited for commands")
+		}
+		return "", false
+	})
+	bl[0].Register(queue)
+	for i := range bl {
+		bl[i].Register(consensus.New(chainedhotstuff.New()), synchronizer.New(testutil.FixedTimeout(1000)))
+	}
+	hl := bl.Build()
+
+	var proposal consensus.ProposeMsg
+	hl[0].Configuration().(*mocks.MockConfiguration).EXPECT().Propose(gomock.Any()).Do(func(p consensus.ProposeMsg) {
+		proposal = p
+	})
+	hl[0].Consensus().Propose(consensus.NewSyncInfo().WithQC(hl[0].Synchronizer().HighQC()))
+
+	if proposal.Block == nil {
+		t.Fatal("no block was proposed")
+	}
+	if proposal.Block.Command() != "" {
+		t.Errorf("expected an empty command, got %q", proposal.Block.Command())
+	}
+
+	// another replica votes for the empty block.
+	leader, _ := hl[1].Configuration().Replica(1)
+	leader.(*mocks.MockReplica).EXPECT().Vote(gomock.Any()).Times(1)
+	hl[1].EventLoop().AddEvent(proposal)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	hl[1].EventLoop().Run(ctx)
+}
+
+// pollingQueue is a command queue that only returns commands through Poll.
+type pollingQueue struct {
+	t    *testing.T
+	cmds []consensus.Command
+}
+
+func (q *pollingQueue) Get(_ context.Context) (consensus.Command, bool) {
+	q.t.Error("the leader waited for commands")
+	return "", false
+}
+
+func (q *pollingQueue) Poll() (consensus.Command, bool) {
+	if len(q.cmds) == 0 {
+		return "", false
+	}
+	cmd := q.cmds[0]
+	q.cmds = q.cmds[1:]
+	return cmd, true
+}
+
+// TestEmptyProposalPoll checks that the leader proposes the commands that are available when AllowEmptyProposals is set,
+// and only proposes an empty block once the queue is empty.
+func TestEmptyProposalPoll(t *testing.T) {
+	const n = 4
+	ctrl := gomock.NewController(t)
+	bl := testutil.CreateBuilders(t, ctrl, n)
+	bl[0].Options().SetAllowEmptyProposals()
+	bl[0].Register(&pollingQueue{t: t, cmds: []consensus.Command{"foo"}})
+	bl[0].Register(consensus.New(chainedhotstuff.New()), synchronizer.New(testutil.FixedTimeout(1000)))
+	hl := bl.Build()
+
+	var proposals []consensus.ProposeMsg
+	hl[0].Configuration().(*mocks.MockConfiguration).EXPECT().Propose(gomock.Any()).Times(2).Do(func(p consensus.ProposeMsg) {
+		proposals = append(proposals, p)
+	})
+	hl[0].Consensus().Propose(consensus.NewSyncInfo().WithQC(hl[0].Synchronizer().HighQC()))
+	hl[0].Consensus().Propose(consensus.NewSyncInfo().WithQC(hl[0].Synchronizer().HighQC()))
+
+	if len(proposals) != 2 {
+		t.Fatalf("expected two proposals, got %d", len(proposals))
+	}
+	if cmd := proposals[0].Block.Command(); cmd != "foo" {
+		t.Errorf("expected the available command to be proposed, got %q", cmd)
+	}
+	if cmd := proposals[1].Block.Command(); cmd != "" {
+		t.Errorf("expected an empty command once the queue was empty, got %q", cmd)
+	}
+}
+
+func TestGenesisSpec(t *testing.T) {
+	const n = 4
+	tests := []struct {
+		name     string
+		specs    []consensus.GenesisSpec
+		mismatch bool
+	}{
+		{name: "Default", specs: make([]consensus.GenesisSpec, n)},
+		{name: "Matching", specs: []consensus.GenesisSpec{{Command: "cfg"}, {Command: "cfg"}, {Command: "cfg"}, {Command: "cfg"}}},
+		{name: "Mismatching", specs: []consensus.GenesisSpec{{Command: "cfg"}, {Command: "cfg"}, {Command: "other"}, {Command: "cfg"}}, mismatch: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			bl := testutil.CreateBuilders(t, ctrl, n)
+			for i := range bl {
+				bl[i].WithGenesis(tt.specs[i])
+				bl[i].Register(consensus.New(chainedhotstuff.New()))
+			}
+			hl := bl.Build()
+
+			hashes := make(map[hotstuff.ID]consensus.Hash)
+			for i, hs := range hl {
+				genesis := hs.Genesis()
+				if genesis.Hash() != tt.specs[i].Hash() {
+					t.Errorf("replica %d: genesis hash does not match the spec", hs.ID())
+				}
+				if block, ok := hs.BlockChain().LocalGet(genesis.Hash()); !ok || block != genesis {
+					t.Errorf("replica %d: blockchain does not start from the genesis block", hs.ID())
+				}
+				if hs.Consensus().CommittedBlock() != genesis {
+					t.Errorf("replica %d: committed block is not the genesis block", hs.ID())
+				}
+				hashes[hs.ID()] = genesis.Hash()
+			}
+
+			err := consensus.CheckGenesis(hashes)
+			if tt.mismatch && err == nil {
+				t.Error("expected an error when the genesis hashes differ")
+			}
+			if !tt.mismatch && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+// TestGenesisHighQC checks that replicas that are initialized independently from the same GenesisSpec
+// agree on the genesis block, and that their first view starts from the QC for it.
+func TestGenesisHighQC(t *testing.T) {
+	spec := consensus.GenesisSpec{Command: "chain-id"}
+	var hashes []consensus.Hash
+	for i := 0; i < 2; i++ {
+		ctrl := gomock.NewController(t)
+		bl := testutil.CreateBuilders(t, ctrl, 4)
+		bl[0].WithGenesis(spec)
+		bl[0].Register(consensus.New(chainedhotstuff.New()), synchronizer.New(testutil.FixedTimeout(1000)))
+		hs := bl[0].Build()
+
+		genesis := hs.Genesis()
+		highQC := hs.Synchronizer().HighQC()
+		if highQC.BlockHash() != genesis.Hash() || highQC.View() != 0 {
+			t.Errorf("instance %d: highQC does not reference the genesis block: %v", i, highQC)
+		}
+		if hs.Synchronizer().LeafBlock() != genesis {
+			t.Errorf("instance %d: leaf block is not the genesis block", i)
+		}
+		if hs.Synchronizer().View() != 1 {
+			t.Errorf("instance %d: wrong initial view: got %d, want 1", i, hs.Synchronizer().View())
+		}
+		hashes = append(hashes, genesis.Hash())
+	}
+	if hashes[0] != hashes[1] {
+		t.Errorf("the instances computed different genesis hashes: %.8s and %.8s", hashes[0], hashes[1])
+	}
+	if hashes[0] != spec.Hash() || hashes[0] == consensus.GetGenesis().Hash() {
+		t.Error("the genesis hash does not match the spec")
+	}
+}
+
+// infoRecorder is a logger that records the messages that are logged at the info and warn levels.
+type infoRecorder struct {
+	logging.Logger
+	lines    []string
+	warnings []string
+}
+
+func (r *infoRecorder) Infof(template string, args ...interface{}) {
+	r.lines = append(r.lines, fmt.Sprintf(template, args...))
+}
+
+func (r *infoRecorder) Warnf(template string, args ...interface{}) {
+	r.warnings = append(r.warnings, fmt.Sprintf(template, args...))
+}
+
+func TestRejectionLogRateLimit(t *testing.T) {
+	const (
+		n         = 4
+		proposals = 50
+	)
+	ctrl := gomock.NewController(t)
+	bl := testutil.CreateBuilders(t, ctrl, n)
+	logger := &infoRecorder{Logger: logging.New("hs1")}
+	bl[0].Options().SetRejectLogInterval(time.Hour)
+	bl[0].Register(consensus.New(chainedhotstuff.New()), logger)
+	hs := bl.Build()[0]
+
+	rejected := 0
+	hs.MetricsEventLoop().RegisterHandler(consensus.MessageRejectedEvent{}, func(event interface{}) {
+		if event.(consensus.MessageRejectedEvent).Reason == consensus.RejectBadSignature {
+			rejected++
+		}
+	})
+
+	// a flood of proposals with invalid QCs.
+	genesis := consensus.GetGenesis()
+	for i := 1; i <= proposals; i++ {
+		qc := consensus.NewQuorumCert(nil, 1, consensus.Hash{1})
+		block := consensus.NewBlock(genesis.Hash(), qc, consensus.Command(fmt.Sprint(i)), consensus.View(i), 2)
+		hs.EventLoop().AddEvent(consensus.ProposeMsg{ID: 2, Block: block})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	hs.EventLoop().Run(ctx)
+	hs.MetricsEventLoop().Run(ctx)
+
+	if rejected != proposals {
+		t.Errorf("expected %d rejections in the metrics, got %d", proposals, rejected)
+	}
+	if len(logger.lines) != 1 {
+		t.Errorf("expected 1 logged rejection, got %d: %v", len(logger.lines), logger.lines)
+	}
+
+	// the suppressed rejections are summarized when the replica stops.
+	hs.FlushRejections()
+	if len(logger.warnings) != 1 || !strings.HasPrefix(logger.warnings[0], fmt.Sprint(proposals-1, " more messages")) {
+		t.Errorf("expected a summary of %d suppressed rejections, got: %v", proposals-1, logger.warnings)
+	}
+}
+
+func TestQuorumCertSigners(t *testing.T) {
+	const n = 4
+	backends := []struct {
+		name   string
+		keyGen func(t testing.TB) consensus.PrivateKey
+		impl   func() consensus.CryptoImpl
+	}{
+		{name: "ECDSA", keyGen: testutil.GenerateECDSAKey, impl: ecdsa.New},
+		{name: "BLS12", keyGen: testutil.GenerateBLS12Key, impl: bls12.New},
+	}
+	for _, backend := range backends {
+		t.Run(backend.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			bl := testutil.CreateBuilders(t, ctrl, n, testutil.GenerateKeys(t, n, backend.keyGen)...)
+			for i := range bl {
+				bl[i].Register(crypto.New(backend.impl()))
+			}
+			hl := bl.Build()
+			signers := hl.Signers()
+
+			block := consensus.NewBlock(consensus.GetGenesis().Hash(), consensus.QuorumCert{}, "foo", 1, 1)
+			qc := testutil.CreateQC(t, block, []consensus.Crypto{signers[3], signers[0], signers[2]})
+			want := []hotstuff.ID{1, 3, 4}
+			if got := qc.Signers(); !reflect.DeepEqual(got, want) {
+				t.Errorf("QC signers: got: %v, want: %v", got, want)
+			}
+
+			tc := testutil.CreateTC(t, 1, []consensus.Crypto{signers[2], signers[1], signers[0]})
+			want = []hotstuff.ID{1, 2, 3}
+			if got := tc.Signers(); !reflect.DeepEqual(got, want) {
+				t.Errorf("TC signers: got: %v, want: %v", got, want)
+			}
+		})
+	}
+
+	if signers := consensus.GetGenesis().QuorumCert().Signers(); signers != nil {
+		t.Errorf("the QC of the genesis block has signers: %v", signers)
+	}
+}
+
+// reconfigRecorder is a Configuration that records the reconfigurations that are scheduled.
+type reconfigRecorder struct {
+	consensus.Configuration
+	changes []consensus.Reconfiguration
+	views   []consensus.View
+}
+
+func (r *reconfigRecorder) Reconfigure(change consensus.Reconfiguration, view consensus.View) error {
+	r.changes = append(r.changes, change)
+	r.views = append(r.views, view)
+	return nil
+}
+
+func (r *reconfigRecorder) EnterView(_ consensus.View) {}
+
+// TestReconfigurationCommit checks that a committed reconfiguration is scheduled ReconfigurationDelay views
+// after the view of its block, and that it is not passed to the executor.
+func TestReconfigurationCommit(t *testing.T) {
+	const (
+		n         = 4
+		proposals = 8
+		delay     = 5
+	)
+	ctrl := gomock.NewController(t)
+	keys := make([]consensus.PrivateKey, 0, n)
+	for i := 0; i < n; i++ {
+		keys = append(keys, testutil.GenerateECDSAKey(t))
+	}
+	bl := testutil.CreateBuilders(t, ctrl, n, keys...)
+	mockCfg, replicas := testutil.CreateMockConfigurationWithReplicas(t, ctrl, n, keys...)
+	mockCfg.EXPECT().Replicas().AnyTimes().DoAndReturn(func() map[hotstuff.ID]consensus.Replica {
+		m := make(map[hotstuff.ID]consensus.Replica)
+		for _, replica := range replicas {
+			m[replica.ID()] = replica
+		}
+		return m
+	})
+	replicas[1].EXPECT().Vote(gomock.Any()).AnyTimes()
+	replicas[1].EXPECT().NewView(gomock.Any()).AnyTimes()
+
+	cfg := &reconfigRecorder{Configuration: mockCfg}
+	recorder := &commitRecorder{}
+	bl[0].Options().SetReconfigurationDelay(delay)
+	bl[0].Register(
+		consensus.New(chainedhotstuff.New()),
+		synchronizer.New(testutil.FixedTimeout(1000)),
+		leaderrotation.NewFixed(2),
+		recorder,
+		cfg,
+	)
+	hl := bl.Build()
+	hs := hl[0]
+	signers := hl.Signers()
+
+	change := consensus.Reconfiguration{ID: 5, Address: "localhost:12345", PublicKey: []byte("key")}
+	parent := consensus.GetGenesis()
+	qc := consensus.NewQuorumCert(nil, 0, parent.Hash())
+	for v := consensus.View(1); v <= proposals; v++ {
+		cmd := consensus.Command("foo")
+		if v == 2 {
+			cmd = change.ToCommand()
+		}
+		block := consensus.NewBlock(parent.Hash(), qc, cmd, v, 2)
+		hs.EventLoop().AddEvent(consensus.ProposeMsg{ID: 2, Block: block})
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		hs.EventLoop().Run(ctx)
+		qc = testutil.CreateQC(t, block, signers)
+		parent = block
+	}
+
+	if len(cfg.changes) != 1 {
+		t.Fatalf("expected 1 scheduled reconfiguration, got %d", len(cfg.changes))
+	}
+	if !reflect.DeepEqual(cfg.changes[0], change) {
+		t.Errorf("wrong reconfiguration: got %+v, want %+v", cfg.changes[0], change)
+	}
+	if cfg.views[0] != 2+delay {
+		t.Errorf("wrong activation view: got %d, want %d", cfg.views[0], 2+delay)
+	}
+	want := []string{"commit 1", "exec 1", "commit 2", "commit 3", "exec 3", "commit 4", "exec 4", "commit 5", "exec 5"}
+	if !reflect.DeepEqual(recorder.calls, want) {
+		t.Errorf("wrong calls:\n got: %v\nwant: %v", recorder.calls, want)
+	}
+}
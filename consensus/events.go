@@ -1,8 +1,8 @@
 package consensus
 
 import (
-	"crypto/sha256"
 	"fmt"
+	"time"
 
 	"github.com/relab/hotstuff"
 )
@@ -12,36 +12,77 @@ type ProposeMsg struct {
 	ID          hotstuff.ID  // The ID of the replica who sent the message.
 	Block       *Block       // The block that is proposed.
 	AggregateQC *AggregateQC // Optional AggregateQC
+	TimeoutCert *TimeoutCert // Optional TC that moved the leader to the view of the proposal
+	Timestamp   time.Time    // The time at which the proposal was sent, according to the sender's clock.
 }
 
 // VoteMsg is sent to the leader by replicas voting on a proposal.
 type VoteMsg struct {
 	ID          hotstuff.ID // the ID of the replica who sent the message.
 	PartialCert PartialCert // The partial certificate.
-	Deferred    bool
+	Deferred    bool        // The vote arrived before its block, and was deferred until a proposal was handled.
+	deferrals   int         // the number of proposals that the vote has waited for
+}
+
+// ResumeEvent is sent on the event loop when consensus is resumed after being paused.
+// Any messages that were buffered while paused are handled after this event.
+type ResumeEvent struct{}
+
+// ProposalAckMsg is sent to the leader by replicas that received its proposal,
+// if proposal acknowledgments are enabled. It is only used for diagnostics.
+type ProposalAckMsg struct {
+	ID        hotstuff.ID // The ID of the replica who sent the message.
+	BlockHash Hash        // The hash of the proposed block.
+}
+
+// AbstainMsg is sent to the leader by replicas that received its proposal, but did not vote for it,
+// if abstaining is enabled. It shows that the sender is alive and synchronized, but it does not count towards a QC.
+type AbstainMsg struct {
+	ID        hotstuff.ID // The ID of the replica who sent the message.
+	View      View        // The view of the proposed block.
+	BlockHash Hash        // The hash of the proposed block.
 }
 
 // TimeoutMsg is broadcast whenever a replica has a local timeout.
 type TimeoutMsg struct {
 	ID            hotstuff.ID // The ID of the replica who sent the message.
 	View          View        // The view that the replica wants to enter.
-	ViewSignature Signature   // A signature of the view
-	MsgSignature  Signature   // A signature of the view, QC.BlockHash, and the replica ID
+	ViewSignature Signature   // A signature of the hash of the timeout message
+	MsgSignature  Signature   // A signature of the hash of the timeout message, which becomes part of the aggregateQC
 	SyncInfo      SyncInfo    // The highest QC/TC known to the sender.
 }
 
+// HighQCRef identifies the QC that a timeout message was sent with, by the view of the QC and the hash of the certified block.
+// The zero value means that the timeout message had no QC.
+type HighQCRef struct {
+	View View
+	Hash Hash
+}
+
+// HighQCRef returns a reference to the QC of the timeout message.
+func (timeout TimeoutMsg) HighQCRef() (ref HighQCRef) {
+	if qc, ok := timeout.SyncInfo.QC(); ok {
+		ref = HighQCRef{View: qc.View(), Hash: qc.BlockHash()}
+	}
+	return ref
+}
+
 // Hash returns a hash of the timeout message.
 func (timeout TimeoutMsg) Hash() Hash {
-	var h Hash
-	hash := sha256.New()
-	hash.Write(timeout.View.ToBytes())
-	if qc, ok := timeout.SyncInfo.QC(); ok {
-		h := qc.BlockHash()
-		hash.Write(h[:])
+	return TimeoutHash(timeout.ID, timeout.View, timeout.HighQCRef())
+}
+
+// TimeoutHash returns the hash of a timeout message from the replica for the view, which was sent with the referenced QC.
+// The hash covers both the view and the QC of the timeout message, such that a timeout certificate can prove
+// which QCs the replicas that timed out had.
+func TimeoutHash(id hotstuff.ID, view View, ref HighQCRef) Hash {
+	b := view.ToBytes()
+	if ref != (HighQCRef{}) {
+		b = append(b, ref.View.ToBytes()...)
+		b = append(b, ref.Hash[:]...)
 	}
-	hash.Write(timeout.ID.ToBytes())
-	hash.Sum(h[:0])
-	return h
+	b = append(b, id.ToBytes()...)
+	return hasher.Sum(b)
 }
 
 func (timeout TimeoutMsg) String() string {
@@ -60,3 +101,136 @@ type NewViewMsg struct {
 type CommitEvent struct {
 	Commands int
 }
+
+// ProposalSentEvent is raised when the leader has sent a proposal to the other replicas.
+type ProposalSentEvent struct {
+	BlockHash Hash      // The hash of the proposed block.
+	Time      time.Time // The time when the proposal was sent.
+}
+
+// QCFormedEvent is raised on the metrics event loop when the voting machine has created a QC from a quorum of votes.
+type QCFormedEvent struct {
+	BlockHash Hash      // The hash of the certified block.
+	Time      time.Time // The time when the QC was created.
+}
+
+// LateVoteEvent is raised on the metrics event loop when the voting machine receives a vote for a block
+// whose QC has already been created. Late votes are not verified, as they are not needed for the QC.
+type LateVoteEvent struct {
+	ID        hotstuff.ID // The ID of the replica that voted.
+	BlockHash Hash        // The hash of the certified block.
+}
+
+// LockHeldEvent is raised when the consensus module has committed a block,
+// and includes the time that the consensus lock was held while executing the committed blocks.
+type LockHeldEvent struct {
+	Duration time.Duration
+}
+
+// AbstainEvent is raised on the metrics event loop when the leader has recorded an abstain message.
+type AbstainEvent struct {
+	ID        hotstuff.ID // The ID of the replica that abstained.
+	View      View        // The view of the proposed block.
+	Abstained int         // The number of replicas that have abstained from voting for the block.
+}
+
+// StaleProposalEvent is raised on the metrics event loop when a replica receives a proposal
+// whose client commands have all been committed already.
+type StaleProposalEvent struct {
+	Proposer hotstuff.ID // The ID of the replica that proposed the block.
+	View     View        // The view of the proposed block.
+}
+
+// EquivocationEvent is raised on the metrics event loop when a replica receives two different blocks
+// proposed by the same replica for the same view.
+type EquivocationEvent struct {
+	Proposer hotstuff.ID // The ID of the replica that proposed the blocks.
+	View     View        // The view of the proposed blocks.
+	First    Hash        // The hash of the first block that was received.
+	Second   Hash        // The hash of the conflicting block.
+}
+
+// StepDownEvent is sent on the event loop when the leader of a view steps down because it cannot reach a quorum.
+// The synchronizer handles it by timing out the view.
+type StepDownEvent struct {
+	View View // The view that the leader stepped down from.
+}
+
+// ReducedResilienceEvent is raised on the metrics event loop when the leader proposes a block,
+// but can only reach a bare quorum of replicas, such that the view fails if one more replica becomes unreachable.
+type ReducedResilienceEvent struct {
+	View      View // The view of the proposal.
+	Reachable int  // The number of replicas that the leader could reach, including itself.
+}
+
+// ChainInconsistencyEvent is raised on the metrics event loop when an audit of the committed blocks
+// finds a block whose certificate or parent link is inconsistent.
+type ChainInconsistencyEvent struct {
+	Block  Hash   // The hash that the block was stored under.
+	View   View   // The view of the block.
+	Reason string // A description of the inconsistency.
+}
+
+// RejectReason describes why a message was rejected.
+type RejectReason int
+
+const (
+	// RejectBadSignature means that a signature or certificate in the message could not be verified.
+	RejectBadSignature RejectReason = iota + 1
+	// RejectStaleView means that the message belongs to a view that is too old.
+	RejectStaleView
+	// RejectUnknownBlock means that the block referenced by the message could not be found.
+	RejectUnknownBlock
+	// RejectOversized means that the message was too large.
+	RejectOversized
+	// RejectEquivocation means that the sender sent conflicting messages for the same view.
+	RejectEquivocation
+	// RejectBufferFull means that the message could not be buffered because the buffer was full.
+	RejectBufferFull
+	// RejectWitness means that the message was sent by a witness, which is not allowed to vote.
+	RejectWitness
+	// RejectUnjustifiedView means that a proposal skipped views without a certificate that justifies the skip.
+	RejectUnjustifiedView
+	// RejectWrongProposer means that a proposal was sent by a replica that is not the leader of its view.
+	RejectWrongProposer
+	// RejectStaleCommands means that a proposal only contained client commands that were already committed.
+	RejectStaleCommands
+	// RejectBrokenChain means that a proposed block does not extend the block certified by its QC.
+	RejectBrokenChain
+)
+
+func (r RejectReason) String() string {
+	switch r {
+	case RejectBadSignature:
+		return "bad signature"
+	case RejectStaleView:
+		return "stale view"
+	case RejectUnknownBlock:
+		return "unknown block"
+	case RejectOversized:
+		return "oversized"
+	case RejectEquivocation:
+		return "equivocation"
+	case RejectBufferFull:
+		return "buffer full"
+	case RejectWitness:
+		return "witness"
+	case RejectUnjustifiedView:
+		return "unjustified view"
+	case RejectWrongProposer:
+		return "wrong proposer"
+	case RejectStaleCommands:
+		return "stale commands"
+	case RejectBrokenChain:
+		return "broken chain"
+	default:
+		return fmt.Sprintf("RejectReason(%d)", int(r))
+	}
+}
+
+// MessageRejectedEvent is raised on the metrics event loop whenever a message is dropped or rejected.
+type MessageRejectedEvent struct {
+	Reason RejectReason
+	Type   string // the type of the message, e.g. "ProposeMsg"
+	Sender hotstuff.ID
+}
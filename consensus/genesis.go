@@ -1,8 +1,58 @@
 package consensus
 
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/relab/hotstuff"
+)
+
 var genesisBlock = NewBlock(Hash{}, QuorumCert{}, "", 0, 0)
 
-// GetGenesis returns a pointer to the genesis block, the starting point for the hotstuff blockchain.
+// GetGenesis returns a pointer to the default genesis block, the starting point for the hotstuff blockchain.
+// Replicas that were built with a GenesisSpec start from the block returned by Modules.Genesis instead.
 func GetGenesis() *Block {
 	return genesisBlock
 }
+
+// GenesisSpec specifies the genesis block. The genesis block is derived deterministically from the spec,
+// so replicas that use the same spec start from the same root, and agree on the hash of the genesis block.
+// The zero value specifies the default genesis block.
+type GenesisSpec struct {
+	// Command is a fixed command that is included in the genesis block, such as an encoding of the initial configuration.
+	Command Command
+}
+
+// Block returns the genesis block specified by the spec.
+func (spec GenesisSpec) Block() *Block {
+	if spec.Command == "" {
+		return genesisBlock
+	}
+	return NewBlock(Hash{}, QuorumCert{}, spec.Command, 0, 0)
+}
+
+// Hash returns the hash of the genesis block specified by the spec.
+func (spec GenesisSpec) Hash() Hash {
+	return spec.Block().Hash()
+}
+
+// CheckGenesis returns an error if the replicas did not compute the same genesis hash.
+// Replicas that start from different genesis blocks cannot agree on any blocks,
+// so a mismatch must be treated as a fatal configuration error.
+func CheckGenesis(hashes map[hotstuff.ID]Hash) error {
+	groups := make(map[Hash][]hotstuff.ID)
+	for id, hash := range hashes {
+		groups[hash] = append(groups[hash], id)
+	}
+	if len(groups) <= 1 {
+		return nil
+	}
+	var desc []string
+	for hash, ids := range groups {
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+		desc = append(desc, fmt.Sprintf("%.8s: %v", hash, ids))
+	}
+	sort.Strings(desc)
+	return fmt.Errorf("replicas computed different genesis hashes: %s", strings.Join(desc, ", "))
+}
@@ -0,0 +1,38 @@
+package consensus
+
+import "crypto/sha256"
+
+// Hasher computes the hashes that identify blocks, and that are signed by the replicas.
+// All replicas must use the same Hasher, or they will not agree on the hashes of the blocks.
+type Hasher interface {
+	// Sum returns the hash of the data.
+	Sum(data []byte) Hash
+}
+
+// HasherFunc is an adapter that allows an ordinary function to be used as a Hasher.
+type HasherFunc func(data []byte) Hash
+
+// Sum returns f(data).
+func (f HasherFunc) Sum(data []byte) Hash {
+	return f(data)
+}
+
+// SHA256 is the default Hasher.
+var SHA256 Hasher = HasherFunc(func(data []byte) Hash {
+	return sha256.Sum256(data)
+})
+
+var hasher = SHA256
+
+// SetHasher replaces the Hasher that is used to compute hashes, and recomputes the default genesis block.
+// It is not safe for concurrent use, and must be called before any blocks are created,
+// such as in the main function, before the replica is started.
+func SetHasher(h Hasher) {
+	hasher = h
+	genesisBlock = NewBlock(Hash{}, QuorumCert{}, "", 0, 0)
+}
+
+// GetHasher returns the Hasher that is used to compute hashes.
+func GetHasher() Hasher {
+	return hasher
+}
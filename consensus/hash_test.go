@@ -0,0 +1,50 @@
+package consensus_test
+
+import (
+	"crypto/sha512"
+	"testing"
+
+	"github.com/relab/hotstuff/consensus"
+)
+
+func TestSetHasher(t *testing.T) {
+	newBlock := func() *consensus.Block {
+		return consensus.NewBlock(consensus.GetGenesis().Hash(), consensus.QuorumCert{}, "foo", 1, 1)
+	}
+	defaultBlock := newBlock()
+	defaultGenesis := consensus.GetGenesis().Hash()
+	defaultView := consensus.View(1).ToHash()
+
+	alt := consensus.HasherFunc(func(data []byte) consensus.Hash {
+		return sha512.Sum512_256(data)
+	})
+	consensus.SetHasher(alt)
+	defer consensus.SetHasher(consensus.SHA256)
+
+	genesis := consensus.GetGenesis()
+	if genesis.Hash() == defaultGenesis {
+		t.Error("the genesis hash did not change with the hasher")
+	}
+	if genesis.Hash() != sha512.Sum512_256(genesis.ToBytes()) {
+		t.Error("the genesis hash was not computed by the hasher")
+	}
+
+	block := newBlock()
+	if block.Hash() == defaultBlock.Hash() {
+		t.Error("the block hash did not change with the hasher")
+	}
+	if block.Hash() != sha512.Sum512_256(block.ToBytes()) {
+		t.Error("the block hash was not computed by the hasher")
+	}
+	if newBlock().Hash() != block.Hash() {
+		t.Error("the block hash is not deterministic")
+	}
+	if consensus.View(1).ToHash() == defaultView {
+		t.Error("the view hash did not change with the hasher")
+	}
+
+	consensus.SetHasher(consensus.SHA256)
+	if consensus.GetGenesis().Hash() != defaultGenesis || newBlock().Hash() != defaultBlock.Hash() {
+		t.Error("the hashes did not change back with the default hasher")
+	}
+}
@@ -2,9 +2,12 @@ package consensus
 
 import (
 	"context"
+	"sort"
+	"time"
 
 	"github.com/relab/hotstuff"
 	"github.com/relab/hotstuff/eventloop"
+	"github.com/relab/hotstuff/internal/logging"
 	"github.com/relab/hotstuff/modules"
 )
 
@@ -14,9 +17,11 @@ type Modules struct {
 	*modules.Modules
 
 	privateKey    PrivateKey
+	genesis       *Block
 	opts          Options
 	eventLoop     *eventloop.EventLoop
 	votingMachine *VotingMachine
+	rejectLog     *logging.RateLimited
 
 	acceptor       Acceptor
 	blockChain     BlockChain
@@ -28,6 +33,7 @@ type Modules struct {
 	crypto         Crypto
 	synchronizer   Synchronizer
 	forkHandler    ForkHandlerExt
+	commitHook     CommitHook
 }
 
 // Run starts both event loops using the provided context and returns when both event loops have exited.
@@ -67,6 +73,31 @@ func (mods *Modules) EventLoop() *eventloop.EventLoop {
 	return mods.eventLoop
 }
 
+// ReportRejection reports that a message was rejected by adding the event to the metrics event loop,
+// and logs the message at the info level. Rejections are logged at most once per message type and reason
+// for each Options.RejectLogInterval, such that a flood of invalid messages cannot flood the log.
+// If the template is empty, the rejection is only reported to the metrics.
+func (mods *Modules) ReportRejection(event MessageRejectedEvent, template string, args ...interface{}) {
+	mods.MetricsEventLoop().AddEvent(event)
+	if template != "" {
+		mods.rejectLog.Infof(event.Type+" rejected: "+event.Reason.String(), template, args...)
+	}
+}
+
+// FlushRejections logs the number of rejections that were not logged because of the rate limit.
+// It is called when the replica stops, such that the suppressed rejections are not lost.
+func (mods *Modules) FlushRejections() {
+	mods.rejectLog.Flush()
+}
+
+// Genesis returns the genesis block that the blockchain starts from.
+func (mods *Modules) Genesis() *Block {
+	if mods.genesis == nil {
+		return GetGenesis()
+	}
+	return mods.genesis
+}
+
 // Acceptor returns the acceptor.
 func (mods *Modules) Acceptor() Acceptor {
 	return mods.acceptor
@@ -97,6 +128,11 @@ func (mods *Modules) Executor() ExecutorExt {
 	return mods.executor
 }
 
+// CommitHook returns the commit hook, or nil if no commit hook was registered.
+func (mods *Modules) CommitHook() CommitHook {
+	return mods.commitHook
+}
+
 // LeaderRotation returns the leader rotation implementation.
 func (mods *Modules) LeaderRotation() LeaderRotation {
 	return mods.leaderRotation
@@ -169,6 +205,9 @@ func (b *Builder) Register(mods ...interface{}) {
 		if m, ok := module.(Executor); ok {
 			b.mods.executor = executorWrapper{m}
 		}
+		if m, ok := module.(CommitHook); ok {
+			b.mods.commitHook = m
+		}
 		if m, ok := module.(LeaderRotation); ok {
 			b.mods.leaderRotation = m
 		}
@@ -190,6 +229,17 @@ func (b *Builder) Register(mods ...interface{}) {
 	}
 }
 
+// WithGenesis sets the genesis block that the blockchain starts from. All replicas must use the same spec.
+// It must be called before the modules are built.
+func (b *Builder) WithGenesis(spec GenesisSpec) {
+	b.mods.genesis = spec.Block()
+}
+
+// Options returns the options builder, which can be used to set options before the modules are built.
+func (b *Builder) Options() *OptionsBuilder {
+	return &b.cfg
+}
+
 // Build initializes all modules and returns the HotStuff object.
 func (b *Builder) Build() *Modules {
 	for _, module := range b.modules {
@@ -197,6 +247,7 @@ func (b *Builder) Build() *Modules {
 	}
 	b.mods.opts = b.cfg.opts
 	b.mods.Modules = b.baseBuilder.Build()
+	b.mods.rejectLog = logging.NewRateLimited(b.mods.Logger(), b.mods.opts.RejectLogInterval())
 	return b.mods
 }
 
@@ -219,6 +270,14 @@ type CommandQueue interface {
 	Get(ctx context.Context) (cmd Command, ok bool)
 }
 
+// CommandPoller is an optional interface for command queues that can return the commands that are available
+// without waiting for more commands. If the AllowEmptyProposals option is set, the leader uses it to decide
+// whether to propose an empty block. Other command queues are called with a cancelled context instead.
+type CommandPoller interface {
+	// Poll returns the next command to be proposed, if a command is available.
+	Poll() (cmd Command, ok bool)
+}
+
 //go:generate mockgen -destination=../internal/mocks/acceptor_mock.go -package=mocks . Acceptor
 
 // Acceptor decides if a replica should accept a command.
@@ -230,6 +289,13 @@ type Acceptor interface {
 	Proposed(Command)
 }
 
+// StaleChecker is an optional interface for Acceptor implementations that can tell
+// whether the client commands in a command have already been committed.
+type StaleChecker interface {
+	// Stale returns true if the command contains client commands, and all of them have already been committed.
+	Stale(Command) bool
+}
+
 //go:generate mockgen -destination=../internal/mocks/executor_mock.go -package=mocks . Executor
 
 // Executor is responsible for executing the commands that are committed by the consensus protocol.
@@ -238,6 +304,14 @@ type Executor interface {
 	Exec(cmd Command)
 }
 
+// ExecutorWithResult is an Executor that also returns the result of executing a command.
+// The replica sends the result back to the client that submitted the command.
+type ExecutorWithResult interface {
+	Executor
+	// ExecWithResult executes the command and returns the result.
+	ExecWithResult(cmd Command) (result []byte)
+}
+
 // ExecutorExt is responsible for executing the commands that are committed by the consensus protocol.
 //
 // This interface is similar to the Executor interface, except it takes a block as an argument, instead of a command,
@@ -247,6 +321,15 @@ type ExecutorExt interface {
 	Exec(block *Block)
 }
 
+// CommitHook is called with each committed block before the block is executed.
+// It allows an application to durably record that a block was committed before it executes the block,
+// such that the application can recover to a state that is consistent with the consensus protocol after a crash.
+type CommitHook interface {
+	// PreExecCommit is called with a committed block before the block is passed to the executor.
+	// If it returns an error, the block is handled according to the PreExecPolicy option.
+	PreExecCommit(block *Block) error
+}
+
 // ForkHandler handles commands that do not get committed due to a forked blockchain.
 //
 // TODO: think of a better name/interface
@@ -275,10 +358,12 @@ type CryptoImpl interface {
 	// CreateThresholdSignatureForMessageSet creates a threshold signature where each partial signature has signed a
 	// different message hash.
 	CreateThresholdSignatureForMessageSet(partialSignatures []Signature, hashes map[hotstuff.ID]Hash) (ThresholdSignature, error)
-	// VerifyThresholdSignature verifies a threshold signature.
-	VerifyThresholdSignature(signature ThresholdSignature, hash Hash) bool
+	// VerifyThresholdSignature verifies a threshold signature that was created in the given view.
+	// The signature must be from a quorum of the replicas that participated in that view.
+	VerifyThresholdSignature(signature ThresholdSignature, hash Hash, view View) bool
 	// VerifyThresholdSignatureForMessageSet verifies a threshold signature against a set of message hashes.
-	VerifyThresholdSignatureForMessageSet(signature ThresholdSignature, hashes map[hotstuff.ID]Hash) bool
+	// The signature must be from a quorum of the replicas that participated in the given view.
+	VerifyThresholdSignatureForMessageSet(signature ThresholdSignature, hashes map[hotstuff.ID]Hash, view View) bool
 }
 
 // Crypto implements the methods required to create and verify signatures and certificates.
@@ -287,13 +372,15 @@ type Crypto interface {
 	CryptoImpl
 	// CreatePartialCert signs a single block and returns the partial certificate.
 	CreatePartialCert(block *Block) (cert PartialCert, err error)
+	// CreatePartialCertWithAux signs a single block and the auxiliary data and returns the partial certificate.
+	CreatePartialCertWithAux(block *Block, aux []byte) (cert PartialCert, err error)
 	// CreateQuorumCert creates a quorum certificate from a list of partial certificates.
 	CreateQuorumCert(block *Block, signatures []PartialCert) (cert QuorumCert, err error)
 	// CreateTimeoutCert creates a timeout certificate from a list of timeout messages.
 	CreateTimeoutCert(view View, timeouts []TimeoutMsg) (cert TimeoutCert, err error)
 	// CreateAggregateQC creates an AggregateQC from the given timeout messages.
 	CreateAggregateQC(view View, timeouts []TimeoutMsg) (aggQC AggregateQC, err error)
-	// VerifyPartialCert verifies a single partial certificate.
+	// VerifyPartialCert verifies a single partial certificate, including its auxiliary data, if any.
 	VerifyPartialCert(cert PartialCert) bool
 	// VerifyQuorumCert verifies a quorum certificate.
 	VerifyQuorumCert(qc QuorumCert) bool
@@ -303,6 +390,30 @@ type Crypto interface {
 	VerifyAggregateQC(aggQC AggregateQC) (ok bool, highQC QuorumCert)
 }
 
+// BatchSigner is an optional interface for Crypto implementations that can sign several blocks
+// more efficiently than signing them one at a time.
+// If the VoteCoalesceWindow option is set, votes are signed in batches using this interface.
+// The Crypto implementation that is returned by crypto.New implements it.
+type BatchSigner interface {
+	// CreatePartialCerts signs the blocks and returns the partial certificates in the same order.
+	CreatePartialCerts(blocks []*Block) (certs []PartialCert, err error)
+}
+
+// MessageSigner is an optional interface for CryptoImpl implementations that sign the full serialized block,
+// rather than only the block hash, when creating partial certificates and quorum certificates.
+// The message that is signed is domain-separated from other uses of the signing key.
+type MessageSigner interface {
+	// SignMessage signs a message.
+	SignMessage(message []byte) (sig Signature, err error)
+	// VerifyMessage verifies a signature given a message.
+	VerifyMessage(sig Signature, message []byte) bool
+	// CreateThresholdSignatureForMessage creates a threshold signature from partial signatures of the message.
+	CreateThresholdSignatureForMessage(partialSignatures []Signature, message []byte) (ThresholdSignature, error)
+	// VerifyThresholdSignatureForMessage verifies a threshold signature given a message.
+	// The signature must be from a quorum of the replicas that participated in the given view.
+	VerifyThresholdSignatureForMessage(signature ThresholdSignature, message []byte, view View) bool
+}
+
 // BlockChain is a datastructure that stores a chain of blocks.
 // It is not required that a block is stored forever,
 // but a block must be stored until at least one of its children have been committed.
@@ -337,12 +448,53 @@ type Replica interface {
 	Vote(cert PartialCert)
 	// NewView sends the quorum certificate to the other replica.
 	NewView(SyncInfo)
+	// AckProposal tells the other replica that the proposal of the block was received.
+	AckProposal(hash Hash)
+	// Abstain tells the other replica that the proposal of the block was received, but not voted for.
+	Abstain(view View, hash Hash)
 	// Rep returns the replicas reputation
 	GetRep() float64
 	//Updates the reputation
 	UpdateRep(float64)
 }
 
+// Witness is an optional interface for Replica implementations that can report whether the replica is a witness.
+// A witness receives and relays blocks, but does not vote. Witnesses are not counted in the quorum size,
+// and their votes and timeouts are ignored.
+type Witness interface {
+	// Witness returns true if the replica is a witness.
+	Witness() bool
+}
+
+// IsWitness returns true if the replica reports that it is a witness.
+func IsWitness(replica Replica) bool {
+	w, ok := replica.(Witness)
+	return ok && w.Witness()
+}
+
+// IsVoter returns true if the replica with the given ID is a member and is not a witness.
+// Only the signatures of voters count toward a quorum.
+func IsVoter(members Membership, id hotstuff.ID) bool {
+	replica, ok := members.Replica(id)
+	return ok && !IsWitness(replica)
+}
+
+// ConnectionReporter is an optional interface for Replica implementations that can report
+// whether the replica is currently connected.
+type ConnectionReporter interface {
+	// Connected returns true if the replica is connected.
+	Connected() bool
+}
+
+// IsConnected returns false if the replica reports that it is not connected.
+// Replicas that cannot report their connection state are assumed to be connected.
+func IsConnected(replica Replica) bool {
+	if r, ok := replica.(ConnectionReporter); ok {
+		return r.Connected()
+	}
+	return true
+}
+
 //go:generate mockgen -destination=../internal/mocks/configuration_mock.go -package=mocks . Configuration
 
 // Configuration holds information about the current configuration of replicas that participate in the protocol,
@@ -364,6 +516,77 @@ type Configuration interface {
 	Fetch(ctx context.Context, hash Hash) (block *Block, ok bool)
 }
 
+// SubsetFetcher is an optional interface for configurations that can request a block from some of the replicas.
+type SubsetFetcher interface {
+	// FetchFrom requests a block from the replicas with the given IDs.
+	FetchFrom(ctx context.Context, hash Hash, ids []hotstuff.ID) (block *Block, ok bool)
+}
+
+// LivenessTracker is an optional interface for configurations that record when messages are received from each replica.
+type LivenessTracker interface {
+	// LastSeen returns the time that the last message from the replica was received,
+	// or the zero time if no message has been received from the replica.
+	LastSeen(id hotstuff.ID) time.Time
+	// IsResponsive returns true if a message has been received from the replica within the ResponsiveThreshold.
+	// The local replica is always responsive.
+	IsResponsive(id hotstuff.ID) bool
+}
+
+// Reconfigurable is an optional interface for configurations whose set of replicas can change.
+// A committed Reconfiguration is scheduled to take effect in a later view, and takes effect
+// when the synchronizer enters that view. Until then, the replicas and the quorum size are based on the old set of replicas.
+// Reconfigurable configurations should also implement MembershipHistory, such that certificates from
+// before the change remain valid after it.
+type Reconfigurable interface {
+	// Reconfigure schedules the change to take effect in the given view.
+	Reconfigure(change Reconfiguration, view View) error
+	// EnterView applies the changes that take effect in or before the given view.
+	EnterView(view View)
+}
+
+// Membership is the set of replicas that participate in the protocol in some view.
+// A Configuration is the membership of the current view.
+type Membership interface {
+	// Replicas returns all of the members.
+	Replicas() map[hotstuff.ID]Replica
+	// Replica returns a replica if it is a member.
+	Replica(hotstuff.ID) (replica Replica, ok bool)
+	// QuorumSize returns the size of a quorum of the members.
+	QuorumSize() int
+}
+
+// MembershipHistory is an optional interface for configurations whose set of replicas can change.
+// It makes it possible to verify a certificate against the replicas that created it.
+type MembershipHistory interface {
+	// MembershipAt returns the replicas that participate in the given view.
+	MembershipAt(view View) Membership
+}
+
+// MembershipAt returns the replicas of the configuration that participate in the given view.
+// The replicas of a configuration that does not implement MembershipHistory never change.
+func MembershipAt(cfg Configuration, view View) Membership {
+	if history, ok := cfg.(MembershipHistory); ok {
+		return history.MembershipAt(view)
+	}
+	return cfg
+}
+
+// SortedReplicas returns the IDs of the members, in ascending order.
+//
+// This is the canonical order of the members: the signers of a threshold signature are indexed by it,
+// and leader rotations that map views or random numbers to replicas must index into it,
+// rather than assume that the IDs are 1 to n, or iterate over the replicas in map order.
+// Thus, all replicas that have the same configuration agree on the order, also after replicas have been added or removed.
+func SortedReplicas(members Membership) []hotstuff.ID {
+	replicas := members.Replicas()
+	ids := make([]hotstuff.ID, 0, len(replicas))
+	for id := range replicas {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
 //go:generate mockgen -destination=../internal/mocks/consensus_mock.go -package=mocks . Consensus
 
 // Consensus implements a byzantine consensus protocol, such as HotStuff.
@@ -376,6 +599,16 @@ type Consensus interface {
 	Propose(cert SyncInfo)
 	// CommittedBlock returns the most recently committed block.
 	CommittedBlock() *Block
+	// Pause stops the processing of proposals and votes until Resume is called.
+	// Messages that arrive in the meantime are buffered or dropped according to the PausePolicy option.
+	Pause()
+	// Resume continues the processing of proposals and votes after a call to Pause.
+	Resume()
+	// Paused returns true if consensus is paused.
+	Paused() bool
+	// RegisterCommitHandler registers a function that is called once for each committed block, in commit order.
+	// The handlers run synchronously on the consensus goroutine, after the block has been executed.
+	RegisterCommitHandler(handler func(*Block))
 }
 
 // LeaderRotation implements a leader rotation scheme.
@@ -384,6 +617,13 @@ type LeaderRotation interface {
 	GetLeader(View) hotstuff.ID
 }
 
+// TimeoutObserver is an optional interface for leader rotations that take view timeouts into account.
+type TimeoutObserver interface {
+	// ViewTimedOut is called by the synchronizer when it leaves the current view because of a timeout certificate,
+	// with the replica that the leader rotation chose as the leader when the view started.
+	ViewTimedOut(view View, leader hotstuff.ID)
+}
+
 //go:generate mockgen -destination=../internal/mocks/synchronizer_mock.go -package=mocks . Synchronizer
 
 // Synchronizer synchronizes replicas to the same view.
@@ -401,6 +641,9 @@ type Synchronizer interface {
 	HighQC() QuorumCert
 	// LeafBlock returns the current leaf block.
 	LeafBlock() *Block
+	// TimedOutView returns the most recent view that was abandoned because of a local timeout.
+	// ok is false if no local timeout has happened.
+	TimedOutView() (view View, ok bool)
 	// Start starts the synchronizer with the given context.
 	Start(context.Context)
 
@@ -1,8 +1,45 @@
 package consensus
 
+import (
+	"time"
+
+	"github.com/relab/hotstuff"
+)
+
+// MaxVoteCoalesceWindow is the upper bound on the time that the signing of a vote can be delayed in order
+// to sign it together with other votes. Longer windows are reduced to this bound to avoid causing timeouts.
+const MaxVoteCoalesceWindow = 10 * time.Millisecond
+
 // Options stores runtime configuration settings.
 type Options struct {
 	shouldUseAggQC bool
+	maxFutureView  View
+	maxBlockSize   int
+	parentDepth    int
+	pausePolicy    PausePolicy
+	voteWindow     time.Duration
+	dropAbandoned  bool
+	relayQC        bool
+	ackProposals   bool
+	maxPending     int
+	strictTCView   bool
+	witness        bool
+	piggybackTC    bool
+	quorumWait     QuorumWaitStrategy
+	voteSigners    int
+	preExecPolicy  PreExecPolicy
+	validateSkips  bool
+	stepDown       int
+	verifyProposer bool
+	timeoutViews   int
+	viewTimeouts   int
+	abstain        bool
+	emptyProposals bool
+	stalePolicy    StaleCommandPolicy
+	rejectLog      time.Duration
+	responsive     time.Duration
+	reconfigDelay  View
+	faultModel     hotstuff.FaultModel
 }
 
 // ShouldUseAggQC returns true if aggregated quorum certificates should be used.
@@ -11,6 +48,174 @@ func (c Options) ShouldUseAggQC() bool {
 	return c.shouldUseAggQC
 }
 
+// MaxFutureView returns the maximum number of views that a certificate may be ahead of the current view.
+// Certificates beyond this bound are rejected without being verified. A value of 0 means that there is no bound.
+func (c Options) MaxFutureView() View {
+	return c.maxFutureView
+}
+
+// MaxProposalSize returns the maximum size in bytes of the command of a proposed block.
+// Proposals with larger commands are rejected without being verified. A value of 0 means that there is no limit.
+func (c Options) MaxProposalSize() int {
+	return c.maxBlockSize
+}
+
+// ParentValidationDepth returns the number of blocks, starting with the proposed block and continuing with its ancestors,
+// whose parent must be the same block as the one referenced by their QC. A value of 0 disables the validation.
+func (c Options) ParentValidationDepth() int {
+	return c.parentDepth
+}
+
+// PreExecPolicy returns the policy for committed blocks that the CommitHook failed to record.
+func (c Options) PreExecPolicy() PreExecPolicy {
+	return c.preExecPolicy
+}
+
+// PausePolicy returns the policy for proposals and votes that arrive while consensus is paused.
+func (c Options) PausePolicy() PausePolicy {
+	return c.pausePolicy
+}
+
+// VoteCoalesceWindow returns the time to wait for other proposals before signing a vote,
+// such that the votes can be signed together. A value of 0 means that votes are signed immediately.
+func (c Options) VoteCoalesceWindow() time.Duration {
+	return c.voteWindow
+}
+
+// DropAbandonedProposals returns true if proposals for views that were abandoned because of a local timeout
+// should be dropped.
+func (c Options) DropAbandonedProposals() bool {
+	return c.dropAbandoned
+}
+
+// ShouldRelayQC returns true if the leader should send new QCs to all replicas,
+// instead of only including them in the next proposal.
+func (c Options) ShouldRelayQC() bool {
+	return c.relayQC
+}
+
+// ShouldAckProposals returns true if replicas should acknowledge the proposals they receive.
+func (c Options) ShouldAckProposals() bool {
+	return c.ackProposals
+}
+
+// MaxPendingProposals returns the maximum number of proposals that are buffered while consensus is paused.
+// When the bound is exceeded, the proposals for the views that are furthest ahead are dropped.
+// A value of 0 means that there is no bound. The bound only applies while paused: proposals that arrive
+// while consensus is running, including proposals for future views, are handled immediately and never buffered.
+func (c Options) MaxPendingProposals() int {
+	return c.maxPending
+}
+
+// StrictTimeoutCertView returns true if a timeout certificate for a view before the current view
+// is rejected, instead of being used to update the high QC.
+func (c Options) StrictTimeoutCertView() bool {
+	return c.strictTCView
+}
+
+// ValidateViewSkips returns true if proposals must carry a certificate for the view before the proposed view.
+// A proposal that skips views must then include a TC for the previous view, so this implies the PiggybackTC setting.
+func (c Options) ValidateViewSkips() bool {
+	return c.validateSkips
+}
+
+// StepDownThreshold returns the number of consecutive proposals that the leader may send without any of them
+// being committed, before it steps down as the leader. A value of 0 means that the leader never steps down.
+func (c Options) StepDownThreshold() int {
+	return c.stepDown
+}
+
+// ShouldVerifyProposer returns true if proposals from replicas other than the leader of the view,
+// according to the leader rotation, should be rejected before their certificates are used.
+// Otherwise, such proposals are not voted for, but their QCs may still advance the view.
+func (c Options) ShouldVerifyProposer() bool {
+	return c.verifyProposer
+}
+
+// MaxTimeoutViews returns the maximum number of views for which the synchronizer buffers timeout messages.
+// When the bound is exceeded, the timeout messages for the views that are furthest ahead are dropped.
+// A value of 0 means that there is no bound.
+func (c Options) MaxTimeoutViews() int {
+	return c.timeoutViews
+}
+
+// MaxTimeoutsPerView returns the maximum number of timeout messages that the synchronizer buffers for a single view.
+// The synchronizer always buffers enough timeout messages to form a quorum, so smaller bounds are raised to the quorum size.
+// A value of 0 means that there is no bound.
+func (c Options) MaxTimeoutsPerView() int {
+	return c.viewTimeouts
+}
+
+// ShouldAbstain returns true if replicas should send an abstain message to the leader
+// when they receive a proposal that they do not vote for.
+func (c Options) ShouldAbstain() bool {
+	return c.abstain
+}
+
+// AllowEmptyProposals returns true if the leader should propose a block with an empty command
+// when no commands are available, instead of waiting for commands. Empty blocks keep the views advancing,
+// such that the blocks that have not been committed yet can reach the commit point.
+func (c Options) AllowEmptyProposals() bool {
+	return c.emptyProposals
+}
+
+// StaleCommandPolicy returns the policy for proposals whose client commands have all been committed already.
+func (c Options) StaleCommandPolicy() StaleCommandPolicy {
+	return c.stalePolicy
+}
+
+// RejectLogInterval returns the interval at which rejected messages are logged.
+// At most one rejection is logged for each message type and reject reason per interval,
+// and the number of rejections that were not logged is summarized in a single line.
+// All rejections are still reported to the metrics. A value of 0 means that all rejections are logged.
+func (c Options) RejectLogInterval() time.Duration {
+	return c.rejectLog
+}
+
+// ResponsiveThreshold returns the time since the last message from a replica, after which the replica
+// is no longer considered responsive. A value of 0 means that a replica is responsive once a message has been received from it.
+func (c Options) ResponsiveThreshold() time.Duration {
+	return c.responsive
+}
+
+// ReconfigurationDelay returns the number of views between the block that commits a reconfiguration
+// and the view in which it takes effect. The delay must be long enough for all correct replicas
+// to commit the block before the change takes effect. A value of 0 means DefaultReconfigurationDelay.
+func (c Options) ReconfigurationDelay() View {
+	if c.reconfigDelay == 0 {
+		return DefaultReconfigurationDelay
+	}
+	return c.reconfigDelay
+}
+
+// FaultModel returns the fault model that determines the quorum size of the configuration.
+func (c Options) FaultModel() hotstuff.FaultModel {
+	return c.faultModel
+}
+
+// IsWitness returns true if the local replica is a witness, which stores and relays blocks,
+// but does not vote or send timeout messages.
+func (c Options) IsWitness() bool {
+	return c.witness
+}
+
+// ShouldPiggybackTC returns true if the leader should include the timeout certificate that moved it to the current view
+// in its proposal, such that replicas that did not receive the timeout certificate can catch up.
+func (c Options) ShouldPiggybackTC() bool {
+	return c.piggybackTC
+}
+
+// QuorumWaitStrategy returns the strategy that the leader uses when some replicas cannot be reached.
+func (c Options) QuorumWaitStrategy() QuorumWaitStrategy {
+	return c.quorumWait
+}
+
+// VoteSigningWorkers returns the number of workers that sign votes concurrently, outside of the event loop.
+// A value of 0 means that votes are signed by the event loop.
+func (c Options) VoteSigningWorkers() int {
+	return c.voteSigners
+}
+
 // OptionsBuilder is used to set the values of immutable configuration settings.
 type OptionsBuilder struct {
 	opts Options
@@ -20,3 +225,149 @@ type OptionsBuilder struct {
 func (builder *OptionsBuilder) SetShouldUseAggQC() {
 	builder.opts.shouldUseAggQC = true
 }
+
+// SetMaxFutureView sets the maximum number of views that a certificate may be ahead of the current view.
+func (builder *OptionsBuilder) SetMaxFutureView(views View) {
+	builder.opts.maxFutureView = views
+}
+
+// SetMaxProposalSize sets the maximum size in bytes of the command of a proposed block.
+func (builder *OptionsBuilder) SetMaxProposalSize(size int) {
+	builder.opts.maxBlockSize = size
+}
+
+// SetParentValidationDepth sets the number of blocks whose parent pointer should be validated against their QC.
+func (builder *OptionsBuilder) SetParentValidationDepth(depth int) {
+	builder.opts.parentDepth = depth
+}
+
+// SetPreExecPolicy sets the policy for committed blocks that the CommitHook failed to record.
+func (builder *OptionsBuilder) SetPreExecPolicy(policy PreExecPolicy) {
+	builder.opts.preExecPolicy = policy
+}
+
+// SetPausePolicy sets the policy for proposals and votes that arrive while consensus is paused.
+func (builder *OptionsBuilder) SetPausePolicy(policy PausePolicy) {
+	builder.opts.pausePolicy = policy
+}
+
+// SetVoteCoalesceWindow sets the time to wait for other proposals before signing a vote.
+// The window is bounded by MaxVoteCoalesceWindow.
+func (builder *OptionsBuilder) SetVoteCoalesceWindow(window time.Duration) {
+	if window > MaxVoteCoalesceWindow {
+		window = MaxVoteCoalesceWindow
+	}
+	builder.opts.voteWindow = window
+}
+
+// SetDropAbandonedProposals sets the DropAbandonedProposals setting to true.
+func (builder *OptionsBuilder) SetDropAbandonedProposals() {
+	builder.opts.dropAbandoned = true
+}
+
+// SetShouldRelayQC sets the ShouldRelayQC setting to true.
+func (builder *OptionsBuilder) SetShouldRelayQC() {
+	builder.opts.relayQC = true
+}
+
+// SetShouldAckProposals sets the ShouldAckProposals setting to true.
+func (builder *OptionsBuilder) SetShouldAckProposals() {
+	builder.opts.ackProposals = true
+}
+
+// SetMaxPendingProposals sets the maximum number of proposals that are buffered while consensus is paused.
+// It does not limit proposals that arrive while consensus is running.
+func (builder *OptionsBuilder) SetMaxPendingProposals(n int) {
+	builder.opts.maxPending = n
+}
+
+// SetStrictTimeoutCertView sets the StrictTimeoutCertView setting to true.
+func (builder *OptionsBuilder) SetStrictTimeoutCertView() {
+	builder.opts.strictTCView = true
+}
+
+// SetValidateViewSkips sets the ValidateViewSkips setting to true.
+// This also sets the ShouldPiggybackTC setting, as the proposals that follow a timeout would be rejected otherwise.
+func (builder *OptionsBuilder) SetValidateViewSkips() {
+	builder.opts.validateSkips = true
+	builder.opts.piggybackTC = true
+}
+
+// SetStepDownThreshold sets the number of consecutive uncommitted proposals after which the leader steps down.
+// As a block is committed some views after it was proposed, the threshold should be larger than the commit delay
+// of the consensus implementation, e.g. greater than 3 for chained HotStuff.
+func (builder *OptionsBuilder) SetStepDownThreshold(proposals int) {
+	builder.opts.stepDown = proposals
+}
+
+// SetShouldVerifyProposer sets the ShouldVerifyProposer setting to true.
+func (builder *OptionsBuilder) SetShouldVerifyProposer() {
+	builder.opts.verifyProposer = true
+}
+
+// SetMaxTimeoutViews sets the maximum number of views for which the synchronizer buffers timeout messages.
+func (builder *OptionsBuilder) SetMaxTimeoutViews(views int) {
+	builder.opts.timeoutViews = views
+}
+
+// SetMaxTimeoutsPerView sets the maximum number of timeout messages that the synchronizer buffers for a single view.
+func (builder *OptionsBuilder) SetMaxTimeoutsPerView(timeouts int) {
+	builder.opts.viewTimeouts = timeouts
+}
+
+// SetShouldAbstain sets the ShouldAbstain setting to true.
+func (builder *OptionsBuilder) SetShouldAbstain() {
+	builder.opts.abstain = true
+}
+
+// SetAllowEmptyProposals sets the AllowEmptyProposals setting to true.
+func (builder *OptionsBuilder) SetAllowEmptyProposals() {
+	builder.opts.emptyProposals = true
+}
+
+// SetStaleCommandPolicy sets the policy for proposals whose client commands have all been committed already.
+func (builder *OptionsBuilder) SetStaleCommandPolicy(policy StaleCommandPolicy) {
+	builder.opts.stalePolicy = policy
+}
+
+// SetRejectLogInterval sets the interval at which rejected messages are logged.
+func (builder *OptionsBuilder) SetRejectLogInterval(interval time.Duration) {
+	builder.opts.rejectLog = interval
+}
+
+// SetResponsiveThreshold sets the time since the last message from a replica, after which the replica
+// is no longer considered responsive.
+func (builder *OptionsBuilder) SetResponsiveThreshold(threshold time.Duration) {
+	builder.opts.responsive = threshold
+}
+
+// SetReconfigurationDelay sets the number of views between the block that commits a reconfiguration
+// and the view in which it takes effect.
+func (builder *OptionsBuilder) SetReconfigurationDelay(views View) {
+	builder.opts.reconfigDelay = views
+}
+
+// SetFaultModel sets the fault model. All replicas must use the same fault model.
+func (builder *OptionsBuilder) SetFaultModel(model hotstuff.FaultModel) {
+	builder.opts.faultModel = model
+}
+
+// SetWitness sets the IsWitness setting to true.
+func (builder *OptionsBuilder) SetWitness() {
+	builder.opts.witness = true
+}
+
+// SetShouldPiggybackTC sets the ShouldPiggybackTC setting to true.
+func (builder *OptionsBuilder) SetShouldPiggybackTC() {
+	builder.opts.piggybackTC = true
+}
+
+// SetQuorumWaitStrategy sets the strategy that the leader uses when some replicas cannot be reached.
+func (builder *OptionsBuilder) SetQuorumWaitStrategy(strategy QuorumWaitStrategy) {
+	builder.opts.quorumWait = strategy
+}
+
+// SetVoteSigningWorkers sets the number of workers that sign votes concurrently, outside of the event loop.
+func (builder *OptionsBuilder) SetVoteSigningWorkers(n int) {
+	builder.opts.voteSigners = n
+}
@@ -0,0 +1,100 @@
+package consensus
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/relab/hotstuff"
+)
+
+// DefaultReconfigurationDelay is the number of views between the block that commits a reconfiguration
+// and the view in which the reconfiguration takes effect, if the ReconfigurationDelay option is not set.
+const DefaultReconfigurationDelay View = 10
+
+// reconfigurationPrefix identifies commands that encode a Reconfiguration.
+// Client commands are protobuf messages or batches, which never start with a zero byte followed by this tag.
+var reconfigurationPrefix = []byte("\x00hotstuff-reconfiguration\x00")
+
+// Reconfiguration is a change to the set of replicas. It is proposed as a command, and takes effect
+// in a view that is determined by the view of the block that commits it, such that all replicas
+// apply the change in the same view.
+type Reconfiguration struct {
+	// Remove is true if the replica should be removed, and false if it should be added.
+	Remove bool
+	// ID is the ID of the replica.
+	ID hotstuff.ID
+	// Address is the address of a replica that is added.
+	Address string
+	// PublicKey is the PEM encoded public key of a replica that is added.
+	PublicKey []byte
+}
+
+// ToCommand encodes the reconfiguration as a command that can be proposed.
+func (r Reconfiguration) ToCommand() Command {
+	var buf bytes.Buffer
+	buf.Write(reconfigurationPrefix)
+	if r.Remove {
+		buf.WriteByte(1)
+	} else {
+		buf.WriteByte(0)
+	}
+	var id [4]byte
+	binary.BigEndian.PutUint32(id[:], uint32(r.ID))
+	buf.Write(id[:])
+	writeLengthPrefixed(&buf, []byte(r.Address))
+	writeLengthPrefixed(&buf, r.PublicKey)
+	return Command(buf.String())
+}
+
+// String returns a string representation of the reconfiguration.
+func (r Reconfiguration) String() string {
+	if r.Remove {
+		return fmt.Sprintf("remove replica %d", r.ID)
+	}
+	return fmt.Sprintf("add replica %d at %s", r.ID, r.Address)
+}
+
+// ReconfigurationFromCommand decodes a reconfiguration from the command.
+// It returns false if the command does not encode a reconfiguration.
+func ReconfigurationFromCommand(cmd Command) (r Reconfiguration, ok bool) {
+	b := []byte(cmd)
+	if !bytes.HasPrefix(b, reconfigurationPrefix) {
+		return Reconfiguration{}, false
+	}
+	b = b[len(reconfigurationPrefix):]
+	if len(b) < 5 || b[0] > 1 {
+		return Reconfiguration{}, false
+	}
+	r.Remove = b[0] == 1
+	r.ID = hotstuff.ID(binary.BigEndian.Uint32(b[1:]))
+	b = b[5:]
+	address, b, ok := readLengthPrefixed(b)
+	if !ok {
+		return Reconfiguration{}, false
+	}
+	r.Address = string(address)
+	if r.PublicKey, b, ok = readLengthPrefixed(b); !ok || len(b) != 0 {
+		return Reconfiguration{}, false
+	}
+	return r, true
+}
+
+func writeLengthPrefixed(buf *bytes.Buffer, b []byte) {
+	var length [4]byte
+	binary.BigEndian.PutUint32(length[:], uint32(len(b)))
+	buf.Write(length[:])
+	buf.Write(b)
+}
+
+func readLengthPrefixed(b []byte) (field, rest []byte, ok bool) {
+	if len(b) < 4 {
+		return nil, nil, false
+	}
+	length := binary.BigEndian.Uint32(b)
+	b = b[4:]
+	if uint64(len(b)) < uint64(length) {
+		return nil, nil, false
+	}
+	return b[:length:length], b[length:], true
+}
@@ -0,0 +1,31 @@
+package consensus_test
+
+import (
+	"testing"
+
+	"github.com/relab/hotstuff/consensus"
+)
+
+// TestReconfigurationCommand checks that reconfigurations can be decoded from their commands,
+// and that other commands are not decoded as reconfigurations.
+func TestReconfigurationCommand(t *testing.T) {
+	for _, r := range []consensus.Reconfiguration{
+		{ID: 5, Address: "127.0.0.1:4000", PublicKey: []byte("-----BEGIN HOTSTUFF PUBLIC KEY-----")},
+		{Remove: true, ID: 2},
+	} {
+		got, ok := consensus.ReconfigurationFromCommand(r.ToCommand())
+		if !ok {
+			t.Fatalf("failed to decode %v", r)
+		}
+		if got.Remove != r.Remove || got.ID != r.ID || got.Address != r.Address || string(got.PublicKey) != string(r.PublicKey) {
+			t.Errorf("wrong reconfiguration: got %+v, want %+v", got, r)
+		}
+	}
+
+	cmd := consensus.Reconfiguration{ID: 5, Address: "127.0.0.1:4000"}.ToCommand()
+	for _, c := range []consensus.Command{"", "foo", cmd[:len(cmd)-1], cmd + "x"} {
+		if r, ok := consensus.ReconfigurationFromCommand(c); ok {
+			t.Errorf("decoded %q as %v", c, r)
+		}
+	}
+}
@@ -24,6 +24,7 @@ func New() consensus.Rules {
 // It also allows the module to set module options using the OptionsBuilder.
 func (hs *SimpleHotStuff) InitConsensusModule(mods *consensus.Modules, _ *consensus.OptionsBuilder) {
 	hs.mods = mods
+	hs.locked = mods.Genesis()
 }
 
 // VoteRule decides if the replica should vote for the given block.
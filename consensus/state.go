@@ -0,0 +1,53 @@
+package consensus
+
+// State is a copy of the internal state of the consensus pipeline of a replica.
+// It is intended for assertions in tests and for debugging.
+type State struct {
+	View             View                   // the current view
+	Leaf             *Block                 // the current leaf block
+	HighQC           QuorumCert             // the highest known QC
+	Committed        *Block                 // the most recently committed block
+	PendingProposals []ProposeMsg           // proposals that were received while paused, sorted by view
+	Votes            map[Hash][]PartialCert // verified votes for blocks that do not yet have a QC
+}
+
+// stateReporter is implemented by Consensus implementations that can report their part of the State.
+type stateReporter interface {
+	// fillState copies the state of the implementation into the state.
+	fillState(state *State)
+}
+
+// State returns a copy of the state of the consensus pipeline.
+//
+// The state of the consensus implementation and the voting machine is copied while holding both of their locks,
+// so that votes and proposals that are handled concurrently do not leave the state half-updated.
+// The synchronizer is only updated by the event loop, so State should be called by the event loop,
+// or while the event loop is not running.
+func (mods *Modules) State() State {
+	vm := mods.votingMachine
+	vm.mut.Lock()
+	defer vm.mut.Unlock()
+
+	state := State{
+		View:   mods.Synchronizer().View(),
+		Leaf:   mods.Synchronizer().LeafBlock(),
+		HighQC: mods.Synchronizer().HighQC(),
+		Votes:  make(map[Hash][]PartialCert, len(vm.verifiedVotes)),
+	}
+	for hash, votes := range vm.verifiedVotes {
+		state.Votes[hash] = append([]PartialCert(nil), votes...)
+	}
+	if r, ok := mods.Consensus().(stateReporter); ok {
+		r.fillState(&state)
+	} else {
+		state.Committed = mods.Consensus().CommittedBlock()
+	}
+	return state
+}
+
+func (cs *consensusBase) fillState(state *State) {
+	cs.mut.Lock()
+	defer cs.mut.Unlock()
+	state.Committed = cs.bExec
+	state.PendingProposals = append([]ProposeMsg(nil), cs.pendingProposals...)
+}
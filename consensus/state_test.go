@@ -0,0 +1,105 @@
+package consensus_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/consensus/chainedhotstuff"
+	"github.com/relab/hotstuff/internal/mocks"
+	"github.com/relab/hotstuff/internal/testutil"
+	"github.com/relab/hotstuff/synchronizer"
+)
+
+// TestState drives a replica through a chain of proposals and checks the state of its pipeline after each step.
+func TestState(t *testing.T) {
+	const n = 4
+	ctrl := gomock.NewController(t)
+	bl := testutil.CreateBuilders(t, ctrl, n)
+	bl[0].Register(
+		consensus.New(chainedhotstuff.New()),
+		synchronizer.New(testutil.FixedTimeout(1000)),
+		testutil.NewLeaderRotation(t, 2, 2, 2, 2, 2, 2),
+	)
+	hl := bl.Build()
+	hs := hl[0]
+	signers := hl.Signers()
+
+	leader, _ := hs.Configuration().Replica(2)
+	leader.(*mocks.MockReplica).EXPECT().Vote(gomock.Any()).AnyTimes()
+	leader.(*mocks.MockReplica).EXPECT().NewView(gomock.Any()).AnyTimes()
+
+	run := func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		hs.EventLoop().Run(ctx)
+	}
+
+	state := hs.State()
+	genesis := consensus.GetGenesis()
+	if state.View != 1 || state.Leaf != genesis || state.Committed != genesis {
+		t.Fatalf("unexpected initial state: %+v", state)
+	}
+
+	// propose a chain of blocks, each certified by the QC in the next block
+	var blocks []*consensus.Block
+	parent := genesis
+	qc := consensus.NewQuorumCert(nil, 0, genesis.Hash())
+	for v := consensus.View(1); v <= 4; v++ {
+		block := consensus.NewBlock(parent.Hash(), qc, "foo", v, 2)
+		hs.EventLoop().AddEvent(consensus.ProposeMsg{ID: 2, Block: block})
+		run()
+		blocks = append(blocks, block)
+
+		state = hs.State()
+		if v > 1 {
+			// the QC in the block certifies its parent, which moves the replica on to the next view
+			if state.View != v {
+				t.Errorf("view %d: wrong current view: got: %d, want: %d", v, state.View, v)
+			}
+			if state.HighQC.BlockHash() != parent.Hash() {
+				t.Errorf("view %d: wrong high QC: got: %.8s, want: %.8s", v, state.HighQC.BlockHash(), parent.Hash())
+			}
+			if state.Leaf != parent {
+				t.Errorf("view %d: wrong leaf: got: %v, want: %v", v, state.Leaf, parent)
+			}
+		}
+		qc = testutil.CreateQC(t, block, signers)
+		parent = block
+	}
+
+	// the fourth block completes a three-chain, which commits the first block
+	if state.Committed != blocks[0] {
+		t.Errorf("wrong committed block: got: %v, want: %v", state.Committed, blocks[0])
+	}
+
+	// proposals that arrive while paused are pending
+	hs.Consensus().Pause()
+	next := consensus.NewBlock(parent.Hash(), qc, "foo", 5, 2)
+	hs.EventLoop().AddEvent(consensus.ProposeMsg{ID: 2, Block: next})
+	run()
+	state = hs.State()
+	if len(state.PendingProposals) != 1 || state.PendingProposals[0].Block != next {
+		t.Errorf("wrong pending proposals: got: %v, want: [%v]", state.PendingProposals, next)
+	}
+	hs.Consensus().Resume()
+
+	// votes are collected until there is a quorum
+	hs.Synchronizer().AdvanceView(consensus.NewSyncInfo().WithQC(qc))
+	block := consensus.NewBlock(parent.Hash(), qc, "bar", 5, 1)
+	hs.BlockChain().Store(block)
+	hs.EventLoop().AddEvent(consensus.VoteMsg{ID: 3, PartialCert: testutil.CreatePC(t, block, signers[2])})
+	run()
+	// the vote is verified asynchronously
+	deadline := time.Now().Add(time.Second)
+	for len(hs.State().Votes[block.Hash()]) == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	votes := hs.State().Votes[block.Hash()]
+	if len(votes) != 1 || votes[0].Signature().Signer() != hotstuff.ID(3) {
+		t.Errorf("wrong votes for block: got: %v", votes)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/base64"
 	"encoding/binary"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -48,6 +49,16 @@ func (s idSetMap) ForEach(f func(hotstuff.ID)) {
 	}
 }
 
+// sortedIDs returns the IDs in the set in ascending order.
+func sortedIDs(set IDSet) []hotstuff.ID {
+	var ids []hotstuff.ID
+	set.ForEach(func(id hotstuff.ID) {
+		ids = append(ids, id)
+	})
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
 // View is a number that uniquely identifies a view.
 type View uint64
 
@@ -58,14 +69,12 @@ func (v View) ToBytes() []byte {
 	return viewBytes[:]
 }
 
-// ToHash converts the view to a Hash type. It does not actually hash the view.
+// ToHash returns the hash of the view, which is signed in timeout messages.
 func (v View) ToHash() Hash {
-	h := Hash{}
-	binary.LittleEndian.PutUint64(h[:8], uint64(v))
-	return h
+	return hasher.Sum(v.ToBytes())
 }
 
-// Hash is a SHA256 hash
+// Hash is a 32 byte hash computed by the Hasher, which is SHA256 by default.
 type Hash [32]byte
 
 func (h Hash) String() string {
@@ -107,14 +116,23 @@ type ThresholdSignature interface {
 }
 
 // PartialCert is a signed block hash.
+// It may also carry auxiliary data, such as sync information, that is covered by a separate signature.
 type PartialCert struct {
-	signature Signature
-	blockHash Hash
+	signature    Signature
+	blockHash    Hash
+	aux          []byte
+	auxSignature Signature
 }
 
 // NewPartialCert returns a new partial certificate.
 func NewPartialCert(signature Signature, blockHash Hash) PartialCert {
-	return PartialCert{signature, blockHash}
+	return PartialCert{signature: signature, blockHash: blockHash}
+}
+
+// NewPartialCertWithAux returns a new partial certificate with auxiliary data.
+// The auxSignature must be a signature of the hash returned by AuxHash.
+func NewPartialCertWithAux(signature Signature, blockHash Hash, aux []byte, auxSignature Signature) PartialCert {
+	return PartialCert{signature, blockHash, aux, auxSignature}
 }
 
 // Signature returns the signature.
@@ -127,9 +145,35 @@ func (pc PartialCert) BlockHash() Hash {
 	return pc.blockHash
 }
 
+// Aux returns the auxiliary data, or nil if the partial certificate has none.
+func (pc PartialCert) Aux() []byte {
+	return pc.aux
+}
+
+// AuxSignature returns the signature of the auxiliary data, or nil if the partial certificate has none.
+func (pc PartialCert) AuxSignature() Signature {
+	return pc.auxSignature
+}
+
+// AuxHash returns the hash that is signed to cover the auxiliary data.
+// The hash includes the block hash, such that the auxiliary data cannot be moved to a different partial certificate.
+func (pc PartialCert) AuxHash() Hash {
+	return AuxHash(pc.blockHash, pc.aux)
+}
+
+// AuxHash returns the hash of the auxiliary data for the block with the given hash.
+func AuxHash(blockHash Hash, aux []byte) Hash {
+	return hasher.Sum(append(blockHash[:], aux...))
+}
+
 // ToBytes returns a byte representation of the partial certificate.
 func (pc PartialCert) ToBytes() []byte {
-	return append(pc.blockHash[:], pc.signature.ToBytes()...)
+	b := append(pc.blockHash[:], pc.signature.ToBytes()...)
+	if pc.auxSignature != nil {
+		b = append(b, pc.aux...)
+		b = append(b, pc.auxSignature.ToBytes()...)
+	}
+	return b
 }
 
 // SyncInfo holds the highest known QC or TC.
@@ -224,11 +268,41 @@ func (qc QuorumCert) ToBytes() []byte {
 	return b
 }
 
+// QuorumCertFromBytes restores a quorum certificate from the representation returned by ToBytes.
+// The threshold signature, if any, is restored by the given function, which depends on the crypto implementation.
+func QuorumCertFromBytes(b []byte, restore func([]byte) (ThresholdSignature, error)) (QuorumCert, error) {
+	headerSize := len(View(0).ToBytes()) + len(Hash{})
+	if len(b) < headerSize {
+		return QuorumCert{}, fmt.Errorf("quorum certificate too short: %d bytes", len(b))
+	}
+	qc := QuorumCert{view: View(binary.LittleEndian.Uint64(b))}
+	copy(qc.hash[:], b[headerSize-len(Hash{}):])
+	if len(b) == headerSize {
+		return qc, nil
+	}
+	signature, err := restore(b[headerSize:])
+	if err != nil {
+		return QuorumCert{}, fmt.Errorf("failed to restore the signature of the quorum certificate: %w", err)
+	}
+	qc.signature = signature
+	return qc, nil
+}
+
 // Signature returns the threshold signature.
 func (qc QuorumCert) Signature() ThresholdSignature {
 	return qc.signature
 }
 
+// Signers returns the IDs of the replicas whose partial certificates are included in the QC, in ascending order.
+// The order does not depend on the crypto implementation, so the IDs can be used as input to a hash function.
+// It returns nil if the QC has no signature, such as the QC of the genesis block.
+func (qc QuorumCert) Signers() []hotstuff.ID {
+	if qc.signature == nil {
+		return nil
+	}
+	return sortedIDs(qc.signature.Participants())
+}
+
 // BlockHash returns the hash of the block that was signed.
 func (qc QuorumCert) BlockHash() Hash {
 	return qc.hash
@@ -255,24 +329,54 @@ func (qc QuorumCert) Equals(other QuorumCert) bool {
 
 func (qc QuorumCert) String() string {
 	var sb strings.Builder
-	if qc.signature != nil {
-		qc.signature.Participants().ForEach(func(id hotstuff.ID) {
-			sb.WriteString(strconv.FormatUint(uint64(id), 10))
-			sb.WriteByte(' ')
-		})
+	for _, id := range qc.Signers() {
+		sb.WriteString(strconv.FormatUint(uint64(id), 10))
+		sb.WriteByte(' ')
 	}
 	return fmt.Sprintf("QC{ hash: %.6s, IDs: [ %s] }", qc.hash, &sb)
 }
 
 // TimeoutCert (TC) is a certificate created by a quorum of timeout messages.
+//
+// Each timeout message signs the view and a reference to the QC of the sender. The TC includes these references,
+// such that the signature can be verified, and the highest QC that they reference, such that the replicas that
+// advance to the next view using the TC also learn of the highest certified block.
 type TimeoutCert struct {
 	signature ThresholdSignature
 	view      View
+	highQCs   map[hotstuff.ID]HighQCRef
+	highQC    *QuorumCert
 }
 
 // NewTimeoutCert returns a new timeout certificate.
 func NewTimeoutCert(signature ThresholdSignature, view View) TimeoutCert {
-	return TimeoutCert{signature, view}
+	return TimeoutCert{signature: signature, view: view}
+}
+
+// WithHighQC returns a copy of the timeout certificate with the given highQC.
+func (tc TimeoutCert) WithHighQC(qc QuorumCert) TimeoutCert {
+	tc.highQC = new(QuorumCert)
+	*tc.highQC = qc
+	return tc
+}
+
+// WithHighQCRefs returns a copy of the timeout certificate with the references to the QCs of the timeout messages.
+func (tc TimeoutCert) WithHighQCRefs(refs map[hotstuff.ID]HighQCRef) TimeoutCert {
+	tc.highQCs = refs
+	return tc
+}
+
+// HighQCRefs returns the references to the QCs of the timeout messages, indexed by the ID of the sender.
+func (tc TimeoutCert) HighQCRefs() map[hotstuff.ID]HighQCRef {
+	return tc.highQCs
+}
+
+// HighQC returns the highest QC from the timeout messages, if present.
+func (tc TimeoutCert) HighQC() (_ QuorumCert, _ bool) {
+	if tc.highQC != nil {
+		return *tc.highQC, true
+	}
+	return
 }
 
 // ToBytes returns a byte representation of the timeout certificate.
@@ -287,6 +391,15 @@ func (tc TimeoutCert) Signature() ThresholdSignature {
 	return tc.signature
 }
 
+// Signers returns the IDs of the replicas whose timeout messages are included in the TC, in ascending order.
+// It returns nil if the TC has no signature.
+func (tc TimeoutCert) Signers() []hotstuff.ID {
+	if tc.signature == nil {
+		return nil
+	}
+	return sortedIDs(tc.signature.Participants())
+}
+
 // View returns the view in which the timeouts occurred.
 func (tc TimeoutCert) View() View {
 	return tc.view
@@ -294,11 +407,9 @@ func (tc TimeoutCert) View() View {
 
 func (tc TimeoutCert) String() string {
 	var sb strings.Builder
-	if tc.signature != nil {
-		tc.signature.Participants().ForEach(func(id hotstuff.ID) {
-			sb.WriteString(strconv.FormatUint(uint64(id), 10))
-			sb.WriteByte(' ')
-		})
+	for _, id := range tc.Signers() {
+		sb.WriteString(strconv.FormatUint(uint64(id), 10))
+		sb.WriteByte(' ')
 	}
 	return fmt.Sprintf("TC{ view: %d, IDs: [ %s] }", tc.view, &sb)
 }
@@ -2,19 +2,34 @@ package consensus
 
 import (
 	"sync"
+	"time"
+
+	"github.com/relab/hotstuff"
 )
 
+// maxVoteDeferrals is the number of proposals that a vote waits for before the voting machine tries to fetch its block.
+// The proposal for a block may arrive after votes for it, as the votes are sent by different replicas.
+const maxVoteDeferrals = 3
+
 // VotingMachine collects votes.
 type VotingMachine struct {
 	mut           sync.Mutex
 	mods          *Modules
-	verifiedVotes map[Hash][]PartialCert // verified votes that could become a QC
+	verifiedVotes map[Hash][]PartialCert            // verified votes that could become a QC
+	certified     map[Hash]struct{}                 // blocks for which a QC has already been created
+	verifying     map[Hash]map[hotstuff.ID]struct{} // senders whose vote for each block is being verified
+	abstained     map[Hash]map[hotstuff.ID]struct{} // replicas that abstained from voting for each block
+	views         map[Hash]View                     // the view of each block in verifiedVotes, certified, and abstained
 }
 
 // NewVotingMachine returns a new VotingMachine.
 func NewVotingMachine() *VotingMachine {
 	return &VotingMachine{
 		verifiedVotes: make(map[Hash][]PartialCert),
+		certified:     make(map[Hash]struct{}),
+		verifying:     make(map[Hash]map[hotstuff.ID]struct{}),
+		abstained:     make(map[Hash]map[hotstuff.ID]struct{}),
+		views:         make(map[Hash]View),
 	}
 }
 
@@ -22,7 +37,16 @@ func NewVotingMachine() *VotingMachine {
 // It also allows the module to set module options using the OptionsBuilder.
 func (vm *VotingMachine) InitConsensusModule(mods *Modules, _ *OptionsBuilder) {
 	vm.mods = mods
-	vm.mods.EventLoop().RegisterHandler(VoteMsg{}, func(event interface{}) { vm.OnVote(event.(VoteMsg)) })
+	vm.mods.EventLoop().RegisterHandler(VoteMsg{}, func(event interface{}) {
+		if vm.mods.Consensus().Paused() {
+			holdWhilePaused(vm.mods, event)
+			return
+		}
+		vm.OnVote(event.(VoteMsg))
+	})
+	vm.mods.EventLoop().RegisterHandler(AbstainMsg{}, func(event interface{}) {
+		vm.OnAbstain(event.(AbstainMsg))
+	})
 }
 
 // OnVote handles an incoming vote.
@@ -30,66 +54,175 @@ func (vm *VotingMachine) OnVote(vote VoteMsg) {
 	cert := vote.PartialCert
 	vm.mods.Logger().Debugf("OnVote(%d): %.8s", vote.ID, cert.BlockHash())
 
+	if replica, ok := vm.mods.Configuration().Replica(vote.ID); ok && IsWitness(replica) {
+		vm.reject(vote.ID, RejectWitness, "OnVote: ignoring vote from witness %d", vote.ID)
+		return
+	}
+
+	vm.mut.Lock()
+	_, late := vm.certified[cert.BlockHash()]
+	vm.mut.Unlock()
+	if late {
+		vm.lateVote(vote.ID, cert.BlockHash())
+		return
+	}
+
 	var (
 		block *Block
 		ok    bool
 	)
 
-	if !vote.Deferred {
-		// first, try to get the block from the local cache
-		block, ok = vm.mods.BlockChain().LocalGet(cert.BlockHash())
-		if !ok {
-			// if that does not work, we will try to handle this event later.
-			// hopefully, the block has arrived by then.
-			vm.mods.Logger().Debugf("Local cache miss for block: %.8s", cert.BlockHash())
-			vote.Deferred = true
-			vm.mods.EventLoop().DelayUntil(ProposeMsg{}, vote)
-			return
-		}
-	} else {
+	// first, try to get the block from the local cache
+	block, ok = vm.mods.BlockChain().LocalGet(cert.BlockHash())
+	if !ok && vote.deferrals < maxVoteDeferrals {
+		// the vote may have arrived before the proposal of its block, so the vote is buffered
+		// until the next proposal has been handled. hopefully, the block has arrived by then.
+		vm.mods.Logger().Debugf("Local cache miss for block: %.8s", cert.BlockHash())
+		vote.Deferred = true
+		vote.deferrals++
+		vm.mods.EventLoop().DelayUntil(ProposeMsg{}, vote)
+		return
+	}
+	if !ok {
 		// if the block has not arrived at this point we will try to fetch it.
 		block, ok = vm.mods.BlockChain().Get(cert.BlockHash())
 		if !ok {
 			vm.mods.Logger().Debugf("Could not find block for vote: %.8s.", cert.BlockHash())
+			vm.reject(vote.ID, RejectUnknownBlock, "")
 			return
 		}
 	}
 
 	if block.View() <= vm.mods.Synchronizer().LeafBlock().View() {
 		// too old
+		vm.reject(vote.ID, RejectStaleView, "")
+		return
+	}
+
+	if !vm.startVerification(vote.ID, cert) {
 		return
 	}
 
-	go vm.verifyCert(cert, block)
+	go vm.verifyCert(vote.ID, cert, block)
 }
 
-func (vm *VotingMachine) verifyCert(cert PartialCert, block *Block) {
-	if !vm.mods.Crypto().VerifyPartialCert(cert) {
-		vm.mods.Logger().Info("OnVote: Vote could not be verified!")
+// startVerification returns false if the vote does not need to be verified, either because the QC for the block
+// has already been created, because a vote from the same signer has already been counted,
+// or because a vote from the same sender is being verified. Otherwise, the vote from the sender is marked as being verified.
+// Thus, a flood of late or duplicate votes does not cause any verification work.
+func (vm *VotingMachine) startVerification(sender hotstuff.ID, cert PartialCert) bool {
+	vm.mut.Lock()
+	defer vm.mut.Unlock()
+
+	hash := cert.BlockHash()
+	if _, ok := vm.certified[hash]; ok {
+		vm.lateVote(sender, hash)
+		return false
+	}
+	for _, vote := range vm.verifiedVotes[hash] {
+		if vote.Signature().Signer() == cert.Signature().Signer() {
+			vm.mods.Logger().Debugf("OnVote: duplicate vote from replica %d for block %.8s", sender, hash)
+			return false
+		}
+	}
+	verifying, ok := vm.verifying[hash]
+	if !ok {
+		verifying = make(map[hotstuff.ID]struct{})
+		vm.verifying[hash] = verifying
+	}
+	if _, ok := verifying[sender]; ok {
+		vm.mods.Logger().Debugf("OnVote: duplicate vote from replica %d for block %.8s", sender, hash)
+		return false
+	}
+	verifying[sender] = struct{}{}
+	return true
+}
+
+// lateVote passes a vote for a block whose QC has already been created on to the metrics.
+// The QC was created as soon as a quorum of votes was collected, so late votes are not needed.
+func (vm *VotingMachine) lateVote(sender hotstuff.ID, hash Hash) {
+	vm.mods.Logger().Debugf("OnVote: late vote from replica %d for block %.8s", sender, hash)
+	vm.mods.MetricsEventLoop().AddEvent(LateVoteEvent{ID: sender, BlockHash: hash})
+}
+
+// OnAbstain handles an incoming abstain message.
+// Abstain messages are recorded for the metrics, but they do not count towards a QC.
+func (vm *VotingMachine) OnAbstain(abstain AbstainMsg) {
+	vm.mods.Logger().Debugf("OnAbstain(%d): %.8s", abstain.ID, abstain.BlockHash)
+
+	if replica, ok := vm.mods.Configuration().Replica(abstain.ID); !ok || IsWitness(replica) {
+		return
+	}
+
+	leaf := vm.mods.Synchronizer().LeafBlock().View()
+	if abstain.View <= leaf {
+		vm.mods.ReportRejection(MessageRejectedEvent{Reason: RejectStaleView, Type: "AbstainMsg", Sender: abstain.ID}, "")
 		return
 	}
 
 	vm.mut.Lock()
 	defer vm.mut.Unlock()
 
-	// this defer will clean up any old votes in verifiedVotes
-	defer func() {
-		// delete any pending QCs with lower height than bLeaf
-		for k := range vm.verifiedVotes {
-			if block, ok := vm.mods.BlockChain().LocalGet(k); ok {
-				if block.View() <= vm.mods.Synchronizer().LeafBlock().View() {
-					delete(vm.verifiedVotes, k)
-				}
-			} else {
-				delete(vm.verifiedVotes, k)
-			}
+	// the abstain messages are removed by prune once the block is no longer above the leaf block.
+	abstainers, ok := vm.abstained[abstain.BlockHash]
+	if !ok {
+		abstainers = make(map[hotstuff.ID]struct{})
+		vm.abstained[abstain.BlockHash] = abstainers
+		if _, ok := vm.views[abstain.BlockHash]; !ok {
+			vm.views[abstain.BlockHash] = abstain.View
 		}
-	}()
+	}
+	if _, ok := abstainers[abstain.ID]; ok {
+		// duplicate
+		return
+	}
+	abstainers[abstain.ID] = struct{}{}
+
+	vm.mods.MetricsEventLoop().AddEvent(AbstainEvent{ID: abstain.ID, View: abstain.View, Abstained: len(abstainers)})
+}
+
+// reject reports that a vote from the sender was rejected for the given reason, and logs the message (see Modules.ReportRejection).
+func (vm *VotingMachine) reject(sender hotstuff.ID, reason RejectReason, template string, args ...interface{}) {
+	vm.mods.ReportRejection(MessageRejectedEvent{Reason: reason, Type: "VoteMsg", Sender: sender}, template, args...)
+}
+
+func (vm *VotingMachine) verifyCert(sender hotstuff.ID, cert PartialCert, block *Block) {
+	valid := vm.mods.Crypto().VerifyPartialCert(cert)
+
+	vm.mut.Lock()
+	defer vm.mut.Unlock()
+
+	if verifying, ok := vm.verifying[cert.BlockHash()]; ok {
+		delete(verifying, sender)
+		if len(verifying) == 0 {
+			delete(vm.verifying, cert.BlockHash())
+		}
+	}
+
+	if !valid {
+		vm.reject(cert.Signature().Signer(), RejectBadSignature, "OnVote: Vote could not be verified!")
+		return
+	}
+
+	if _, ok := vm.certified[cert.BlockHash()]; ok {
+		// another vote completed the quorum while this vote was being verified
+		vm.lateVote(sender, cert.BlockHash())
+		return
+	}
 
 	votes := vm.verifiedVotes[cert.BlockHash()]
+	for _, vote := range votes {
+		if vote.Signature().Signer() == cert.Signature().Signer() {
+			// duplicate vote
+			return
+		}
+	}
 	votes = append(votes, cert)
 	vm.verifiedVotes[cert.BlockHash()] = votes
+	// the view of the block takes precedence over the view claimed by abstain messages.
+	vm.views[cert.BlockHash()] = block.View()
 
+	// create the QC as soon as there is a quorum of votes; we do not wait for the remaining votes.
 	if len(votes) < vm.mods.Configuration().QuorumSize() {
 		return
 	}
@@ -100,8 +233,41 @@ func (vm *VotingMachine) verifyCert(cert PartialCert, block *Block) {
 		return
 	}
 	delete(vm.verifiedVotes, cert.BlockHash())
+	vm.certified[cert.BlockHash()] = struct{}{}
+	vm.mods.MetricsEventLoop().AddEvent(QCFormedEvent{BlockHash: cert.BlockHash(), Time: time.Now()})
+	vm.prune()
+
+	if vm.mods.Options().ShouldRelayQC() {
+		vm.relayQC(qc)
+	}
 
 	// signal the synchronizer
 	// because votes are handled asynchronously, we can safely use AddEvent without starting a goroutine.
 	vm.mods.EventLoop().AddEvent(NewViewMsg{ID: vm.mods.ID(), SyncInfo: NewSyncInfo().WithQC(qc)})
 }
+
+// prune removes the votes, the certified blocks, and the abstain messages from the views that are not above
+// the view of the leaf block, as they can no longer become part of the chain. It is called once for each QC that is created.
+func (vm *VotingMachine) prune() {
+	leaf := vm.mods.Synchronizer().LeafBlock().View()
+	for hash, view := range vm.views {
+		if view <= leaf {
+			delete(vm.verifiedVotes, hash)
+			delete(vm.certified, hash)
+			delete(vm.abstained, hash)
+			delete(vm.views, hash)
+		}
+	}
+}
+
+// relayQC sends the QC to all other replicas, such that replicas that did not receive the votes,
+// or that will not receive the next proposal in time, can still advance to the next view.
+func (vm *VotingMachine) relayQC(qc QuorumCert) {
+	syncInfo := NewSyncInfo().WithQC(qc)
+	for id, replica := range vm.mods.Configuration().Replicas() {
+		if id == vm.mods.ID() {
+			continue
+		}
+		replica.NewView(syncInfo)
+	}
+}
@@ -0,0 +1,220 @@
+package consensus_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/crypto"
+	"github.com/relab/hotstuff/crypto/ecdsa"
+	"github.com/relab/hotstuff/internal/mocks"
+	"github.com/relab/hotstuff/internal/testutil"
+)
+
+// countingCrypto is a Crypto implementation that counts the partial certificates that are created and verified.
+type countingCrypto struct {
+	consensus.Crypto
+	signed   int64
+	verified int64
+}
+
+func (c *countingCrypto) InitConsensusModule(mods *consensus.Modules, opts *consensus.OptionsBuilder) {
+	if m, ok := c.Crypto.(consensus.Module); ok {
+		m.InitConsensusModule(mods, opts)
+	}
+}
+
+func (c *countingCrypto) CreatePartialCert(block *consensus.Block) (consensus.PartialCert, error) {
+	atomic.AddInt64(&c.signed, 1)
+	return c.Crypto.CreatePartialCert(block)
+}
+
+func (c *countingCrypto) VerifyPartialCert(cert consensus.PartialCert) bool {
+	atomic.AddInt64(&c.verified, 1)
+	return c.Crypto.VerifyPartialCert(cert)
+}
+
+// voteCollector sends votes to the voting machine of the first replica, and collects the QCs that it creates.
+type voteCollector struct {
+	mods    *consensus.Modules
+	crypto  *countingCrypto
+	signers []consensus.Crypto
+	votes   []consensus.PartialCert // the votes for the block, indexed by replica
+	qcs     []consensus.QuorumCert
+	late    int // the number of late votes that were passed on to the metrics
+}
+
+func newVoteCollector(tb testing.TB, n int) *voteCollector {
+	tb.Helper()
+	ctrl := gomock.NewController(tb)
+	bl := testutil.CreateBuilders(tb, ctrl, n)
+	sync := mocks.NewMockSynchronizer(ctrl)
+	sync.EXPECT().LeafBlock().AnyTimes().Return(consensus.GetGenesis())
+	vc := &voteCollector{crypto: &countingCrypto{Crypto: crypto.NewCache(ecdsa.New(), 10)}}
+	bl[0].Register(sync, vc.crypto)
+	hl := bl.Build()
+	vc.mods = hl[0]
+	vc.mods.EventLoop().RegisterHandler(consensus.NewViewMsg{}, func(event interface{}) {
+		qc, _ := event.(consensus.NewViewMsg).SyncInfo.QC()
+		vc.qcs = append(vc.qcs, qc)
+	})
+	vc.mods.MetricsEventLoop().RegisterObserver(consensus.LateVoteEvent{}, func(_ interface{}) {
+		vc.late++
+	})
+
+	block := consensus.NewBlock(consensus.GetGenesis().Hash(), consensus.QuorumCert{}, "foo", 1, 1)
+	vc.mods.BlockChain().Store(block)
+	vc.signers = hl.Signers()
+	for _, signer := range vc.signers {
+		vc.votes = append(vc.votes, testutil.CreatePC(tb, block, signer))
+	}
+	return vc
+}
+
+// vote sends the vote from the replica with the given index to the voting machine.
+func (vc *voteCollector) vote(i int) {
+	vc.mods.EventLoop().AddEvent(consensus.VoteMsg{ID: hotstuff.ID(i + 1), PartialCert: vc.votes[i]})
+	vc.drain()
+}
+
+// drain handles the events that are currently in the event loop and the metrics event loop.
+func (vc *voteCollector) drain() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	vc.mods.EventLoop().Run(ctx)
+	vc.mods.MetricsEventLoop().Run(ctx)
+}
+
+// propose handles a proposal, and then handles the given number of delayed votes that the proposal released.
+func (vc *voteCollector) propose(delayed int) {
+	vc.mods.EventLoop().AddEvent(consensus.ProposeMsg{})
+	vc.drain()
+	// the delayed events are added to the event loop by a separate goroutine.
+	deadline := time.Now().Add(time.Second)
+	for vc.mods.EventLoop().Len() < delayed && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	vc.drain()
+}
+
+// settle waits until the given number of votes have been verified and the resulting events have been handled.
+func (vc *voteCollector) settle(verified int64) {
+	deadline := time.Now().Add(time.Second)
+	for atomic.LoadInt64(&vc.crypto.verified) < verified && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	// the verified votes are counted after the verification, and the QC is created after that.
+	time.Sleep(10 * time.Millisecond)
+	vc.drain()
+}
+
+// TestVoteQuorum checks that the QC is created exactly when a quorum of distinct replicas has voted,
+// and that duplicate and late votes are neither counted nor verified. The late votes are passed on to the metrics.
+func TestVoteQuorum(t *testing.T) {
+	const n = 4
+	vc := newVoteCollector(t, n)
+	quorum := vc.mods.Configuration().QuorumSize()
+
+	for i := 0; i < quorum-1; i++ {
+		vc.vote(i)
+	}
+	vc.settle(int64(quorum - 1))
+	for r := 0; r < 10; r++ {
+		for i := 0; i < quorum-1; i++ {
+			vc.vote(i)
+		}
+	}
+	vc.settle(int64(quorum - 1))
+	if len(vc.qcs) != 0 {
+		t.Fatalf("QC created with only %d distinct votes", quorum-1)
+	}
+	if verified := atomic.LoadInt64(&vc.crypto.verified); verified != int64(quorum-1) {
+		t.Errorf("wrong number of verified votes: got %d, want %d", verified, quorum-1)
+	}
+
+	vc.vote(quorum - 1)
+	vc.settle(int64(quorum))
+	if len(vc.qcs) != 1 {
+		t.Fatalf("expected 1 QC once the quorum was reached, got %d", len(vc.qcs))
+	}
+	if signers := vc.qcs[0].Signers(); len(signers) != quorum {
+		t.Errorf("wrong number of signers: got %d, want %d", len(signers), quorum)
+	}
+
+	for r := 0; r < 10; r++ {
+		for i := 0; i < n; i++ {
+			vc.vote(i)
+		}
+	}
+	vc.settle(int64(quorum))
+	if len(vc.qcs) != 1 {
+		t.Errorf("late votes created %d more QCs", len(vc.qcs)-1)
+	}
+	if verified := atomic.LoadInt64(&vc.crypto.verified); verified != int64(quorum) {
+		t.Errorf("late votes were verified: got %d verified votes, want %d", verified, quorum)
+	}
+	if vc.late != 10*n {
+		t.Errorf("wrong number of late votes: got %d, want %d", vc.late, 10*n)
+	}
+}
+
+// TestEarlyVotes checks that votes that arrive before their block are buffered across proposals until the block
+// arrives, instead of causing the block to be fetched.
+func TestEarlyVotes(t *testing.T) {
+	const n = 4
+	vc := newVoteCollector(t, n)
+	quorum := vc.mods.Configuration().QuorumSize()
+
+	block := consensus.NewBlock(consensus.GetGenesis().Hash(), consensus.QuorumCert{}, "bar", 2, 1)
+	for i, signer := range vc.signers[:quorum] {
+		vc.mods.EventLoop().AddEvent(consensus.VoteMsg{ID: hotstuff.ID(i + 1), PartialCert: testutil.CreatePC(t, block, signer)})
+	}
+	vc.drain()
+	// the mock configuration fails the test if the voting machine tries to fetch the block.
+	for i := 0; i < 2; i++ {
+		vc.propose(quorum)
+	}
+	if verified := atomic.LoadInt64(&vc.crypto.verified); verified != 0 {
+		t.Fatalf("%d votes were verified before their block arrived", verified)
+	}
+
+	vc.mods.BlockChain().Store(block)
+	vc.propose(quorum)
+	vc.settle(int64(quorum))
+	if len(vc.qcs) != 1 {
+		t.Fatalf("wrong number of QCs: got %d, want 1", len(vc.qcs))
+	}
+	if vc.qcs[0].BlockHash() != block.Hash() {
+		t.Error("the QC does not certify the block")
+	}
+}
+
+// BenchmarkVoteFlood measures the number of votes that are verified when each replica sends its vote many times.
+func BenchmarkVoteFlood(b *testing.B) {
+	const (
+		n     = 4
+		flood = 32
+	)
+	var verified int64
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		vc := newVoteCollector(b, n)
+		b.StartTimer()
+
+		for r := 0; r < flood; r++ {
+			for j := 0; j < n; j++ {
+				vc.vote(j)
+			}
+		}
+		for len(vc.qcs) == 0 {
+			vc.drain()
+		}
+		verified += atomic.LoadInt64(&vc.crypto.verified)
+	}
+	b.ReportMetric(float64(verified)/float64(b.N), "verifications/op")
+	b.ReportMetric(float64(n*flood), "votes/op")
+}
@@ -2,48 +2,112 @@
 package crypto
 
 import (
+	"bytes"
+	"fmt"
+	"sync"
+
 	"github.com/relab/hotstuff"
 	"github.com/relab/hotstuff/consensus"
 )
 
+// blockDomain is prepended to the serialized blocks that are signed by a MessageSigner.
+const blockDomain = "hotstuff/block:"
+
 type base struct {
 	consensus.CryptoImpl
+	mods *consensus.Modules
 }
 
 // New returns a new base implementation of the Crypto interface. It will use the given CryptoImpl to create and verify
-// signatures.
+// signatures. If the CryptoImpl implements consensus.MessageSigner, blocks are signed over their serialized bytes
+// rather than their hash.
 func New(impl consensus.CryptoImpl) consensus.Crypto {
-	return base{CryptoImpl: impl}
+	return &base{CryptoImpl: impl}
 }
 
 // InitConsensusModule gives the module a reference to the Modules object.
 // It also allows the module to set module options using the OptionsBuilder.
-func (base base) InitConsensusModule(mods *consensus.Modules, cfg *consensus.OptionsBuilder) {
+func (base *base) InitConsensusModule(mods *consensus.Modules, cfg *consensus.OptionsBuilder) {
+	base.mods = mods
 	if mod, ok := base.CryptoImpl.(consensus.Module); ok {
 		mod.InitConsensusModule(mods, cfg)
 	}
 }
 
+// blockMessage returns the domain-separated message that is signed for the block by a MessageSigner.
+func blockMessage(block *consensus.Block) []byte {
+	return append([]byte(blockDomain), block.ToBytes()...)
+}
+
+// signBlock signs the block, either over its serialized bytes or its hash, depending on the CryptoImpl.
+func (base base) signBlock(block *consensus.Block) (sig consensus.Signature, err error) {
+	if signer, ok := base.CryptoImpl.(consensus.MessageSigner); ok {
+		return signer.SignMessage(blockMessage(block))
+	}
+	return base.Sign(block.Hash())
+}
+
 // CreatePartialCert signs a single block and returns the partial certificate.
 func (base base) CreatePartialCert(block *consensus.Block) (cert consensus.PartialCert, err error) {
-	sig, err := base.Sign(block.Hash())
+	sig, err := base.signBlock(block)
 	if err != nil {
 		return consensus.PartialCert{}, err
 	}
 	return consensus.NewPartialCert(sig, block.Hash()), nil
 }
 
+// CreatePartialCerts signs the blocks and returns the partial certificates in the same order.
+// The blocks are signed concurrently, such that a batch of votes takes about as long to sign as a single vote.
+func (base base) CreatePartialCerts(blocks []*consensus.Block) (certs []consensus.PartialCert, err error) {
+	certs = make([]consensus.PartialCert, len(blocks))
+	errs := make([]error, len(blocks))
+	var wg sync.WaitGroup
+	wg.Add(len(blocks))
+	for i, block := range blocks {
+		go func(i int, block *consensus.Block) {
+			defer wg.Done()
+			certs[i], errs[i] = base.CreatePartialCert(block)
+		}(i, block)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return certs, nil
+}
+
+// CreatePartialCertWithAux signs a single block and the auxiliary data and returns the partial certificate.
+// The auxiliary data is signed separately, such that the block signature can still be used in a quorum certificate.
+func (base base) CreatePartialCertWithAux(block *consensus.Block, aux []byte) (cert consensus.PartialCert, err error) {
+	sig, err := base.signBlock(block)
+	if err != nil {
+		return consensus.PartialCert{}, err
+	}
+	auxSig, err := base.Sign(consensus.AuxHash(block.Hash(), aux))
+	if err != nil {
+		return consensus.PartialCert{}, err
+	}
+	return consensus.NewPartialCertWithAux(sig, block.Hash(), aux, auxSig), nil
+}
+
 // CreateQuorumCert creates a quorum certificate from a list of partial certificates.
 func (base base) CreateQuorumCert(block *consensus.Block, signatures []consensus.PartialCert) (cert consensus.QuorumCert, err error) {
 	// genesis QC is always valid.
-	if block.Hash() == consensus.GetGenesis().Hash() {
-		return consensus.NewQuorumCert(nil, 0, consensus.GetGenesis().Hash()), nil
+	if genesis := base.mods.Genesis(); block.Hash() == genesis.Hash() {
+		return consensus.NewQuorumCert(nil, 0, genesis.Hash()), nil
 	}
 	sigs := make([]consensus.Signature, 0, len(signatures))
 	for _, sig := range signatures {
 		sigs = append(sigs, sig.Signature())
 	}
-	sig, err := base.CreateThresholdSignature(sigs, block.Hash())
+	var sig consensus.ThresholdSignature
+	if signer, ok := base.CryptoImpl.(consensus.MessageSigner); ok {
+		sig, err = signer.CreateThresholdSignatureForMessage(sigs, blockMessage(block))
+	} else {
+		sig, err = base.CreateThresholdSignature(sigs, block.Hash())
+	}
 	if err != nil {
 		return consensus.QuorumCert{}, err
 	}
@@ -51,20 +115,60 @@ func (base base) CreateQuorumCert(block *consensus.Block, signatures []consensus
 }
 
 // CreateTimeoutCert creates a timeout certificate from a list of timeout messages.
+// The TC includes the highest QC of the timeout messages, which must be valid.
 func (base base) CreateTimeoutCert(view consensus.View, timeouts []consensus.TimeoutMsg) (cert consensus.TimeoutCert, err error) {
 	// view 0 is always valid.
 	if view == 0 {
 		return consensus.NewTimeoutCert(nil, 0), nil
 	}
 	sigs := make([]consensus.Signature, 0, len(timeouts))
+	hashes := make(map[hotstuff.ID]consensus.Hash, len(timeouts))
+	refs := make(map[hotstuff.ID]consensus.HighQCRef, len(timeouts))
+	qcs := make(map[consensus.HighQCRef]consensus.QuorumCert, len(timeouts))
 	for _, timeout := range timeouts {
+		ref := timeout.HighQCRef()
 		sigs = append(sigs, timeout.ViewSignature)
+		hashes[timeout.ID] = consensus.TimeoutHash(timeout.ID, view, ref)
+		refs[timeout.ID] = ref
+		if qc, ok := timeout.SyncInfo.QC(); ok {
+			qcs[ref] = qc
+		}
 	}
-	sig, err := base.CreateThresholdSignature(sigs, view.ToHash())
+	sig, err := base.CreateThresholdSignatureForMessageSet(sigs, hashes)
 	if err != nil {
 		return consensus.TimeoutCert{}, err
 	}
-	return consensus.NewTimeoutCert(sig, view), nil
+	// the TC only needs the references of the timeouts whose signatures were included.
+	for id := range refs {
+		if !sig.Participants().Contains(id) {
+			delete(refs, id)
+		}
+	}
+	cert = consensus.NewTimeoutCert(sig, view).WithHighQCRefs(refs)
+	highest, ok := highestRef(refs)
+	if !ok {
+		return cert, nil
+	}
+	highQC := qcs[highest]
+	if highQC.View() >= view || !base.VerifyQuorumCert(highQC) {
+		return consensus.TimeoutCert{}, fmt.Errorf("the highest QC of the timeouts for view %d is invalid: %v", view, highQC)
+	}
+	return cert.WithHighQC(highQC), nil
+}
+
+// highestRef returns the reference to the QC with the highest view.
+// QCs from the same view are ordered by the hash of the block, such that the result does not depend on the order of the timeouts.
+func highestRef(refs map[hotstuff.ID]consensus.HighQCRef) (highest consensus.HighQCRef, ok bool) {
+	for _, ref := range refs {
+		if ref == (consensus.HighQCRef{}) {
+			continue
+		}
+		if ok && (ref.View < highest.View || (ref.View == highest.View && bytes.Compare(ref.Hash[:], highest.Hash[:]) >= 0)) {
+			continue
+		}
+		highest, ok = ref, true
+	}
+	return highest, ok
 }
 
 func (base base) CreateAggregateQC(view consensus.View, timeouts []consensus.TimeoutMsg) (aggQC consensus.AggregateQC, err error) {
@@ -89,23 +193,74 @@ func (base base) CreateAggregateQC(view consensus.View, timeouts []consensus.Tim
 
 // VerifyPartialCert verifies a single partial certificate.
 func (base base) VerifyPartialCert(cert consensus.PartialCert) bool {
+	if auxSig := cert.AuxSignature(); auxSig != nil {
+		if auxSig.Signer() != cert.Signature().Signer() || !base.Verify(auxSig, cert.AuxHash()) {
+			return false
+		}
+	}
+	if signer, ok := base.CryptoImpl.(consensus.MessageSigner); ok {
+		// the block should have been received before any votes for it are verified.
+		block, ok := base.mods.BlockChain().LocalGet(cert.BlockHash())
+		if !ok {
+			return false
+		}
+		return signer.VerifyMessage(cert.Signature(), blockMessage(block))
+	}
 	return base.Verify(cert.Signature(), cert.BlockHash())
 }
 
 // VerifyQuorumCert verifies a quorum certificate.
 func (base base) VerifyQuorumCert(qc consensus.QuorumCert) bool {
-	if qc.BlockHash() == consensus.GetGenesis().Hash() {
+	if qc.BlockHash() == base.mods.Genesis().Hash() {
 		return true
 	}
-	return base.VerifyThresholdSignature(qc.Signature(), qc.BlockHash())
+	if signer, ok := base.CryptoImpl.(consensus.MessageSigner); ok {
+		block, ok := base.mods.BlockChain().Get(qc.BlockHash())
+		if !ok {
+			return false
+		}
+		return signer.VerifyThresholdSignatureForMessage(qc.Signature(), blockMessage(block), qc.View())
+	}
+	return base.VerifyThresholdSignature(qc.Signature(), qc.BlockHash(), qc.View())
 }
 
-// VerifyTimeoutCert verifies a timeout certificate.
+// VerifyTimeoutCert verifies a timeout certificate, and the highQC that it includes, if any.
+// The signature of the TC covers the QC references of the timeout messages, and the highQC must be
+// the highest of the referenced QCs, such that the aggregator of the TC cannot replace it with a lower QC.
+// The highQC must also be from an earlier view than the TC, as it was known when the replicas timed out.
 func (base base) VerifyTimeoutCert(tc consensus.TimeoutCert) bool {
 	if tc.View() == 0 {
 		return true
 	}
-	return base.VerifyThresholdSignature(tc.Signature(), tc.View().ToHash())
+	if tc.Signature() == nil {
+		return false
+	}
+	refs := tc.HighQCRefs()
+	covered := true
+	tc.Signature().Participants().ForEach(func(id hotstuff.ID) {
+		if _, ok := refs[id]; !ok {
+			covered = false
+		}
+	})
+	if !covered {
+		return false
+	}
+	hashes := make(map[hotstuff.ID]consensus.Hash, len(refs))
+	for id, ref := range refs {
+		hashes[id] = consensus.TimeoutHash(id, tc.View(), ref)
+	}
+	if !base.VerifyThresholdSignatureForMessageSet(tc.Signature(), hashes, tc.View()) {
+		return false
+	}
+	highest, hasRef := highestRef(refs)
+	highQC, hasQC := tc.HighQC()
+	if !hasRef {
+		return !hasQC
+	}
+	if !hasQC || highQC.View() != highest.View || highQC.BlockHash() != highest.Hash {
+		return false
+	}
+	return highQC.View() < tc.View() && base.VerifyQuorumCert(highQC)
 }
 
 // VerifyAggregateQC verifies the AggregateQC and returns the highQC, if valid.
@@ -127,7 +282,7 @@ func (base base) VerifyAggregateQC(aggQC consensus.AggregateQC) (bool, consensus
 			SyncInfo: consensus.NewSyncInfo().WithQC(qc),
 		}.Hash()
 	}
-	ok := base.VerifyThresholdSignatureForMessageSet(aggQC.Sig(), hashes)
+	ok := base.VerifyThresholdSignatureForMessageSet(aggQC.Sig(), hashes, aggQC.View())
 	if !ok {
 		return false, consensus.QuorumCert{}
 	}
@@ -136,3 +291,5 @@ func (base base) VerifyAggregateQC(aggQC consensus.AggregateQC) (bool, consensus
 	}
 	return false, consensus.QuorumCert{}
 }
+
+var _ consensus.BatchSigner = (*base)(nil)
@@ -131,13 +131,19 @@ func RestoreAggregateSignature(sig []byte, participants crypto.Bitfield) (s *Agg
 	}, nil
 }
 
-// ToBytes returns a byte representation of the aggregate signature.
+// ToBytes returns a byte representation of the aggregate signature, followed by the bitmap of the participants.
+// The bitmap is included such that the byte representation, which is hashed as part of a block and used as a key
+// by the signature cache, depends on the set of replicas whose signatures were aggregated.
 func (agg *AggregateSignature) ToBytes() []byte {
 	if agg == nil {
 		return nil
 	}
-	b := bls12.NewG2().ToCompressed(&agg.sig)
-	return b
+	return append(agg.Compressed(), agg.participants...)
+}
+
+// Compressed returns the compressed aggregate signature, without the participants.
+func (agg *AggregateSignature) Compressed() []byte {
+	return bls12.NewG2().ToCompressed(&agg.sig)
 }
 
 // Participants returns the IDs of replicas who participated in the threshold signature.
@@ -220,17 +226,23 @@ func (bc *bls12Crypto) Verify(sig consensus.Signature, hash consensus.Hash) bool
 // and all public keys are known by all replicas.
 
 // VerifyThresholdSignature verifies an aggregate signature.
-func (bc *bls12Crypto) VerifyThresholdSignature(signature consensus.ThresholdSignature, hash consensus.Hash) bool {
+func (bc *bls12Crypto) VerifyThresholdSignature(signature consensus.ThresholdSignature, hash consensus.Hash, view consensus.View) bool {
 	sig, ok := signature.(*AggregateSignature)
 	if !ok {
 		return false
 	}
+	members := consensus.MembershipAt(bc.mods.Configuration(), view)
 	pubKeys := make([]*PublicKey, 0)
+	voters := 0
 	sig.participants.ForEach(func(id hotstuff.ID) {
-		replica, ok := bc.mods.Configuration().Replica(id)
+		replica, ok := members.Replica(id)
 		if !ok {
 			return
 		}
+		// the signature of a witness must be verified as part of the aggregate, but it does not count toward the quorum.
+		if !consensus.IsWitness(replica) {
+			voters++
+		}
 		pubKeys = append(pubKeys, replica.PublicKey().(*PublicKey))
 	})
 	ps, err := bls12.NewG2().HashToCurve(hash[:], domain)
@@ -238,7 +250,7 @@ func (bc *bls12Crypto) VerifyThresholdSignature(signature consensus.ThresholdSig
 		bc.mods.Logger().Error(err)
 		return false
 	}
-	if len(pubKeys) < bc.mods.Configuration().QuorumSize() {
+	if voters < members.QuorumSize() {
 		return false
 	}
 	engine := bls12.NewEngine()
@@ -250,12 +262,14 @@ func (bc *bls12Crypto) VerifyThresholdSignature(signature consensus.ThresholdSig
 }
 
 // VerifyThresholdSignatureForMessageSet verifies a threshold signature against a set of message hashes.
-func (bc *bls12Crypto) VerifyThresholdSignatureForMessageSet(signature consensus.ThresholdSignature, hashes map[hotstuff.ID]consensus.Hash) bool {
+func (bc *bls12Crypto) VerifyThresholdSignatureForMessageSet(signature consensus.ThresholdSignature, hashes map[hotstuff.ID]consensus.Hash, view consensus.View) bool {
 	sig, ok := signature.(*AggregateSignature)
 	if !ok {
 		return false
 	}
+	members := consensus.MembershipAt(bc.mods.Configuration(), view)
 	hashSet := make(map[consensus.Hash]struct{})
+	voters := 0
 	engine := bls12.NewEngine()
 	engine.AddPairInv(&bls12.G1One, &sig.sig)
 	for id, hash := range hashes {
@@ -263,10 +277,13 @@ func (bc *bls12Crypto) VerifyThresholdSignatureForMessageSet(signature consensus
 			continue
 		}
 		hashSet[hash] = struct{}{}
-		replica, ok := bc.mods.Configuration().Replica(id)
+		replica, ok := members.Replica(id)
 		if !ok {
 			return false
 		}
+		if !consensus.IsWitness(replica) {
+			voters++
+		}
 		pk, ok := replica.PublicKey().(*PublicKey)
 		if !ok {
 			return false
@@ -281,8 +298,8 @@ func (bc *bls12Crypto) VerifyThresholdSignatureForMessageSet(signature consensus
 		return false
 	}
 	// if we managed to verify the aggregate signature, we just need to make sure that the number of verified signatures
-	// is a quorum.
-	return len(hashSet) >= bc.mods.Configuration().QuorumSize()
+	// from voters is a quorum.
+	return voters >= members.QuorumSize()
 }
 
 // TODO: should we check each signature's validity before aggregating?
@@ -305,7 +322,13 @@ func (bc *bls12Crypto) CreateThresholdSignature(partialSignatures []consensus.Si
 		}
 		sigs[sig.Signer()] = s
 	}
-	if len(sigs) < bc.mods.Configuration().QuorumSize() {
+	voters := 0
+	for id := range sigs {
+		if consensus.IsVoter(bc.mods.Configuration(), id) {
+			voters++
+		}
+	}
+	if voters < bc.mods.Configuration().QuorumSize() {
 		return nil, multierr.Combine(crypto.ErrNotAQuorum, err)
 	}
 	return bc.aggregateSignatures(sigs), nil
@@ -3,6 +3,7 @@ package crypto
 import (
 	"container/list"
 	"crypto/sha256"
+	"sort"
 	"sync"
 
 	"github.com/relab/hotstuff"
@@ -20,11 +21,22 @@ type cache struct {
 // NewCache returns a new Crypto implementation that caches the results of the operations of the given CryptoImpl
 // implementation.
 func NewCache(impl consensus.CryptoImpl, capacity int) consensus.Crypto {
-	return New(&cache{
+	c := &cache{
 		impl:     impl,
 		capacity: capacity,
 		entries:  make(map[consensus.Hash]*list.Element, capacity),
-	})
+	}
+	if signer, ok := impl.(consensus.MessageSigner); ok {
+		return New(messageCache{cache: c, MessageSigner: signer})
+	}
+	return New(c)
+}
+
+// messageCache is a cache for a MessageSigner.
+// Only the hash-based operations are cached; the message-based operations are passed through.
+type messageCache struct {
+	*cache
+	consensus.MessageSigner
 }
 
 // InitConsensusModule gives the module a reference to the Modules object.
@@ -95,26 +107,21 @@ func (cache *cache) Verify(sig consensus.Signature, hash consensus.Hash) bool {
 }
 
 // CreateThresholdSignature creates a threshold signature from the given partial signatures.
+// The signature is not cached, as it is only valid for the views where the signers are a quorum.
 func (cache *cache) CreateThresholdSignature(partialSignatures []consensus.Signature, hash consensus.Hash) (sig consensus.ThresholdSignature, err error) {
-	sig, err = cache.impl.CreateThresholdSignature(partialSignatures, hash)
-	if err != nil {
-		return nil, err
-	}
-	key := sha256.Sum256(append(hash[:], sig.ToBytes()...))
-	cache.insert(key)
-	return sig, nil
+	return cache.impl.CreateThresholdSignature(partialSignatures, hash)
 }
 
 // VerifyThresholdSignature verifies a threshold signature.
-func (cache *cache) VerifyThresholdSignature(signature consensus.ThresholdSignature, hash consensus.Hash) bool {
+func (cache *cache) VerifyThresholdSignature(signature consensus.ThresholdSignature, hash consensus.Hash, view consensus.View) bool {
 	if signature == nil {
 		return false
 	}
-	key := sha256.Sum256(append(hash[:], signature.ToBytes()...))
+	key := thresholdKey(signature, view, hash[:])
 	if cache.check(key) {
 		return true
 	}
-	if cache.impl.VerifyThresholdSignature(signature, hash) {
+	if cache.impl.VerifyThresholdSignature(signature, hash, view) {
 		cache.insert(key)
 		return true
 	}
@@ -122,41 +129,45 @@ func (cache *cache) VerifyThresholdSignature(signature consensus.ThresholdSignat
 }
 
 // CreateThresholdSignatureForMessageSet creates a threshold signature where each partial signature has signed a
-// different message hash.
+// different message hash. The signature is not cached, as it is only valid for the views where the signers are a quorum.
 func (cache *cache) CreateThresholdSignatureForMessageSet(partialSignatures []consensus.Signature, hashes map[hotstuff.ID]consensus.Hash) (consensus.ThresholdSignature, error) {
-	signature, err := cache.impl.CreateThresholdSignatureForMessageSet(partialSignatures, hashes)
-	if err != nil {
-		return nil, err
-	}
-	var key consensus.Hash
-	hash := sha256.New()
-	for _, h := range hashes {
-		hash.Write(h[:])
-	}
-	hash.Write(signature.ToBytes())
-	hash.Sum(key[:0])
-	cache.insert(key)
-	return signature, nil
+	return cache.impl.CreateThresholdSignatureForMessageSet(partialSignatures, hashes)
 }
 
 // VerifyThresholdSignatureForMessageSet verifies a threshold signature against a set of message hashes.
-func (cache *cache) VerifyThresholdSignatureForMessageSet(signature consensus.ThresholdSignature, hashes map[hotstuff.ID]consensus.Hash) bool {
+func (cache *cache) VerifyThresholdSignatureForMessageSet(signature consensus.ThresholdSignature, hashes map[hotstuff.ID]consensus.Hash, view consensus.View) bool {
 	if signature == nil {
 		return false
 	}
-	var key consensus.Hash
-	hash := sha256.New()
-	for _, h := range hashes {
-		hash.Write(h[:])
+	ids := make([]hotstuff.ID, 0, len(hashes))
+	for id := range hashes {
+		ids = append(ids, id)
 	}
-	hash.Write(signature.ToBytes())
-	hash.Sum(key[:0])
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	messages := make([]byte, 0, len(hashes)*len(consensus.Hash{}))
+	for _, id := range ids {
+		h := hashes[id]
+		messages = append(messages, h[:]...)
+	}
+	key := thresholdKey(signature, view, messages)
 	if cache.check(key) {
 		return true
 	}
-	if cache.impl.VerifyThresholdSignatureForMessageSet(signature, hashes) {
+	if cache.impl.VerifyThresholdSignatureForMessageSet(signature, hashes, view) {
 		cache.insert(key)
 		return true
 	}
 	return false
 }
+
+// thresholdKey returns the cache key for a threshold signature of the messages that is verified for the view.
+// The view is part of the key, as the signature is verified against the replicas that participate in the view,
+// and the same signature may not be a valid certificate for a view with other replicas.
+func thresholdKey(signature consensus.ThresholdSignature, view consensus.View, messages []byte) (key consensus.Hash) {
+	hash := sha256.New()
+	hash.Write(messages)
+	hash.Write(view.ToBytes())
+	hash.Write(signature.ToBytes())
+	hash.Sum(key[:0])
+	return key
+}
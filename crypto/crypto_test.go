@@ -1,6 +1,9 @@
 package crypto_test
 
 import (
+	"bytes"
+	"fmt"
+	"math/big"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -9,6 +12,7 @@ import (
 	"github.com/relab/hotstuff/crypto"
 	"github.com/relab/hotstuff/crypto/bls12"
 	"github.com/relab/hotstuff/crypto/ecdsa"
+	"github.com/relab/hotstuff/internal/proto/hotstuffpb"
 	"github.com/relab/hotstuff/internal/testutil"
 )
 
@@ -34,6 +38,43 @@ func TestCreatePartialCert(t *testing.T) {
 	runAll(t, run)
 }
 
+// TestCreatePartialCerts checks that a batch of blocks is signed in order, and that each signature is valid.
+func TestCreatePartialCerts(t *testing.T) {
+	run := func(t *testing.T, setup setupFunc) {
+		ctrl := gomock.NewController(t)
+		td := setup(t, ctrl, 2)
+
+		var blocks []*consensus.Block
+		for view := consensus.View(1); view <= 5; view++ {
+			block := consensus.NewBlock(td.block.Hash(), td.block.QuorumCert(), "foo", view, 1)
+			blocks = append(blocks, block)
+			// message signers need the block to verify signatures.
+			td.chains[1].Store(block)
+		}
+
+		signer, ok := td.signers[0].(consensus.BatchSigner)
+		if !ok {
+			t.Fatal("the crypto implementation does not sign batches")
+		}
+		certs, err := signer.CreatePartialCerts(blocks)
+		if err != nil {
+			t.Fatalf("Failed to create partial certificates: %v", err)
+		}
+		if len(certs) != len(blocks) {
+			t.Fatalf("wrong number of partial certificates: got: %d, want: %d", len(certs), len(blocks))
+		}
+		for i, cert := range certs {
+			if cert.BlockHash() != blocks[i].Hash() {
+				t.Errorf("partial certificate %d is for the wrong block", i)
+			}
+			if !td.verifiers[1].VerifyPartialCert(cert) {
+				t.Errorf("partial certificate %d was not verified", i)
+			}
+		}
+	}
+	runAll(t, run)
+}
+
 func TestVerifyPartialCert(t *testing.T) {
 	run := func(t *testing.T, setup setupFunc) {
 		ctrl := gomock.NewController(t)
@@ -48,6 +89,90 @@ func TestVerifyPartialCert(t *testing.T) {
 	runAll(t, run)
 }
 
+func TestPartialCertWithAux(t *testing.T) {
+	run := func(t *testing.T, setup setupFunc) {
+		ctrl := gomock.NewController(t)
+
+		td := setup(t, ctrl, 4)
+		aux := []byte("highQC view: 41")
+
+		var partialCerts []consensus.PartialCert
+		for _, signer := range td.signers {
+			pc, err := signer.CreatePartialCertWithAux(td.block, aux)
+			if err != nil {
+				t.Fatalf("Failed to create partial certificate: %v", err)
+			}
+			// send the partial certificate through the wire format
+			partialCerts = append(partialCerts, hotstuffpb.PartialCertFromProto(hotstuffpb.PartialCertToProto(pc)))
+		}
+
+		pc := partialCerts[0]
+		if !bytes.Equal(pc.Aux(), aux) {
+			t.Errorf("wrong auxiliary data: got: %q, want: %q", pc.Aux(), aux)
+		}
+		if !td.verifiers[0].VerifyPartialCert(pc) {
+			t.Error("Partial certificate with auxiliary data was not verified.")
+		}
+
+		tampered := consensus.NewPartialCertWithAux(pc.Signature(), pc.BlockHash(), []byte("highQC view: 99"), pc.AuxSignature())
+		if td.verifiers[0].VerifyPartialCert(tampered) {
+			t.Error("Partial certificate with tampered auxiliary data was verified.")
+		}
+
+		stolen := consensus.NewPartialCertWithAux(pc.Signature(), pc.BlockHash(), aux, partialCerts[1].AuxSignature())
+		if td.verifiers[0].VerifyPartialCert(stolen) {
+			t.Error("Partial certificate with auxiliary data signed by another replica was verified.")
+		}
+
+		// the block signatures can still be combined into a quorum certificate
+		qc, err := td.signers[0].CreateQuorumCert(td.block, partialCerts)
+		if err != nil {
+			t.Fatalf("Failed to create QC: %v", err)
+		}
+		if !td.verifiers[0].VerifyQuorumCert(qc) {
+			t.Error("Quorum certificate was not verified.")
+		}
+	}
+	runAll(t, run)
+}
+
+func TestMessageSigner(t *testing.T) {
+	run := func(t *testing.T, setup setupFunc) {
+		ctrl := gomock.NewController(t)
+
+		td := setup(t, ctrl, 4)
+
+		pc, err := td.signers[0].CreatePartialCert(td.block)
+		if err != nil {
+			t.Fatalf("Failed to create partial certificate: %v", err)
+		}
+		for i, verifier := range td.verifiers {
+			if !verifier.VerifyPartialCert(pc) {
+				t.Errorf("verifier %d failed to verify partial certificate!", i+1)
+			}
+		}
+
+		// the block bytes were signed, not the block hash
+		if td.verifiers[0].Verify(pc.Signature(), td.block.Hash()) {
+			t.Error("Signature over the block was verified against the block hash.")
+		}
+
+		modified := consensus.NewBlock(td.block.Parent(), td.block.QuorumCert(), "bar", td.block.View(), td.block.Proposer())
+		modifiedPC, err := td.signers[0].CreatePartialCert(modified)
+		if err != nil {
+			t.Fatalf("Failed to create partial certificate: %v", err)
+		}
+		forged := consensus.NewPartialCert(modifiedPC.Signature(), td.block.Hash())
+		if td.verifiers[0].VerifyPartialCert(forged) {
+			t.Error("Signature over a modified block was verified.")
+		}
+	}
+	t.Run("EcdsaMessage", func(t *testing.T) { run(t, setup(NewBase(ecdsa.NewMessageSigner), testutil.GenerateECDSAKey)) })
+	t.Run("Cache+EcdsaMessage", func(t *testing.T) {
+		run(t, setup(NewCache(ecdsa.NewMessageSigner), testutil.GenerateECDSAKey))
+	})
+}
+
 func TestCreateQuorumCert(t *testing.T) {
 	run := func(t *testing.T, setup setupFunc) {
 		ctrl := gomock.NewController(t)
@@ -166,11 +291,15 @@ func runAll(t *testing.T, run func(*testing.T, setupFunc)) {
 	t.Helper()
 	t.Run("Ecdsa", func(t *testing.T) { run(t, setup(NewBase(ecdsa.New), testutil.GenerateECDSAKey)) })
 	t.Run("Cache+Ecdsa", func(t *testing.T) { run(t, setup(NewCache(ecdsa.New), testutil.GenerateECDSAKey)) })
+	t.Run("EcdsaMessage", func(t *testing.T) { run(t, setup(NewBase(ecdsa.NewMessageSigner), testutil.GenerateECDSAKey)) })
+	t.Run("Cache+EcdsaMessage", func(t *testing.T) {
+		run(t, setup(NewCache(ecdsa.NewMessageSigner), testutil.GenerateECDSAKey))
+	})
 	t.Run("BLS12-381", func(t *testing.T) { run(t, setup(NewBase(bls12.New), testutil.GenerateBLS12Key)) })
 	t.Run("Cache+BLS12-381", func(t *testing.T) { run(t, setup(NewCache(bls12.New), testutil.GenerateBLS12Key)) })
 }
 
-func createBlock(t *testing.T, signer consensus.Crypto) *consensus.Block {
+func createBlock(t testing.TB, signer consensus.Crypto) *consensus.Block {
 	t.Helper()
 
 	qc, err := signer.CreateQuorumCert(consensus.GetGenesis(), []consensus.PartialCert{})
@@ -182,11 +311,11 @@ func createBlock(t *testing.T, signer consensus.Crypto) *consensus.Block {
 	return b
 }
 
-type keyFunc func(t *testing.T) consensus.PrivateKey
-type setupFunc func(*testing.T, *gomock.Controller, int) testData
+type keyFunc func(t testing.TB) consensus.PrivateKey
+type setupFunc func(testing.TB, *gomock.Controller, int) testData
 
 func setup(newFunc func() consensus.Crypto, keyFunc keyFunc) setupFunc {
-	return func(t *testing.T, ctrl *gomock.Controller, n int) testData {
+	return func(t testing.TB, ctrl *gomock.Controller, n int) testData {
 		return newTestData(t, ctrl, n, newFunc, keyFunc)
 	}
 }
@@ -206,10 +335,11 @@ func NewBase(impl func() consensus.CryptoImpl) func() consensus.Crypto {
 type testData struct {
 	signers   []consensus.Crypto
 	verifiers []consensus.Crypto
+	chains    []consensus.BlockChain
 	block     *consensus.Block
 }
 
-func newTestData(t *testing.T, ctrl *gomock.Controller, n int, newFunc func() consensus.Crypto, keyFunc keyFunc) testData {
+func newTestData(t testing.TB, ctrl *gomock.Controller, n int, newFunc func() consensus.Crypto, keyFunc keyFunc) testData {
 	t.Helper()
 
 	bl := testutil.CreateBuilders(t, ctrl, n, testutil.GenerateKeys(t, n, keyFunc)...)
@@ -219,9 +349,205 @@ func newTestData(t *testing.T, ctrl *gomock.Controller, n int, newFunc func() co
 	}
 	hl := bl.Build()
 
+	block := createBlock(t, hl[0].Crypto())
+	// message signers need the block to verify signatures.
+	var chains []consensus.BlockChain
+	for _, mods := range hl {
+		mods.BlockChain().Store(block)
+		chains = append(chains, mods.BlockChain())
+	}
+
 	return testData{
 		signers:   hl.Signers(),
 		verifiers: hl.Verifiers(),
-		block:     createBlock(t, hl[0].Crypto()),
+		chains:    chains,
+		block:     block,
+	}
+}
+
+// BenchmarkQuorumCert compares the size and verification time of quorum certificates for the signature backends.
+func BenchmarkQuorumCert(b *testing.B) {
+	backends := []struct {
+		name    string
+		newFunc func() consensus.Crypto
+		keyFunc keyFunc
+	}{
+		{"Ecdsa", NewBase(ecdsa.New), testutil.GenerateECDSAKey},
+		{"BLS12-381", NewBase(bls12.New), testutil.GenerateBLS12Key},
+	}
+	for _, backend := range backends {
+		for _, n := range []int{4, 16, 64} {
+			b.Run(fmt.Sprintf("%s/n=%d", backend.name, n), func(b *testing.B) {
+				ctrl := gomock.NewController(b)
+				td := setup(backend.newFunc, backend.keyFunc)(b, ctrl, n)
+				qc := testutil.CreateQC(b, td.block, td.signers)
+
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					if !td.verifiers[0].VerifyQuorumCert(qc) {
+						b.Fatal("failed to verify QC")
+					}
+				}
+				b.ReportMetric(float64(len(qc.Signature().ToBytes())), "bytes/qc")
+			})
+		}
+	}
+}
+
+func TestVerifyQuorumCertQuorumSize(t *testing.T) {
+	run := func(t *testing.T, setup setupFunc) {
+		ctrl := gomock.NewController(t)
+		td := setup(t, ctrl, 4)
+
+		// a quorum of 4 replicas is 3
+		qc := testutil.CreateQC(t, td.block, td.signers[:3])
+		if !td.verifiers[3].VerifyQuorumCert(qc) {
+			t.Error("QC with exactly a quorum of signatures was not verified")
+		}
+
+		pcs := testutil.CreatePCs(t, td.block, td.signers[:2])
+		if qc, err := td.signers[0].CreateQuorumCert(td.block, pcs); err == nil && td.verifiers[3].VerifyQuorumCert(qc) {
+			t.Error("QC with less than a quorum of signatures was verified")
+		}
+	}
+	runAll(t, run)
+}
+
+func TestVerifyForgedQuorumCert(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	td := setup(NewBase(ecdsa.New), testutil.GenerateECDSAKey)(t, ctrl, 4)
+
+	qc := testutil.CreateQC(t, td.block, td.signers[:3])
+	sig := qc.Signature().(ecdsa.ThresholdSignature)
+
+	// replace the signature of replica 3 with its signature of another block
+	other := consensus.NewBlock(td.block.Parent(), td.block.QuorumCert(), "bar", td.block.View(), td.block.Proposer())
+	otherSig := testutil.CreateQC(t, other, td.signers[:3]).Signature().(ecdsa.ThresholdSignature)
+	signature := func(sig ecdsa.ThresholdSignature, id hotstuff.ID) *ecdsa.Signature {
+		s, ok := sig.Signature(id)
+		if !ok {
+			t.Fatalf("missing signature from replica %d", id)
+		}
+		return s
+	}
+	members := sig.Members()
+	forged := ecdsa.RestoreThresholdSignature(members, []*ecdsa.Signature{signature(sig, 1), signature(sig, 2), signature(otherSig, 3)})
+	if td.verifiers[3].VerifyQuorumCert(consensus.NewQuorumCert(forged, qc.View(), qc.BlockHash())) {
+		t.Error("QC with a forged signature was verified")
+	}
+
+	// count the signature of replica 1 twice, by placing it at the position of replica 4
+	var positions crypto.Bitfield
+	for _, id := range []hotstuff.ID{1, 2, 4} {
+		positions.Add(id)
+	}
+	duplicate, err := ecdsa.RestoreThresholdSignatureFromPositions(members, positions,
+		[]*ecdsa.Signature{signature(sig, 1), signature(sig, 2), signature(sig, 1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if td.verifiers[3].VerifyQuorumCert(consensus.NewQuorumCert(duplicate, qc.View(), qc.BlockHash())) {
+		t.Error("QC with a duplicated signature was verified")
+	}
+
+	// the positions of the signers are only meaningful in the canonical order of the replicas
+	reordered := ecdsa.RestoreThresholdSignature([]hotstuff.ID{2, 1, 3, 4},
+		[]*ecdsa.Signature{signature(sig, 1), signature(sig, 2), signature(sig, 3)})
+	if td.verifiers[3].VerifyQuorumCert(consensus.NewQuorumCert(reordered, qc.View(), qc.BlockHash())) {
+		t.Error("QC whose signers are indexed by a different order was verified")
+	}
+}
+
+// TestQuorumCertBytes checks that an ECDSA quorum certificate can be restored from its byte representation,
+// where the signers are encoded as a bitfield of their positions among the replicas,
+// and that this is smaller than listing the ID of each signer.
+func TestQuorumCertBytes(t *testing.T) {
+	for _, n := range []int{4, 16} {
+		t.Run(fmt.Sprintf("n=%d", n), func(t *testing.T) {
+			members := make([]hotstuff.ID, n)
+			for i := range members {
+				members[i] = hotstuff.ID(i + 1)
+			}
+			restore := func(b []byte) (consensus.ThresholdSignature, error) {
+				return ecdsa.RestoreThresholdSignatureFromBytes(members, b)
+			}
+			ctrl := gomock.NewController(t)
+			td := setup(NewBase(ecdsa.New), testutil.GenerateECDSAKey)(t, ctrl, n)
+			// leave out the first signer, such that the bitfield is not all ones.
+			qc := testutil.CreateQC(t, td.block, td.signers[1:])
+
+			restored, err := consensus.QuorumCertFromBytes(qc.ToBytes(), restore)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !restored.Equals(qc) {
+				t.Error("the restored QC does not equal the original")
+			}
+			if got, want := restored.Signers(), qc.Signers(); fmt.Sprint(got) != fmt.Sprint(want) {
+				t.Errorf("wrong signers: got %v, want %v", got, want)
+			}
+			if !bytes.Equal(restored.ToBytes(), qc.ToBytes()) {
+				t.Error("the restored QC has a different byte representation")
+			}
+			if !td.verifiers[0].VerifyQuorumCert(restored) {
+				t.Error("failed to verify the restored QC")
+			}
+
+			// the list encoding stores the 4 byte ID of each signer with its signature.
+			listSize := 0
+			qc.Signature().Participants().ForEach(func(id hotstuff.ID) {
+				sig, _ := qc.Signature().(ecdsa.ThresholdSignature).Signature(id)
+				listSize += 4 + 2 + len(sig.R().Bytes()) + len(sig.S().Bytes())
+			})
+			bitfieldSize := len(qc.Signature().ToBytes())
+			if bitfieldSize >= listSize {
+				t.Errorf("the bitfield encoding is not smaller than the list encoding: %d >= %d bytes", bitfieldSize, listSize)
+			}
+			t.Logf("bitfield encoding: %d bytes, list encoding: %d bytes", bitfieldSize, listSize)
+		})
+	}
+
+	genesisQC := consensus.NewQuorumCert(nil, 0, consensus.GetGenesis().Hash())
+	restored, err := consensus.QuorumCertFromBytes(genesisQC.ToBytes(), func(b []byte) (consensus.ThresholdSignature, error) {
+		return nil, fmt.Errorf("the genesis QC has no signature")
+	})
+	if err != nil || !restored.Equals(genesisQC) {
+		t.Errorf("failed to restore the genesis QC: %v", err)
+	}
+
+	members := []hotstuff.ID{1, 2, 3, 4}
+	for _, b := range [][]byte{{0xff}, {1, 0x01}, {1, 0x01, 2, 1}, {1, 0x01, 1, 1, 1, 1, 0}, {1, 0x10, 1, 1, 1, 1}} {
+		if _, err := ecdsa.RestoreThresholdSignatureFromBytes(members, b); err == nil {
+			t.Errorf("restored a threshold signature from invalid bytes %v", b)
+		}
+	}
+}
+
+// TestThresholdSignaturePositions checks that the signers of an ECDSA threshold signature are encoded by their position
+// in the canonical order of the replicas, such that the encoding does not grow with the IDs of the replicas.
+func TestThresholdSignaturePositions(t *testing.T) {
+	members := []hotstuff.ID{3, 70, 200, 1000}
+	var sigs []*ecdsa.Signature
+	for _, id := range []hotstuff.ID{70, 200, 1000} {
+		sigs = append(sigs, ecdsa.RestoreSignature(big.NewInt(int64(id)), big.NewInt(1), id))
+	}
+	sig := ecdsa.RestoreThresholdSignature(members, sigs)
+
+	signers, _ := sig.Positions()
+	if len(signers) != 1 {
+		t.Errorf("the bitfield of 4 replicas uses %d bytes", len(signers))
+	}
+	restored, err := ecdsa.RestoreThresholdSignatureFromBytes(members, sig.ToBytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range sigs {
+		got, ok := restored.Signature(want.Signer())
+		if !ok || got.R().Cmp(want.R()) != 0 {
+			t.Errorf("the signature of replica %d was not restored", want.Signer())
+		}
+	}
+	if restored.Contains(3) {
+		t.Error("replica 3 was restored as a signer")
 	}
 }
@@ -4,9 +4,10 @@ package ecdsa
 import (
 	"crypto/ecdsa"
 	"crypto/rand"
+	"crypto/sha512"
+	"encoding/binary"
 	"fmt"
 	"math/big"
-	"sort"
 
 	"github.com/relab/hotstuff"
 	"github.com/relab/hotstuff/consensus"
@@ -60,35 +61,160 @@ var _ consensus.Signature = (*Signature)(nil)
 
 // ThresholdSignature is a set of (partial) signatures that form a valid threshold signature when there are a quorum
 // of valid (partial) signatures.
-type ThresholdSignature map[hotstuff.ID]*Signature
+//
+// The signers are identified by their position in the canonical order of the replicas that participate in the view
+// of the signature, as returned by consensus.SortedReplicas, such that the signers can be encoded as a bitfield.
+type ThresholdSignature struct {
+	members []hotstuff.ID              // the canonical order of the replicas
+	sigs    map[hotstuff.ID]*Signature // the signatures by signer
+}
 
 // RestoreThresholdSignature should only be used to restore an existing threshold signature from a set of signatures.
 // To create a new verifiable threshold signature, use CreateThresholdSignature instead.
-func RestoreThresholdSignature(signatures []*Signature) ThresholdSignature {
-	sig := make(ThresholdSignature, len(signatures))
+// The members are the canonical order of the replicas that participate in the view of the signature.
+func RestoreThresholdSignature(members []hotstuff.ID, signatures []*Signature) ThresholdSignature {
+	sig := newThresholdSignature(members)
 	for _, s := range signatures {
-		sig[s.signer] = s
+		sig.sigs[s.signer] = s
 	}
 	return sig
 }
 
+func newThresholdSignature(members []hotstuff.ID) ThresholdSignature {
+	return ThresholdSignature{members: members, sigs: make(map[hotstuff.ID]*Signature)}
+}
+
+// RestoreThresholdSignatureFromPositions restores a threshold signature from the positions of the signers
+// in the canonical order of the members, and their signatures in the same order.
+// The signer of each signature is determined by its position.
+func RestoreThresholdSignatureFromPositions(members []hotstuff.ID, signers crypto.Bitfield, signatures []*Signature) (ThresholdSignature, error) {
+	sig := newThresholdSignature(members)
+	i := 0
+	var err error
+	signers.ForEach(func(position hotstuff.ID) {
+		if err != nil {
+			return
+		}
+		// the bitfield is indexed from 1.
+		if int(position) > len(members) {
+			err = fmt.Errorf("signer at position %d is not a member", position-1)
+			return
+		}
+		if i >= len(signatures) {
+			err = fmt.Errorf("missing signature for the signer at position %d", position-1)
+			return
+		}
+		id := members[position-1]
+		sig.sigs[id] = &Signature{signatures[i].r, signatures[i].s, id}
+		i++
+	})
+	if err != nil {
+		return ThresholdSignature{}, err
+	}
+	if i != len(signatures) {
+		return ThresholdSignature{}, fmt.Errorf("%d signatures for %d signers", len(signatures), i)
+	}
+	return sig, nil
+}
+
+// Members returns the canonical order of the replicas that the signers are identified by.
+func (sig ThresholdSignature) Members() []hotstuff.ID {
+	return sig.members
+}
+
+// Signature returns the signature of the signer, if any.
+func (sig ThresholdSignature) Signature(signer hotstuff.ID) (*Signature, bool) {
+	s, ok := sig.sigs[signer]
+	return s, ok
+}
+
+// Positions returns the positions of the signers in the canonical order as a bitfield, and their signatures in the same order.
+// The bitfield is indexed from 1, such that the signer at position i is stored as the ID i+1.
+// The signatures of replicas that are not members are left out.
+func (sig ThresholdSignature) Positions() (signers crypto.Bitfield, signatures []*Signature) {
+	for i, id := range sig.members {
+		if s, ok := sig.sigs[id]; ok {
+			signers.Add(hotstuff.ID(i + 1))
+			signatures = append(signatures, s)
+		}
+	}
+	return signers, signatures
+}
+
 // ToBytes returns the object as bytes.
+// The positions of the signers are encoded as a bitfield, prefixed by its length as a uvarint,
+// followed by the signatures of the signers in the canonical order.
+// The r and s values of each signature are prefixed by their length in bytes.
+// RestoreThresholdSignatureFromBytes restores the threshold signature from this representation.
 func (sig ThresholdSignature) ToBytes() []byte {
-	var b []byte
-	// sort by ID to make it deterministic
-	order := make([]hotstuff.ID, 0, len(sig))
-	for _, signature := range sig {
-		i := sort.Search(len(order), func(i int) bool { return signature.signer < order[i] })
-		order = append(order, 0)
-		copy(order[i+1:], order[i:])
-		order[i] = signature.signer
-	}
-	for _, id := range order {
-		b = append(b, sig[id].ToBytes()...)
+	signers, signatures := sig.Positions()
+	var length [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(length[:], uint64(len(signers)))
+	b := append(length[:n:n], signers...)
+	for _, s := range signatures {
+		r, s := s.r.Bytes(), s.s.Bytes()
+		b = append(b, byte(len(r)))
+		b = append(b, r...)
+		b = append(b, byte(len(s)))
+		b = append(b, s...)
 	}
 	return b
 }
 
+// RestoreThresholdSignatureFromBytes restores a threshold signature from the representation returned by ToBytes,
+// given the canonical order of the replicas that participate in the view of the signature.
+// The signer of each signature is determined by its position in the bitfield.
+func RestoreThresholdSignatureFromBytes(members []hotstuff.ID, b []byte) (ThresholdSignature, error) {
+	length, n := binary.Uvarint(b)
+	if n <= 0 || uint64(len(b)-n) < length {
+		return ThresholdSignature{}, fmt.Errorf("truncated signer bitfield")
+	}
+	signers := crypto.Bitfield(b[n : n+int(length)])
+	b = b[n+int(length):]
+
+	var signatures []*Signature
+	for len(b) > 0 {
+		var r, s []byte
+		var err error
+		if r, b, err = readValue(b); err != nil {
+			return ThresholdSignature{}, err
+		}
+		if s, b, err = readValue(b); err != nil {
+			return ThresholdSignature{}, err
+		}
+		signatures = append(signatures, &Signature{r: new(big.Int).SetBytes(r), s: new(big.Int).SetBytes(s)})
+	}
+	return RestoreThresholdSignatureFromPositions(members, signers, signatures)
+}
+
+// readValue reads a value that is prefixed by its length in bytes.
+func readValue(b []byte) (value, rest []byte, err error) {
+	if len(b) < 1 || len(b)-1 < int(b[0]) {
+		return nil, b, fmt.Errorf("truncated signature")
+	}
+	return b[1 : 1+b[0]], b[1+b[0]:], nil
+}
+
+// indexedBy returns true if the signers are identified by their position in the given canonical order,
+// and each signature is stored under the ID of its signer.
+// The positions in the encoding of a signature only identify the signers if the verifier agrees on the order.
+func (sig ThresholdSignature) indexedBy(members []hotstuff.ID) bool {
+	if len(sig.members) != len(members) {
+		return false
+	}
+	for i := range members {
+		if sig.members[i] != members[i] {
+			return false
+		}
+	}
+	for id, s := range sig.sigs {
+		if s == nil || s.Signer() != id {
+			return false
+		}
+	}
+	return true
+}
+
 // Participants returns the IDs of replicas who participated in the threshold signature.
 func (sig ThresholdSignature) Participants() consensus.IDSet {
 	return sig
@@ -101,17 +227,27 @@ func (sig ThresholdSignature) Add(id hotstuff.ID) {
 
 // Contains returns true if the set contains the ID.
 func (sig ThresholdSignature) Contains(id hotstuff.ID) bool {
-	_, ok := sig[id]
+	_, ok := sig.sigs[id]
 	return ok
 }
 
 // ForEach calls f for each ID in the set.
 func (sig ThresholdSignature) ForEach(f func(hotstuff.ID)) {
-	for id := range sig {
+	for id := range sig.sigs {
 		f(id)
 	}
 }
 
+// voters returns the number of signers that are voters in the configuration.
+func (sig ThresholdSignature) voters(cfg consensus.Configuration) (n int) {
+	for id := range sig.sigs {
+		if consensus.IsVoter(cfg, id) {
+			n++
+		}
+	}
+	return n
+}
+
 var _ consensus.ThresholdSignature = (*ThresholdSignature)(nil)
 var _ consensus.IDSet = (*ThresholdSignature)(nil)
 
@@ -138,7 +274,11 @@ func (ec *ecdsaCrypto) getPrivateKey() *ecdsa.PrivateKey {
 
 // Sign signs a hash.
 func (ec *ecdsaCrypto) Sign(hash consensus.Hash) (sig consensus.Signature, err error) {
-	r, s, err := ecdsa.Sign(rand.Reader, ec.getPrivateKey(), hash[:])
+	return ec.sign(hash[:])
+}
+
+func (ec *ecdsaCrypto) sign(digest []byte) (sig consensus.Signature, err error) {
+	r, s, err := ecdsa.Sign(rand.Reader, ec.getPrivateKey(), digest)
 	if err != nil {
 		return nil, fmt.Errorf("ecdsa: sign failed: %w", err)
 	}
@@ -151,22 +291,37 @@ func (ec *ecdsaCrypto) Sign(hash consensus.Hash) (sig consensus.Signature, err e
 
 // Verify verifies a signature given a hash.
 func (ec *ecdsaCrypto) Verify(sig consensus.Signature, hash consensus.Hash) bool {
+	return ec.verify(ec.mods.Configuration(), sig, hash[:])
+}
+
+// verify verifies the signature with the public key that the signer has in the given membership.
+func (ec *ecdsaCrypto) verify(members consensus.Membership, sig consensus.Signature, digest []byte) bool {
 	_sig, ok := sig.(*Signature)
 	if !ok {
 		return false
 	}
-	replica, ok := ec.mods.Configuration().Replica(sig.Signer())
+	replica, ok := members.Replica(sig.Signer())
 	if !ok {
 		ec.mods.Logger().Infof("ecdsaCrypto: got signature from replica whose ID (%d) was not in the config.", sig.Signer())
 		return false
 	}
 	pk := replica.PublicKey().(*ecdsa.PublicKey)
-	return ecdsa.Verify(pk, hash[:], _sig.R(), _sig.S())
+	return ecdsa.Verify(pk, digest, _sig.R(), _sig.S())
 }
 
 // CreateThresholdSignature creates a threshold signature from the given partial signatures.
 func (ec *ecdsaCrypto) CreateThresholdSignature(partialSignatures []consensus.Signature, hash consensus.Hash) (_ consensus.ThresholdSignature, err error) {
-	thrSig := make(ThresholdSignature)
+	return ec.createThresholdSignature(partialSignatures, func(sig consensus.Signature) bool {
+		// use the registered verifier instead of ourself to verify.
+		// this makes it possible for the signatureCache to work.
+		return ec.mods.Crypto().Verify(sig, hash)
+	})
+}
+
+// createThresholdSignature creates a threshold signature from the partial signatures that are valid.
+// It returns an error if the valid signatures are not from a quorum of voters.
+func (ec *ecdsaCrypto) createThresholdSignature(partialSignatures []consensus.Signature, valid func(consensus.Signature) bool) (_ consensus.ThresholdSignature, err error) {
+	thrSig := newThresholdSignature(consensus.SortedReplicas(ec.mods.Configuration()))
 	for _, s := range partialSignatures {
 		if thrSig.Participants().Contains(s.Signer()) {
 			err = multierr.Append(err, crypto.ErrPartialDuplicate)
@@ -179,14 +334,12 @@ func (ec *ecdsaCrypto) CreateThresholdSignature(partialSignatures []consensus.Si
 			continue
 		}
 
-		// use the registered verifier instead of ourself to verify.
-		// this makes it possible for the signatureCache to work.
-		if ec.mods.Crypto().Verify(s, hash) {
-			thrSig[sig.signer] = sig
+		if valid(s) {
+			thrSig.sigs[sig.signer] = sig
 		}
 	}
 
-	if len(thrSig) >= ec.mods.Configuration().QuorumSize() {
+	if thrSig.voters(ec.mods.Configuration()) >= ec.mods.Configuration().QuorumSize() {
 		return thrSig, nil
 	}
 
@@ -197,7 +350,7 @@ func (ec *ecdsaCrypto) CreateThresholdSignature(partialSignatures []consensus.Si
 // has signed a different message hash.
 func (ec *ecdsaCrypto) CreateThresholdSignatureForMessageSet(partialSignatures []consensus.Signature, hashes map[hotstuff.ID]consensus.Hash) (_ consensus.ThresholdSignature, err error) {
 	ec.mods.Logger().Debug(hashes)
-	thrSig := make(ThresholdSignature)
+	thrSig := newThresholdSignature(consensus.SortedReplicas(ec.mods.Configuration()))
 	for _, s := range partialSignatures {
 		if thrSig.Participants().Contains(s.Signer()) {
 			err = multierr.Append(err, crypto.ErrPartialDuplicate)
@@ -218,11 +371,11 @@ func (ec *ecdsaCrypto) CreateThresholdSignatureForMessageSet(partialSignatures [
 		// use the registered verifier instead of ourself to verify.
 		// this makes it possible for the signatureCache to work.
 		if ec.mods.Crypto().Verify(s, hash) {
-			thrSig[sig.signer] = sig
+			thrSig.sigs[sig.signer] = sig
 		}
 	}
 
-	if len(thrSig) >= ec.mods.Configuration().QuorumSize() {
+	if thrSig.voters(ec.mods.Configuration()) >= ec.mods.Configuration().QuorumSize() {
 		return thrSig, nil
 	}
 
@@ -230,36 +383,47 @@ func (ec *ecdsaCrypto) CreateThresholdSignatureForMessageSet(partialSignatures [
 }
 
 // VerifyThresholdSignature verifies a threshold signature.
-func (ec *ecdsaCrypto) VerifyThresholdSignature(signature consensus.ThresholdSignature, hash consensus.Hash) bool {
+func (ec *ecdsaCrypto) VerifyThresholdSignature(signature consensus.ThresholdSignature, hash consensus.Hash, view consensus.View) bool {
+	return ec.verifyThresholdSignature(signature, hash[:], view)
+}
+
+// verifyThresholdSignature returns true if a quorum of the voters in the view have signed the digest.
+func (ec *ecdsaCrypto) verifyThresholdSignature(signature consensus.ThresholdSignature, digest []byte, view consensus.View) bool {
 	sig, ok := signature.(ThresholdSignature)
 	if !ok {
 		return false
 	}
-	if len(sig) < ec.mods.Configuration().QuorumSize() {
+	members := consensus.MembershipAt(ec.mods.Configuration(), view)
+	if len(sig.sigs) < members.QuorumSize() || !sig.indexedBy(consensus.SortedReplicas(members)) {
 		return false
 	}
 	results := make(chan bool)
-	for _, pSig := range sig {
+	for _, pSig := range sig.sigs {
 		go func(sig *Signature) {
-			results <- ec.mods.Crypto().Verify(sig, hash)
+			// witnesses do not vote, so their signatures do not count toward the quorum.
+			results <- consensus.IsVoter(members, sig.signer) && ec.verify(members, sig, digest)
 		}(pSig)
 	}
 	numVerified := 0
-	for range sig {
+	for range sig.sigs {
 		if <-results {
 			numVerified++
 		}
 	}
-	return numVerified >= ec.mods.Configuration().QuorumSize()
+	return numVerified >= members.QuorumSize()
 }
 
 // VerifyThresholdSignatureForMessageSet verifies a threshold signature against a set of message hashes.
-func (ec *ecdsaCrypto) VerifyThresholdSignatureForMessageSet(signature consensus.ThresholdSignature, hashes map[hotstuff.ID]consensus.Hash) bool {
+func (ec *ecdsaCrypto) VerifyThresholdSignatureForMessageSet(signature consensus.ThresholdSignature, hashes map[hotstuff.ID]consensus.Hash, view consensus.View) bool {
 	ec.mods.Logger().Debug(hashes)
 	sig, ok := signature.(ThresholdSignature)
 	if !ok {
 		return false
 	}
+	members := consensus.MembershipAt(ec.mods.Configuration(), view)
+	if !sig.indexedBy(consensus.SortedReplicas(members)) {
+		return false
+	}
 	hashSet := make(map[consensus.Hash]struct{})
 	results := make(chan bool)
 	for id, hash := range hashes {
@@ -267,21 +431,64 @@ func (ec *ecdsaCrypto) VerifyThresholdSignatureForMessageSet(signature consensus
 			return false
 		}
 		hashSet[hash] = struct{}{}
-		s, ok := sig[id]
+		s, ok := sig.sigs[id]
 		if !ok {
 			return false
 		}
 		go func(sig *Signature, hash consensus.Hash) {
-			results <- ec.mods.Crypto().Verify(sig, hash)
+			results <- consensus.IsVoter(members, sig.signer) && ec.verify(members, sig, hash[:])
 		}(s, hash)
 	}
 	numVerified := 0
-	for range sig {
+	for range hashes {
 		if <-results {
 			numVerified++
 		}
 	}
-	return numVerified >= ec.mods.Configuration().QuorumSize()
+	return numVerified >= members.QuorumSize()
 }
 
 var _ consensus.CryptoImpl = (*ecdsaCrypto)(nil)
+
+// messageCrypto is an ecdsaCrypto that signs full messages instead of hashes.
+type messageCrypto struct {
+	*ecdsaCrypto
+}
+
+// NewMessageSigner returns a new signer and verifier that signs the full serialized blocks,
+// rather than only their hashes, when creating partial certificates and quorum certificates.
+func NewMessageSigner() consensus.CryptoImpl {
+	return messageCrypto{&ecdsaCrypto{}}
+}
+
+// messageDigest returns the digest of the message that is signed.
+// ECDSA signs a digest of the message, so we use SHA-512/256 instead of the SHA-256 that is used for block hashes.
+func messageDigest(message []byte) []byte {
+	digest := sha512.Sum512_256(message)
+	return digest[:]
+}
+
+// SignMessage signs a message.
+func (mc messageCrypto) SignMessage(message []byte) (sig consensus.Signature, err error) {
+	return mc.sign(messageDigest(message))
+}
+
+// VerifyMessage verifies a signature given a message.
+func (mc messageCrypto) VerifyMessage(sig consensus.Signature, message []byte) bool {
+	return mc.verify(mc.mods.Configuration(), sig, messageDigest(message))
+}
+
+// CreateThresholdSignatureForMessage creates a threshold signature from partial signatures of the message.
+func (mc messageCrypto) CreateThresholdSignatureForMessage(partialSignatures []consensus.Signature, message []byte) (_ consensus.ThresholdSignature, err error) {
+	digest := messageDigest(message)
+	return mc.createThresholdSignature(partialSignatures, func(sig consensus.Signature) bool {
+		return mc.verify(mc.mods.Configuration(), sig, digest)
+	})
+}
+
+// VerifyThresholdSignatureForMessage verifies a threshold signature given a message.
+func (mc messageCrypto) VerifyThresholdSignatureForMessage(signature consensus.ThresholdSignature, message []byte, view consensus.View) bool {
+	return mc.verifyThresholdSignature(signature, messageDigest(message), view)
+}
+
+var _ consensus.MessageSigner = (*messageCrypto)(nil)
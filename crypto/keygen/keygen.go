@@ -318,7 +318,7 @@ func GenerateKeyChain(id hotstuff.ID, validFor []string, crypto string, ca *x509
 
 	var privateKey consensus.PrivateKey
 	switch crypto {
-	case "ecdsa":
+	case "ecdsa", "ecdsa-message":
 		privateKey = ecdsaKey
 	case "bls12":
 		privateKey, err = bls12.GeneratePrivateKey()
@@ -69,6 +69,11 @@ func (el *EventLoop) AddEvent(event interface{}) {
 	el.eventQ <- event
 }
 
+// Len returns the number of events that are waiting in the event queue.
+func (el *EventLoop) Len() int {
+	return len(el.eventQ)
+}
+
 // Run runs the event loop. A context object can be provided to stop the event loop.
 func (el *EventLoop) Run(ctx context.Context) {
 	for {
@@ -131,10 +136,11 @@ func (el *EventLoop) dispatchDelayedEvents(t reflect.Type) {
 // The eventType parameter decides the type of event to wait for, and it should be the zero value
 // of that event type. The event parameter is the event that will be delayed.
 func (el *EventLoop) DelayUntil(eventType, event interface{}) {
+	t := reflect.TypeOf(eventType)
 	el.mut.Lock()
-	v := el.waitingEvents[eventType]
+	v := el.waitingEvents[t]
 	v = append(v, event)
-	el.waitingEvents[eventType] = v
+	el.waitingEvents[t] = v
 	el.mut.Unlock()
 }
 
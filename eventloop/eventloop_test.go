@@ -76,3 +76,67 @@ func TestTicker(t *testing.T) {
 		t.Fatal("ticker was not stopped")
 	}
 }
+
+func TestLen(t *testing.T) {
+	el := eventloop.New(10)
+	done := make(chan struct{})
+	el.RegisterHandler(testEvent(0), func(event interface{}) {
+		done <- struct{}{}
+	})
+
+	for i := 0; i < 3; i++ {
+		el.AddEvent(testEvent(i))
+	}
+	if l := el.Len(); l != 3 {
+		t.Fatalf("wrong queue length: got: %d, want: %d", l, 3)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	go el.Run(ctx)
+
+	for i := 0; i < 3; i++ {
+		select {
+		case <-ctx.Done():
+			t.Fatal("timed out")
+		case <-done:
+		}
+	}
+	if l := el.Len(); l != 0 {
+		t.Fatalf("wrong queue length after processing: got: %d, want: %d", l, 0)
+	}
+}
+
+type otherEvent struct{}
+
+func TestDelayUntil(t *testing.T) {
+	el := eventloop.New(10)
+	c := make(chan interface{}, 1)
+	el.RegisterHandler(testEvent(0), func(event interface{}) {
+		c <- event
+	})
+
+	el.DelayUntil(otherEvent{}, testEvent(42))
+	// an event of another type must not release the delayed event.
+	el.AddEvent(testEvent(1))
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	el.Run(cancelled)
+	if event := <-c; event != testEvent(1) {
+		t.Fatalf("delayed event was handled too early: got: %v, want: %v", event, testEvent(1))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	go el.Run(ctx)
+
+	el.AddEvent(otherEvent{})
+	select {
+	case <-ctx.Done():
+		t.Fatal("timed out")
+	case event := <-c:
+		if event != testEvent(42) {
+			t.Fatalf("wrong event: got: %v, want: %v", event, testEvent(42))
+		}
+	}
+}
@@ -9,13 +9,13 @@ require (
 	github.com/kilic/bls12-381 v0.1.1-0.20210208205449-6045b0235e36
 	github.com/mattn/go-isatty v0.0.12
 	github.com/mitchellh/go-homedir v1.1.0
-	github.com/mroth/weightedrand v0.4.1
 	github.com/relab/gorums v0.5.1-0.20210629194217-9811e4f219ca
 	github.com/relab/iago v0.0.0-20210721102751-67ef5c5ec2b0
 	github.com/relab/wrfs v0.0.0-20210628111300-b51570396aec
 	github.com/spf13/cobra v1.2.1
 	github.com/spf13/viper v1.8.1
 	go-hep.org/x/hep v0.28.6
+	go.etcd.io/bbolt v1.3.5
 	go.uber.org/multierr v1.7.0
 	go.uber.org/zap v1.17.0
 	golang.org/x/time v0.0.0-20210723032227-1f47c861a9ac
@@ -1,5 +1,30 @@
 package hotstuff
 
+// FaultModel determines the kind of faults that a configuration tolerates,
+// and thereby the number of faulty replicas and the quorum size.
+type FaultModel int
+
+const (
+	// Byzantine tolerates f arbitrary faults among n = 3f+1 replicas, with quorums of 2f+1 replicas.
+	Byzantine FaultModel = iota
+	// CrashOnly tolerates f crashed replicas among n = 2f+1 replicas, with quorums of f+1 replicas.
+	// It must only be used if no replica can deviate from the protocol.
+	CrashOnly
+)
+
+// NumFaulty calculates the number of replicas that can be faulty for a configuration of size 'n' under the fault model.
+func (m FaultModel) NumFaulty(n int) int {
+	if m == CrashOnly {
+		return (n - 1) / 2
+	}
+	return NumFaulty(n)
+}
+
+// QuorumSize calculates the quorum size for a configuration of size 'n' under the fault model.
+func (m FaultModel) QuorumSize(n int) int {
+	return n - m.NumFaulty(n)
+}
+
 // NumFaulty calculates 'f', which is the number of replicas that can be faulty for a configuration of size 'n'.
 func NumFaulty(n int) int {
 	return (n - 1) / 3
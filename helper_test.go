@@ -0,0 +1,29 @@
+package hotstuff
+
+import "testing"
+
+func TestFaultModelQuorumSize(t *testing.T) {
+	tests := []struct {
+		n         int
+		byzantine int
+		crashOnly int
+	}{
+		{n: 1, byzantine: 1, crashOnly: 1},
+		{n: 3, byzantine: 3, crashOnly: 2},
+		{n: 4, byzantine: 3, crashOnly: 3},
+		{n: 5, byzantine: 4, crashOnly: 3},
+		{n: 7, byzantine: 5, crashOnly: 4},
+		{n: 10, byzantine: 7, crashOnly: 6},
+	}
+	for _, tt := range tests {
+		if got := Byzantine.QuorumSize(tt.n); got != tt.byzantine {
+			t.Errorf("Byzantine.QuorumSize(%d) = %d, want %d", tt.n, got, tt.byzantine)
+		}
+		if got := CrashOnly.QuorumSize(tt.n); got != tt.crashOnly {
+			t.Errorf("CrashOnly.QuorumSize(%d) = %d, want %d", tt.n, got, tt.crashOnly)
+		}
+		if got := QuorumSize(tt.n); got != tt.byzantine {
+			t.Errorf("QuorumSize(%d) = %d, want %d", tt.n, got, tt.byzantine)
+		}
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/relab/hotstuff"
 	"github.com/relab/hotstuff/internal/orchestration"
 	"github.com/relab/hotstuff/internal/proto/orchestrationpb"
 	"github.com/relab/hotstuff/internal/protostream"
@@ -44,20 +45,64 @@ func init() {
 	rootCmd.AddCommand(runCmd)
 
 	runCmd.Flags().Int("replicas", 4, "number of replicas to run")
+	runCmd.Flags().Int("witnesses", 0, "number of replicas that store and relay blocks without voting")
 	runCmd.Flags().Int("clients", 1, "number of clients to run")
 	runCmd.Flags().Int("batch-size", 1, "number of commands to batch together in each block")
+	runCmd.Flags().Uint64("warmup-view", 0, "first view in which client commands are accepted")
+	runCmd.Flags().Bool("order-by-timestamp", false, "propose commands in the order of their client timestamps")
+	runCmd.Flags().Bool("canonical-batches", false, "encode batches as length-prefixed commands in insertion order")
+	runCmd.Flags().Uint32("confirmation-depth", 0, "number of blocks that must be committed on top of a command before the client is notified")
 	runCmd.Flags().Int("payload-size", 0, "size in bytes of the command payload")
 	runCmd.Flags().Int("max-concurrent", 4, "maximum number of conccurrent commands per client")
+	runCmd.Flags().Uint32("priority-levels", 0, "number of priority levels that the replicas order client commands by (0 = no priorities)")
+	runCmd.Flags().Duration("priority-aging", 0, "time after which a command is proposed before commands with a higher priority (0 = no aging)")
+	runCmd.Flags().Uint32("client-priority", 0, "priority of the commands sent by the clients")
+	runCmd.Flags().Uint32("max-command-size", 0, "maximum size in bytes of an encoded client command (0 = no limit)")
+	runCmd.Flags().Uint32("max-proposal-size", 0, "maximum size in bytes of the command of a proposed block, larger proposals are rejected (0 = no limit)")
 	runCmd.Flags().Duration("duration", 10*time.Second, "duration of the experiment")
+	runCmd.Flags().IntSlice("remove-replicas", nil, "IDs of replicas to remove from the configuration during the experiment")
+	runCmd.Flags().Duration("remove-after", 0, "time after the start of the experiment at which the replicas are removed")
 	runCmd.Flags().Duration("connect-timeout", 5*time.Second, "duration of the initial connection timeout")
+	runCmd.Flags().Duration("keepalive-time", 0, "idle time before a keepalive ping is sent to other replicas (disabled by default)")
+	runCmd.Flags().Duration("keepalive-timeout", 20*time.Second, "time to wait for a keepalive ping to be acknowledged")
 	runCmd.Flags().Duration("view-timeout", 100*time.Millisecond, "duration of the first view")
 	runCmd.Flags().Duration("max-timeout", 0, "upper limit on view timeouts")
 	runCmd.Flags().Int("duration-samples", 1000, "number of previous views to consider when predicting view duration")
 	runCmd.Flags().Float32("timeout-multiplier", 1.2, "number to multiply the view duration by in case of a timeout")
+	runCmd.Flags().Uint64("max-future-view", 0, "maximum number of views a certificate may be ahead of the current view (0 = no limit)")
+	runCmd.Flags().Duration("fetch-timeout", 0, "time to wait for the first attempt to fetch a block before retrying with more replicas (0 = no retries)")
+	runCmd.Flags().Duration("max-fetch-timeout", 0, "upper limit on the time to wait for an attempt to fetch a block")
+	runCmd.Flags().String("block-store", "", "directory on the worker hosts in which the replicas store their blocks (blocks are only kept in memory by default)")
+	runCmd.Flags().String("block-store-type", "file", "kind of block store: file or bolt")
+	runCmd.Flags().Uint32("fetch-retention", 0, "number of committed blocks to keep for lagging replicas to fetch (0 = keep all blocks)")
+	runCmd.Flags().Uint32("max-timeout-views", 0, "maximum number of views for which timeout messages are buffered (0 = no limit)")
+	runCmd.Flags().Uint32("max-timeouts-per-view", 0, "maximum number of timeout messages buffered for a single view (0 = no limit)")
+	runCmd.Flags().Uint32("parent-validation-depth", 0, "number of ancestors of a proposal whose parent must match their QC (0 = disabled)")
+	runCmd.Flags().Bool("drop-abandoned-proposals", false, "drop proposals for views that were abandoned after a local timeout")
+	runCmd.Flags().Bool("strict-tc-view", false, "reject timeout certificates for views before the current view")
+	runCmd.Flags().Bool("piggyback-tc", false, "make the leader include the timeout certificate that moved it to a new view in its proposal")
+	runCmd.Flags().Bool("verify-proposer", false, "reject proposals from replicas that are not the leader of the view before using their certificates")
+	runCmd.Flags().Bool("validate-view-skips", false, "reject proposals that skip views without a certificate for the previous view (implies --piggyback-tc)")
+	runCmd.Flags().String("quorum-wait", "none", "what the leader does when some replicas are unreachable: 'none' or 'reachable' (step down without a quorum)")
+	runCmd.Flags().String("stale-commands", "ignore", "what replicas do with proposals of already committed commands: 'ignore', 'flag', or 'reject'")
+	runCmd.Flags().String("fault-model", "byzantine", "the faults that are tolerated: 'byzantine' (quorums of 2f+1 out of 3f+1) or 'crash' (quorums of f+1 out of 2f+1)")
+	runCmd.Flags().String("genesis", "", "command included in the genesis block (must be the same for all replicas)")
+	runCmd.Flags().Uint32("step-down-threshold", 0, "number of consecutive uncommitted proposals after which a leader steps down (0 = never)")
+	runCmd.Flags().Duration("reject-log-interval", time.Second, "log at most one rejected message per message type and reason in each interval (0 = log all)")
+	runCmd.Flags().Duration("responsive-threshold", 0, "time since the last message from a replica after which it is no longer considered responsive (0 = responsive once heard from)")
+	runCmd.Flags().Duration("audit-interval", 0, "how often the committed blocks are checked for inconsistencies (0 = never)")
+	runCmd.Flags().Uint32("audit-depth", 0, "number of committed blocks to check in each audit (0 = all local blocks)")
+	runCmd.Flags().Duration("vote-coalesce-window", 0, "time to wait for other proposals before signing a vote, such that votes are signed together (at most 10ms)")
+	runCmd.Flags().Uint32("vote-signing-workers", 0, "number of workers that sign votes outside of the event loop (0 = sign on the event loop)")
+	runCmd.Flags().Bool("relay-qc", false, "make the leader send new QCs to all replicas (useful for star topologies)")
+	runCmd.Flags().Bool("ack-proposals", false, "make replicas acknowledge the proposals they receive (for diagnostics)")
+	runCmd.Flags().Bool("abstain", false, "make replicas tell the leader when they do not vote for its proposal")
+	runCmd.Flags().Bool("empty-proposals", false, "make the leader propose empty blocks when there are no commands, instead of waiting for commands")
 	runCmd.Flags().String("consensus", "chainedhotstuff", "name of the consensus implementation")
 	runCmd.Flags().String("crypto", "ecdsa", "name of the crypto implementation")
 	runCmd.Flags().String("leader-rotation", "rep", "name of the leader rotation algorithm")
-	
+	runCmd.Flags().Uint32("leader-window", 100, "number of recent views that the leader rotation takes into account (rep, liveness, reputation)")
+	runCmd.Flags().Uint32("leader-depth", 10, "number of views between a view and the committed block its leader is derived from (rep, liveness, reputation, chain-seeded)")
 
 	runCmd.Flags().Bool("worker", false, "run a local worker")
 	runCmd.Flags().StringSlice("hosts", nil, "the remote hosts to run the experiment on via ssh")
@@ -96,18 +141,60 @@ func runController() {
 	experiment := orchestration.Experiment{
 		NumReplicas: viper.GetInt("replicas"),
 		NumClients:  viper.GetInt("clients"),
+		NumWitness:  viper.GetInt("witnesses"),
 		Duration:    viper.GetDuration("duration"),
+		RemoveAfter: viper.GetDuration("remove-after"),
 		ReplicaOpts: &orchestrationpb.ReplicaOpts{
-			UseTLS:            true,
-			BatchSize:         viper.GetUint32("batch-size"),
-			TimeoutMultiplier: float32(viper.GetFloat64("timeout-multiplier")),
-			Consensus:         viper.GetString("consensus"),
-			Crypto:            viper.GetString("crypto"),
-			LeaderRotation:    viper.GetString("leader-rotation"),
-			ConnectTimeout:    durationpb.New(viper.GetDuration("connect-timeout")),
-			InitialTimeout:    durationpb.New(viper.GetDuration("view-timeout")),
-			TimeoutSamples:    viper.GetUint32("duration-samples"),
-			MaxTimeout:        durationpb.New(viper.GetDuration("max-timeout")),
+			UseTLS:                 true,
+			BatchSize:              viper.GetUint32("batch-size"),
+			WarmupView:             viper.GetUint64("warmup-view"),
+			OrderByTimestamp:       viper.GetBool("order-by-timestamp"),
+			CanonicalBatches:       viper.GetBool("canonical-batches"),
+			ConfirmationDepth:      viper.GetUint32("confirmation-depth"),
+			TimeoutMultiplier:      float32(viper.GetFloat64("timeout-multiplier")),
+			Consensus:              viper.GetString("consensus"),
+			Crypto:                 viper.GetString("crypto"),
+			LeaderRotation:         viper.GetString("leader-rotation"),
+			LeaderWindow:           viper.GetUint32("leader-window"),
+			LeaderDepth:            viper.GetUint32("leader-depth"),
+			ConnectTimeout:         durationpb.New(viper.GetDuration("connect-timeout")),
+			KeepaliveTime:          durationpb.New(viper.GetDuration("keepalive-time")),
+			KeepaliveTimeout:       durationpb.New(viper.GetDuration("keepalive-timeout")),
+			InitialTimeout:         durationpb.New(viper.GetDuration("view-timeout")),
+			TimeoutSamples:         viper.GetUint32("duration-samples"),
+			MaxTimeout:             durationpb.New(viper.GetDuration("max-timeout")),
+			MaxFutureView:          viper.GetUint64("max-future-view"),
+			MaxProposalSize:        viper.GetUint32("max-proposal-size"),
+			PriorityLevels:         viper.GetUint32("priority-levels"),
+			PriorityAging:          durationpb.New(viper.GetDuration("priority-aging")),
+			ParentValidationDepth:  viper.GetUint32("parent-validation-depth"),
+			FetchRetention:         viper.GetUint32("fetch-retention"),
+			BlockStore:             viper.GetString("block-store"),
+			BlockStoreType:         viper.GetString("block-store-type"),
+			FetchTimeout:           durationpb.New(viper.GetDuration("fetch-timeout")),
+			MaxFetchTimeout:        durationpb.New(viper.GetDuration("max-fetch-timeout")),
+			MaxTimeoutViews:        viper.GetUint32("max-timeout-views"),
+			MaxTimeoutsPerView:     viper.GetUint32("max-timeouts-per-view"),
+			DropAbandonedProposals: viper.GetBool("drop-abandoned-proposals"),
+			RelayQC:                viper.GetBool("relay-qc"),
+			StrictTCView:           viper.GetBool("strict-tc-view"),
+			PiggybackTC:            viper.GetBool("piggyback-tc"),
+			ValidateViewSkips:      viper.GetBool("validate-view-skips"),
+			VerifyProposer:         viper.GetBool("verify-proposer"),
+			QuorumWait:             viper.GetString("quorum-wait"),
+			StaleCommands:          viper.GetString("stale-commands"),
+			FaultModel:             viper.GetString("fault-model"),
+			Genesis:                []byte(viper.GetString("genesis")),
+			VoteSigningWorkers:     viper.GetUint32("vote-signing-workers"),
+			VoteCoalesceWindow:     durationpb.New(viper.GetDuration("vote-coalesce-window")),
+			StepDownThreshold:      viper.GetUint32("step-down-threshold"),
+			AckProposals:           viper.GetBool("ack-proposals"),
+			Abstain:                viper.GetBool("abstain"),
+			AllowEmptyProposals:    viper.GetBool("empty-proposals"),
+			AuditInterval:          durationpb.New(viper.GetDuration("audit-interval")),
+			RejectLogInterval:      durationpb.New(viper.GetDuration("reject-log-interval")),
+			ResponsiveThreshold:    durationpb.New(viper.GetDuration("responsive-threshold")),
+			AuditDepth:             viper.GetUint32("audit-depth"),
 		},
 		ClientOpts: &orchestrationpb.ClientOpts{
 			UseTLS:           true,
@@ -117,12 +204,18 @@ func runController() {
 			RateLimit:        viper.GetFloat64("rate-limit"),
 			RateStep:         viper.GetFloat64("rate-step"),
 			RateStepInterval: durationpb.New(viper.GetDuration("rate-step-interval")),
+			MaxCommandSize:   viper.GetUint32("max-command-size"),
+			Priority:         viper.GetUint32("client-priority"),
 		},
 	}
 
 	experiment.Byzantine, err = parseByzantine()
 	checkf("%v", err)
 
+	for _, id := range viper.GetIntSlice("remove-replicas") {
+		experiment.RemoveReplicas = append(experiment.RemoveReplicas, hotstuff.ID(id))
+	}
+
 	worker := viper.GetBool("worker")
 	hosts := viper.GetStringSlice("hosts")
 	exePath := viper.GetString("exe")
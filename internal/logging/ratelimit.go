@@ -0,0 +1,92 @@
+package logging
+
+import (
+	"sync"
+	"time"
+)
+
+// RateLimited is a logger that logs at most one message for each key per interval.
+// The messages that are suppressed within an interval are counted,
+// and the count is logged as a single line when the next message for the key is logged after the interval,
+// or when Flush is called.
+type RateLimited struct {
+	logger   Logger
+	interval time.Duration
+	now      func() time.Time
+
+	mut     sync.Mutex
+	entries map[string]*rateLimitEntry
+}
+
+type rateLimitEntry struct {
+	start      time.Time // the start of the current interval
+	suppressed uint64    // the number of messages suppressed in the current interval
+}
+
+// NewRateLimited returns a logger that logs at most one message for each key per interval to the given logger.
+// If the interval is 0, all messages are logged.
+func NewRateLimited(logger Logger, interval time.Duration) *RateLimited {
+	return &RateLimited{
+		logger:   logger,
+		interval: interval,
+		now:      time.Now,
+		entries:  make(map[string]*rateLimitEntry),
+	}
+}
+
+// Infof logs a message at the info level, unless a message with the same key was logged in the current interval.
+func (l *RateLimited) Infof(key string, template string, args ...interface{}) {
+	if l.allow(key) {
+		l.logger.Infof(template, args...)
+	}
+}
+
+// Warnf logs a message at the warn level, unless a message with the same key was logged in the current interval.
+func (l *RateLimited) Warnf(key string, template string, args ...interface{}) {
+	if l.allow(key) {
+		l.logger.Warnf(template, args...)
+	}
+}
+
+// allow returns true if a message with the given key should be logged.
+// If the previous interval for the key has ended, the number of messages that were suppressed in it is logged.
+func (l *RateLimited) allow(key string) bool {
+	if l.interval <= 0 {
+		return true
+	}
+	l.mut.Lock()
+	defer l.mut.Unlock()
+
+	now := l.now()
+	entry, ok := l.entries[key]
+	if !ok {
+		l.entries[key] = &rateLimitEntry{start: now}
+		return true
+	}
+	if now.Sub(entry.start) < l.interval {
+		entry.suppressed++
+		return false
+	}
+	l.summarize(key, entry)
+	entry.start = now
+	return true
+}
+
+// Flush logs the number of suppressed messages for all keys that have suppressed messages.
+func (l *RateLimited) Flush() {
+	l.mut.Lock()
+	defer l.mut.Unlock()
+	for key, entry := range l.entries {
+		l.summarize(key, entry)
+	}
+}
+
+// summarize logs the number of messages that were suppressed for the key, and resets the count.
+// The caller must hold the lock.
+func (l *RateLimited) summarize(key string, entry *rateLimitEntry) {
+	if entry.suppressed == 0 {
+		return
+	}
+	l.logger.Warnf("%d more messages for %q in the last %v", entry.suppressed, key, l.interval)
+	entry.suppressed = 0
+}
@@ -0,0 +1,69 @@
+package logging
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// recorder is a Logger that records the lines that are logged at the info and warn levels.
+type recorder struct {
+	Logger
+	lines []string
+}
+
+func (r *recorder) Infof(template string, args ...interface{}) {
+	r.lines = append(r.lines, fmt.Sprintf(template, args...))
+}
+
+func (r *recorder) Warnf(template string, args ...interface{}) {
+	r.lines = append(r.lines, fmt.Sprintf(template, args...))
+}
+
+func TestRateLimited(t *testing.T) {
+	const n = 1000
+	var (
+		rec = &recorder{}
+		now = time.Unix(0, 0)
+		l   = NewRateLimited(rec, time.Second)
+	)
+	l.now = func() time.Time { return now }
+
+	for i := 0; i < n; i++ {
+		l.Infof("bad signature", "message %d", i)
+		l.Infof("stale view", "message %d", i)
+		now = now.Add(time.Millisecond / 2)
+	}
+	if len(rec.lines) != 2 {
+		t.Fatalf("expected 2 lines within the interval, got %d: %v", len(rec.lines), rec.lines)
+	}
+
+	now = now.Add(time.Second)
+	l.Infof("bad signature", "message %d", n)
+	if len(rec.lines) != 4 {
+		t.Fatalf("expected a summary and a new message after the interval, got %v", rec.lines)
+	}
+	if want := fmt.Sprintf("%d more messages for %q", n-1, "bad signature"); !strings.HasPrefix(rec.lines[2], want) {
+		t.Errorf("got summary %q, want prefix %q", rec.lines[2], want)
+	}
+
+	l.Flush()
+	if len(rec.lines) != 5 {
+		t.Fatalf("expected Flush to summarize the remaining key, got %v", rec.lines)
+	}
+	if want := fmt.Sprintf("%d more messages for %q", n-1, "stale view"); !strings.HasPrefix(rec.lines[4], want) {
+		t.Errorf("got summary %q, want prefix %q", rec.lines[4], want)
+	}
+}
+
+func TestRateLimitedDisabled(t *testing.T) {
+	rec := &recorder{}
+	l := NewRateLimited(rec, 0)
+	for i := 0; i < 10; i++ {
+		l.Warnf("key", "message %d", i)
+	}
+	if len(rec.lines) != 10 {
+		t.Errorf("expected all messages to be logged, got %d", len(rec.lines))
+	}
+}
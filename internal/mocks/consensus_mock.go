@@ -48,6 +48,32 @@ func (mr *MockConsensusMockRecorder) CommittedBlock() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommittedBlock", reflect.TypeOf((*MockConsensus)(nil).CommittedBlock))
 }
 
+// Pause mocks base method.
+func (m *MockConsensus) Pause() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Pause")
+}
+
+// Pause indicates an expected call of Pause.
+func (mr *MockConsensusMockRecorder) Pause() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pause", reflect.TypeOf((*MockConsensus)(nil).Pause))
+}
+
+// Paused mocks base method.
+func (m *MockConsensus) Paused() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Paused")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Paused indicates an expected call of Paused.
+func (mr *MockConsensusMockRecorder) Paused() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Paused", reflect.TypeOf((*MockConsensus)(nil).Paused))
+}
+
 // Propose mocks base method.
 func (m *MockConsensus) Propose(arg0 consensus.SyncInfo) {
 	m.ctrl.T.Helper()
@@ -60,6 +86,30 @@ func (mr *MockConsensusMockRecorder) Propose(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Propose", reflect.TypeOf((*MockConsensus)(nil).Propose), arg0)
 }
 
+// RegisterCommitHandler mocks base method.
+func (m *MockConsensus) RegisterCommitHandler(arg0 func(*consensus.Block)) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RegisterCommitHandler", arg0)
+}
+
+// RegisterCommitHandler indicates an expected call of RegisterCommitHandler.
+func (mr *MockConsensusMockRecorder) RegisterCommitHandler(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterCommitHandler", reflect.TypeOf((*MockConsensus)(nil).RegisterCommitHandler), arg0)
+}
+
+// Resume mocks base method.
+func (m *MockConsensus) Resume() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Resume")
+}
+
+// Resume indicates an expected call of Resume.
+func (mr *MockConsensusMockRecorder) Resume() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resume", reflect.TypeOf((*MockConsensus)(nil).Resume))
+}
+
 // StopVoting mocks base method.
 func (m *MockConsensus) StopVoting(arg0 consensus.View) {
 	m.ctrl.T.Helper()
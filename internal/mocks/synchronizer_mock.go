@@ -9,6 +9,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	hotstuff "github.com/relab/hotstuff"
 	consensus "github.com/relab/hotstuff/consensus"
 )
 
@@ -75,6 +76,34 @@ func (mr *MockSynchronizerMockRecorder) LeafBlock() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LeafBlock", reflect.TypeOf((*MockSynchronizer)(nil).LeafBlock))
 }
 
+// MostRep mocks base method.
+func (m *MockSynchronizer) MostRep() float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MostRep")
+	ret0, _ := ret[0].(float64)
+	return ret0
+}
+
+// MostRep indicates an expected call of MostRep.
+func (mr *MockSynchronizerMockRecorder) MostRep() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MostRep", reflect.TypeOf((*MockSynchronizer)(nil).MostRep))
+}
+
+// NewLeader mocks base method.
+func (m *MockSynchronizer) NewLeader() hotstuff.ID {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewLeader")
+	ret0, _ := ret[0].(hotstuff.ID)
+	return ret0
+}
+
+// NewLeader indicates an expected call of NewLeader.
+func (mr *MockSynchronizerMockRecorder) NewLeader() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewLeader", reflect.TypeOf((*MockSynchronizer)(nil).NewLeader))
+}
+
 // Start mocks base method.
 func (m *MockSynchronizer) Start(arg0 context.Context) {
 	m.ctrl.T.Helper()
@@ -87,6 +116,21 @@ func (mr *MockSynchronizerMockRecorder) Start(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockSynchronizer)(nil).Start), arg0)
 }
 
+// TimedOutView mocks base method.
+func (m *MockSynchronizer) TimedOutView() (consensus.View, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TimedOutView")
+	ret0, _ := ret[0].(consensus.View)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// TimedOutView indicates an expected call of TimedOutView.
+func (mr *MockSynchronizerMockRecorder) TimedOutView() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TimedOutView", reflect.TypeOf((*MockSynchronizer)(nil).TimedOutView))
+}
+
 // UpdateHighQC mocks base method.
 func (m *MockSynchronizer) UpdateHighQC(arg0 consensus.QuorumCert) {
 	m.ctrl.T.Helper()
@@ -99,6 +143,18 @@ func (mr *MockSynchronizerMockRecorder) UpdateHighQC(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateHighQC", reflect.TypeOf((*MockSynchronizer)(nil).UpdateHighQC), arg0)
 }
 
+// UpdateValues mocks base method.
+func (m *MockSynchronizer) UpdateValues(arg0 hotstuff.ID, arg1 float64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateValues", arg0, arg1)
+}
+
+// UpdateValues indicates an expected call of UpdateValues.
+func (mr *MockSynchronizerMockRecorder) UpdateValues(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateValues", reflect.TypeOf((*MockSynchronizer)(nil).UpdateValues), arg0, arg1)
+}
+
 // View mocks base method.
 func (m *MockSynchronizer) View() consensus.View {
 	m.ctrl.T.Helper()
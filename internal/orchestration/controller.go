@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/consensus"
 	"github.com/relab/hotstuff/crypto/keygen"
 	"github.com/relab/hotstuff/internal/proto/orchestrationpb"
 	"go.uber.org/multierr"
@@ -29,8 +30,13 @@ type Experiment struct {
 
 	NumReplicas int
 	NumClients  int
+	NumWitness  int // number of replicas that do not vote, assigned to the highest IDs
 	Duration    time.Duration
 
+	// RemoveReplicas are removed from the configuration RemoveAfter the replicas were started.
+	RemoveReplicas []hotstuff.ID
+	RemoveAfter    time.Duration
+
 	Hosts       map[string]RemoteWorker
 	HostConfigs map[string]HostConfig
 	Byzantine   map[string]int // number of replicas to assign to each byzantine strategy
@@ -73,7 +79,16 @@ func (e *Experiment) Run() (err error) {
 		return fmt.Errorf("failed to start clients: %w", err)
 	}
 
-	time.Sleep(e.Duration)
+	if len(e.RemoveReplicas) > 0 && e.RemoveAfter < e.Duration {
+		time.Sleep(e.RemoveAfter)
+		err = e.removeReplicas()
+		if err != nil {
+			return fmt.Errorf("failed to remove replicas: %w", err)
+		}
+		time.Sleep(e.Duration - e.RemoveAfter)
+	} else {
+		time.Sleep(e.Duration)
+	}
 
 	err = e.stopClients()
 	if err != nil {
@@ -133,6 +148,16 @@ func (e *Experiment) createReplicas() (cfg *orchestrationpb.ReplicaConfiguration
 		}
 	}
 
+	genesis := make(map[hotstuff.ID]consensus.Hash)
+	for id, replicaCfg := range cfg.GetReplicas() {
+		var hash consensus.Hash
+		copy(hash[:], replicaCfg.GetGenesisHash())
+		genesis[hotstuff.ID(id)] = hash
+	}
+	if err := consensus.CheckGenesis(genesis); err != nil {
+		return nil, err
+	}
+
 	return cfg, nil
 }
 
@@ -227,6 +252,7 @@ func (e *Experiment) assignReplicasAndClients() (err error) {
 			replicaOpts := proto.Clone(e.ReplicaOpts).(*orchestrationpb.ReplicaOpts)
 			replicaOpts.ID = uint32(nextReplicaID)
 			replicaOpts.ByzantineStrategy = byzantineStrategy
+			replicaOpts.Witness = int(nextReplicaID) > e.NumReplicas-e.NumWitness
 
 			e.hostsToReplicas[host] = append(e.hostsToReplicas[host], nextReplicaID)
 			e.replicaOpts[nextReplicaID] = replicaOpts
@@ -271,6 +297,10 @@ func (e *Experiment) stopReplicas() error {
 			return err
 		}
 		for id, hash := range res.GetHashes() {
+			if e.removed(hotstuff.ID(id)) {
+				// removed replicas stop executing commands when they are removed.
+				continue
+			}
 			hashes[id] = hash
 		}
 	}
@@ -286,6 +316,36 @@ func (e *Experiment) stopReplicas() error {
 	return nil
 }
 
+// removeReplicas asks all replicas to remove the RemoveReplicas from the configuration.
+// Each replica must authorize the change before it votes for it.
+func (e *Experiment) removeReplicas() error {
+	for _, id := range e.RemoveReplicas {
+		for host, worker := range e.Hosts {
+			req := &orchestrationpb.ReconfigureRequest{
+				IDs:       getIDs(host, e.hostsToReplicas),
+				Remove:    true,
+				ReplicaID: uint32(id),
+			}
+			_, err := worker.Reconfigure(req)
+			if err != nil {
+				return err
+			}
+		}
+		log.Printf("requested the removal of replica %d", id)
+	}
+	return nil
+}
+
+// removed returns true if the replica is removed during the experiment.
+func (e *Experiment) removed(id hotstuff.ID) bool {
+	for _, removed := range e.RemoveReplicas {
+		if removed == id {
+			return true
+		}
+	}
+	return false
+}
+
 func (e *Experiment) startClients(cfg *orchestrationpb.ReplicaConfiguration) error {
 	for host, worker := range e.Hosts {
 		req := &orchestrationpb.StartClientRequest{}
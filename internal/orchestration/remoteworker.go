@@ -80,6 +80,19 @@ func (w RemoteWorker) StopReplica(req *orchestrationpb.StopReplicaRequest) (res
 	return res, nil
 }
 
+// Reconfigure requests that the specified replicas of the remote worker authorize and propose the change.
+func (w RemoteWorker) Reconfigure(req *orchestrationpb.ReconfigureRequest) (res *orchestrationpb.ReconfigureResponse, err error) {
+	msg, err := w.rpc(req)
+	if err != nil {
+		return nil, err
+	}
+	res, ok := msg.(*orchestrationpb.ReconfigureResponse)
+	if !ok {
+		return nil, fmt.Errorf("wrong type for response message: got %T, wanted: %T", msg, res)
+	}
+	return res, nil
+}
+
 // StartClient requests that the remote worker starts the specified clients.
 func (w RemoteWorker) StartClient(req *orchestrationpb.StartClientRequest) (res *orchestrationpb.StartClientResponse, err error) {
 	msg, err := w.rpc(req)
@@ -7,12 +7,16 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"os"
+	"path/filepath"
 	"strconv"
 	"time"
 
 	"github.com/relab/gorums"
 	"github.com/relab/hotstuff"
+	backend "github.com/relab/hotstuff/backend/gorums"
 	"github.com/relab/hotstuff/blockchain"
+	"github.com/relab/hotstuff/blockchain/persistent"
 	"github.com/relab/hotstuff/client"
 	"github.com/relab/hotstuff/config"
 	"github.com/relab/hotstuff/consensus"
@@ -48,6 +52,7 @@ type Worker struct {
 	measurementInterval time.Duration
 
 	replicas map[hotstuff.ID]*replica.Replica
+	stores   map[hotstuff.ID]io.Closer // the block stores of the replicas, which are closed when the replicas stop
 	clients  map[hotstuff.ID]*client.Client
 }
 
@@ -67,6 +72,8 @@ func (w *Worker) Run() error {
 			res, err = w.startReplicas(req)
 		case *orchestrationpb.StopReplicaRequest:
 			res, err = w.stopReplicas(req)
+		case *orchestrationpb.ReconfigureRequest:
+			res, err = w.reconfigure(req)
 		case *orchestrationpb.StartClientRequest:
 			res, err = w.startClients(req)
 		case *orchestrationpb.StopClientRequest:
@@ -96,6 +103,7 @@ func NewWorker(send *protostream.Writer, recv *protostream.Reader, dl modules.Me
 		metrics:             metrics,
 		measurementInterval: measurementInterval,
 		replicas:            make(map[hotstuff.ID]*replica.Replica),
+		stores:              make(map[hotstuff.ID]io.Closer),
 		clients:             make(map[hotstuff.ID]*client.Client),
 	}
 }
@@ -129,11 +137,14 @@ func (w *Worker) createReplicas(req *orchestrationpb.CreateReplicaRequest) (*orc
 		r.StartServers(replicaListener, clientListener)
 		w.replicas[hotstuff.ID(cfg.GetID())] = r
 
+		genesis := r.Genesis().Hash()
 		resp.Replicas[cfg.GetID()] = &orchestrationpb.ReplicaInfo{
 			ID:          cfg.GetID(),
 			PublicKey:   cfg.GetPublicKey(),
 			ReplicaPort: replicaPort,
 			ClientPort:  clientPort,
+			Witness:     cfg.GetWitness(),
+			GenesisHash: genesis[:],
 		}
 	}
 	return resp, nil
@@ -159,6 +170,7 @@ func (w *Worker) createReplica(opts *orchestrationpb.ReplicaOpts) (*replica.Repl
 	}
 	// prepare modules
 	builder := consensus.NewBuilder(hotstuff.ID(opts.GetID()), privKey)
+	builder.WithGenesis(consensus.GenesisSpec{Command: consensus.Command(opts.GetGenesis())})
 
 	var consensusRules consensus.Rules
 	switch opts.GetConsensus() {
@@ -186,6 +198,8 @@ func (w *Worker) createReplica(opts *orchestrationpb.ReplicaOpts) (*replica.Repl
 	switch opts.GetCrypto() {
 	case "ecdsa":
 		cryptoImpl = ecdsa.New()
+	case "ecdsa-message":
+		cryptoImpl = ecdsa.NewMessageSigner()
 	case "bls12":
 		cryptoImpl = bls12.New()
 	default:
@@ -200,9 +214,20 @@ func (w *Worker) createReplica(opts *orchestrationpb.ReplicaOpts) (*replica.Repl
 		// TODO: consider making this configurable.
 		leaderRotation = leaderrotation.NewFixed(1)
 	case "rep":
-		leaderRotation = leaderrotation.NewRepBased()
+		leaderRotation = leaderrotation.NewRepBased(consensus.View(opts.GetLeaderWindow()), consensus.View(opts.GetLeaderDepth()))
+	case "rep-fallback":
+		leaderRotation = leaderrotation.NewFallback(
+			leaderrotation.NewRepBased(consensus.View(opts.GetLeaderWindow()), consensus.View(opts.GetLeaderDepth())),
+			leaderrotation.NewRoundRobin(),
+			leaderrotation.NewFixed(1),
+		)
+	case "liveness":
+		leaderRotation = leaderrotation.NewLivenessBased(consensus.View(opts.GetLeaderWindow()), consensus.View(opts.GetLeaderDepth()))
+	case "reputation":
+		leaderRotation = leaderrotation.NewReputation(consensus.View(opts.GetLeaderWindow()), consensus.View(opts.GetLeaderDepth()))
+	case "chain-seeded":
+		leaderRotation = leaderrotation.NewChainSeeded(consensus.View(opts.GetLeaderDepth()), leaderrotation.NewRoundRobin())
 	case "car":
-		fmt.Println(" --------------- CAR _-----------------------")
 		leaderRotation = leaderrotation.NewCarousel()
 	default:
 		return nil, fmt.Errorf("invalid leader-rotation algorithm: '%s'", opts.GetLeaderRotation())
@@ -215,14 +240,97 @@ func (w *Worker) createReplica(opts *orchestrationpb.ReplicaOpts) (*replica.Repl
 		float64(opts.GetTimeoutMultiplier()),
 	))
 
+	var chainOpts []blockchain.Option
+	if retention := opts.GetFetchRetention(); retention > 0 {
+		chainOpts = append(chainOpts, blockchain.WithFetchRetention(int(retention)))
+	}
+	if timeout := opts.GetFetchTimeout().AsDuration(); timeout > 0 {
+		chainOpts = append(chainOpts, blockchain.WithFetchRetry(timeout, opts.GetMaxFetchTimeout().AsDuration()))
+	}
+
+	chain, err := w.createBlockChain(hotstuff.ID(opts.GetID()), opts.GetBlockStore(), opts.GetBlockStoreType(), chainOpts)
+	if err != nil {
+		return nil, err
+	}
+
 	builder.Register(
 		consensus.New(consensusRules),
 		crypto.NewCache(cryptoImpl, 100), // TODO: consider making this configurable
 		leaderRotation,
 		sync,
 		w.metricsLogger,
-		blockchain.New(),
+		chain,
 	)
+	if interval := opts.GetAuditInterval().AsDuration(); interval > 0 {
+		builder.Register(blockchain.NewAuditor(interval, int(opts.GetAuditDepth())))
+	}
+
+	builder.Options().SetMaxFutureView(consensus.View(opts.GetMaxFutureView()))
+	builder.Options().SetMaxProposalSize(int(opts.GetMaxProposalSize()))
+	builder.Options().SetParentValidationDepth(int(opts.GetParentValidationDepth()))
+	builder.Options().SetVoteSigningWorkers(int(opts.GetVoteSigningWorkers()))
+	builder.Options().SetVoteCoalesceWindow(opts.GetVoteCoalesceWindow().AsDuration())
+	builder.Options().SetStepDownThreshold(int(opts.GetStepDownThreshold()))
+	builder.Options().SetMaxTimeoutViews(int(opts.GetMaxTimeoutViews()))
+	builder.Options().SetMaxTimeoutsPerView(int(opts.GetMaxTimeoutsPerView()))
+	if opts.GetDropAbandonedProposals() {
+		builder.Options().SetDropAbandonedProposals()
+	}
+	if opts.GetRelayQC() {
+		builder.Options().SetShouldRelayQC()
+	}
+	if opts.GetStrictTCView() {
+		builder.Options().SetStrictTimeoutCertView()
+	}
+	if opts.GetPiggybackTC() {
+		builder.Options().SetShouldPiggybackTC()
+	}
+	if opts.GetValidateViewSkips() {
+		builder.Options().SetValidateViewSkips()
+	}
+	if opts.GetVerifyProposer() {
+		builder.Options().SetShouldVerifyProposer()
+	}
+	if opts.GetAckProposals() {
+		builder.Options().SetShouldAckProposals()
+	}
+	if opts.GetAbstain() {
+		builder.Options().SetShouldAbstain()
+	}
+	if opts.GetAllowEmptyProposals() {
+		builder.Options().SetAllowEmptyProposals()
+	}
+	switch opts.GetQuorumWait() {
+	case "", "none":
+		builder.Options().SetQuorumWaitStrategy(consensus.QuorumWaitNone)
+	case "reachable":
+		builder.Options().SetQuorumWaitStrategy(consensus.QuorumWaitReachable)
+	default:
+		return nil, fmt.Errorf("invalid quorum wait strategy: '%s'", opts.GetQuorumWait())
+	}
+	switch opts.GetStaleCommands() {
+	case "", "ignore":
+		builder.Options().SetStaleCommandPolicy(consensus.StaleIgnore)
+	case "flag":
+		builder.Options().SetStaleCommandPolicy(consensus.StaleFlag)
+	case "reject":
+		builder.Options().SetStaleCommandPolicy(consensus.StaleReject)
+	default:
+		return nil, fmt.Errorf("invalid stale command policy: '%s'", opts.GetStaleCommands())
+	}
+	switch opts.GetFaultModel() {
+	case "", "byzantine":
+		builder.Options().SetFaultModel(hotstuff.Byzantine)
+	case "crash":
+		builder.Options().SetFaultModel(hotstuff.CrashOnly)
+	default:
+		return nil, fmt.Errorf("invalid fault model: '%s'", opts.GetFaultModel())
+	}
+	if opts.GetWitness() {
+		builder.Options().SetWitness()
+	}
+	builder.Options().SetRejectLogInterval(opts.GetRejectLogInterval().AsDuration())
+	builder.Options().SetResponsiveThreshold(opts.GetResponsiveThreshold().AsDuration())
 
 	if w.measurementInterval > 0 {
 		replicaMetrics := metrics.GetReplicaMetrics(w.metrics...)
@@ -231,21 +339,61 @@ func (w *Worker) createReplica(opts *orchestrationpb.ReplicaOpts) (*replica.Repl
 	}
 
 	c := replica.Config{
-		ID:          hotstuff.ID(opts.GetID()),
-		PrivateKey:  privKey,
-		TLS:         opts.GetUseTLS(),
-		Certificate: &certificate,
-		RootCAs:     rootCAs,
-		BatchSize:   opts.GetBatchSize(),
+		ID:                hotstuff.ID(opts.GetID()),
+		PrivateKey:        privKey,
+		TLS:               opts.GetUseTLS(),
+		Certificate:       &certificate,
+		RootCAs:           rootCAs,
+		BatchSize:         opts.GetBatchSize(),
+		WarmupView:        consensus.View(opts.GetWarmupView()),
+		OrderByTimestamp:  opts.GetOrderByTimestamp(),
+		PriorityLevels:    opts.GetPriorityLevels(),
+		PriorityAging:     opts.GetPriorityAging().AsDuration(),
+		CanonicalBatches:  opts.GetCanonicalBatches(),
+		ConfirmationDepth: opts.GetConfirmationDepth(),
 		ManagerOptions: []gorums.ManagerOption{
 			gorums.WithDialTimeout(opts.GetConnectTimeout().AsDuration()),
 			gorums.WithGrpcDialOptions(grpc.WithReturnConnectionError()),
 		},
 	}
 
+	if keepalive := opts.GetKeepaliveTime().AsDuration(); keepalive > 0 {
+		c.ManagerOptions = append(c.ManagerOptions, backend.WithKeepalive(keepalive, opts.GetKeepaliveTimeout().AsDuration()))
+		c.ReplicaServerOptions = append(c.ReplicaServerOptions, backend.WithKeepalivePolicy(keepalive))
+	}
+
 	return replica.New(c, builder), nil
 }
 
+// createBlockChain returns a blockchain that stores the blocks of the replica in a store of the given kind in the directory,
+// or an in-memory blockchain if the directory is empty.
+func (w *Worker) createBlockChain(id hotstuff.ID, dir, kind string, opts []blockchain.Option) (consensus.BlockChain, error) {
+	if dir == "" {
+		return blockchain.New(opts...), nil
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create block store directory: %w", err)
+	}
+	switch kind {
+	case "", "file":
+		store, err := persistent.OpenFileStore(filepath.Join(dir, fmt.Sprintf("replica-%d.blocks", id)))
+		if err != nil {
+			return nil, err
+		}
+		w.stores[id] = store
+		return persistent.New(store, opts...), nil
+	case "bolt":
+		chain, store, err := persistent.NewPersistentBlockChain(filepath.Join(dir, fmt.Sprintf("replica-%d.db", id)), opts...)
+		if err != nil {
+			return nil, err
+		}
+		w.stores[id] = store
+		return chain, nil
+	default:
+		return nil, fmt.Errorf("invalid block store type: '%s'", kind)
+	}
+}
+
 func (w *Worker) startReplicas(req *orchestrationpb.StartReplicaRequest) (*orchestrationpb.StartReplicaResponse, error) {
 	for _, id := range req.GetIDs() {
 		replica, ok := w.replicas[hotstuff.ID(id)]
@@ -279,11 +427,34 @@ func (w *Worker) stopReplicas(req *orchestrationpb.StopReplicaRequest) (*orchest
 		}
 		r.Stop()
 		res.Hashes[id] = r.GetHash()
+		if store, ok := w.stores[hotstuff.ID(id)]; ok {
+			if err := store.Close(); err != nil {
+				return nil, err
+			}
+			delete(w.stores, hotstuff.ID(id))
+		}
 		// TODO: return test results
 	}
 	return res, nil
 }
 
+func (w *Worker) reconfigure(req *orchestrationpb.ReconfigureRequest) (*orchestrationpb.ReconfigureResponse, error) {
+	change := consensus.Reconfiguration{
+		Remove:    req.GetRemove(),
+		ID:        hotstuff.ID(req.GetReplicaID()),
+		Address:   req.GetAddress(),
+		PublicKey: req.GetPublicKey(),
+	}
+	for _, id := range req.GetIDs() {
+		r, ok := w.replicas[hotstuff.ID(id)]
+		if !ok {
+			return nil, status.Errorf(codes.NotFound, "The replica with id %d was not found.", id)
+		}
+		r.Reconfigure(change)
+	}
+	return &orchestrationpb.ReconfigureResponse{}, nil
+}
+
 func (w *Worker) startClients(req *orchestrationpb.StartClientRequest) (*orchestrationpb.StartClientResponse, error) {
 	ca := req.GetCertificateAuthority()
 	cp := x509.NewCertPool()
@@ -305,6 +476,8 @@ func (w *Worker) startClients(req *orchestrationpb.StartClientRequest) (*orchest
 			RateLimit:        opts.GetRateLimit(),
 			RateStep:         opts.GetRateStep(),
 			RateStepInterval: opts.GetRateStepInterval().AsDuration(),
+			MaxCommandSize:   opts.GetMaxCommandSize(),
+			Priority:         opts.GetPriority(),
 		}
 		mods := modules.NewBuilder(c.ID)
 
@@ -360,6 +533,7 @@ func getConfiguration(id hotstuff.ID, conf map[uint32]*orchestrationpb.ReplicaIn
 			ID:      hotstuff.ID(replica.GetID()),
 			Address: addr,
 			PubKey:  pubKey,
+			Witness: replica.GetWitness(),
 		}
 	}
 	return cfg, nil
@@ -12,7 +12,6 @@ import (
 	gorums "github.com/relab/gorums"
 	encoding "google.golang.org/grpc/encoding"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
-	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
 const (
@@ -122,21 +121,40 @@ type Node struct {
 
 // ExecCommand sends a command to all replicas and waits for valid signatures
 // from f+1 replicas
-func (c *Configuration) ExecCommand(ctx context.Context, in *Command) *AsyncEmpty {
+func (c *Configuration) ExecCommand(ctx context.Context, in *Command) *AsyncResult {
 	cd := gorums.QuorumCallData{
 		Message: in,
 		Method:  "clientpb.Client.ExecCommand",
 	}
 	cd.QuorumFunction = func(req protoreflect.ProtoMessage, replies map[uint32]protoreflect.ProtoMessage) (protoreflect.ProtoMessage, bool) {
-		r := make(map[uint32]*emptypb.Empty, len(replies))
+		r := make(map[uint32]*Result, len(replies))
 		for k, v := range replies {
-			r[k] = v.(*emptypb.Empty)
+			r[k] = v.(*Result)
 		}
 		return c.qspec.ExecCommandQF(req.(*Command), r)
 	}
 
 	fut := c.Configuration.AsyncCall(ctx, cd)
-	return &AsyncEmpty{fut}
+	return &AsyncResult{fut}
+}
+
+// AwaitProposal waits until f+1 replicas have accepted a proposed block that
+// contains the command. It must be sent before the command itself.
+func (c *Configuration) AwaitProposal(ctx context.Context, in *Command) *AsyncAccepted {
+	cd := gorums.QuorumCallData{
+		Message: in,
+		Method:  "clientpb.Client.AwaitProposal",
+	}
+	cd.QuorumFunction = func(req protoreflect.ProtoMessage, replies map[uint32]protoreflect.ProtoMessage) (protoreflect.ProtoMessage, bool) {
+		r := make(map[uint32]*Accepted, len(replies))
+		for k, v := range replies {
+			r[k] = v.(*Accepted)
+		}
+		return c.qspec.AwaitProposalQF(req.(*Command), r)
+	}
+
+	fut := c.Configuration.AsyncCall(ctx, cd)
+	return &AsyncAccepted{fut}
 }
 
 // QuorumSpec is the interface of quorum functions for Client.
@@ -148,12 +166,20 @@ type QuorumSpec interface {
 	// supplied to the ExecCommand method at call time, and may or may not
 	// be used by the quorum function. If the in parameter is not needed
 	// you should implement your quorum function with '_ *Command'.
-	ExecCommandQF(in *Command, replies map[uint32]*emptypb.Empty) (*emptypb.Empty, bool)
+	ExecCommandQF(in *Command, replies map[uint32]*Result) (*Result, bool)
+
+	// AwaitProposalQF is the quorum function for the AwaitProposal
+	// asynchronous quorum call method. The in parameter is the request object
+	// supplied to the AwaitProposal method at call time, and may or may not
+	// be used by the quorum function. If the in parameter is not needed
+	// you should implement your quorum function with '_ *Command'.
+	AwaitProposalQF(in *Command, replies map[uint32]*Accepted) (*Accepted, bool)
 }
 
 // Client is the server-side API for the Client Service
 type Client interface {
-	ExecCommand(ctx gorums.ServerCtx, request *Command) (response *emptypb.Empty, err error)
+	ExecCommand(ctx gorums.ServerCtx, request *Command) (response *Result, err error)
+	AwaitProposal(ctx gorums.ServerCtx, request *Command) (response *Accepted, err error)
 }
 
 func RegisterClientServer(srv *gorums.Server, impl Client) {
@@ -163,25 +189,52 @@ func RegisterClientServer(srv *gorums.Server, impl Client) {
 		resp, err := impl.ExecCommand(ctx, req)
 		gorums.SendMessage(ctx, finished, gorums.WrapMessage(in.Metadata, resp, err))
 	})
+	srv.RegisterHandler("clientpb.Client.AwaitProposal", func(ctx gorums.ServerCtx, in *gorums.Message, finished chan<- *gorums.Message) {
+		req := in.Message.(*Command)
+		defer ctx.Release()
+		resp, err := impl.AwaitProposal(ctx, req)
+		gorums.SendMessage(ctx, finished, gorums.WrapMessage(in.Metadata, resp, err))
+	})
 }
 
-type internalEmpty struct {
+type internalAccepted struct {
 	nid   uint32
-	reply *emptypb.Empty
+	reply *Accepted
 	err   error
 }
 
-// AsyncEmpty is a async object for processing replies.
-type AsyncEmpty struct {
+type internalResult struct {
+	nid   uint32
+	reply *Result
+	err   error
+}
+
+// AsyncAccepted is a async object for processing replies.
+type AsyncAccepted struct {
+	*gorums.Async
+}
+
+// Get returns the reply and any error associated with the called method.
+// The method blocks until a reply or error is available.
+func (f *AsyncAccepted) Get() (*Accepted, error) {
+	resp, err := f.Async.Get()
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*Accepted), err
+}
+
+// AsyncResult is a async object for processing replies.
+type AsyncResult struct {
 	*gorums.Async
 }
 
 // Get returns the reply and any error associated with the called method.
 // The method blocks until a reply or error is available.
-func (f *AsyncEmpty) Get() (*emptypb.Empty, error) {
+func (f *AsyncResult) Get() (*Result, error) {
 	resp, err := f.Async.Get()
 	if err != nil {
 		return nil, err
 	}
-	return resp.(*emptypb.Empty), err
+	return resp.(*Result), err
 }
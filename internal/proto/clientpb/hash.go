@@ -0,0 +1,17 @@
+package clientpb
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+)
+
+// CommandHash returns the hash of the command, which identifies the command in the results sent by the replicas.
+func CommandHash(cmd *Command) []byte {
+	var buf [12]byte
+	binary.LittleEndian.PutUint32(buf[:4], cmd.GetClientID())
+	binary.LittleEndian.PutUint64(buf[4:], cmd.GetSequenceNumber())
+	h := sha256.New()
+	_, _ = h.Write(buf[:])
+	_, _ = h.Write(cmd.GetData())
+	return h.Sum(nil)
+}
@@ -0,0 +1,42 @@
+package hotstuffpb
+
+import (
+	"fmt"
+
+	"github.com/relab/hotstuff/consensus"
+	"google.golang.org/protobuf/proto"
+)
+
+// BlockEncodingVersion is the version of the block encoding produced by MarshalBlock.
+// It is stored in the first byte of the encoding, such that the encoding can be changed
+// without misinterpreting blocks that were stored by an earlier version.
+const BlockEncodingVersion = 1
+
+var blockMarshaler = proto.MarshalOptions{Deterministic: true}
+
+// MarshalBlock encodes the parent hash, view, command, proposer, and quorum certificate of the block.
+// The encoding is deterministic, so equal blocks are encoded to the same bytes.
+// The encoding is used to store and send blocks. The hash of a block is computed from Block.ToBytes.
+func MarshalBlock(block *consensus.Block) ([]byte, error) {
+	b, err := blockMarshaler.Marshal(BlockToProto(block))
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{BlockEncodingVersion}, b...), nil
+}
+
+// UnmarshalBlock decodes a block that was encoded by MarshalBlock.
+// The members are needed to identify the signers of the quorum certificate.
+func UnmarshalBlock(b []byte, members Members) (*consensus.Block, error) {
+	if len(b) == 0 {
+		return nil, fmt.Errorf("empty block encoding")
+	}
+	if b[0] != BlockEncodingVersion {
+		return nil, fmt.Errorf("unsupported block encoding version %d", b[0])
+	}
+	pb := new(Block)
+	if err := proto.Unmarshal(b[1:], pb); err != nil {
+		return nil, err
+	}
+	return BlockFromProto(pb, members), nil
+}
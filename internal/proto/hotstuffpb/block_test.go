@@ -0,0 +1,94 @@
+package hotstuffpb
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/crypto"
+	"github.com/relab/hotstuff/crypto/bls12"
+	"github.com/relab/hotstuff/crypto/ecdsa"
+	"github.com/relab/hotstuff/internal/testutil"
+)
+
+func TestMarshalBlock(t *testing.T) {
+	backends := []struct {
+		name    string
+		impl    func() consensus.CryptoImpl
+		keyFunc func(testing.TB) consensus.PrivateKey
+	}{
+		{"ECDSA", ecdsa.New, testutil.GenerateECDSAKey},
+		{"BLS12", bls12.New, testutil.GenerateBLS12Key},
+	}
+	for _, backend := range backends {
+		t.Run(backend.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			builders := testutil.CreateBuilders(t, ctrl, 4, testutil.GenerateKeys(t, 4, backend.keyFunc)...)
+			for i := range builders {
+				builders[i].Register(crypto.New(backend.impl()))
+			}
+			hl := builders.Build()
+
+			genesis := consensus.GetGenesis()
+			parent := consensus.NewBlock(genesis.Hash(), consensus.NewQuorumCert(nil, 0, genesis.Hash()), "foo", 1, 1)
+			pcs := testutil.CreatePCs(t, parent, hl.Signers())
+
+			// construct the same block twice, with QCs that are created independently.
+			newBlock := func() *consensus.Block {
+				qc, err := hl[0].Crypto().CreateQuorumCert(parent, pcs)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return consensus.NewBlock(parent.Hash(), qc, "bar", 2, 2)
+			}
+			a, b := newBlock(), newBlock()
+
+			encA, err := MarshalBlock(a)
+			if err != nil {
+				t.Fatal(err)
+			}
+			encB, err := MarshalBlock(b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(encA, encB) {
+				t.Error("equal blocks were encoded to different bytes")
+			}
+			if !bytes.Equal(a.ToBytes(), b.ToBytes()) || a.Hash() != b.Hash() {
+				t.Error("equal blocks have different hashes")
+			}
+
+			got, err := UnmarshalBlock(encA, ConfigurationMembers(hl[1].Configuration()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Hash() != a.Hash() || got.View() != a.View() || got.Proposer() != a.Proposer() ||
+				got.Parent() != a.Parent() || got.Command() != a.Command() {
+				t.Errorf("decoded block %v does not match the encoded block %v", got, a)
+			}
+			if !hl[1].Crypto().VerifyQuorumCert(got.QuorumCert()) {
+				t.Error("the QC of the decoded block could not be verified")
+			}
+		})
+	}
+}
+
+func TestUnmarshalBlockVersion(t *testing.T) {
+	genesis := consensus.GetGenesis()
+	b, err := MarshalBlock(genesis)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := UnmarshalBlock(b, nil); err != nil || got.Hash() != genesis.Hash() {
+		t.Errorf("failed to decode the genesis block: %v", err)
+	}
+
+	b[0] = BlockEncodingVersion + 1
+	if _, err := UnmarshalBlock(b, nil); err == nil {
+		t.Error("expected an error when decoding an unsupported version")
+	}
+	if _, err := UnmarshalBlock(nil, nil); err == nil {
+		t.Error("expected an error when decoding an empty slice")
+	}
+}
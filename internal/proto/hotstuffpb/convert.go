@@ -5,10 +5,24 @@ import (
 
 	"github.com/relab/hotstuff"
 	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/crypto"
 	"github.com/relab/hotstuff/crypto/bls12"
 	"github.com/relab/hotstuff/crypto/ecdsa"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// Members returns the canonical order of the replicas that participate in the view, as returned by consensus.SortedReplicas.
+// The signers of an ECDSA threshold signature are identified by their position in this order,
+// so it is needed to convert certificates from the protobuf types.
+type Members func(view consensus.View) []hotstuff.ID
+
+// ConfigurationMembers returns the canonical order of the replicas that participate in each view of the configuration.
+func ConfigurationMembers(cfg consensus.Configuration) Members {
+	return func(view consensus.View) []hotstuff.ID {
+		return consensus.SortedReplicas(consensus.MembershipAt(cfg, view))
+	}
+}
+
 // SignatureToProto converts a consensus.Signature to a hotstuffpb.Signature.
 func SignatureToProto(sig consensus.Signature) *Signature {
 	signature := &Signature{}
@@ -52,28 +66,31 @@ func ThresholdSignatureToProto(sig consensus.ThresholdSignature) *ThresholdSigna
 	signature := &ThresholdSignature{}
 	switch s := sig.(type) {
 	case ecdsa.ThresholdSignature:
-		sigs := make([]*ECDSASignature, 0, len(s))
-		for _, p := range s {
+		// the signers are identified by their position, so the signatures do not include the ID of the signer.
+		signers, signatures := s.Positions()
+		sigs := make([]*ECDSASignature, 0, len(signatures))
+		for _, p := range signatures {
 			sigs = append(sigs, &ECDSASignature{
-				Signer: uint32(p.Signer()),
-				R:      p.R().Bytes(),
-				S:      p.S().Bytes(),
+				R: p.R().Bytes(),
+				S: p.S().Bytes(),
 			})
 		}
 		signature.AggSig = &ThresholdSignature_ECDSASigs{ECDSASigs: &ECDSAThresholdSignature{
-			Sigs: sigs,
+			Sigs:    sigs,
+			Signers: signers,
 		}}
 	case *bls12.AggregateSignature:
 		signature.AggSig = &ThresholdSignature_BLS12Sig{BLS12Sig: &BLS12AggregateSignature{
-			Sig:          s.ToBytes(),
+			Sig:          s.Compressed(),
 			Participants: s.Bitfield(),
 		}}
 	}
 	return signature
 }
 
-// ThresholdSignatureFromProto converts a protocol buffers message to a threshold signature.
-func ThresholdSignatureFromProto(sig *ThresholdSignature) consensus.ThresholdSignature {
+// ThresholdSignatureFromProto converts a protocol buffers message to a threshold signature from the given view.
+// The members are only needed for ECDSA threshold signatures.
+func ThresholdSignatureFromProto(sig *ThresholdSignature, view consensus.View, members Members) consensus.ThresholdSignature {
 	if signature := sig.GetECDSASigs(); signature != nil {
 		sigs := make([]*ecdsa.Signature, len(signature.GetSigs()))
 		for i, sig := range signature.GetSigs() {
@@ -81,9 +98,13 @@ func ThresholdSignatureFromProto(sig *ThresholdSignature) consensus.ThresholdSig
 			r.SetBytes(sig.GetR())
 			s := new(big.Int)
 			s.SetBytes(sig.GetS())
-			sigs[i] = ecdsa.RestoreSignature(r, s, hotstuff.ID(sig.GetSigner()))
+			sigs[i] = ecdsa.RestoreSignature(r, s, 0)
 		}
-		return ecdsa.RestoreThresholdSignature(sigs)
+		thrSig, err := ecdsa.RestoreThresholdSignatureFromPositions(members(view), crypto.Bitfield(signature.GetSigners()), sigs)
+		if err != nil {
+			return nil
+		}
+		return thrSig
 	}
 	if signature := sig.GetBLS12Sig(); signature != nil {
 		aggSig, err := bls12.RestoreAggregateSignature(signature.GetSig(), signature.GetParticipants())
@@ -98,16 +119,24 @@ func ThresholdSignatureFromProto(sig *ThresholdSignature) consensus.ThresholdSig
 // PartialCertToProto converts a consensus.PartialCert to a hotstuffpb.Partialcert.
 func PartialCertToProto(cert consensus.PartialCert) *PartialCert {
 	hash := cert.BlockHash()
-	return &PartialCert{
+	pc := &PartialCert{
 		Sig:  SignatureToProto(cert.Signature()),
 		Hash: hash[:],
 	}
+	if auxSig := cert.AuxSignature(); auxSig != nil {
+		pc.Aux = cert.Aux()
+		pc.AuxSig = SignatureToProto(auxSig)
+	}
+	return pc
 }
 
 // PartialCertFromProto converts a hotstuffpb.PartialCert to an ecdsa.PartialCert.
 func PartialCertFromProto(cert *PartialCert) consensus.PartialCert {
 	var h consensus.Hash
 	copy(h[:], cert.GetHash())
+	if cert.GetAuxSig() != nil {
+		return consensus.NewPartialCertWithAux(SignatureFromProto(cert.GetSig()), h, cert.GetAux(), SignatureFromProto(cert.GetAuxSig()))
+	}
 	return consensus.NewPartialCert(SignatureFromProto(cert.GetSig()), h)
 }
 
@@ -122,10 +151,11 @@ func QuorumCertToProto(qc consensus.QuorumCert) *QuorumCert {
 }
 
 // QuorumCertFromProto converts a hotstuffpb.QuorumCert to an ecdsa.QuorumCert.
-func QuorumCertFromProto(qc *QuorumCert) consensus.QuorumCert {
+func QuorumCertFromProto(qc *QuorumCert, members Members) consensus.QuorumCert {
 	var h consensus.Hash
 	copy(h[:], qc.GetHash())
-	return consensus.NewQuorumCert(ThresholdSignatureFromProto(qc.GetSig()), consensus.View(qc.GetView()), h)
+	view := consensus.View(qc.GetView())
+	return consensus.NewQuorumCert(ThresholdSignatureFromProto(qc.GetSig(), view, members), view, h)
 }
 
 // ProposalToProto converts a ProposeMsg to a protobuf message.
@@ -136,16 +166,29 @@ func ProposalToProto(proposal consensus.ProposeMsg) *Proposal {
 	if proposal.AggregateQC != nil {
 		p.AggQC = AggregateQCToProto(*proposal.AggregateQC)
 	}
+	if proposal.TimeoutCert != nil {
+		p.TC = TimeoutCertToProto(*proposal.TimeoutCert)
+	}
+	if !proposal.Timestamp.IsZero() {
+		p.Timestamp = timestamppb.New(proposal.Timestamp)
+	}
 	return p
 }
 
 // ProposalFromProto converts a protobuf message to a ProposeMsg.
-func ProposalFromProto(p *Proposal) (proposal consensus.ProposeMsg) {
-	proposal.Block = BlockFromProto(p.GetBlock())
+func ProposalFromProto(p *Proposal, members Members) (proposal consensus.ProposeMsg) {
+	proposal.Block = BlockFromProto(p.GetBlock(), members)
 	if p.GetAggQC() != nil {
-		aggQC := AggregateQCFromProto(p.GetAggQC())
+		aggQC := AggregateQCFromProto(p.GetAggQC(), members)
 		proposal.AggregateQC = &aggQC
 	}
+	if p.GetTC() != nil {
+		tc := TimeoutCertFromProto(p.GetTC(), members)
+		proposal.TimeoutCert = &tc
+	}
+	if p.GetTimestamp() != nil {
+		proposal.Timestamp = p.GetTimestamp().AsTime()
+	}
 	return
 }
 
@@ -162,12 +205,12 @@ func BlockToProto(block *consensus.Block) *Block {
 }
 
 // BlockFromProto converts a hotstuffpb.Block to a consensus.Block.
-func BlockFromProto(block *Block) *consensus.Block {
+func BlockFromProto(block *Block, members Members) *consensus.Block {
 	var p consensus.Hash
 	copy(p[:], block.GetParent())
 	return consensus.NewBlock(
 		p,
-		QuorumCertFromProto(block.GetQC()),
+		QuorumCertFromProto(block.GetQC(), members),
 		consensus.Command(block.GetCommand()),
 		consensus.View(block.GetView()),
 		hotstuff.ID(block.GetProposer()),
@@ -175,10 +218,10 @@ func BlockFromProto(block *Block) *consensus.Block {
 }
 
 // TimeoutMsgFromProto converts a TimeoutMsg proto to the hotstuff type.
-func TimeoutMsgFromProto(m *TimeoutMsg) consensus.TimeoutMsg {
+func TimeoutMsgFromProto(m *TimeoutMsg, members Members) consensus.TimeoutMsg {
 	timeoutMsg := consensus.TimeoutMsg{
 		View:          consensus.View(m.GetView()),
-		SyncInfo:      SyncInfoFromProto(m.GetSyncInfo()),
+		SyncInfo:      SyncInfoFromProto(m.GetSyncInfo(), members),
 		ViewSignature: SignatureFromProto(m.GetViewSig()),
 	}
 	if m.GetViewSig() != nil {
@@ -201,25 +244,48 @@ func TimeoutMsgToProto(timeoutMsg consensus.TimeoutMsg) *TimeoutMsg {
 }
 
 // TimeoutCertFromProto converts a timeout certificate from the protobuf type to the hotstuff type.
-func TimeoutCertFromProto(m *TimeoutCert) consensus.TimeoutCert {
-	return consensus.NewTimeoutCert(ThresholdSignatureFromProto(m.GetSig()), consensus.View(m.GetView()))
+func TimeoutCertFromProto(m *TimeoutCert, members Members) consensus.TimeoutCert {
+	view := consensus.View(m.GetView())
+	tc := consensus.NewTimeoutCert(ThresholdSignatureFromProto(m.GetSig(), view, members), view)
+	if m.HighQC != nil {
+		tc = tc.WithHighQC(QuorumCertFromProto(m.GetHighQC(), members))
+	}
+	refs := make(map[hotstuff.ID]consensus.HighQCRef, len(m.GetHighQCs()))
+	for id, ref := range m.GetHighQCs() {
+		var hash consensus.Hash
+		copy(hash[:], ref.GetHash())
+		refs[hotstuff.ID(id)] = consensus.HighQCRef{View: consensus.View(ref.GetView()), Hash: hash}
+	}
+	return tc.WithHighQCRefs(refs)
 }
 
 // TimeoutCertToProto converts a timeout certificate from the hotstuff type to the protobuf type.
 func TimeoutCertToProto(timeoutCert consensus.TimeoutCert) *TimeoutCert {
-	return &TimeoutCert{
+	tc := &TimeoutCert{
 		View: uint64(timeoutCert.View()),
 		Sig:  ThresholdSignatureToProto(timeoutCert.Signature()),
 	}
+	if highQC, ok := timeoutCert.HighQC(); ok {
+		tc.HighQC = QuorumCertToProto(highQC)
+	}
+	if refs := timeoutCert.HighQCRefs(); len(refs) > 0 {
+		tc.HighQCs = make(map[uint32]*HighQCRef, len(refs))
+		for id, ref := range refs {
+			hash := ref.Hash
+			tc.HighQCs[uint32(id)] = &HighQCRef{View: uint64(ref.View), Hash: hash[:]}
+		}
+	}
+	return tc
 }
 
 // AggregateQCFromProto converts an AggregateQC from the protobuf type to the hotstuff type.
-func AggregateQCFromProto(m *AggQC) consensus.AggregateQC {
+func AggregateQCFromProto(m *AggQC, members Members) consensus.AggregateQC {
 	qcs := make(map[hotstuff.ID]consensus.QuorumCert)
 	for id, pQC := range m.GetQCs() {
-		qcs[hotstuff.ID(id)] = QuorumCertFromProto(pQC)
+		qcs[hotstuff.ID(id)] = QuorumCertFromProto(pQC, members)
 	}
-	return consensus.NewAggregateQC(qcs, ThresholdSignatureFromProto(m.GetSig()), consensus.View(m.GetView()))
+	view := consensus.View(m.GetView())
+	return consensus.NewAggregateQC(qcs, ThresholdSignatureFromProto(m.GetSig(), view, members), view)
 }
 
 // AggregateQCToProto converts an AggregateQC from the hotstuff type to the protobuf type.
@@ -232,16 +298,16 @@ func AggregateQCToProto(aggQC consensus.AggregateQC) *AggQC {
 }
 
 // SyncInfoFromProto converts a SyncInfo struct from the protobuf type to the hotstuff type.
-func SyncInfoFromProto(m *SyncInfo) consensus.SyncInfo {
+func SyncInfoFromProto(m *SyncInfo, members Members) consensus.SyncInfo {
 	si := consensus.NewSyncInfo()
 	if qc := m.GetQC(); qc != nil {
-		si = si.WithQC(QuorumCertFromProto(qc))
+		si = si.WithQC(QuorumCertFromProto(qc, members))
 	}
 	if tc := m.GetTC(); tc != nil {
-		si = si.WithTC(TimeoutCertFromProto(tc))
+		si = si.WithTC(TimeoutCertFromProto(tc, members))
 	}
 	if aggQC := m.GetAggQC(); aggQC != nil {
-		si = si.WithAggQC(AggregateQCFromProto(aggQC))
+		si = si.WithAggQC(AggregateQCFromProto(aggQC, members))
 	}
 	return si
 }
@@ -47,7 +47,13 @@ func TestConvertQuorumCert(t *testing.T) {
 	}
 
 	pb := QuorumCertToProto(want)
-	got := QuorumCertFromProto(pb)
+	// the signers are identified by their position in the bitfield.
+	for _, sig := range pb.GetSig().GetECDSASigs().GetSigs() {
+		if sig.GetSigner() != 0 {
+			t.Errorf("the signature of replica %d includes its ID", sig.GetSigner())
+		}
+	}
+	got := QuorumCertFromProto(pb, ConfigurationMembers(hl[1].Configuration()))
 
 	if !bytes.Equal(want.ToBytes(), got.ToBytes()) {
 		t.Error("Certificates don't match.")
@@ -58,7 +64,7 @@ func TestConvertBlock(t *testing.T) {
 	qc := consensus.NewQuorumCert(nil, 0, consensus.Hash{})
 	want := consensus.NewBlock(consensus.GetGenesis().Hash(), qc, "", 1, 1)
 	pb := BlockToProto(want)
-	got := BlockFromProto(pb)
+	got := BlockFromProto(pb, nil)
 
 	if want.Hash() != got.Hash() {
 		t.Error("Hashes don't match.")
@@ -77,7 +83,7 @@ func TestConvertTimeoutCertBLS12(t *testing.T) {
 	tc1 := testutil.CreateTC(t, 1, hl.Signers())
 
 	pb := TimeoutCertToProto(tc1)
-	tc2 := TimeoutCertFromProto(pb)
+	tc2 := TimeoutCertFromProto(pb, nil)
 
 	if !hl[0].Crypto().VerifyTimeoutCert(tc2) {
 		t.Fatal("Failed to verify timeout cert")
@@ -10,7 +10,9 @@ import (
 	_ "github.com/relab/gorums"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	anypb "google.golang.org/protobuf/types/known/anypb"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -29,6 +31,10 @@ type Proposal struct {
 
 	Block *Block `protobuf:"bytes,1,opt,name=Block,proto3" json:"Block,omitempty"`
 	AggQC *AggQC `protobuf:"bytes,2,opt,name=AggQC,proto3,oneof" json:"AggQC,omitempty"`
+	// The time at which the proposal was sent, according to the sender's clock.
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=Timestamp,proto3" json:"Timestamp,omitempty"`
+	// The timeout certificate that moved the leader to the view of the proposal.
+	TC *TimeoutCert `protobuf:"bytes,4,opt,name=TC,proto3,oneof" json:"TC,omitempty"`
 }
 
 func (x *Proposal) Reset() {
@@ -77,6 +83,20 @@ func (x *Proposal) GetAggQC() *AggQC {
 	return nil
 }
 
+func (x *Proposal) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *Proposal) GetTC() *TimeoutCert {
+	if x != nil {
+		return x.TC
+	}
+	return nil
+}
+
 type BlockHash struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -124,6 +144,110 @@ func (x *BlockHash) GetHash() []byte {
 	return nil
 }
 
+// AbstainMsg tells the leader that a proposal was received, but not voted for.
+type AbstainMsg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	View uint64 `protobuf:"varint,1,opt,name=View,proto3" json:"View,omitempty"`
+	Hash []byte `protobuf:"bytes,2,opt,name=Hash,proto3" json:"Hash,omitempty"`
+}
+
+func (x *AbstainMsg) Reset() {
+	*x = AbstainMsg{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AbstainMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AbstainMsg) ProtoMessage() {}
+
+func (x *AbstainMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AbstainMsg.ProtoReflect.Descriptor instead.
+func (*AbstainMsg) Descriptor() ([]byte, []int) {
+	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AbstainMsg) GetView() uint64 {
+	if x != nil {
+		return x.View
+	}
+	return 0
+}
+
+func (x *AbstainMsg) GetHash() []byte {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+// CustomMessage carries an application-specific message.
+type CustomMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message *anypb.Any `protobuf:"bytes,1,opt,name=Message,proto3" json:"Message,omitempty"`
+}
+
+func (x *CustomMessage) Reset() {
+	*x = CustomMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CustomMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomMessage) ProtoMessage() {}
+
+func (x *CustomMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustomMessage.ProtoReflect.Descriptor instead.
+func (*CustomMessage) Descriptor() ([]byte, []int) {
+	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CustomMessage) GetMessage() *anypb.Any {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
 type Block struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -139,7 +263,7 @@ type Block struct {
 func (x *Block) Reset() {
 	*x = Block{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[2]
+		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -152,7 +276,7 @@ func (x *Block) String() string {
 func (*Block) ProtoMessage() {}
 
 func (x *Block) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[2]
+	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -165,7 +289,7 @@ func (x *Block) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Block.ProtoReflect.Descriptor instead.
 func (*Block) Descriptor() ([]byte, []int) {
-	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{2}
+	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *Block) GetParent() []byte {
@@ -216,7 +340,7 @@ type ECDSASignature struct {
 func (x *ECDSASignature) Reset() {
 	*x = ECDSASignature{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[3]
+		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -229,7 +353,7 @@ func (x *ECDSASignature) String() string {
 func (*ECDSASignature) ProtoMessage() {}
 
 func (x *ECDSASignature) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[3]
+	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -242,7 +366,7 @@ func (x *ECDSASignature) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ECDSASignature.ProtoReflect.Descriptor instead.
 func (*ECDSASignature) Descriptor() ([]byte, []int) {
-	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{3}
+	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ECDSASignature) GetSigner() uint32 {
@@ -277,7 +401,7 @@ type BLS12Signature struct {
 func (x *BLS12Signature) Reset() {
 	*x = BLS12Signature{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[4]
+		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -290,7 +414,7 @@ func (x *BLS12Signature) String() string {
 func (*BLS12Signature) ProtoMessage() {}
 
 func (x *BLS12Signature) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[4]
+	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -303,7 +427,7 @@ func (x *BLS12Signature) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BLS12Signature.ProtoReflect.Descriptor instead.
 func (*BLS12Signature) Descriptor() ([]byte, []int) {
-	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{4}
+	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *BLS12Signature) GetSig() []byte {
@@ -327,7 +451,7 @@ type Signature struct {
 func (x *Signature) Reset() {
 	*x = Signature{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[5]
+		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -340,7 +464,7 @@ func (x *Signature) String() string {
 func (*Signature) ProtoMessage() {}
 
 func (x *Signature) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[5]
+	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -353,7 +477,7 @@ func (x *Signature) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Signature.ProtoReflect.Descriptor instead.
 func (*Signature) Descriptor() ([]byte, []int) {
-	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{5}
+	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{7}
 }
 
 func (m *Signature) GetSig() isSignature_Sig {
@@ -400,12 +524,15 @@ type PartialCert struct {
 
 	Sig  *Signature `protobuf:"bytes,1,opt,name=Sig,proto3" json:"Sig,omitempty"`
 	Hash []byte     `protobuf:"bytes,2,opt,name=Hash,proto3" json:"Hash,omitempty"`
+	// Optional auxiliary data, signed by AuxSig.
+	Aux    []byte     `protobuf:"bytes,3,opt,name=Aux,proto3" json:"Aux,omitempty"`
+	AuxSig *Signature `protobuf:"bytes,4,opt,name=AuxSig,proto3" json:"AuxSig,omitempty"`
 }
 
 func (x *PartialCert) Reset() {
 	*x = PartialCert{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[6]
+		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -418,7 +545,7 @@ func (x *PartialCert) String() string {
 func (*PartialCert) ProtoMessage() {}
 
 func (x *PartialCert) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[6]
+	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -431,7 +558,7 @@ func (x *PartialCert) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PartialCert.ProtoReflect.Descriptor instead.
 func (*PartialCert) Descriptor() ([]byte, []int) {
-	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{6}
+	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *PartialCert) GetSig() *Signature {
@@ -448,18 +575,36 @@ func (x *PartialCert) GetHash() []byte {
 	return nil
 }
 
+func (x *PartialCert) GetAux() []byte {
+	if x != nil {
+		return x.Aux
+	}
+	return nil
+}
+
+func (x *PartialCert) GetAuxSig() *Signature {
+	if x != nil {
+		return x.AuxSig
+	}
+	return nil
+}
+
+// The signers are identified by their position in the canonical order of the
+// replicas in the view of the certificate, and are encoded as a bitfield. The
+// signatures are in the same order, and do not include the ID of the signer.
 type ECDSAThresholdSignature struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Sigs []*ECDSASignature `protobuf:"bytes,1,rep,name=Sigs,proto3" json:"Sigs,omitempty"`
+	Sigs    []*ECDSASignature `protobuf:"bytes,1,rep,name=Sigs,proto3" json:"Sigs,omitempty"`
+	Signers []byte            `protobuf:"bytes,2,opt,name=Signers,proto3" json:"Signers,omitempty"`
 }
 
 func (x *ECDSAThresholdSignature) Reset() {
 	*x = ECDSAThresholdSignature{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[7]
+		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -472,7 +617,7 @@ func (x *ECDSAThresholdSignature) String() string {
 func (*ECDSAThresholdSignature) ProtoMessage() {}
 
 func (x *ECDSAThresholdSignature) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[7]
+	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -485,7 +630,7 @@ func (x *ECDSAThresholdSignature) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ECDSAThresholdSignature.ProtoReflect.Descriptor instead.
 func (*ECDSAThresholdSignature) Descriptor() ([]byte, []int) {
-	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{7}
+	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ECDSAThresholdSignature) GetSigs() []*ECDSASignature {
@@ -495,6 +640,13 @@ func (x *ECDSAThresholdSignature) GetSigs() []*ECDSASignature {
 	return nil
 }
 
+func (x *ECDSAThresholdSignature) GetSigners() []byte {
+	if x != nil {
+		return x.Signers
+	}
+	return nil
+}
+
 type BLS12AggregateSignature struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -507,7 +659,7 @@ type BLS12AggregateSignature struct {
 func (x *BLS12AggregateSignature) Reset() {
 	*x = BLS12AggregateSignature{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[8]
+		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -520,7 +672,7 @@ func (x *BLS12AggregateSignature) String() string {
 func (*BLS12AggregateSignature) ProtoMessage() {}
 
 func (x *BLS12AggregateSignature) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[8]
+	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -533,7 +685,7 @@ func (x *BLS12AggregateSignature) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BLS12AggregateSignature.ProtoReflect.Descriptor instead.
 func (*BLS12AggregateSignature) Descriptor() ([]byte, []int) {
-	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{8}
+	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *BLS12AggregateSignature) GetSig() []byte {
@@ -564,7 +716,7 @@ type ThresholdSignature struct {
 func (x *ThresholdSignature) Reset() {
 	*x = ThresholdSignature{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[9]
+		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -577,7 +729,7 @@ func (x *ThresholdSignature) String() string {
 func (*ThresholdSignature) ProtoMessage() {}
 
 func (x *ThresholdSignature) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[9]
+	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -590,7 +742,7 @@ func (x *ThresholdSignature) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ThresholdSignature.ProtoReflect.Descriptor instead.
 func (*ThresholdSignature) Descriptor() ([]byte, []int) {
-	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{9}
+	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{11}
 }
 
 func (m *ThresholdSignature) GetAggSig() isThresholdSignature_AggSig {
@@ -643,7 +795,7 @@ type QuorumCert struct {
 func (x *QuorumCert) Reset() {
 	*x = QuorumCert{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[10]
+		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -656,7 +808,7 @@ func (x *QuorumCert) String() string {
 func (*QuorumCert) ProtoMessage() {}
 
 func (x *QuorumCert) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[10]
+	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -669,7 +821,7 @@ func (x *QuorumCert) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QuorumCert.ProtoReflect.Descriptor instead.
 func (*QuorumCert) Descriptor() ([]byte, []int) {
-	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{10}
+	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *QuorumCert) GetSig() *ThresholdSignature {
@@ -700,12 +852,16 @@ type TimeoutCert struct {
 
 	Sig  *ThresholdSignature `protobuf:"bytes,1,opt,name=Sig,proto3" json:"Sig,omitempty"`
 	View uint64              `protobuf:"varint,2,opt,name=View,proto3" json:"View,omitempty"`
+	// The highest QC from the timeout messages.
+	HighQC *QuorumCert `protobuf:"bytes,3,opt,name=HighQC,proto3,oneof" json:"HighQC,omitempty"`
+	// The QCs that the timeout messages were sent with, indexed by the ID of the sender.
+	HighQCs map[uint32]*HighQCRef `protobuf:"bytes,4,rep,name=HighQCs,proto3" json:"HighQCs,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
 func (x *TimeoutCert) Reset() {
 	*x = TimeoutCert{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[11]
+		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -718,7 +874,7 @@ func (x *TimeoutCert) String() string {
 func (*TimeoutCert) ProtoMessage() {}
 
 func (x *TimeoutCert) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[11]
+	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -731,7 +887,7 @@ func (x *TimeoutCert) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TimeoutCert.ProtoReflect.Descriptor instead.
 func (*TimeoutCert) Descriptor() ([]byte, []int) {
-	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{11}
+	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *TimeoutCert) GetSig() *ThresholdSignature {
@@ -748,6 +904,76 @@ func (x *TimeoutCert) GetView() uint64 {
 	return 0
 }
 
+func (x *TimeoutCert) GetHighQC() *QuorumCert {
+	if x != nil {
+		return x.HighQC
+	}
+	return nil
+}
+
+func (x *TimeoutCert) GetHighQCs() map[uint32]*HighQCRef {
+	if x != nil {
+		return x.HighQCs
+	}
+	return nil
+}
+
+// HighQCRef identifies a QC by its view and the hash of the certified block.
+type HighQCRef struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	View uint64 `protobuf:"varint,1,opt,name=View,proto3" json:"View,omitempty"`
+	Hash []byte `protobuf:"bytes,2,opt,name=Hash,proto3" json:"Hash,omitempty"`
+}
+
+func (x *HighQCRef) Reset() {
+	*x = HighQCRef{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HighQCRef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HighQCRef) ProtoMessage() {}
+
+func (x *HighQCRef) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HighQCRef.ProtoReflect.Descriptor instead.
+func (*HighQCRef) Descriptor() ([]byte, []int) {
+	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *HighQCRef) GetView() uint64 {
+	if x != nil {
+		return x.View
+	}
+	return 0
+}
+
+func (x *HighQCRef) GetHash() []byte {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
 type TimeoutMsg struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -762,7 +988,7 @@ type TimeoutMsg struct {
 func (x *TimeoutMsg) Reset() {
 	*x = TimeoutMsg{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[12]
+		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -775,7 +1001,7 @@ func (x *TimeoutMsg) String() string {
 func (*TimeoutMsg) ProtoMessage() {}
 
 func (x *TimeoutMsg) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[12]
+	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -788,7 +1014,7 @@ func (x *TimeoutMsg) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TimeoutMsg.ProtoReflect.Descriptor instead.
 func (*TimeoutMsg) Descriptor() ([]byte, []int) {
-	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{12}
+	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *TimeoutMsg) GetView() uint64 {
@@ -832,7 +1058,7 @@ type SyncInfo struct {
 func (x *SyncInfo) Reset() {
 	*x = SyncInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[13]
+		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -845,7 +1071,7 @@ func (x *SyncInfo) String() string {
 func (*SyncInfo) ProtoMessage() {}
 
 func (x *SyncInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[13]
+	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -858,7 +1084,7 @@ func (x *SyncInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SyncInfo.ProtoReflect.Descriptor instead.
 func (*SyncInfo) Descriptor() ([]byte, []int) {
-	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{13}
+	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *SyncInfo) GetQC() *QuorumCert {
@@ -895,7 +1121,7 @@ type AggQC struct {
 func (x *AggQC) Reset() {
 	*x = AggQC{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[14]
+		mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -908,7 +1134,7 @@ func (x *AggQC) String() string {
 func (*AggQC) ProtoMessage() {}
 
 func (x *AggQC) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[14]
+	mi := &file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -921,7 +1147,7 @@ func (x *AggQC) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AggQC.ProtoReflect.Descriptor instead.
 func (*AggQC) Descriptor() ([]byte, []int) {
-	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{14}
+	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *AggQC) GetQCs() map[uint32]*QuorumCert {
@@ -952,135 +1178,188 @@ var file_internal_proto_hotstuffpb_hotstuff_proto_rawDesc = []byte{
 	0x2f, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2f, 0x68, 0x6f, 0x74, 0x73,
 	0x74, 0x75, 0x66, 0x66, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x68, 0x6f, 0x74, 0x73,
 	0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x1a, 0x0c, 0x67, 0x6f, 0x72, 0x75, 0x6d, 0x73, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x22, 0x6b, 0x0a, 0x08, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x12, 0x27, 0x0a,
-	0x05, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x68,
-	0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52,
-	0x05, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x2c, 0x0a, 0x05, 0x41, 0x67, 0x67, 0x51, 0x43, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66,
-	0x70, 0x62, 0x2e, 0x41, 0x67, 0x67, 0x51, 0x43, 0x48, 0x00, 0x52, 0x05, 0x41, 0x67, 0x67, 0x51,
-	0x43, 0x88, 0x01, 0x01, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x41, 0x67, 0x67, 0x51, 0x43, 0x22, 0x1f,
-	0x0a, 0x09, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x48,
-	0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x48, 0x61, 0x73, 0x68, 0x22,
-	0x91, 0x01, 0x0a, 0x05, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x16, 0x0a, 0x06, 0x50, 0x61, 0x72,
-	0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x50, 0x61, 0x72, 0x65, 0x6e,
-	0x74, 0x12, 0x26, 0x0a, 0x02, 0x51, 0x43, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e,
-	0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x51, 0x75, 0x6f, 0x72, 0x75,
-	0x6d, 0x43, 0x65, 0x72, 0x74, 0x52, 0x02, 0x51, 0x43, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x69, 0x65,
-	0x77, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x56, 0x69, 0x65, 0x77, 0x12, 0x18, 0x0a,
-	0x07, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07,
-	0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x50, 0x72, 0x6f, 0x70, 0x6f,
-	0x73, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x50, 0x72, 0x6f, 0x70, 0x6f,
-	0x73, 0x65, 0x72, 0x22, 0x44, 0x0a, 0x0e, 0x45, 0x43, 0x44, 0x53, 0x41, 0x53, 0x69, 0x67, 0x6e,
-	0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x12, 0x0c, 0x0a,
-	0x01, 0x52, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x52, 0x12, 0x0c, 0x0a, 0x01, 0x53,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x53, 0x22, 0x22, 0x0a, 0x0e, 0x42, 0x4c, 0x53,
-	0x31, 0x32, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x53,
-	0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x53, 0x69, 0x67, 0x22, 0x86, 0x01,
-	0x0a, 0x09, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x38, 0x0a, 0x08, 0x45,
-	0x43, 0x44, 0x53, 0x41, 0x53, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
-	0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x45, 0x43, 0x44, 0x53, 0x41,
-	0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x48, 0x00, 0x52, 0x08, 0x45, 0x43, 0x44,
-	0x53, 0x41, 0x53, 0x69, 0x67, 0x12, 0x38, 0x0a, 0x08, 0x42, 0x4c, 0x53, 0x31, 0x32, 0x53, 0x69,
-	0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75,
-	0x66, 0x66, 0x70, 0x62, 0x2e, 0x42, 0x4c, 0x53, 0x31, 0x32, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74,
-	0x75, 0x72, 0x65, 0x48, 0x00, 0x52, 0x08, 0x42, 0x4c, 0x53, 0x31, 0x32, 0x53, 0x69, 0x67, 0x42,
-	0x05, 0x0a, 0x03, 0x53, 0x69, 0x67, 0x22, 0x4a, 0x0a, 0x0b, 0x50, 0x61, 0x72, 0x74, 0x69, 0x61,
-	0x6c, 0x43, 0x65, 0x72, 0x74, 0x12, 0x27, 0x0a, 0x03, 0x53, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e,
-	0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x03, 0x53, 0x69, 0x67, 0x12, 0x12,
-	0x0a, 0x04, 0x48, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x48, 0x61,
-	0x73, 0x68, 0x22, 0x49, 0x0a, 0x17, 0x45, 0x43, 0x44, 0x53, 0x41, 0x54, 0x68, 0x72, 0x65, 0x73,
-	0x68, 0x6f, 0x6c, 0x64, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x2e, 0x0a,
-	0x04, 0x53, 0x69, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x68, 0x6f,
-	0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x45, 0x43, 0x44, 0x53, 0x41, 0x53, 0x69,
-	0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x04, 0x53, 0x69, 0x67, 0x73, 0x22, 0x4f, 0x0a,
-	0x17, 0x42, 0x4c, 0x53, 0x31, 0x32, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x53,
-	0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x53, 0x69, 0x67, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x53, 0x69, 0x67, 0x12, 0x22, 0x0a, 0x0c, 0x70, 0x61,
-	0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x0c, 0x70, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x73, 0x22, 0xa6,
-	0x01, 0x0a, 0x12, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x53, 0x69, 0x67, 0x6e,
-	0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x43, 0x0a, 0x09, 0x45, 0x43, 0x44, 0x53, 0x41, 0x53, 0x69,
-	0x67, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74,
-	0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x45, 0x43, 0x44, 0x53, 0x41, 0x54, 0x68, 0x72, 0x65, 0x73,
-	0x68, 0x6f, 0x6c, 0x64, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x48, 0x00, 0x52,
-	0x09, 0x45, 0x43, 0x44, 0x53, 0x41, 0x53, 0x69, 0x67, 0x73, 0x12, 0x41, 0x0a, 0x08, 0x42, 0x4c,
-	0x53, 0x31, 0x32, 0x53, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x68,
-	0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x42, 0x4c, 0x53, 0x31, 0x32, 0x41,
-	0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
-	0x65, 0x48, 0x00, 0x52, 0x08, 0x42, 0x4c, 0x53, 0x31, 0x32, 0x53, 0x69, 0x67, 0x42, 0x08, 0x0a,
-	0x06, 0x41, 0x67, 0x67, 0x53, 0x69, 0x67, 0x22, 0x66, 0x0a, 0x0a, 0x51, 0x75, 0x6f, 0x72, 0x75,
-	0x6d, 0x43, 0x65, 0x72, 0x74, 0x12, 0x30, 0x0a, 0x03, 0x53, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e,
+	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x19, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x61, 0x6e, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
+	0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xda, 0x01,
+	0x0a, 0x08, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x12, 0x27, 0x0a, 0x05, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x68, 0x6f, 0x74, 0x73,
+	0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x05, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x12, 0x2c, 0x0a, 0x05, 0x41, 0x67, 0x67, 0x51, 0x43, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x11, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e,
+	0x41, 0x67, 0x67, 0x51, 0x43, 0x48, 0x00, 0x52, 0x05, 0x41, 0x67, 0x67, 0x51, 0x43, 0x88, 0x01,
+	0x01, 0x12, 0x38, 0x0a, 0x09, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x09, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x2c, 0x0a, 0x02, 0x54,
+	0x43, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75,
+	0x66, 0x66, 0x70, 0x62, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x43, 0x65, 0x72, 0x74,
+	0x48, 0x01, 0x52, 0x02, 0x54, 0x43, 0x88, 0x01, 0x01, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x41, 0x67,
+	0x67, 0x51, 0x43, 0x42, 0x05, 0x0a, 0x03, 0x5f, 0x54, 0x43, 0x22, 0x1f, 0x0a, 0x09, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x48, 0x61, 0x73, 0x68, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x48, 0x61, 0x73, 0x68, 0x22, 0x34, 0x0a, 0x0a, 0x41,
+	0x62, 0x73, 0x74, 0x61, 0x69, 0x6e, 0x4d, 0x73, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x69, 0x65,
+	0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x56, 0x69, 0x65, 0x77, 0x12, 0x12, 0x0a,
+	0x04, 0x48, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x48, 0x61, 0x73,
+	0x68, 0x22, 0x3f, 0x0a, 0x0d, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x2e, 0x0a, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x22, 0x91, 0x01, 0x0a, 0x05, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x16, 0x0a, 0x06,
+	0x50, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x50, 0x61,
+	0x72, 0x65, 0x6e, 0x74, 0x12, 0x26, 0x0a, 0x02, 0x51, 0x43, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x16, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x51, 0x75,
+	0x6f, 0x72, 0x75, 0x6d, 0x43, 0x65, 0x72, 0x74, 0x52, 0x02, 0x51, 0x43, 0x12, 0x12, 0x0a, 0x04,
+	0x56, 0x69, 0x65, 0x77, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x56, 0x69, 0x65, 0x77,
+	0x12, 0x18, 0x0a, 0x07, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x07, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x50, 0x72,
+	0x6f, 0x70, 0x6f, 0x73, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x50, 0x72,
+	0x6f, 0x70, 0x6f, 0x73, 0x65, 0x72, 0x22, 0x44, 0x0a, 0x0e, 0x45, 0x43, 0x44, 0x53, 0x41, 0x53,
+	0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x69, 0x67, 0x6e,
+	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x72,
+	0x12, 0x0c, 0x0a, 0x01, 0x52, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x52, 0x12, 0x0c,
+	0x0a, 0x01, 0x53, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x53, 0x22, 0x22, 0x0a, 0x0e,
+	0x42, 0x4c, 0x53, 0x31, 0x32, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x10,
+	0x0a, 0x03, 0x53, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x53, 0x69, 0x67,
+	0x22, 0x86, 0x01, 0x0a, 0x09, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x38,
+	0x0a, 0x08, 0x45, 0x43, 0x44, 0x53, 0x41, 0x53, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x45, 0x43,
+	0x44, 0x53, 0x41, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x48, 0x00, 0x52, 0x08,
+	0x45, 0x43, 0x44, 0x53, 0x41, 0x53, 0x69, 0x67, 0x12, 0x38, 0x0a, 0x08, 0x42, 0x4c, 0x53, 0x31,
+	0x32, 0x53, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x68, 0x6f, 0x74,
+	0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x42, 0x4c, 0x53, 0x31, 0x32, 0x53, 0x69, 0x67,
+	0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x48, 0x00, 0x52, 0x08, 0x42, 0x4c, 0x53, 0x31, 0x32, 0x53,
+	0x69, 0x67, 0x42, 0x05, 0x0a, 0x03, 0x53, 0x69, 0x67, 0x22, 0x8b, 0x01, 0x0a, 0x0b, 0x50, 0x61,
+	0x72, 0x74, 0x69, 0x61, 0x6c, 0x43, 0x65, 0x72, 0x74, 0x12, 0x27, 0x0a, 0x03, 0x53, 0x69, 0x67,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66,
+	0x66, 0x70, 0x62, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x03, 0x53,
+	0x69, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x48, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x04, 0x48, 0x61, 0x73, 0x68, 0x12, 0x10, 0x0a, 0x03, 0x41, 0x75, 0x78, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x03, 0x41, 0x75, 0x78, 0x12, 0x2d, 0x0a, 0x06, 0x41, 0x75, 0x78, 0x53,
+	0x69, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74,
+	0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52,
+	0x06, 0x41, 0x75, 0x78, 0x53, 0x69, 0x67, 0x22, 0x63, 0x0a, 0x17, 0x45, 0x43, 0x44, 0x53, 0x41,
 	0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
-	0x72, 0x65, 0x52, 0x03, 0x53, 0x69, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x69, 0x65, 0x77, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x56, 0x69, 0x65, 0x77, 0x12, 0x12, 0x0a, 0x04, 0x48,
-	0x61, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x48, 0x61, 0x73, 0x68, 0x22,
-	0x53, 0x0a, 0x0b, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x43, 0x65, 0x72, 0x74, 0x12, 0x30,
+	0x72, 0x65, 0x12, 0x2e, 0x0a, 0x04, 0x53, 0x69, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x45, 0x43,
+	0x44, 0x53, 0x41, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x04, 0x53, 0x69,
+	0x67, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x07, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x73, 0x22, 0x4f, 0x0a, 0x17,
+	0x42, 0x4c, 0x53, 0x31, 0x32, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x53, 0x69,
+	0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x53, 0x69, 0x67, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x53, 0x69, 0x67, 0x12, 0x22, 0x0a, 0x0c, 0x70, 0x61, 0x72,
+	0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x0c, 0x70, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x73, 0x22, 0xa6, 0x01,
+	0x0a, 0x12, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x53, 0x69, 0x67, 0x6e, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x12, 0x43, 0x0a, 0x09, 0x45, 0x43, 0x44, 0x53, 0x41, 0x53, 0x69, 0x67,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75,
+	0x66, 0x66, 0x70, 0x62, 0x2e, 0x45, 0x43, 0x44, 0x53, 0x41, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68,
+	0x6f, 0x6c, 0x64, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x48, 0x00, 0x52, 0x09,
+	0x45, 0x43, 0x44, 0x53, 0x41, 0x53, 0x69, 0x67, 0x73, 0x12, 0x41, 0x0a, 0x08, 0x42, 0x4c, 0x53,
+	0x31, 0x32, 0x53, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x68, 0x6f,
+	0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x42, 0x4c, 0x53, 0x31, 0x32, 0x41, 0x67,
+	0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x48, 0x00, 0x52, 0x08, 0x42, 0x4c, 0x53, 0x31, 0x32, 0x53, 0x69, 0x67, 0x42, 0x08, 0x0a, 0x06,
+	0x41, 0x67, 0x67, 0x53, 0x69, 0x67, 0x22, 0x66, 0x0a, 0x0a, 0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d,
+	0x43, 0x65, 0x72, 0x74, 0x12, 0x30, 0x0a, 0x03, 0x53, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1e, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x54,
+	0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x52, 0x03, 0x53, 0x69, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x69, 0x65, 0x77, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x56, 0x69, 0x65, 0x77, 0x12, 0x12, 0x0a, 0x04, 0x48, 0x61,
+	0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x48, 0x61, 0x73, 0x68, 0x22, 0xa6,
+	0x02, 0x0a, 0x0b, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x43, 0x65, 0x72, 0x74, 0x12, 0x30,
 	0x0a, 0x03, 0x53, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x68, 0x6f,
 	0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f,
 	0x6c, 0x64, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x03, 0x53, 0x69, 0x67,
 	0x12, 0x12, 0x0a, 0x04, 0x56, 0x69, 0x65, 0x77, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04,
-	0x56, 0x69, 0x65, 0x77, 0x22, 0xc2, 0x01, 0x0a, 0x0a, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74,
-	0x4d, 0x73, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x69, 0x65, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x04, 0x52, 0x04, 0x56, 0x69, 0x65, 0x77, 0x12, 0x30, 0x0a, 0x08, 0x53, 0x79, 0x6e, 0x63, 0x49,
-	0x6e, 0x66, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x68, 0x6f, 0x74, 0x73,
-	0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x49, 0x6e, 0x66, 0x6f, 0x52,
-	0x08, 0x53, 0x79, 0x6e, 0x63, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x2f, 0x0a, 0x07, 0x56, 0x69, 0x65,
-	0x77, 0x53, 0x69, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x68, 0x6f, 0x74,
-	0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
-	0x65, 0x52, 0x07, 0x56, 0x69, 0x65, 0x77, 0x53, 0x69, 0x67, 0x12, 0x32, 0x0a, 0x06, 0x4d, 0x73,
-	0x67, 0x53, 0x69, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x68, 0x6f, 0x74,
-	0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
-	0x65, 0x48, 0x00, 0x52, 0x06, 0x4d, 0x73, 0x67, 0x53, 0x69, 0x67, 0x88, 0x01, 0x01, 0x42, 0x09,
-	0x0a, 0x07, 0x5f, 0x4d, 0x73, 0x67, 0x53, 0x69, 0x67, 0x22, 0xab, 0x01, 0x0a, 0x08, 0x53, 0x79,
-	0x6e, 0x63, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x2b, 0x0a, 0x02, 0x51, 0x43, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x16, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e,
-	0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x43, 0x65, 0x72, 0x74, 0x48, 0x00, 0x52, 0x02, 0x51, 0x43,
-	0x88, 0x01, 0x01, 0x12, 0x2c, 0x0a, 0x02, 0x54, 0x43, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x17, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x54, 0x69, 0x6d,
-	0x65, 0x6f, 0x75, 0x74, 0x43, 0x65, 0x72, 0x74, 0x48, 0x01, 0x52, 0x02, 0x54, 0x43, 0x88, 0x01,
-	0x01, 0x12, 0x2c, 0x0a, 0x05, 0x41, 0x67, 0x67, 0x51, 0x43, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x11, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x41, 0x67,
-	0x67, 0x51, 0x43, 0x48, 0x02, 0x52, 0x05, 0x41, 0x67, 0x67, 0x51, 0x43, 0x88, 0x01, 0x01, 0x42,
-	0x05, 0x0a, 0x03, 0x5f, 0x51, 0x43, 0x42, 0x05, 0x0a, 0x03, 0x5f, 0x54, 0x43, 0x42, 0x08, 0x0a,
-	0x06, 0x5f, 0x41, 0x67, 0x67, 0x51, 0x43, 0x22, 0xcb, 0x01, 0x0a, 0x05, 0x41, 0x67, 0x67, 0x51,
-	0x43, 0x12, 0x2c, 0x0a, 0x03, 0x51, 0x43, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a,
-	0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x41, 0x67, 0x67, 0x51,
-	0x43, 0x2e, 0x51, 0x43, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x03, 0x51, 0x43, 0x73, 0x12,
-	0x30, 0x0a, 0x03, 0x53, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x68,
-	0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68,
-	0x6f, 0x6c, 0x64, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x03, 0x53, 0x69,
-	0x67, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x69, 0x65, 0x77, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52,
-	0x04, 0x56, 0x69, 0x65, 0x77, 0x1a, 0x4e, 0x0a, 0x08, 0x51, 0x43, 0x73, 0x45, 0x6e, 0x74, 0x72,
-	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03,
-	0x6b, 0x65, 0x79, 0x12, 0x2c, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x16, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e,
-	0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x43, 0x65, 0x72, 0x74, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x3a, 0x02, 0x38, 0x01, 0x32, 0xc1, 0x02, 0x0a, 0x08, 0x48, 0x6f, 0x74, 0x73, 0x74, 0x75,
-	0x66, 0x66, 0x12, 0x3d, 0x0a, 0x07, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x12, 0x14, 0x2e,
-	0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x50, 0x72, 0x6f, 0x70, 0x6f,
-	0x73, 0x61, 0x6c, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x04, 0x98, 0xb5, 0x18,
-	0x01, 0x12, 0x3d, 0x0a, 0x04, 0x56, 0x6f, 0x74, 0x65, 0x12, 0x17, 0x2e, 0x68, 0x6f, 0x74, 0x73,
-	0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x43, 0x65,
-	0x72, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x04, 0x90, 0xb5, 0x18, 0x01,
-	0x12, 0x3f, 0x0a, 0x07, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x16, 0x2e, 0x68, 0x6f,
-	0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74,
-	0x4d, 0x73, 0x67, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x04, 0x98, 0xb5, 0x18,
-	0x01, 0x12, 0x3d, 0x0a, 0x07, 0x4e, 0x65, 0x77, 0x56, 0x69, 0x65, 0x77, 0x12, 0x14, 0x2e, 0x68,
-	0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x49, 0x6e,
-	0x66, 0x6f, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x04, 0x90, 0xb5, 0x18, 0x01,
-	0x12, 0x37, 0x0a, 0x05, 0x46, 0x65, 0x74, 0x63, 0x68, 0x12, 0x15, 0x2e, 0x68, 0x6f, 0x74, 0x73,
-	0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68,
-	0x1a, 0x11, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x42, 0x6c,
-	0x6f, 0x63, 0x6b, 0x22, 0x04, 0xa0, 0xb5, 0x18, 0x01, 0x42, 0x35, 0x5a, 0x33, 0x67, 0x69, 0x74,
+	0x56, 0x69, 0x65, 0x77, 0x12, 0x33, 0x0a, 0x06, 0x48, 0x69, 0x67, 0x68, 0x51, 0x43, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70,
+	0x62, 0x2e, 0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x43, 0x65, 0x72, 0x74, 0x48, 0x00, 0x52, 0x06,
+	0x48, 0x69, 0x67, 0x68, 0x51, 0x43, 0x88, 0x01, 0x01, 0x12, 0x3e, 0x0a, 0x07, 0x48, 0x69, 0x67,
+	0x68, 0x51, 0x43, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x68, 0x6f, 0x74,
+	0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x43,
+	0x65, 0x72, 0x74, 0x2e, 0x48, 0x69, 0x67, 0x68, 0x51, 0x43, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x07, 0x48, 0x69, 0x67, 0x68, 0x51, 0x43, 0x73, 0x1a, 0x51, 0x0a, 0x0c, 0x48, 0x69, 0x67,
+	0x68, 0x51, 0x43, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2b, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x68, 0x6f, 0x74,
+	0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x48, 0x69, 0x67, 0x68, 0x51, 0x43, 0x52, 0x65,
+	0x66, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x09, 0x0a, 0x07,
+	0x5f, 0x48, 0x69, 0x67, 0x68, 0x51, 0x43, 0x22, 0x33, 0x0a, 0x09, 0x48, 0x69, 0x67, 0x68, 0x51,
+	0x43, 0x52, 0x65, 0x66, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x69, 0x65, 0x77, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x04, 0x56, 0x69, 0x65, 0x77, 0x12, 0x12, 0x0a, 0x04, 0x48, 0x61, 0x73, 0x68,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x48, 0x61, 0x73, 0x68, 0x22, 0xc2, 0x01, 0x0a,
+	0x0a, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x4d, 0x73, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x56,
+	0x69, 0x65, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x56, 0x69, 0x65, 0x77, 0x12,
+	0x30, 0x0a, 0x08, 0x53, 0x79, 0x6e, 0x63, 0x49, 0x6e, 0x66, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x14, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x53,
+	0x79, 0x6e, 0x63, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x08, 0x53, 0x79, 0x6e, 0x63, 0x49, 0x6e, 0x66,
+	0x6f, 0x12, 0x2f, 0x0a, 0x07, 0x56, 0x69, 0x65, 0x77, 0x53, 0x69, 0x67, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e,
+	0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x07, 0x56, 0x69, 0x65, 0x77, 0x53,
+	0x69, 0x67, 0x12, 0x32, 0x0a, 0x06, 0x4d, 0x73, 0x67, 0x53, 0x69, 0x67, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e,
+	0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x48, 0x00, 0x52, 0x06, 0x4d, 0x73, 0x67,
+	0x53, 0x69, 0x67, 0x88, 0x01, 0x01, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x4d, 0x73, 0x67, 0x53, 0x69,
+	0x67, 0x22, 0xab, 0x01, 0x0a, 0x08, 0x53, 0x79, 0x6e, 0x63, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x2b,
+	0x0a, 0x02, 0x51, 0x43, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x68, 0x6f, 0x74,
+	0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x43, 0x65,
+	0x72, 0x74, 0x48, 0x00, 0x52, 0x02, 0x51, 0x43, 0x88, 0x01, 0x01, 0x12, 0x2c, 0x0a, 0x02, 0x54,
+	0x43, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75,
+	0x66, 0x66, 0x70, 0x62, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x43, 0x65, 0x72, 0x74,
+	0x48, 0x01, 0x52, 0x02, 0x54, 0x43, 0x88, 0x01, 0x01, 0x12, 0x2c, 0x0a, 0x05, 0x41, 0x67, 0x67,
+	0x51, 0x43, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74,
+	0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x41, 0x67, 0x67, 0x51, 0x43, 0x48, 0x02, 0x52, 0x05, 0x41,
+	0x67, 0x67, 0x51, 0x43, 0x88, 0x01, 0x01, 0x42, 0x05, 0x0a, 0x03, 0x5f, 0x51, 0x43, 0x42, 0x05,
+	0x0a, 0x03, 0x5f, 0x54, 0x43, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x41, 0x67, 0x67, 0x51, 0x43, 0x22,
+	0xcb, 0x01, 0x0a, 0x05, 0x41, 0x67, 0x67, 0x51, 0x43, 0x12, 0x2c, 0x0a, 0x03, 0x51, 0x43, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66,
+	0x66, 0x70, 0x62, 0x2e, 0x41, 0x67, 0x67, 0x51, 0x43, 0x2e, 0x51, 0x43, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x03, 0x51, 0x43, 0x73, 0x12, 0x30, 0x0a, 0x03, 0x53, 0x69, 0x67, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70,
+	0x62, 0x2e, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x53, 0x69, 0x67, 0x6e, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x52, 0x03, 0x53, 0x69, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x69, 0x65,
+	0x77, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x56, 0x69, 0x65, 0x77, 0x1a, 0x4e, 0x0a,
+	0x08, 0x51, 0x43, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2c, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x68, 0x6f, 0x74,
+	0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x43, 0x65,
+	0x72, 0x74, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x32, 0x89, 0x04,
+	0x0a, 0x08, 0x48, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x12, 0x3d, 0x0a, 0x07, 0x50, 0x72,
+	0x6f, 0x70, 0x6f, 0x73, 0x65, 0x12, 0x14, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66,
+	0x70, 0x62, 0x2e, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x1a, 0x16, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x22, 0x04, 0x98, 0xb5, 0x18, 0x01, 0x12, 0x3d, 0x0a, 0x04, 0x56, 0x6f, 0x74,
+	0x65, 0x12, 0x17, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x50,
+	0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x43, 0x65, 0x72, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x22, 0x04, 0x90, 0xb5, 0x18, 0x01, 0x12, 0x3f, 0x0a, 0x07, 0x54, 0x69, 0x6d, 0x65,
+	0x6f, 0x75, 0x74, 0x12, 0x16, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x4d, 0x73, 0x67, 0x1a, 0x16, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x22, 0x04, 0x98, 0xb5, 0x18, 0x01, 0x12, 0x3d, 0x0a, 0x07, 0x4e, 0x65, 0x77,
+	0x56, 0x69, 0x65, 0x77, 0x12, 0x14, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70,
+	0x62, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x49, 0x6e, 0x66, 0x6f, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x22, 0x04, 0x90, 0xb5, 0x18, 0x01, 0x12, 0x37, 0x0a, 0x05, 0x46, 0x65, 0x74, 0x63,
+	0x68, 0x12, 0x15, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x1a, 0x11, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74,
+	0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x22, 0x04, 0xa0, 0xb5, 0x18,
+	0x01, 0x12, 0x42, 0x0a, 0x0b, 0x41, 0x63, 0x6b, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c,
+	0x12, 0x15, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22,
+	0x04, 0x90, 0xb5, 0x18, 0x01, 0x12, 0x41, 0x0a, 0x06, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x12,
+	0x19, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62, 0x2e, 0x43, 0x75, 0x73,
+	0x74, 0x6f, 0x6d, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x22, 0x04, 0x90, 0xb5, 0x18, 0x01, 0x12, 0x3f, 0x0a, 0x07, 0x41, 0x62, 0x73, 0x74,
+	0x61, 0x69, 0x6e, 0x12, 0x16, 0x2e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62,
+	0x2e, 0x41, 0x62, 0x73, 0x74, 0x61, 0x69, 0x6e, 0x4d, 0x73, 0x67, 0x1a, 0x16, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x22, 0x04, 0x90, 0xb5, 0x18, 0x01, 0x42, 0x35, 0x5a, 0x33, 0x67, 0x69, 0x74,
 	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x72, 0x65, 0x6c, 0x61, 0x62, 0x2f, 0x68, 0x6f,
 	0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x70, 0x62,
@@ -1099,62 +1378,81 @@ func file_internal_proto_hotstuffpb_hotstuff_proto_rawDescGZIP() []byte {
 	return file_internal_proto_hotstuffpb_hotstuff_proto_rawDescData
 }
 
-var file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
 var file_internal_proto_hotstuffpb_hotstuff_proto_goTypes = []interface{}{
 	(*Proposal)(nil),                // 0: hotstuffpb.Proposal
 	(*BlockHash)(nil),               // 1: hotstuffpb.BlockHash
-	(*Block)(nil),                   // 2: hotstuffpb.Block
-	(*ECDSASignature)(nil),          // 3: hotstuffpb.ECDSASignature
-	(*BLS12Signature)(nil),          // 4: hotstuffpb.BLS12Signature
-	(*Signature)(nil),               // 5: hotstuffpb.Signature
-	(*PartialCert)(nil),             // 6: hotstuffpb.PartialCert
-	(*ECDSAThresholdSignature)(nil), // 7: hotstuffpb.ECDSAThresholdSignature
-	(*BLS12AggregateSignature)(nil), // 8: hotstuffpb.BLS12AggregateSignature
-	(*ThresholdSignature)(nil),      // 9: hotstuffpb.ThresholdSignature
-	(*QuorumCert)(nil),              // 10: hotstuffpb.QuorumCert
-	(*TimeoutCert)(nil),             // 11: hotstuffpb.TimeoutCert
-	(*TimeoutMsg)(nil),              // 12: hotstuffpb.TimeoutMsg
-	(*SyncInfo)(nil),                // 13: hotstuffpb.SyncInfo
-	(*AggQC)(nil),                   // 14: hotstuffpb.AggQC
-	nil,                             // 15: hotstuffpb.AggQC.QCsEntry
-	(*emptypb.Empty)(nil),           // 16: google.protobuf.Empty
+	(*AbstainMsg)(nil),              // 2: hotstuffpb.AbstainMsg
+	(*CustomMessage)(nil),           // 3: hotstuffpb.CustomMessage
+	(*Block)(nil),                   // 4: hotstuffpb.Block
+	(*ECDSASignature)(nil),          // 5: hotstuffpb.ECDSASignature
+	(*BLS12Signature)(nil),          // 6: hotstuffpb.BLS12Signature
+	(*Signature)(nil),               // 7: hotstuffpb.Signature
+	(*PartialCert)(nil),             // 8: hotstuffpb.PartialCert
+	(*ECDSAThresholdSignature)(nil), // 9: hotstuffpb.ECDSAThresholdSignature
+	(*BLS12AggregateSignature)(nil), // 10: hotstuffpb.BLS12AggregateSignature
+	(*ThresholdSignature)(nil),      // 11: hotstuffpb.ThresholdSignature
+	(*QuorumCert)(nil),              // 12: hotstuffpb.QuorumCert
+	(*TimeoutCert)(nil),             // 13: hotstuffpb.TimeoutCert
+	(*HighQCRef)(nil),               // 14: hotstuffpb.HighQCRef
+	(*TimeoutMsg)(nil),              // 15: hotstuffpb.TimeoutMsg
+	(*SyncInfo)(nil),                // 16: hotstuffpb.SyncInfo
+	(*AggQC)(nil),                   // 17: hotstuffpb.AggQC
+	nil,                             // 18: hotstuffpb.TimeoutCert.HighQCsEntry
+	nil,                             // 19: hotstuffpb.AggQC.QCsEntry
+	(*timestamppb.Timestamp)(nil),   // 20: google.protobuf.Timestamp
+	(*anypb.Any)(nil),               // 21: google.protobuf.Any
+	(*emptypb.Empty)(nil),           // 22: google.protobuf.Empty
 }
 var file_internal_proto_hotstuffpb_hotstuff_proto_depIdxs = []int32{
-	2,  // 0: hotstuffpb.Proposal.Block:type_name -> hotstuffpb.Block
-	14, // 1: hotstuffpb.Proposal.AggQC:type_name -> hotstuffpb.AggQC
-	10, // 2: hotstuffpb.Block.QC:type_name -> hotstuffpb.QuorumCert
-	3,  // 3: hotstuffpb.Signature.ECDSASig:type_name -> hotstuffpb.ECDSASignature
-	4,  // 4: hotstuffpb.Signature.BLS12Sig:type_name -> hotstuffpb.BLS12Signature
-	5,  // 5: hotstuffpb.PartialCert.Sig:type_name -> hotstuffpb.Signature
-	3,  // 6: hotstuffpb.ECDSAThresholdSignature.Sigs:type_name -> hotstuffpb.ECDSASignature
-	7,  // 7: hotstuffpb.ThresholdSignature.ECDSASigs:type_name -> hotstuffpb.ECDSAThresholdSignature
-	8,  // 8: hotstuffpb.ThresholdSignature.BLS12Sig:type_name -> hotstuffpb.BLS12AggregateSignature
-	9,  // 9: hotstuffpb.QuorumCert.Sig:type_name -> hotstuffpb.ThresholdSignature
-	9,  // 10: hotstuffpb.TimeoutCert.Sig:type_name -> hotstuffpb.ThresholdSignature
-	13, // 11: hotstuffpb.TimeoutMsg.SyncInfo:type_name -> hotstuffpb.SyncInfo
-	5,  // 12: hotstuffpb.TimeoutMsg.ViewSig:type_name -> hotstuffpb.Signature
-	5,  // 13: hotstuffpb.TimeoutMsg.MsgSig:type_name -> hotstuffpb.Signature
-	10, // 14: hotstuffpb.SyncInfo.QC:type_name -> hotstuffpb.QuorumCert
-	11, // 15: hotstuffpb.SyncInfo.TC:type_name -> hotstuffpb.TimeoutCert
-	14, // 16: hotstuffpb.SyncInfo.AggQC:type_name -> hotstuffpb.AggQC
-	15, // 17: hotstuffpb.AggQC.QCs:type_name -> hotstuffpb.AggQC.QCsEntry
-	9,  // 18: hotstuffpb.AggQC.Sig:type_name -> hotstuffpb.ThresholdSignature
-	10, // 19: hotstuffpb.AggQC.QCsEntry.value:type_name -> hotstuffpb.QuorumCert
-	0,  // 20: hotstuffpb.Hotstuff.Propose:input_type -> hotstuffpb.Proposal
-	6,  // 21: hotstuffpb.Hotstuff.Vote:input_type -> hotstuffpb.PartialCert
-	12, // 22: hotstuffpb.Hotstuff.Timeout:input_type -> hotstuffpb.TimeoutMsg
-	13, // 23: hotstuffpb.Hotstuff.NewView:input_type -> hotstuffpb.SyncInfo
-	1,  // 24: hotstuffpb.Hotstuff.Fetch:input_type -> hotstuffpb.BlockHash
-	16, // 25: hotstuffpb.Hotstuff.Propose:output_type -> google.protobuf.Empty
-	16, // 26: hotstuffpb.Hotstuff.Vote:output_type -> google.protobuf.Empty
-	16, // 27: hotstuffpb.Hotstuff.Timeout:output_type -> google.protobuf.Empty
-	16, // 28: hotstuffpb.Hotstuff.NewView:output_type -> google.protobuf.Empty
-	2,  // 29: hotstuffpb.Hotstuff.Fetch:output_type -> hotstuffpb.Block
-	25, // [25:30] is the sub-list for method output_type
-	20, // [20:25] is the sub-list for method input_type
-	20, // [20:20] is the sub-list for extension type_name
-	20, // [20:20] is the sub-list for extension extendee
-	0,  // [0:20] is the sub-list for field type_name
+	4,  // 0: hotstuffpb.Proposal.Block:type_name -> hotstuffpb.Block
+	17, // 1: hotstuffpb.Proposal.AggQC:type_name -> hotstuffpb.AggQC
+	20, // 2: hotstuffpb.Proposal.Timestamp:type_name -> google.protobuf.Timestamp
+	13, // 3: hotstuffpb.Proposal.TC:type_name -> hotstuffpb.TimeoutCert
+	21, // 4: hotstuffpb.CustomMessage.Message:type_name -> google.protobuf.Any
+	12, // 5: hotstuffpb.Block.QC:type_name -> hotstuffpb.QuorumCert
+	5,  // 6: hotstuffpb.Signature.ECDSASig:type_name -> hotstuffpb.ECDSASignature
+	6,  // 7: hotstuffpb.Signature.BLS12Sig:type_name -> hotstuffpb.BLS12Signature
+	7,  // 8: hotstuffpb.PartialCert.Sig:type_name -> hotstuffpb.Signature
+	7,  // 9: hotstuffpb.PartialCert.AuxSig:type_name -> hotstuffpb.Signature
+	5,  // 10: hotstuffpb.ECDSAThresholdSignature.Sigs:type_name -> hotstuffpb.ECDSASignature
+	9,  // 11: hotstuffpb.ThresholdSignature.ECDSASigs:type_name -> hotstuffpb.ECDSAThresholdSignature
+	10, // 12: hotstuffpb.ThresholdSignature.BLS12Sig:type_name -> hotstuffpb.BLS12AggregateSignature
+	11, // 13: hotstuffpb.QuorumCert.Sig:type_name -> hotstuffpb.ThresholdSignature
+	11, // 14: hotstuffpb.TimeoutCert.Sig:type_name -> hotstuffpb.ThresholdSignature
+	12, // 15: hotstuffpb.TimeoutCert.HighQC:type_name -> hotstuffpb.QuorumCert
+	18, // 16: hotstuffpb.TimeoutCert.HighQCs:type_name -> hotstuffpb.TimeoutCert.HighQCsEntry
+	16, // 17: hotstuffpb.TimeoutMsg.SyncInfo:type_name -> hotstuffpb.SyncInfo
+	7,  // 18: hotstuffpb.TimeoutMsg.ViewSig:type_name -> hotstuffpb.Signature
+	7,  // 19: hotstuffpb.TimeoutMsg.MsgSig:type_name -> hotstuffpb.Signature
+	12, // 20: hotstuffpb.SyncInfo.QC:type_name -> hotstuffpb.QuorumCert
+	13, // 21: hotstuffpb.SyncInfo.TC:type_name -> hotstuffpb.TimeoutCert
+	17, // 22: hotstuffpb.SyncInfo.AggQC:type_name -> hotstuffpb.AggQC
+	19, // 23: hotstuffpb.AggQC.QCs:type_name -> hotstuffpb.AggQC.QCsEntry
+	11, // 24: hotstuffpb.AggQC.Sig:type_name -> hotstuffpb.ThresholdSignature
+	14, // 25: hotstuffpb.TimeoutCert.HighQCsEntry.value:type_name -> hotstuffpb.HighQCRef
+	12, // 26: hotstuffpb.AggQC.QCsEntry.value:type_name -> hotstuffpb.QuorumCert
+	0,  // 27: hotstuffpb.Hotstuff.Propose:input_type -> hotstuffpb.Proposal
+	8,  // 28: hotstuffpb.Hotstuff.Vote:input_type -> hotstuffpb.PartialCert
+	15, // 29: hotstuffpb.Hotstuff.Timeout:input_type -> hotstuffpb.TimeoutMsg
+	16, // 30: hotstuffpb.Hotstuff.NewView:input_type -> hotstuffpb.SyncInfo
+	1,  // 31: hotstuffpb.Hotstuff.Fetch:input_type -> hotstuffpb.BlockHash
+	1,  // 32: hotstuffpb.Hotstuff.AckProposal:input_type -> hotstuffpb.BlockHash
+	3,  // 33: hotstuffpb.Hotstuff.Custom:input_type -> hotstuffpb.CustomMessage
+	2,  // 34: hotstuffpb.Hotstuff.Abstain:input_type -> hotstuffpb.AbstainMsg
+	22, // 35: hotstuffpb.Hotstuff.Propose:output_type -> google.protobuf.Empty
+	22, // 36: hotstuffpb.Hotstuff.Vote:output_type -> google.protobuf.Empty
+	22, // 37: hotstuffpb.Hotstuff.Timeout:output_type -> google.protobuf.Empty
+	22, // 38: hotstuffpb.Hotstuff.NewView:output_type -> google.protobuf.Empty
+	4,  // 39: hotstuffpb.Hotstuff.Fetch:output_type -> hotstuffpb.Block
+	22, // 40: hotstuffpb.Hotstuff.AckProposal:output_type -> google.protobuf.Empty
+	22, // 41: hotstuffpb.Hotstuff.Custom:output_type -> google.protobuf.Empty
+	22, // 42: hotstuffpb.Hotstuff.Abstain:output_type -> google.protobuf.Empty
+	35, // [35:43] is the sub-list for method output_type
+	27, // [27:35] is the sub-list for method input_type
+	27, // [27:27] is the sub-list for extension type_name
+	27, // [27:27] is the sub-list for extension extendee
+	0,  // [0:27] is the sub-list for field type_name
 }
 
 func init() { file_internal_proto_hotstuffpb_hotstuff_proto_init() }
@@ -1188,7 +1486,7 @@ func file_internal_proto_hotstuffpb_hotstuff_proto_init() {
 			}
 		}
 		file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Block); i {
+			switch v := v.(*AbstainMsg); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1200,7 +1498,7 @@ func file_internal_proto_hotstuffpb_hotstuff_proto_init() {
 			}
 		}
 		file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ECDSASignature); i {
+			switch v := v.(*CustomMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1212,7 +1510,7 @@ func file_internal_proto_hotstuffpb_hotstuff_proto_init() {
 			}
 		}
 		file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BLS12Signature); i {
+			switch v := v.(*Block); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1224,7 +1522,7 @@ func file_internal_proto_hotstuffpb_hotstuff_proto_init() {
 			}
 		}
 		file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Signature); i {
+			switch v := v.(*ECDSASignature); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1236,7 +1534,7 @@ func file_internal_proto_hotstuffpb_hotstuff_proto_init() {
 			}
 		}
 		file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PartialCert); i {
+			switch v := v.(*BLS12Signature); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1248,7 +1546,7 @@ func file_internal_proto_hotstuffpb_hotstuff_proto_init() {
 			}
 		}
 		file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ECDSAThresholdSignature); i {
+			switch v := v.(*Signature); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1260,7 +1558,7 @@ func file_internal_proto_hotstuffpb_hotstuff_proto_init() {
 			}
 		}
 		file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BLS12AggregateSignature); i {
+			switch v := v.(*PartialCert); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1272,7 +1570,7 @@ func file_internal_proto_hotstuffpb_hotstuff_proto_init() {
 			}
 		}
 		file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ThresholdSignature); i {
+			switch v := v.(*ECDSAThresholdSignature); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1284,7 +1582,7 @@ func file_internal_proto_hotstuffpb_hotstuff_proto_init() {
 			}
 		}
 		file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*QuorumCert); i {
+			switch v := v.(*BLS12AggregateSignature); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1296,7 +1594,7 @@ func file_internal_proto_hotstuffpb_hotstuff_proto_init() {
 			}
 		}
 		file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TimeoutCert); i {
+			switch v := v.(*ThresholdSignature); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1308,7 +1606,7 @@ func file_internal_proto_hotstuffpb_hotstuff_proto_init() {
 			}
 		}
 		file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TimeoutMsg); i {
+			switch v := v.(*QuorumCert); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1320,7 +1618,7 @@ func file_internal_proto_hotstuffpb_hotstuff_proto_init() {
 			}
 		}
 		file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SyncInfo); i {
+			switch v := v.(*TimeoutCert); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1332,6 +1630,42 @@ func file_internal_proto_hotstuffpb_hotstuff_proto_init() {
 			}
 		}
 		file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HighQCRef); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TimeoutMsg); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SyncInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*AggQC); i {
 			case 0:
 				return &v.state
@@ -1345,23 +1679,24 @@ func file_internal_proto_hotstuffpb_hotstuff_proto_init() {
 		}
 	}
 	file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[0].OneofWrappers = []interface{}{}
-	file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[5].OneofWrappers = []interface{}{
+	file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[7].OneofWrappers = []interface{}{
 		(*Signature_ECDSASig)(nil),
 		(*Signature_BLS12Sig)(nil),
 	}
-	file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[9].OneofWrappers = []interface{}{
+	file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[11].OneofWrappers = []interface{}{
 		(*ThresholdSignature_ECDSASigs)(nil),
 		(*ThresholdSignature_BLS12Sig)(nil),
 	}
-	file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[12].OneofWrappers = []interface{}{}
 	file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[13].OneofWrappers = []interface{}{}
+	file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[15].OneofWrappers = []interface{}{}
+	file_internal_proto_hotstuffpb_hotstuff_proto_msgTypes[16].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_internal_proto_hotstuffpb_hotstuff_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   16,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -189,6 +189,9 @@ type Hotstuff interface {
 	Timeout(ctx gorums.ServerCtx, request *TimeoutMsg)
 	NewView(ctx gorums.ServerCtx, request *SyncInfo)
 	Fetch(ctx gorums.ServerCtx, request *BlockHash) (response *Block, err error)
+	AckProposal(ctx gorums.ServerCtx, request *BlockHash)
+	Custom(ctx gorums.ServerCtx, request *CustomMessage)
+	Abstain(ctx gorums.ServerCtx, request *AbstainMsg)
 }
 
 func RegisterHotstuffServer(srv *gorums.Server, impl Hotstuff) {
@@ -221,6 +224,21 @@ func RegisterHotstuffServer(srv *gorums.Server, impl Hotstuff) {
 		case <-ctx.Done():
 		}
 	})
+	srv.RegisterHandler("hotstuffpb.Hotstuff.AckProposal", func(ctx gorums.ServerCtx, in *gorums.Message, _ chan<- *gorums.Message) {
+		req := in.Message.(*BlockHash)
+		defer ctx.Release()
+		impl.AckProposal(ctx, req)
+	})
+	srv.RegisterHandler("hotstuffpb.Hotstuff.Custom", func(ctx gorums.ServerCtx, in *gorums.Message, _ chan<- *gorums.Message) {
+		req := in.Message.(*CustomMessage)
+		defer ctx.Release()
+		impl.Custom(ctx, req)
+	})
+	srv.RegisterHandler("hotstuffpb.Hotstuff.Abstain", func(ctx gorums.ServerCtx, in *gorums.Message, _ chan<- *gorums.Message) {
+		req := in.Message.(*AbstainMsg)
+		defer ctx.Release()
+		impl.Abstain(ctx, req)
+	})
 }
 
 type internalBlock struct {
@@ -256,3 +274,45 @@ func (n *Node) NewView(ctx context.Context, in *SyncInfo, opts ...gorums.CallOpt
 
 	n.Node.Unicast(ctx, cd, opts...)
 }
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ emptypb.Empty
+
+// AckProposal is a quorum call invoked on all nodes in configuration c,
+// with the same argument in, and returns a combined result.
+func (n *Node) AckProposal(ctx context.Context, in *BlockHash, opts ...gorums.CallOption) {
+	cd := gorums.CallData{
+		Message: in,
+		Method:  "hotstuffpb.Hotstuff.AckProposal",
+	}
+
+	n.Node.Unicast(ctx, cd, opts...)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ emptypb.Empty
+
+// Custom is a quorum call invoked on all nodes in configuration c,
+// with the same argument in, and returns a combined result.
+func (n *Node) Custom(ctx context.Context, in *CustomMessage, opts ...gorums.CallOption) {
+	cd := gorums.CallData{
+		Message: in,
+		Method:  "hotstuffpb.Hotstuff.Custom",
+	}
+
+	n.Node.Unicast(ctx, cd, opts...)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ emptypb.Empty
+
+// Abstain is a quorum call invoked on all nodes in configuration c,
+// with the same argument in, and returns a combined result.
+func (n *Node) Abstain(ctx context.Context, in *AbstainMsg, opts ...gorums.CallOption) {
+	cd := gorums.CallData{
+		Message: in,
+		Method:  "hotstuffpb.Hotstuff.Abstain",
+	}
+
+	n.Node.Unicast(ctx, cd, opts...)
+}
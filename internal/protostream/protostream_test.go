@@ -31,7 +31,7 @@ func TestProtostream(t *testing.T) {
 		t.Fatalf("wrong message type returned: got: %T, want: %T", got, msg)
 	}
 
-	gotBlock := hotstuffpb.BlockFromProto(got)
+	gotBlock := hotstuffpb.BlockFromProto(got, nil)
 	if gotBlock.Hash() != consensus.GetGenesis().Hash() {
 		t.Fatalf("message hash did not match")
 	}
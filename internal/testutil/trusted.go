@@ -0,0 +1,117 @@
+package testutil
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/crypto"
+	"github.com/relab/hotstuff/crypto/ecdsa"
+)
+
+// trustedSigner is a CryptoImpl that creates signatures without signing anything, and accepts all signatures.
+// The signatures are empty ECDSA signatures, such that they can be sent over the network like ordinary signatures.
+type trustedSigner struct {
+	mods *consensus.Modules
+}
+
+// NewTrustedSigner returns a CryptoImpl that produces and verifies signatures without doing any cryptography.
+// Every signature is considered valid, so it must only be used in tests where the replicas trust each other,
+// such as large simulations on a single host where the cost of the cryptography would dominate the runtime.
+// It requires a testing.TB to ensure that it cannot be used outside of tests.
+func NewTrustedSigner(t testing.TB) consensus.CryptoImpl {
+	t.Helper()
+	return &trustedSigner{}
+}
+
+// InitConsensusModule gives the module a reference to the Modules object.
+// It also allows the module to set module options using the OptionsBuilder.
+func (ts *trustedSigner) InitConsensusModule(mods *consensus.Modules, _ *consensus.OptionsBuilder) {
+	ts.mods = mods
+}
+
+// Sign returns an empty signature from the local replica.
+func (ts *trustedSigner) Sign(_ consensus.Hash) (sig consensus.Signature, err error) {
+	return ecdsa.RestoreSignature(new(big.Int), new(big.Int), ts.mods.ID()), nil
+}
+
+// Verify accepts the signature.
+func (ts *trustedSigner) Verify(_ consensus.Signature, _ consensus.Hash) bool {
+	return true
+}
+
+// CreateThresholdSignature combines the partial signatures, provided that they are from a quorum of replicas.
+func (ts *trustedSigner) CreateThresholdSignature(partialSignatures []consensus.Signature, _ consensus.Hash) (consensus.ThresholdSignature, error) {
+	return ts.combine(partialSignatures)
+}
+
+// CreateThresholdSignatureForMessageSet combines the partial signatures, provided that they are from a quorum of replicas.
+func (ts *trustedSigner) CreateThresholdSignatureForMessageSet(partialSignatures []consensus.Signature, _ map[hotstuff.ID]consensus.Hash) (consensus.ThresholdSignature, error) {
+	return ts.combine(partialSignatures)
+}
+
+func (ts *trustedSigner) combine(partialSignatures []consensus.Signature) (consensus.ThresholdSignature, error) {
+	sigs := make([]*ecdsa.Signature, 0, len(partialSignatures))
+	for _, s := range partialSignatures {
+		sig, ok := s.(*ecdsa.Signature)
+		if !ok {
+			return nil, fmt.Errorf("%w: %T", crypto.ErrWrongType, s)
+		}
+		sigs = append(sigs, sig)
+	}
+	thrSig := ecdsa.RestoreThresholdSignature(consensus.SortedReplicas(ts.mods.Configuration()), sigs)
+	signers := 0
+	thrSig.ForEach(func(hotstuff.ID) { signers++ })
+	if signers < ts.mods.Configuration().QuorumSize() {
+		return nil, crypto.ErrNotAQuorum
+	}
+	return thrSig, nil
+}
+
+// VerifyThresholdSignature accepts the threshold signature.
+func (ts *trustedSigner) VerifyThresholdSignature(_ consensus.ThresholdSignature, _ consensus.Hash, _ consensus.View) bool {
+	return true
+}
+
+// VerifyThresholdSignatureForMessageSet accepts the threshold signature.
+func (ts *trustedSigner) VerifyThresholdSignatureForMessageSet(_ consensus.ThresholdSignature, _ map[hotstuff.ID]consensus.Hash, _ consensus.View) bool {
+	return true
+}
+
+// trustedVerifier is a CryptoImpl that creates signatures using another CryptoImpl, but accepts all signatures.
+type trustedVerifier struct {
+	consensus.CryptoImpl
+}
+
+// NewTrustedVerifier returns a CryptoImpl that creates signatures using the given CryptoImpl,
+// but accepts all signatures without verifying them.
+// This keeps the cost of signing, and the format of the signatures, while removing the cost of verification.
+// Like NewTrustedSigner, it must only be used in tests.
+func NewTrustedVerifier(t testing.TB, impl consensus.CryptoImpl) consensus.CryptoImpl {
+	t.Helper()
+	return &trustedVerifier{impl}
+}
+
+// InitConsensusModule initializes the wrapped CryptoImpl.
+func (tv *trustedVerifier) InitConsensusModule(mods *consensus.Modules, opts *consensus.OptionsBuilder) {
+	if mod, ok := tv.CryptoImpl.(consensus.Module); ok {
+		mod.InitConsensusModule(mods, opts)
+	}
+}
+
+// Verify accepts the signature.
+func (tv *trustedVerifier) Verify(_ consensus.Signature, _ consensus.Hash) bool {
+	return true
+}
+
+// VerifyThresholdSignature accepts the threshold signature.
+func (tv *trustedVerifier) VerifyThresholdSignature(_ consensus.ThresholdSignature, _ consensus.Hash, _ consensus.View) bool {
+	return true
+}
+
+// VerifyThresholdSignatureForMessageSet accepts the threshold signature.
+func (tv *trustedVerifier) VerifyThresholdSignatureForMessageSet(_ consensus.ThresholdSignature, _ map[hotstuff.ID]consensus.Hash, _ consensus.View) bool {
+	return true
+}
@@ -0,0 +1,57 @@
+package leaderrotation
+
+import (
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/consensus"
+)
+
+// committedAncestor returns the block in the committed chain that has the highest view that is at most view-depth.
+// Leader rotations that derive the leader of a view from the committed chain should use this block
+// rather than the last committed block, as the last committed block differs between replicas,
+// while the ancestor is the same for all replicas that have committed the chain up to view-depth.
+// A replica that has not yet committed a block above view-depth uses its last committed block, which is the same block
+// unless a block between them is committed later.
+//
+// It returns false if view-depth is not above the genesis block, if the ancestor is the genesis block,
+// or if a block in the chain is not available locally.
+func committedAncestor(mods *consensus.Modules, view, depth consensus.View) (*consensus.Block, bool) {
+	if view <= depth {
+		return nil, false
+	}
+	return ancestorAt(mods.BlockChain(), mods.Consensus().CommittedBlock(), view-depth)
+}
+
+// ancestorAt walks the chain backwards from the block, and returns the first block whose view is at most the target.
+// It returns false if the genesis block is reached, or if a block is not available locally.
+func ancestorAt(chain consensus.BlockChain, block *consensus.Block, target consensus.View) (*consensus.Block, bool) {
+	for block.View() > target {
+		parent, ok := chain.LocalGet(block.Parent())
+		if !ok {
+			return nil, false
+		}
+		block = parent
+	}
+	if block.View() == 0 {
+		return nil, false
+	}
+	return block, true
+}
+
+// ranking is the order of the replicas computed from an anchor block.
+type ranking struct {
+	view consensus.View
+	ids  []hotstuff.ID
+}
+
+// isCurrent returns true if the ranked replicas are the replicas in the configuration.
+func isCurrent(cfg consensus.Configuration, ids []hotstuff.ID) bool {
+	if len(ids) != cfg.Len() {
+		return false
+	}
+	for _, id := range ids {
+		if _, ok := cfg.Replica(id); !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -1,84 +1,81 @@
 package leaderrotation
 
 import (
-	"fmt"
-	"math/rand"
-	"time"
-
 	"github.com/relab/hotstuff"
 	"github.com/relab/hotstuff/consensus"
 )
 
+// carousel is a leader rotation based on the Carousel scheme of DiemBFT.
+//
+// The leader is chosen among the active replicas, which are the replicas that signed each of the last f+1 committed QCs.
+// The proposers of the last f committed blocks are excluded from the candidates, such that the leadership rotates.
+// If fewer than a quorum of replicas are active, the leader is chosen by round-robin over all replicas.
+// Because the leader only depends on the committed chain, all replicas that agree on the last committed block
+// also agree on the leader.
 type carousel struct {
 	mods *consensus.Modules
 }
 
+// InitConsensusModule gives the module a reference to the Modules object.
+// It also allows the module to set module options using the OptionsBuilder.
 func (c *carousel) InitConsensusModule(mods *consensus.Modules, _ *consensus.OptionsBuilder) {
 	c.mods = mods
 }
 
-func RemoveActive(active []hotstuff.ID, last []hotstuff.ID) []hotstuff.ID {
-	for _, ID := range last {
-		for index, IDa := range active {
-			if ID == IDa {
-				remove(active, index)
-			}
-		}
-
+// GetLeader returns the id of the leader in the given view.
+func (c carousel) GetLeader(view consensus.View) hotstuff.ID {
+	numReplicas := c.mods.Configuration().Len()
+	active, lastAuthors := c.activeReplicas(c.mods.Options().FaultModel().NumFaulty(numReplicas))
+	if len(active) < c.mods.Configuration().QuorumSize() {
+		return roundRobinLeader(c.mods.Configuration(), view)
 	}
-	return active
-}
 
-func remove(active []hotstuff.ID, IDIndex int) []hotstuff.ID {
-	active[IDIndex] = active[len(active)-1]
-	return active[:len(active)-1]
-}
-
-func getActiveID(actives consensus.IDSet) []hotstuff.ID {
-	myActives := []hotstuff.ID{}
-	actives.ForEach(func(i hotstuff.ID) {
-		myActives = append(myActives, i)
-	})
-	return myActives
-}
-
-func (c carousel) GetLeader(round consensus.View) hotstuff.ID {
-	commit_head := c.mods.Consensus().CommittedBlock()
-	if round <= 10 { //roundrobin first x views so that "particpants" is not empty
-		fmt.Println("Carousel Startup")
-		return hotstuff.ID(round%consensus.View(c.mods.Configuration().Len()) + 1)
+	candidates := make([]hotstuff.ID, 0, len(active))
+	for _, id := range active {
+		if !lastAuthors.Contains(id) {
+			candidates = append(candidates, id)
+		}
 	}
-	endorsers := commit_head.QuorumCert().Signature().Participants()
-	last_authors := []hotstuff.ID{}
-	//current view vs nextRound-1
-	//a blocks round must be larger than of its parent
-	c.mods.Logger().Info(c.mods.Synchronizer().HighQC().View(), round-1)
-	if c.mods.Synchronizer().HighQC().View() != round-1 {
-		c.mods.Logger().Info("Carousel: Fallback to RoundRobin")
-		return hotstuff.ID(round%consensus.View(c.mods.Configuration().Len()) + 1) //Fallback to Roundrobin
+	if len(candidates) == 0 {
+		candidates = active
 	}
-	c.mods.Logger().Info(" -------------- Continued PAST RR ---------------")
-	active := getActiveID(endorsers) //endorsers = voters that participated
-	block := commit_head
-	bc := c.mods.BlockChain()
+	return candidates[view%consensus.View(len(candidates))]
+}
 
-	f := hotstuff.NumFaulty(c.mods.Configuration().Len())
-	for len(last_authors) < f && block != consensus.GetGenesis() {
-		last_authors = append(last_authors, block.Proposer())
-		block, _ = bc.Get(block.Parent())
+// activeReplicas returns the IDs of the replicas that signed each of the last f+1 committed QCs, ordered by ID,
+// and the proposers of the last f committed blocks. If fewer than f+1 QCs have been committed, no replicas are active.
+func (c carousel) activeReplicas(f int) (active []hotstuff.ID, lastAuthors consensus.IDSet) {
+	lastAuthors = consensus.NewIDSet()
+	signed := make(map[hotstuff.ID]int)
+	block := c.mods.Consensus().CommittedBlock()
+	for i := 0; i <= f; i++ {
+		signers := block.QuorumCert().Signers()
+		if block.View() == 0 || len(signers) == 0 {
+			return nil, lastAuthors
+		}
+		for _, id := range signers {
+			signed[id]++
+		}
+		if i == f {
+			break
+		}
+		lastAuthors.Add(block.Proposer())
+		parent, ok := c.mods.BlockChain().LocalGet(block.Parent())
+		if !ok {
+			return nil, lastAuthors
+		}
+		block = parent
 	}
-
-	last_wo_active := RemoveActive(active, last_authors[:]) //active instead of 0.
-	leader_candidates := last_wo_active
-
-	s := rand.NewSource(time.Now().Unix())
-	r := rand.New(s)
-	n := r.Int() % len(leader_candidates)
-	//fmt.Println("leader_candidates", leader_candidates)
-	//fmt.Println("new leader", leader_candidates[n])
-	return leader_candidates[n]
+	for id, count := range signed {
+		if count == f+1 {
+			active = append(active, id)
+		}
+	}
+	sortIDs(active)
+	return active, lastAuthors
 }
 
+// NewCarousel returns a new leader rotation that chooses the leader among the replicas that signed the recent committed QCs.
 func NewCarousel() consensus.LeaderRotation {
 	return &carousel{}
 }
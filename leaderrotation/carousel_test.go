@@ -0,0 +1,85 @@
+package leaderrotation_test
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/internal/mocks"
+	"github.com/relab/hotstuff/internal/testutil"
+	"github.com/relab/hotstuff/leaderrotation"
+)
+
+func TestCarousel(t *testing.T) {
+	const n = 4
+	type block struct {
+		proposer hotstuff.ID
+		signers  []hotstuff.ID // the signers of the QC in the block
+	}
+	tests := []struct {
+		name    string
+		chain   []block // the committed chain, starting at view 1
+		leaders map[consensus.View]hotstuff.ID
+	}{
+		{
+			name:    "NoQCs",
+			chain:   []block{{proposer: 2}},
+			leaders: map[consensus.View]hotstuff.ID{10: 3, 11: 4},
+		},
+		{
+			name: "Active",
+			chain: []block{
+				{proposer: 2},
+				{proposer: 3, signers: []hotstuff.ID{1, 2, 3, 4}},
+				{proposer: 2, signers: []hotstuff.ID{1, 2, 3}},
+			},
+			// replica 4 did not sign the last QC, and replica 2 proposed the last block.
+			leaders: map[consensus.View]hotstuff.ID{10: 1, 11: 3, 12: 1},
+		},
+		{
+			name: "FallbackTooFewSigners",
+			chain: []block{
+				{proposer: 2},
+				{proposer: 3, signers: []hotstuff.ID{1, 2, 3}},
+				{proposer: 4, signers: []hotstuff.ID{2, 3, 4}},
+			},
+			// only replicas 2 and 3 signed both QCs, which is less than a quorum.
+			leaders: map[consensus.View]hotstuff.ID{10: 3, 11: 4, 12: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			builders := testutil.CreateBuilders(t, ctrl, n)
+			cs := mocks.NewMockConsensus(ctrl)
+			builders[0].Register(cs, leaderrotation.NewCarousel())
+			hl := builders.Build()
+			mods := hl[0]
+			signers := hl.Signers()
+
+			head := consensus.GetGenesis()
+			for i, b := range tt.chain {
+				qc := consensus.NewQuorumCert(nil, head.View(), head.Hash())
+				if len(b.signers) > 0 {
+					var qcSigners []consensus.Crypto
+					for _, id := range b.signers {
+						qcSigners = append(qcSigners, signers[id-1])
+					}
+					qc = testutil.CreateQC(t, head, qcSigners)
+				}
+				block := consensus.NewBlock(head.Hash(), qc, "foo", consensus.View(i+1), b.proposer)
+				mods.BlockChain().Store(block)
+				head = block
+			}
+			cs.EXPECT().CommittedBlock().AnyTimes().Return(head)
+
+			for view, want := range tt.leaders {
+				if got := mods.LeaderRotation().GetLeader(view); got != want {
+					t.Errorf("wrong leader in view %d: got: %d, want: %d", view, got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package leaderrotation
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/consensus"
+)
+
+// chainSeeded is a leader rotation where the leader of a view is derived from the hash of a committed block.
+//
+// The leader of view v is chosen using the committed block at view v-depth, or the closest committed block below it,
+// as the seed. Thus, the schedule cannot be predicted more than depth views in advance,
+// but it is the same for all replicas once the chain is committed.
+// Until the chain has been committed up to view v-depth, the bootstrap rotation chooses the leader.
+// The bootstrap rotation is also used if a block between the committed block and the seed block is not available locally,
+// since the leader computation must not wait for blocks to be fetched from other replicas.
+//
+// Note that replicas that have not yet committed the seed block use the bootstrap rotation,
+// and may disagree with the replicas that have. The depth should be large enough that the seed block
+// is committed by all correct replicas before view v starts.
+type chainSeeded struct {
+	mods      *consensus.Modules
+	depth     consensus.View
+	bootstrap consensus.LeaderRotation
+}
+
+// InitConsensusModule gives the module a reference to the Modules object.
+// It also allows the module to set module options using the OptionsBuilder.
+func (cr *chainSeeded) InitConsensusModule(mods *consensus.Modules, opts *consensus.OptionsBuilder) {
+	cr.mods = mods
+	if mod, ok := cr.bootstrap.(consensus.Module); ok {
+		mod.InitConsensusModule(mods, opts)
+	}
+}
+
+// GetLeader returns the id of the leader in the given view.
+func (cr chainSeeded) GetLeader(view consensus.View) hotstuff.ID {
+	seed, ok := cr.seedBlock(view)
+	if !ok {
+		return cr.bootstrap.GetLeader(view)
+	}
+	return ShuffleIDs(consensus.SortedReplicas(cr.mods.Configuration()), viewSeed(seed.Hash(), view))[0]
+}
+
+// seedBlock returns the committed block at view-depth, or the closest committed block below it.
+// It returns false if the chain has not been committed that far, if no block has been committed below it yet,
+// or if a block in the chain is not available locally.
+func (cr chainSeeded) seedBlock(view consensus.View) (*consensus.Block, bool) {
+	if view <= cr.depth {
+		return nil, false
+	}
+	target := view - cr.depth
+	block := cr.mods.Consensus().CommittedBlock()
+	if block.View() < target {
+		return nil, false
+	}
+	return ancestorAt(cr.mods.BlockChain(), block, target)
+}
+
+// viewSeed combines the hash of the seed block with the view,
+// such that consecutive views that use the same seed block are assigned different leaders.
+func viewSeed(hash consensus.Hash, view consensus.View) consensus.Hash {
+	var input [len(consensus.Hash{}) + 8]byte
+	copy(input[:], hash[:])
+	binary.BigEndian.PutUint64(input[len(hash):], uint64(view))
+	return sha256.Sum256(input[:])
+}
+
+// NewChainSeeded returns a leader rotation where the leader of each view is derived from the hash of
+// the block that was committed depth views earlier. The bootstrap rotation is used until such a block exists.
+func NewChainSeeded(depth consensus.View, bootstrap consensus.LeaderRotation) consensus.LeaderRotation {
+	return &chainSeeded{depth: depth, bootstrap: bootstrap}
+}
@@ -0,0 +1,121 @@
+package leaderrotation_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/internal/mocks"
+	"github.com/relab/hotstuff/internal/testutil"
+	"github.com/relab/hotstuff/leaderrotation"
+)
+
+// extendChain stores blocks for the views from parent.View()+1 through lastView on top of parent,
+// using the prefix to create distinct commands. The last block is returned.
+func extendChain(chain consensus.BlockChain, parent *consensus.Block, lastView consensus.View, prefix string) *consensus.Block {
+	for v := parent.View() + 1; v <= lastView; v++ {
+		block := consensus.NewBlock(
+			parent.Hash(),
+			consensus.NewQuorumCert(nil, parent.View(), parent.Hash()),
+			consensus.Command(fmt.Sprintf("%s%d", prefix, v)),
+			v,
+			1,
+		)
+		chain.Store(block)
+		parent = block
+	}
+	return parent
+}
+
+func TestChainSeeded(t *testing.T) {
+	const (
+		n         = 4
+		depth     = 5
+		bootstrap = hotstuff.ID(4)
+	)
+	ctrl := gomock.NewController(t)
+
+	// newRotation returns a chain-seeded rotation whose committed chain is built by the build function.
+	newRotation := func(build func(chain consensus.BlockChain) *consensus.Block) consensus.LeaderRotation {
+		builders := testutil.CreateBuilders(t, ctrl, n)
+		cs := mocks.NewMockConsensus(ctrl)
+		builders[0].Register(cs, leaderrotation.NewChainSeeded(depth, leaderrotation.NewFixed(bootstrap)))
+		mods := builders[0].Build()
+		head := build(mods.BlockChain())
+		cs.EXPECT().CommittedBlock().AnyTimes().Return(head)
+		return mods.LeaderRotation()
+	}
+
+	genesis := consensus.GetGenesis()
+	common := func(chain consensus.BlockChain) *consensus.Block {
+		return extendChain(chain, genesis, 30, "a")
+	}
+	forked := func(chain consensus.BlockChain) *consensus.Block {
+		return extendChain(chain, extendChain(chain, genesis, 20, "a"), 30, "b")
+	}
+
+	replicas := []consensus.LeaderRotation{newRotation(common), newRotation(common), newRotation(common)}
+	fork := newRotation(forked)
+
+	leaders := make(map[hotstuff.ID]int)
+	diverged := false
+	for view := consensus.View(1); view <= 30+depth; view++ {
+		leader := replicas[0].GetLeader(view)
+		for i, replica := range replicas[1:] {
+			if other := replica.GetLeader(view); other != leader {
+				t.Errorf("replica %d disagrees about the leader of view %d: got: %d, want: %d", i+2, view, other, leader)
+			}
+		}
+		if view <= depth && leader != bootstrap {
+			t.Errorf("wrong leader during bootstrap in view %d: got: %d, want: %d", view, leader, bootstrap)
+		}
+		if view > depth {
+			leaders[leader]++
+		}
+
+		// the schedule only depends on the chain up to view-depth.
+		forkLeader := fork.GetLeader(view)
+		if view <= 20+depth && forkLeader != leader {
+			t.Errorf("leader of view %d depends on blocks after view %d", view, view-depth)
+		}
+		if forkLeader != leader {
+			diverged = true
+		}
+	}
+	if !diverged {
+		t.Error("the schedule did not change when the chain changed")
+	}
+	if len(leaders) < 2 {
+		t.Errorf("the schedule did not rotate among the replicas: %v", leaders)
+	}
+
+	// views that are further ahead than the committed chain allows use the bootstrap rotation.
+	if leader := replicas[0].GetLeader(31 + depth); leader != bootstrap {
+		t.Errorf("wrong leader for a view beyond the committed chain: got: %d, want: %d", leader, bootstrap)
+	}
+}
+
+// TestChainSeededMissingBlock checks that the bootstrap rotation is used, without fetching,
+// if a block between the committed block and the seed block is not available locally.
+func TestChainSeededMissingBlock(t *testing.T) {
+	const (
+		depth     = 5
+		bootstrap = hotstuff.ID(4)
+	)
+	ctrl := gomock.NewController(t)
+	builders := testutil.CreateBuilders(t, ctrl, 4)
+	cs := mocks.NewMockConsensus(ctrl)
+	builders[0].Register(cs, leaderrotation.NewChainSeeded(depth, leaderrotation.NewFixed(bootstrap)))
+	mods := builders[0].Build()
+
+	// the blocks are not stored, so the parent of the committed block is missing.
+	parent := consensus.NewBlock(consensus.GetGenesis().Hash(), consensus.QuorumCert{}, "a", 10, 1)
+	head := consensus.NewBlock(parent.Hash(), consensus.NewQuorumCert(nil, 10, parent.Hash()), "b", 20, 1)
+	cs.EXPECT().CommittedBlock().AnyTimes().Return(head)
+	// the mock configuration fails the test if the block is fetched.
+	if leader := mods.LeaderRotation().GetLeader(20); leader != bootstrap {
+		t.Errorf("wrong leader when the seed block is missing: got: %d, want: %d", leader, bootstrap)
+	}
+}
@@ -0,0 +1,56 @@
+package leaderrotation
+
+import (
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/consensus"
+)
+
+// fallback is a leader rotation that composes several leader rotations.
+// It returns the leader chosen by the first rotation that is able to produce a valid leader.
+type fallback struct {
+	mods      *consensus.Modules
+	rotations []consensus.LeaderRotation
+}
+
+// InitConsensusModule gives the module a reference to the Modules object.
+// It also allows the module to set module options using the OptionsBuilder.
+func (fb *fallback) InitConsensusModule(mods *consensus.Modules, opts *consensus.OptionsBuilder) {
+	fb.mods = mods
+	for _, rotation := range fb.rotations {
+		if mod, ok := rotation.(consensus.Module); ok {
+			mod.InitConsensusModule(mods, opts)
+		}
+	}
+}
+
+// GetLeader returns the id of the leader in the given view.
+// If none of the rotations produce a valid leader, the leader chosen by the last rotation is returned.
+func (fb fallback) GetLeader(view consensus.View) (leader hotstuff.ID) {
+	for _, rotation := range fb.rotations {
+		leader = rotation.GetLeader(view)
+		if fb.isValid(leader) {
+			return leader
+		}
+		fb.mods.Logger().Debugf("Leader %d chosen for view %d is not valid, trying next leader rotation", leader, view)
+	}
+	return leader
+}
+
+// isValid returns true if the replica is part of the configuration.
+// If the replica is able to report its connection state, it must also be connected.
+func (fb fallback) isValid(id hotstuff.ID) bool {
+	if id == fb.mods.ID() {
+		return true
+	}
+	replica, ok := fb.mods.Configuration().Replica(id)
+	return ok && consensus.IsConnected(replica)
+}
+
+// NewFallback returns a leader rotation that uses the primary leader rotation,
+// and falls back to the other leader rotations, in order, if the primary does not choose a valid leader.
+// A leader is valid if it is part of the configuration and is not known to be disconnected.
+//
+// Note that replicas may disagree about which replicas are connected, and thus also about the leader.
+func NewFallback(primary consensus.LeaderRotation, fallbacks ...consensus.LeaderRotation) consensus.LeaderRotation {
+	return &fallback{rotations: append([]consensus.LeaderRotation{primary}, fallbacks...)}
+}
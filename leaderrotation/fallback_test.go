@@ -0,0 +1,60 @@
+package leaderrotation_test
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/internal/mocks"
+	"github.com/relab/hotstuff/internal/testutil"
+	"github.com/relab/hotstuff/leaderrotation"
+)
+
+// disconnectableReplica is a replica that reports whether it is connected.
+type disconnectableReplica struct {
+	*mocks.MockReplica
+	connected bool
+}
+
+func (r disconnectableReplica) Connected() bool {
+	return r.connected
+}
+
+func TestFallbackDisconnectedLeader(t *testing.T) {
+	const n = 4
+	ctrl := gomock.NewController(t)
+
+	cfg := mocks.NewMockConfiguration(ctrl)
+	replicas := make(map[hotstuff.ID]consensus.Replica)
+	for id := hotstuff.ID(1); id <= n; id++ {
+		replicas[id] = disconnectableReplica{
+			MockReplica: testutil.CreateMockReplica(t, ctrl, id, testutil.GenerateECDSAKey(t).Public()),
+			connected:   id != 2,
+		}
+	}
+	cfg.EXPECT().Len().AnyTimes().Return(n)
+	cfg.EXPECT().Replica(gomock.Any()).AnyTimes().DoAndReturn(func(id hotstuff.ID) (consensus.Replica, bool) {
+		replica, ok := replicas[id]
+		return replica, ok
+	})
+
+	builder := consensus.NewBuilder(1, testutil.GenerateECDSAKey(t))
+	builder.Register(cfg, leaderrotation.NewFallback(
+		testutil.NewLeaderRotation(t, 2, 3, 5, 1),
+		leaderrotation.NewFixed(4),
+	))
+	mods := builder.Build()
+
+	want := map[consensus.View]hotstuff.ID{
+		1: 4, // the primary chose a disconnected replica
+		2: 3,
+		3: 4, // the primary chose a replica outside of the configuration
+		4: 1,
+	}
+	for view, id := range want {
+		if leader := mods.LeaderRotation().GetLeader(view); leader != id {
+			t.Errorf("wrong leader in view %d: got: %d, want: %d", view, leader, id)
+		}
+	}
+}
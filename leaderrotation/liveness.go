@@ -0,0 +1,150 @@
+package leaderrotation
+
+import (
+	"sync"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/consensus"
+)
+
+// livenessBased is a leader rotation that prefers replicas whose views tend to commit.
+//
+// The leader of view v is chosen by ranking the replicas by the liveness scores that are computed from the committed block
+// at view v-depth, or the closest committed block below it. The liveness score of a replica is the fraction of the views
+// it led within the window that ends at that block which produced a committed block. A view that is missing from the
+// committed chain is charged to the replica that this rotation chose as its leader, which is again determined by the chain.
+// Because the scores are derived from the committed chain only, all replicas that have committed the chain up to view v-depth
+// agree on the leader of view v. Until a block has been committed, the leader is chosen by round-robin.
+type livenessBased struct {
+	mods   *consensus.Modules
+	window consensus.View
+	depth  consensus.View
+
+	mut   sync.Mutex
+	ranks map[consensus.Hash]ranking // the rankings computed from recent anchor blocks
+}
+
+// InitConsensusModule gives the module a reference to the Modules object.
+// It also allows the module to set module options using the OptionsBuilder.
+func (lb *livenessBased) InitConsensusModule(mods *consensus.Modules, _ *consensus.OptionsBuilder) {
+	lb.mods = mods
+}
+
+// GetLeader returns the id of the leader in the given view.
+func (lb *livenessBased) GetLeader(view consensus.View) hotstuff.ID {
+	anchor, ok := committedAncestor(lb.mods, view, lb.depth)
+	if !ok {
+		// no history yet
+		return roundRobinLeader(lb.mods.Configuration(), view)
+	}
+	leader := lb.leaderFrom(anchor, view)
+	lb.prune(anchor.View())
+	return leader
+}
+
+// chosenLeader returns the leader that the rotation chose for the view,
+// given the last block in the committed chain before the view.
+func (lb *livenessBased) chosenLeader(parent *consensus.Block, view consensus.View) hotstuff.ID {
+	if view <= lb.depth {
+		return roundRobinLeader(lb.mods.Configuration(), view)
+	}
+	anchor, ok := ancestorAt(lb.mods.BlockChain(), parent, view-lb.depth)
+	if !ok {
+		return roundRobinLeader(lb.mods.Configuration(), view)
+	}
+	return lb.leaderFrom(anchor, view)
+}
+
+// leaderFrom returns the leader of the view, given the anchor block that the scores are computed from.
+func (lb *livenessBased) leaderFrom(anchor *consensus.Block, view consensus.View) hotstuff.ID {
+	ranked := lb.rank(anchor)
+	// rotate among the best replicas, leaving out the f replicas with the lowest scores.
+	candidates := ranked[:lb.mods.Options().FaultModel().QuorumSize(len(ranked))]
+	return candidates[view%consensus.View(len(candidates))]
+}
+
+// rank returns the IDs of all replicas ordered by liveness score from best to worst. Ties are broken by RankByScore.
+func (lb *livenessBased) rank(anchor *consensus.Block) []hotstuff.ID {
+	lb.mut.Lock()
+	cached, ok := lb.ranks[anchor.Hash()]
+	lb.mut.Unlock()
+	if ok && isCurrent(lb.mods.Configuration(), cached.ids) {
+		return cached.ids
+	}
+
+	scores := lb.scores(anchor)
+	ids := make([]hotstuff.ID, 0, len(scores))
+	for id := range scores {
+		ids = append(ids, id)
+	}
+	RankByScore(ids, func(id hotstuff.ID) float64 {
+		return scores[id]
+	})
+
+	lb.mut.Lock()
+	lb.ranks[anchor.Hash()] = ranking{view: anchor.View(), ids: ids}
+	lb.mut.Unlock()
+	return ids
+}
+
+// prune forgets the rankings that are no longer needed to choose the leader of views after the anchor view.
+func (lb *livenessBased) prune(anchorView consensus.View) {
+	lb.mut.Lock()
+	defer lb.mut.Unlock()
+	for hash, r := range lb.ranks {
+		if r.view+lb.window+lb.depth < anchorView {
+			delete(lb.ranks, hash)
+		}
+	}
+}
+
+// scores computes the liveness score of each replica by walking the committed chain backwards from the anchor block.
+func (lb *livenessBased) scores(anchor *consensus.Block) map[hotstuff.ID]float64 {
+	var lowest consensus.View
+	if anchor.View() > lb.window {
+		lowest = anchor.View() - lb.window
+	}
+
+	led := make(map[hotstuff.ID]int)
+	succeeded := make(map[hotstuff.ID]int)
+	for block := anchor; block.View() > lowest; {
+		led[block.Proposer()]++
+		succeeded[block.Proposer()]++
+		parent, ok := lb.mods.BlockChain().LocalGet(block.Parent())
+		if !ok {
+			break
+		}
+		// the views between the parent and the block did not produce a committed block
+		v := parent.View() + 1
+		if v <= lowest {
+			v = lowest + 1
+		}
+		for ; v < block.View(); v++ {
+			led[lb.chosenLeader(parent, v)]++
+		}
+		block = parent
+	}
+
+	scores := make(map[hotstuff.ID]float64)
+	for id := range lb.mods.Configuration().Replicas() {
+		if led[id] == 0 {
+			// give replicas without any history the benefit of the doubt
+			scores[id] = 1
+			continue
+		}
+		scores[id] = float64(succeeded[id]) / float64(led[id])
+	}
+	return scores
+}
+
+// NewLivenessBased returns a new leader rotation that prefers replicas whose views have committed.
+// The window determines how many views are taken into account, and the depth determines how many views before
+// a view the committed block that its leader is computed from must be.
+// The depth should be large enough that the block is committed by all correct replicas before the view starts.
+func NewLivenessBased(window, depth consensus.View) consensus.LeaderRotation {
+	return &livenessBased{
+		window: window,
+		depth:  depth,
+		ranks:  make(map[consensus.Hash]ranking),
+	}
+}
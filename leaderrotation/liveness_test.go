@@ -0,0 +1,118 @@
+package leaderrotation_test
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/internal/mocks"
+	"github.com/relab/hotstuff/internal/testutil"
+	"github.com/relab/hotstuff/leaderrotation"
+)
+
+// buildChain stores a chain of blocks for views 1 through numViews, where each block is proposed by the round-robin
+// leader of its view. Views led by a replica in failing are left out of the chain. The last block is returned.
+func buildChain(chain consensus.BlockChain, n, numViews int, failing ...hotstuff.ID) *consensus.Block {
+	skip := make(map[hotstuff.ID]bool)
+	for _, id := range failing {
+		skip[id] = true
+	}
+	parent := consensus.GetGenesis()
+	for v := 1; v <= numViews; v++ {
+		proposer := hotstuff.ID(v%n + 1)
+		if skip[proposer] {
+			continue
+		}
+		block := consensus.NewBlock(
+			parent.Hash(),
+			consensus.NewQuorumCert(nil, parent.View(), parent.Hash()),
+			"foo",
+			consensus.View(v),
+			proposer,
+		)
+		chain.Store(block)
+		parent = block
+	}
+	return parent
+}
+
+// TestLivenessBasedFailingLeaderLosesRank runs the rotation against a chain where the views led by a failing replica
+// are missing, and checks that the missed views are charged to the failing replica, rather than to a correct replica.
+func TestLivenessBasedFailingLeaderLosesRank(t *testing.T) {
+	const (
+		n        = 4
+		window   = 50
+		depth    = 2
+		numViews = 200
+	)
+	const failing = 2
+	ctrl := gomock.NewController(t)
+	builders := testutil.CreateBuilders(t, ctrl, n)
+	cs := mocks.NewMockConsensus(ctrl)
+	builders[0].Register(cs, leaderrotation.NewLivenessBased(window, depth))
+	mods := builders[0].Build()
+
+	head := consensus.GetGenesis()
+	cs.EXPECT().CommittedBlock().AnyTimes().DoAndReturn(func() *consensus.Block { return head })
+
+	counts := make(map[hotstuff.ID]int)
+	for v := consensus.View(1); v <= numViews; v++ {
+		leader := mods.LeaderRotation().GetLeader(v)
+		counts[leader]++
+		if leader == failing {
+			continue
+		}
+		block := consensus.NewBlock(head.Hash(), consensus.NewQuorumCert(nil, head.View(), head.Hash()), "foo", v, leader)
+		mods.BlockChain().Store(block)
+		head = block
+	}
+
+	// the failing replica regains the benefit of the doubt once its missed view leaves the window.
+	if max := numViews/window + 1; counts[failing] > max {
+		t.Errorf("replica %d was selected as leader %d times after failing all of its views, want at most %d", failing, counts[failing], max)
+	}
+	for _, id := range []hotstuff.ID{1, 3, 4} {
+		if counts[id] < numViews/n {
+			t.Errorf("replica %d was selected as leader %d times, want at least %d: %v", id, counts[id], numViews/n, counts)
+		}
+	}
+}
+
+// TestLivenessBasedAnchor checks that replicas that have committed different blocks agree on the leader,
+// as long as they have committed the chain up to depth views before the view.
+func TestLivenessBasedAnchor(t *testing.T) {
+	const (
+		n     = 4
+		depth = 5
+	)
+	ctrl := gomock.NewController(t)
+	builders := testutil.CreateBuilders(t, ctrl, n)
+	lagging := mocks.NewMockConsensus(ctrl)
+	ahead := mocks.NewMockConsensus(ctrl)
+	builders[0].Register(lagging, leaderrotation.NewLivenessBased(100, depth))
+	builders[1].Register(ahead, leaderrotation.NewLivenessBased(100, depth))
+	hl := builders.Build()
+
+	// the views led by replica 3 are missing, such that the scores change with every committed block.
+	var blocks []*consensus.Block
+	parent := consensus.GetGenesis()
+	for v := consensus.View(1); v <= 40; v++ {
+		if v%n == 2 {
+			continue
+		}
+		block := consensus.NewBlock(parent.Hash(), consensus.NewQuorumCert(nil, parent.View(), parent.Hash()), "foo", v, hotstuff.ID(v%n+1))
+		hl[0].BlockChain().Store(block)
+		hl[1].BlockChain().Store(block)
+		blocks = append(blocks, block)
+		parent = block
+	}
+	lagging.EXPECT().CommittedBlock().AnyTimes().Return(blocks[len(blocks)-4])
+	ahead.EXPECT().CommittedBlock().AnyTimes().Return(blocks[len(blocks)-1])
+
+	for v := blocks[len(blocks)-4].View() + 1; v <= blocks[len(blocks)-4].View()+depth; v++ {
+		if got, want := hl[0].LeaderRotation().GetLeader(v), hl[1].LeaderRotation().GetLeader(v); got != want {
+			t.Errorf("replicas disagree on the leader of view %d: %d and %d", v, got, want)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package leaderrotation
+
+import (
+	"sync"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/consensus"
+)
+
+// participation is a leader rotation that chooses the replica that has participated in the most recent QCs.
+//
+// The reputation of a replica is the number of QCs in the committed chain that include its partial certificate,
+// counting only the QCs for the views within the window that ends at the committed block at view v-depth,
+// or the closest committed block below it. The replica with the highest reputation is chosen as the leader of view v,
+// and ties are broken by RankByScore. Because the reputations are derived from the committed chain only,
+// all replicas that have committed the chain up to view v-depth agree on the leader of view v.
+// Until a QC with known participants has been committed, the leader is chosen by round-robin.
+//
+// If the penalty is positive, the reputation of a replica is reduced by the penalty for each view within the window
+// that it led, but which is missing from the committed chain, such as a view that ended in a timeout.
+// The leader of a missing view is the replica that this rotation chose, which is again determined by the chain.
+// As the missing views leave the window, the reputation recovers.
+type participation struct {
+	mods    *consensus.Modules
+	window  consensus.View
+	depth   consensus.View
+	penalty float64
+
+	mut   sync.Mutex
+	ranks map[consensus.Hash]ranking // the rankings computed from recent anchor blocks
+}
+
+// InitConsensusModule gives the module a reference to the Modules object.
+// It also allows the module to set module options using the OptionsBuilder.
+func (p *participation) InitConsensusModule(mods *consensus.Modules, _ *consensus.OptionsBuilder) {
+	p.mods = mods
+}
+
+// GetLeader returns the id of the leader in the given view.
+func (p *participation) GetLeader(view consensus.View) hotstuff.ID {
+	anchor, ok := committedAncestor(p.mods, view, p.depth)
+	if !ok {
+		// no history yet
+		return roundRobinLeader(p.mods.Configuration(), view)
+	}
+	leader := p.leaderFrom(anchor, view)
+	p.prune(anchor.View())
+	return leader
+}
+
+// Scores returns the reputation of each replica in the current view.
+func (p *participation) Scores() map[hotstuff.ID]float64 {
+	anchor, ok := committedAncestor(p.mods, p.mods.Synchronizer().View(), p.depth)
+	if !ok {
+		reputations := make(map[hotstuff.ID]float64, p.mods.Configuration().Len())
+		for id := range p.mods.Configuration().Replicas() {
+			reputations[id] = 0
+		}
+		return reputations
+	}
+	reputations, _ := p.reputations(anchor)
+	return reputations
+}
+
+// chosenLeader returns the leader that the rotation chose for the view,
+// given the last block in the committed chain before the view.
+func (p *participation) chosenLeader(parent *consensus.Block, view consensus.View) hotstuff.ID {
+	if view <= p.depth {
+		return roundRobinLeader(p.mods.Configuration(), view)
+	}
+	anchor, ok := ancestorAt(p.mods.BlockChain(), parent, view-p.depth)
+	if !ok {
+		return roundRobinLeader(p.mods.Configuration(), view)
+	}
+	return p.leaderFrom(anchor, view)
+}
+
+// leaderFrom returns the leader of the view, given the anchor block that the reputations are computed from.
+func (p *participation) leaderFrom(anchor *consensus.Block, view consensus.View) hotstuff.ID {
+	p.mut.Lock()
+	cached, ok := p.ranks[anchor.Hash()]
+	p.mut.Unlock()
+	if ok && isCurrent(p.mods.Configuration(), cached.ids) {
+		return cached.ids[0]
+	}
+
+	reputations, ok := p.reputations(anchor)
+	if !ok {
+		// no reputation data yet
+		return roundRobinLeader(p.mods.Configuration(), view)
+	}
+	ids := make([]hotstuff.ID, 0, len(reputations))
+	for id := range reputations {
+		ids = append(ids, id)
+	}
+	RankByScore(ids, func(id hotstuff.ID) float64 {
+		return reputations[id]
+	})
+
+	p.mut.Lock()
+	p.ranks[anchor.Hash()] = ranking{view: anchor.View(), ids: ids}
+	p.mut.Unlock()
+	return ids[0]
+}
+
+// prune forgets the rankings that are no longer needed to choose the leader of views after the anchor view.
+func (p *participation) prune(anchorView consensus.View) {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+	for hash, r := range p.ranks {
+		if r.view+p.window+p.depth < anchorView {
+			delete(p.ranks, hash)
+		}
+	}
+}
+
+// reputations computes the reputation of each replica by walking the committed chain backwards from the anchor block.
+// It returns false if none of the QCs within the window have been counted.
+func (p *participation) reputations(anchor *consensus.Block) (reputations map[hotstuff.ID]float64, ok bool) {
+	var lowest consensus.View
+	if anchor.View() > p.window {
+		lowest = anchor.View() - p.window
+	}
+
+	scores := make(map[hotstuff.ID]int)
+	missed := make(map[hotstuff.ID]int)
+	for block := anchor; block.View() > lowest; {
+		if qc := block.QuorumCert(); qc.View() > lowest {
+			for _, id := range qc.Signers() {
+				scores[id]++
+				ok = true
+			}
+		}
+		parent, found := p.mods.BlockChain().LocalGet(block.Parent())
+		if !found {
+			break
+		}
+		if p.penalty > 0 {
+			// the views between the parent and the block did not produce a committed block
+			v := parent.View() + 1
+			if v <= lowest {
+				v = lowest + 1
+			}
+			for ; v < block.View(); v++ {
+				missed[p.chosenLeader(parent, v)]++
+			}
+		}
+		block = parent
+	}
+
+	reputations = make(map[hotstuff.ID]float64, p.mods.Configuration().Len())
+	for id := range p.mods.Configuration().Replicas() {
+		reputations[id] = float64(scores[id]) - p.penalty*float64(missed[id])
+	}
+	return reputations, ok
+}
+
+// NewReputation returns a new leader rotation that chooses the replica that participated in the most QCs
+// within the given number of the most recent committed views. The depth determines how many views before a view
+// the committed block that its leader is computed from must be.
+func NewReputation(window, depth consensus.View) consensus.LeaderRotation {
+	return NewReputationWithPenalty(window, depth, 0)
+}
+
+// NewReputationWithPenalty returns a new leader rotation that works like NewReputation,
+// except that the reputation of a replica is reduced by the penalty for each recent view that it led
+// which is missing from the committed chain.
+func NewReputationWithPenalty(window, depth consensus.View, penalty float64) consensus.LeaderRotation {
+	return &participation{
+		window:  window,
+		depth:   depth,
+		penalty: penalty,
+		ranks:   make(map[consensus.Hash]ranking),
+	}
+}
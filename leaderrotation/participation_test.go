@@ -0,0 +1,159 @@
+package leaderrotation_test
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/internal/mocks"
+	"github.com/relab/hotstuff/internal/testutil"
+	"github.com/relab/hotstuff/leaderrotation"
+)
+
+func TestReputationByParticipation(t *testing.T) {
+	const (
+		n      = 4
+		window = 4
+	)
+	ctrl := gomock.NewController(t)
+	builders := testutil.CreateBuilders(t, ctrl, n)
+	cs := mocks.NewMockConsensus(ctrl)
+	builders[0].Register(cs, leaderrotation.NewReputation(window, 1))
+	hl := builders.Build()
+	mods := hl[0]
+	signers := hl.Signers()
+
+	head := consensus.GetGenesis()
+	cs.EXPECT().CommittedBlock().AnyTimes().DoAndReturn(func() *consensus.Block { return head })
+
+	// no reputation data yet, so the leader is chosen by round-robin.
+	for view := consensus.View(1); view <= n; view++ {
+		if leader := mods.LeaderRotation().GetLeader(view); leader != hotstuff.ID(view%n+1) {
+			t.Errorf("wrong leader without reputation data in view %d: got: %d, want: %d", view, leader, view%n+1)
+		}
+	}
+
+	// the QC in each block is signed by the given replicas.
+	chain := []struct {
+		signers []hotstuff.ID
+		leader  hotstuff.ID // the expected leader once the block is committed
+	}{
+		{signers: []hotstuff.ID{1, 2, 3}, leader: 3}, // the QC for the genesis block is not counted, so round-robin is used
+		{signers: []hotstuff.ID{2, 3, 4}, leader: 2}, // scores: 2, 3, 4: 1
+		{signers: []hotstuff.ID{2, 3, 4}, leader: 2}, // scores: 2, 3, 4: 2
+		{signers: []hotstuff.ID{1, 3, 4}, leader: 3}, // scores: 1: 1, 2: 2, 3, 4: 3
+		{signers: []hotstuff.ID{1, 2, 4}, leader: 4}, // the QC for view 1 leaves the window. scores: 1, 2, 3: 2, 4: 3
+		{signers: []hotstuff.ID{1, 3, 4}, leader: 1}, // scores: 1: 3, 2: 1, 3: 2, 4: 3
+	}
+	for i, b := range chain {
+		var qcSigners []consensus.Crypto
+		for _, id := range b.signers {
+			qcSigners = append(qcSigners, signers[id-1])
+		}
+		view := consensus.View(i + 1)
+		block := consensus.NewBlock(head.Hash(), testutil.CreateQC(t, head, qcSigners), "foo", view, 1)
+		mods.BlockChain().Store(block)
+		head = block
+
+		if leader := mods.LeaderRotation().GetLeader(view + 1); leader != b.leader {
+			t.Errorf("wrong leader after committing view %d: got: %d, want: %d", view, leader, b.leader)
+		}
+	}
+}
+
+// TestReputationTimeoutPenalty checks that a leader whose views are missing from the committed chain is not chosen
+// while the missing views are within the window, and that its reputation recovers once they have left the window.
+func TestReputationTimeoutPenalty(t *testing.T) {
+	const (
+		n      = 4
+		window = 10
+	)
+	ctrl := gomock.NewController(t)
+	builders := testutil.CreateBuilders(t, ctrl, n)
+	cs := mocks.NewMockConsensus(ctrl)
+	builders[0].Register(cs, leaderrotation.NewReputationWithPenalty(window, 1, 1))
+	hl := builders.Build()
+	mods := hl[0]
+	signers := hl.Signers()
+
+	head := consensus.GetGenesis()
+	cs.EXPECT().CommittedBlock().AnyTimes().DoAndReturn(func() *consensus.Block { return head })
+	commit := func(view consensus.View, ids ...hotstuff.ID) {
+		var qcSigners []consensus.Crypto
+		for _, id := range ids {
+			qcSigners = append(qcSigners, signers[id-1])
+		}
+		block := consensus.NewBlock(head.Hash(), testutil.CreateQC(t, head, qcSigners), "foo", view, 1)
+		mods.BlockChain().Store(block)
+		head = block
+	}
+
+	// the scores are 1: 4, 2: 3, 3: 3, 4: 2. The QC in the first block is for the genesis block, and is not counted.
+	for i, ids := range [][]hotstuff.ID{{1, 2, 3}, {1, 2, 3}, {1, 2, 3}, {1, 2, 4}, {1, 3, 4}} {
+		commit(consensus.View(i+1), ids...)
+	}
+	if leader := mods.LeaderRotation().GetLeader(6); leader != 1 {
+		t.Fatalf("wrong leader before any timeouts: got: %d, want: 1", leader)
+	}
+	if leader := mods.LeaderRotation().GetLeader(7); leader != 1 {
+		t.Fatalf("wrong leader of view 7: got: %d, want: 1", leader)
+	}
+
+	// the views 6 and 7, which were led by replica 1, timed out, so the block for view 8 extends the block for view 5.
+	// Afterwards, all replicas participate in every QC, such that replica 1 would win every tie-break.
+	commit(8, 2, 3, 4)
+	for view := consensus.View(9); view <= 7+window; view++ {
+		if leader := mods.LeaderRotation().GetLeader(view); leader == 1 {
+			t.Errorf("replica 1 was chosen as the leader of view %d after causing two timeouts", view)
+		}
+		commit(view, 1, 2, 3, 4)
+	}
+
+	// the missing views have left the window.
+	if leader := mods.LeaderRotation().GetLeader(8 + window); leader != 1 {
+		t.Errorf("wrong leader after the penalties expired: got: %d, want: 1", leader)
+	}
+}
+
+// TestReputationAnchor checks that replicas that have committed different blocks agree on the leader,
+// as long as they have committed the chain up to depth views before the view.
+func TestReputationAnchor(t *testing.T) {
+	const (
+		n     = 4
+		depth = 3
+	)
+	ctrl := gomock.NewController(t)
+	builders := testutil.CreateBuilders(t, ctrl, n)
+	lagging := mocks.NewMockConsensus(ctrl)
+	ahead := mocks.NewMockConsensus(ctrl)
+	builders[0].Register(lagging, leaderrotation.NewReputation(10, depth))
+	builders[1].Register(ahead, leaderrotation.NewReputation(10, depth))
+	hl := builders.Build()
+	signers := hl.Signers()
+
+	// each QC leaves out a different replica, such that the leader changes with every committed block.
+	var blocks []*consensus.Block
+	parent := consensus.GetGenesis()
+	for v := consensus.View(1); v <= 8; v++ {
+		var qcSigners []consensus.Crypto
+		for id := hotstuff.ID(1); id <= n; id++ {
+			if id != hotstuff.ID(v%n+1) {
+				qcSigners = append(qcSigners, signers[id-1])
+			}
+		}
+		block := consensus.NewBlock(parent.Hash(), testutil.CreateQC(t, parent, qcSigners), "foo", v, 1)
+		hl[0].BlockChain().Store(block)
+		hl[1].BlockChain().Store(block)
+		blocks = append(blocks, block)
+		parent = block
+	}
+	lagging.EXPECT().CommittedBlock().AnyTimes().Return(blocks[4])
+	ahead.EXPECT().CommittedBlock().AnyTimes().Return(blocks[7])
+
+	for v := blocks[4].View() + 1; v <= blocks[4].View()+depth; v++ {
+		if got, want := hl[0].LeaderRotation().GetLeader(v), hl[1].LeaderRotation().GetLeader(v); got != want {
+			t.Errorf("replicas disagree on the leader of view %d: %d and %d", v, got, want)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package leaderrotation
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/consensus"
+)
+
+// random is a leader rotation that chooses the leader of each view pseudo-randomly.
+//
+// The leader of a view is derived from the SHA-256 hash of the seed followed by the view, both as big-endian integers.
+// Since the seed is part of the shared configuration, all replicas agree on the leader without communicating.
+type random struct {
+	replicas []hotstuff.ID // ordered by ID
+	seed     int64
+}
+
+// GetLeader returns the id of the leader in the given view.
+func (r random) GetLeader(view consensus.View) hotstuff.ID {
+	var input [16]byte
+	binary.BigEndian.PutUint64(input[:8], uint64(r.seed))
+	binary.BigEndian.PutUint64(input[8:], uint64(view))
+	stream := newSeededStream(sha256.Sum256(input[:]))
+	return r.replicas[stream.intn(uint64(len(r.replicas)))]
+}
+
+// NewRandom returns a new leader rotation that chooses the leader of each view uniformly at random among the replicas,
+// using the seed and the view. All replicas must use the same seed and the same set of replicas, in any order.
+func NewRandom(replicas []hotstuff.ID, seed int64) consensus.LeaderRotation {
+	ids := make([]hotstuff.ID, len(replicas))
+	copy(ids, replicas)
+	sortIDs(ids)
+	return random{replicas: ids, seed: seed}
+}
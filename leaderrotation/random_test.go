@@ -0,0 +1,53 @@
+package leaderrotation_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/leaderrotation"
+)
+
+func TestRandomUniform(t *testing.T) {
+	const views = 5000
+	replicas := []hotstuff.ID{2, 3, 5, 7, 11}
+	rotation := leaderrotation.NewRandom(replicas, 42)
+	other := leaderrotation.NewRandom(replicas, 42)
+
+	counts := make(map[hotstuff.ID]int)
+	for view := consensus.View(1); view <= views; view++ {
+		leader := rotation.GetLeader(view)
+		if other.GetLeader(view) != leader {
+			t.Fatalf("replicas with the same seed disagree about the leader of view %d", view)
+		}
+		counts[leader]++
+	}
+
+	expected := float64(views) / float64(len(replicas))
+	// allow 5 standard deviations of the binomial distribution.
+	p := 1 / float64(len(replicas))
+	tolerance := 5 * math.Sqrt(views*p*(1-p))
+	total := 0
+	for _, id := range replicas {
+		if diff := math.Abs(float64(counts[id]) - expected); diff > tolerance {
+			t.Errorf("replica %d was selected %d times, expected %.0f ± %.0f", id, counts[id], expected, tolerance)
+		}
+		total += counts[id]
+	}
+	if total != views {
+		t.Errorf("selected replicas that are not in the replica set: %v", counts)
+	}
+}
+
+func TestRandomSeed(t *testing.T) {
+	replicas := []hotstuff.ID{1, 2, 3, 4}
+	a := leaderrotation.NewRandom(replicas, 1)
+	b := leaderrotation.NewRandom(replicas, 2)
+	for view := consensus.View(1); view <= 100; view++ {
+		if a.GetLeader(view) != b.GetLeader(view) {
+			return
+		}
+	}
+	t.Error("the schedule does not depend on the seed")
+}
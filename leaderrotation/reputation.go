@@ -1,74 +1,148 @@
 package leaderrotation
 
 import (
-	"fmt"
-	"hash/fnv"
-	"math/rand"
-	"strconv"
-
-	wr "github.com/mroth/weightedrand"
+	"sort"
 
 	"github.com/relab/hotstuff"
 	"github.com/relab/hotstuff/consensus"
 )
 
+// Ranker is implemented by leader rotations that rank the replicas.
+type Ranker interface {
+	// RankedReplicas returns the IDs of the replicas, ordered from best to worst.
+	RankedReplicas() []hotstuff.ID
+}
+
+// Scorer is implemented by leader rotations that compute a reputation score for each replica.
+type Scorer interface {
+	// Scores returns a snapshot of the reputation score of each replica in the configuration.
+	Scores() map[hotstuff.ID]float64
+}
+
+// repBased is a leader rotation that chooses the leader among the voters of a committed block,
+// with probability proportional to their reputation.
+//
+// For each QC in the committed chain within the window that ends at the committed block at view v-depth,
+// or the closest committed block below it, each signer of the QC gains (votes - 2n/3) / (2n/3) reputation,
+// where votes is the number of signers plus the leader. The leader of view v is drawn from the signers of the QC
+// in that block, using the hash of the block as the source of randomness. Because the reputations are derived
+// from the committed chain only, GetLeader is a pure function of the view for all replicas that have committed
+// the chain up to view v-depth. For the first n+10 views, and until a block has been committed,
+// the leader is chosen by round-robin.
 type repBased struct {
-	mods        *consensus.Modules
-	replicaList []wr.Choice
+	mods   *consensus.Modules
+	window consensus.View
+	depth  consensus.View
 }
 
 //InitConsensusModule gives the module a reference to the Modules object.
 //It also allows the module to set module options using the OptionsBuilder
 func (r *repBased) InitConsensusModule(mods *consensus.Modules, _ *consensus.OptionsBuilder) {
 	r.mods = mods
-	r.replicaList = []wr.Choice{}
 }
 
 //GetLeader returns the id of the leader in the given view
-func (r repBased) GetLeader(view consensus.View) hotstuff.ID {
-	commit_head := r.mods.Consensus().CommittedBlock() //fetch previous comitted block
-	numReplicas := r.mods.Configuration().Len()
-	blockHash := r.mods.Consensus().CommittedBlock().Hash().String()
-	h := fnv.New32a()
-	h.Write([]byte(blockHash))
-	hashInt := h.Sum32()
-	//rand.Seed(int64(hashInt))
+func (r *repBased) GetLeader(view consensus.View) hotstuff.ID {
+	if int(view) <= r.mods.Configuration().Len()+10 {
+		return roundRobinLeader(r.mods.Configuration(), view)
+	}
+	anchor, ok := committedAncestor(r.mods, view, r.depth)
+	if !ok {
+		// no history yet
+		return roundRobinLeader(r.mods.Configuration(), view)
+	}
+	reputations := r.reputations(anchor)
+
+	// the voters are ranked by RankByScore, such that all replicas that agree on the reputations
+	// build the same list of candidates.
+	voters := anchor.QuorumCert().Signers()
+	rep := func(id hotstuff.ID) float64 {
+		return reputations[id]
+	}
+	RankByScore(voters, rep)
 
-	if int(view) <= numReplicas+10 {
-		return hotstuff.ID(view%consensus.View(numReplicas) + 1)
+	// the leader is chosen with probability proportional to its reputation,
+	// using the hash of the anchor block as the source of randomness.
+	cumulative := make([]uint64, len(voters))
+	var total uint64
+	for i, id := range voters {
+		if weight := rep(id) * 10; weight >= 1 {
+			total += uint64(weight)
+		}
+		cumulative[i] = total
+	}
+	if total == 0 {
+		return roundRobinLeader(r.mods.Configuration(), view)
 	}
+	n := newSeededStream(anchor.Hash()).intn(total)
+	i := sort.Search(len(cumulative), func(i int) bool { return cumulative[i] > n })
+	return voters[i]
+}
 
-	voters := commit_head.QuorumCert().Signature().Participants()
-	numVotes := 1.0 //is 1 because leader counts as a vote
-	voters.ForEach(func(hotstuff.ID) {
-		numVotes += 1.0
+// RankedReplicas returns the IDs of the replicas, ordered by their reputation in the current view from best to worst.
+// Ties are broken by RankByScore. It is safe to call from any goroutine.
+func (r *repBased) RankedReplicas() []hotstuff.ID {
+	scores := r.Scores()
+	ids := make([]hotstuff.ID, 0, len(scores))
+	for id := range scores {
+		ids = append(ids, id)
+	}
+	RankByScore(ids, func(id hotstuff.ID) float64 {
+		return scores[id]
 	})
-	frac := float64((2.0 / 3.0) * float64(numReplicas))
-	reputation := ((numVotes - frac) / frac)
+	return ids
+}
+
+// Scores returns the reputation of each replica in the current view. It is safe to call from any goroutine.
+func (r *repBased) Scores() map[hotstuff.ID]float64 {
+	anchor, ok := committedAncestor(r.mods, r.mods.Synchronizer().View(), r.depth)
+	if !ok {
+		reputations := make(map[hotstuff.ID]float64, r.mods.Configuration().Len())
+		for id := range r.mods.Configuration().Replicas() {
+			reputations[id] = 0
+		}
+		return reputations
+	}
+	return r.reputations(anchor)
+}
 
-	voters.ForEach(func(voterID hotstuff.ID) {
-		currentVoter, ok := r.mods.Configuration().Replica(voterID)
+// reputations computes the reputation of each replica by walking the committed chain backwards from the anchor block.
+func (r *repBased) reputations(anchor *consensus.Block) map[hotstuff.ID]float64 {
+	var lowest consensus.View
+	if anchor.View() > r.window {
+		lowest = anchor.View() - r.window
+	}
+
+	reputations := make(map[hotstuff.ID]float64, r.mods.Configuration().Len())
+	for id := range r.mods.Configuration().Replicas() {
+		reputations[id] = 0
+	}
+	frac := (2.0 / 3.0) * float64(r.mods.Configuration().Len())
+	for block := anchor; block.View() > lowest; {
+		if qc := block.QuorumCert(); qc.View() > lowest {
+			voters := qc.Signers()
+			numVotes := 1.0 + float64(len(voters)) // the leader counts as a vote
+			for _, id := range voters {
+				if _, ok := reputations[id]; ok {
+					reputations[id] += (numVotes - frac) / frac
+				}
+			}
+		}
+		parent, ok := r.mods.BlockChain().LocalGet(block.Parent())
 		if !ok {
-			r.mods.Logger().Info("Failed fetching current replica", currentVoter)
+			break
 		}
-		if currentVoter.ID() == voterID {
-				currentVoter.UpdateRep(reputation)
-		} 
-		r.replicaList = append(r.replicaList, wr.Choice{Item: strconv.Itoa(int(currentVoter.ID())), Weight: uint(currentVoter.GetRep()*10)}) 
-	})
-	//fmt.Println("the list", r.replicaList)
-	chooser, err := wr.NewChooser(r.replicaList...)
-	if err != nil {
-		fmt.Println(err)
+		block = parent
 	}
-	rs := rand.New(rand.NewSource(int64(hashInt)))
-	resultLeader := chooser.PickSource(rs).(string)
-	intLeader, _ := strconv.Atoi(resultLeader)
-	fmt.Println("picked leader", intLeader, "with seed", hashInt, "from",  r.mods.ID())
-	return hotstuff.ID(intLeader)
+	return reputations
 }
 
-//NewRepBased returns a new random reputation-based leader rotation implementation
-func NewRepBased() consensus.LeaderRotation {
-	return &repBased{}
+//NewRepBased returns a new random reputation-based leader rotation implementation.
+//The window determines how many views are taken into account, and the depth determines how many views before
+//a view the committed block that its leader is computed from must be.
+func NewRepBased(window, depth consensus.View) consensus.LeaderRotation {
+	return &repBased{
+		window: window,
+		depth:  depth,
+	}
 }
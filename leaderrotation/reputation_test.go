@@ -0,0 +1,116 @@
+package leaderrotation_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/internal/mocks"
+	"github.com/relab/hotstuff/internal/testutil"
+	"github.com/relab/hotstuff/leaderrotation"
+)
+
+// repChain builds a chain of blocks where the QC in each block is signed by the given replicas.
+// The QC in the first block certifies the genesis block.
+func repChain(t *testing.T, signers []consensus.Crypto, qcSigners ...[]hotstuff.ID) []*consensus.Block {
+	t.Helper()
+	var blocks []*consensus.Block
+	parent := consensus.GetGenesis()
+	for i, ids := range qcSigners {
+		var qcCryptos []consensus.Crypto
+		for _, id := range ids {
+			qcCryptos = append(qcCryptos, signers[id-1])
+		}
+		block := consensus.NewBlock(parent.Hash(), testutil.CreateQC(t, parent, qcCryptos), "foo", consensus.View(i+1), 1)
+		blocks = append(blocks, block)
+		parent = block
+	}
+	return blocks
+}
+
+func TestRankedReplicas(t *testing.T) {
+	const n = 4
+	ctrl := gomock.NewController(t)
+	builders := testutil.CreateBuilders(t, ctrl, n)
+	cs := mocks.NewMockConsensus(ctrl)
+	sync := mocks.NewMockSynchronizer(ctrl)
+	builders[0].Register(cs, sync, leaderrotation.NewRepBased(10, 1))
+	hl := builders.Build()
+	mods := hl[0]
+
+	// a QC with 3 signers gives each of them 0.5. The QC in the first block is for the genesis block, and is not counted.
+	blocks := repChain(t, hl.Signers(), []hotstuff.ID{1, 2, 3}, []hotstuff.ID{1, 2, 4}, []hotstuff.ID{2, 3, 4}, []hotstuff.ID{1, 2, 4})
+	for _, block := range blocks {
+		mods.BlockChain().Store(block)
+	}
+	cs.EXPECT().CommittedBlock().AnyTimes().Return(blocks[len(blocks)-1])
+	sync.EXPECT().View().AnyTimes().Return(consensus.View(len(blocks) + 1))
+
+	ranker, ok := mods.LeaderRotation().(leaderrotation.Ranker)
+	if !ok {
+		t.Fatal("the reputation-based leader rotation does not rank replicas")
+	}
+	got := ranker.RankedReplicas()
+	// replicas 2 and 4 have the same reputation, and are ordered by ID
+	want := []hotstuff.ID{2, 4, 1, 3}
+	if !equalIDs(got, want) {
+		t.Errorf("wrong ranking: got: %v, want: %v", got, want)
+	}
+}
+
+// TestRepBasedDeterministic checks that replicas that have committed different blocks pick the same leader,
+// as long as they have committed the chain up to depth views before the view,
+// and that choosing the leader does not change the reputations.
+func TestRepBasedDeterministic(t *testing.T) {
+	const (
+		n     = 4
+		depth = 2
+		view  = 21
+	)
+	ctrl := gomock.NewController(t)
+	builders := testutil.CreateBuilders(t, ctrl, n)
+	lagging := mocks.NewMockConsensus(ctrl)
+	ahead := mocks.NewMockConsensus(ctrl)
+	sync := mocks.NewMockSynchronizer(ctrl)
+	sync.EXPECT().View().AnyTimes().Return(consensus.View(view))
+	builders[0].Register(lagging, sync, leaderrotation.NewRepBased(10, depth))
+	builders[1].Register(ahead, leaderrotation.NewRepBased(10, depth))
+	hl := builders.Build()
+
+	// each QC leaves out a different replica, such that the reputations differ.
+	var qcSigners [][]hotstuff.ID
+	for v := 1; v < view; v++ {
+		var ids []hotstuff.ID
+		for id := hotstuff.ID(1); id <= n; id++ {
+			if int(id) != v%n+1 || v%3 == 0 {
+				ids = append(ids, id)
+			}
+		}
+		qcSigners = append(qcSigners, ids)
+	}
+	blocks := repChain(t, hl.Signers(), qcSigners...)
+	for _, mods := range hl[:2] {
+		for _, block := range blocks {
+			mods.BlockChain().Store(block)
+		}
+	}
+	lagging.EXPECT().CommittedBlock().AnyTimes().Return(blocks[view-depth-1])
+	ahead.EXPECT().CommittedBlock().AnyTimes().Return(blocks[len(blocks)-1])
+
+	a, b := hl[0].LeaderRotation(), hl[1].LeaderRotation()
+	before := a.(leaderrotation.Scorer).Scores()
+	want := a.GetLeader(view)
+	for i := 0; i < 10; i++ {
+		if got := a.GetLeader(view); got != want {
+			t.Fatalf("the leader changed between calls: got: %d, want: %d", got, want)
+		}
+		if got := b.GetLeader(view); got != want {
+			t.Fatalf("the replicas picked different leaders: got: %d, want: %d", got, want)
+		}
+	}
+	if after := a.(leaderrotation.Scorer).Scores(); !reflect.DeepEqual(before, after) {
+		t.Errorf("choosing the leader changed the reputations: before: %v, after: %v", before, after)
+	}
+}
@@ -20,15 +20,13 @@ func (rr *roundRobin) InitConsensusModule(mods *consensus.Modules, _ *consensus.
 
 // GetLeader returns the id of the leader in the given view
 func (rr roundRobin) GetLeader(view consensus.View) hotstuff.ID {
-	// TODO: does not support reconfiguration
-	// assume IDs start at 1
 	blockHash := rr.mods.Consensus().CommittedBlock().Hash().String()
 	h := fnv.New32a()
 	h.Write([]byte(blockHash))
 	hashInt := h.Sum32()
 
 	fmt.Println("the block hash", hashInt)
-	return hotstuff.ID(view%consensus.View(rr.mods.Configuration().Len()) + 1)
+	return roundRobinLeader(rr.mods.Configuration(), view)
 }
 
 // NewRoundRobin returns a new round-robin leader rotation implementation.
@@ -0,0 +1,64 @@
+package leaderrotation
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/consensus"
+)
+
+// seededStream is a deterministic stream of pseudo-random numbers derived from a seed.
+// The i-th block of the stream is the SHA-256 hash of the seed followed by i as a big-endian uint64.
+// Unlike math/rand, the output does not depend on the Go version or the platform.
+type seededStream struct {
+	seed    consensus.Hash
+	counter uint64
+	buf     [sha256.Size]byte
+	pos     int
+}
+
+func newSeededStream(seed consensus.Hash) *seededStream {
+	return &seededStream{seed: seed, pos: sha256.Size}
+}
+
+// uint64 returns the next 64 bits of the stream.
+func (s *seededStream) uint64() uint64 {
+	if s.pos+8 > len(s.buf) {
+		var input [len(consensus.Hash{}) + 8]byte
+		copy(input[:], s.seed[:])
+		binary.BigEndian.PutUint64(input[len(s.seed):], s.counter)
+		s.buf = sha256.Sum256(input[:])
+		s.counter++
+		s.pos = 0
+	}
+	v := binary.BigEndian.Uint64(s.buf[s.pos:])
+	s.pos += 8
+	return v
+}
+
+// intn returns a uniformly distributed number in [0, n).
+func (s *seededStream) intn(n uint64) uint64 {
+	// reject values from the incomplete range at the top to avoid modulo bias.
+	limit := ^uint64(0) - (^uint64(0)%n+1)%n
+	for {
+		if v := s.uint64(); v <= limit {
+			return v % n
+		}
+	}
+}
+
+// ShuffleIDs returns a permutation of the given IDs that is determined by the seed.
+// Replicas that shuffle the same IDs, in the same order, with the same seed, get the same permutation.
+// The input slice is not modified.
+func ShuffleIDs(ids []hotstuff.ID, seed consensus.Hash) []hotstuff.ID {
+	shuffled := make([]hotstuff.ID, len(ids))
+	copy(shuffled, ids)
+	stream := newSeededStream(seed)
+	// Fisher-Yates shuffle
+	for i := len(shuffled) - 1; i > 0; i-- {
+		j := stream.intn(uint64(i + 1))
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	}
+	return shuffled
+}
@@ -0,0 +1,50 @@
+package leaderrotation_test
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/leaderrotation"
+)
+
+func TestShuffleIDs(t *testing.T) {
+	ids := []hotstuff.ID{1, 2, 3, 4, 5, 6, 7}
+
+	// The expected permutations are fixed, such that a change in the output,
+	// which would make replicas running different versions disagree, is detected.
+	tests := []struct {
+		seed string
+		want []hotstuff.ID
+	}{
+		{"epoch 1", []hotstuff.ID{4, 7, 3, 6, 5, 1, 2}},
+		{"epoch 2", []hotstuff.ID{4, 5, 7, 2, 3, 6, 1}},
+	}
+
+	for _, test := range tests {
+		seed := sha256.Sum256([]byte(test.seed))
+		got := leaderrotation.ShuffleIDs(ids, seed)
+		if !equalIDs(got, test.want) {
+			t.Errorf("ShuffleIDs(%s): got: %v, want: %v", test.seed, got, test.want)
+		}
+		if again := leaderrotation.ShuffleIDs(ids, seed); !equalIDs(again, got) {
+			t.Errorf("ShuffleIDs(%s) is not deterministic: got %v and %v", test.seed, got, again)
+		}
+	}
+
+	if !equalIDs(ids, []hotstuff.ID{1, 2, 3, 4, 5, 6, 7}) {
+		t.Errorf("ShuffleIDs modified its input: %v", ids)
+	}
+}
+
+func equalIDs(a, b []hotstuff.ID) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,49 @@
+package leaderrotation
+
+import (
+	"math"
+	"sort"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/consensus"
+)
+
+// roundRobinLeader returns the leader of the view when the leadership rotates through the replicas in ascending order of ID.
+func roundRobinLeader(cfg consensus.Configuration, view consensus.View) hotstuff.ID {
+	ids := consensus.SortedReplicas(cfg)
+	return ids[view%consensus.View(len(ids))]
+}
+
+// sortIDs sorts the IDs in place, in ascending order.
+func sortIDs(ids []hotstuff.ID) {
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+}
+
+// RankByScore sorts the IDs in place, from the highest score to the lowest.
+//
+// Replicas with the same score are ordered by their ID, lowest first. Thus, all replicas that compute the same scores
+// agree on the order, regardless of the order of the input, such as when the IDs are collected from a map.
+// A score that is NaN is ranked below all other scores, and NaN scores are tied with each other.
+// Leader rotations that rank replicas by a score should use this function, such that ties are broken the same way.
+func RankByScore(ids []hotstuff.ID, score func(id hotstuff.ID) float64) {
+	scores := make(map[hotstuff.ID]float64, len(ids))
+	for _, id := range ids {
+		scores[id] = score(id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ranksBefore(ids[i], ids[j], scores[ids[i]], scores[ids[j]])
+	})
+}
+
+// ranksBefore returns true if replica a, with score sa, should be ranked before replica b, with score sb.
+func ranksBefore(a, b hotstuff.ID, sa, sb float64) bool {
+	aNaN, bNaN := math.IsNaN(sa), math.IsNaN(sb)
+	switch {
+	case aNaN != bNaN:
+		return bNaN
+	case !aNaN && sa != sb:
+		return sa > sb
+	default:
+		return a < b
+	}
+}
@@ -0,0 +1,91 @@
+package leaderrotation_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/internal/mocks"
+	"github.com/relab/hotstuff/internal/testutil"
+	"github.com/relab/hotstuff/leaderrotation"
+)
+
+func TestRankByScoreTies(t *testing.T) {
+	scores := map[hotstuff.ID]float64{1: 0.5, 2: 1, 3: 0.5, 4: math.NaN(), 5: 1, 6: 0.5, 7: math.NaN()}
+	want := []hotstuff.ID{2, 5, 1, 3, 6, 4, 7}
+
+	// every ordering of the input must produce the same ranking
+	inputs := [][]hotstuff.ID{
+		{1, 2, 3, 4, 5, 6, 7},
+		{7, 6, 5, 4, 3, 2, 1},
+		{4, 6, 2, 7, 1, 5, 3},
+	}
+	for _, ids := range inputs {
+		input := append([]hotstuff.ID(nil), ids...)
+		leaderrotation.RankByScore(ids, func(id hotstuff.ID) float64 { return scores[id] })
+		if !equalIDs(ids, want) {
+			t.Errorf("wrong ranking of %v: got: %v, want: %v", input, ids, want)
+		}
+	}
+}
+
+// TestLivenessBasedTies checks that replicas with the same liveness score are ranked by ID,
+// such that all replicas agree on the leader.
+func TestLivenessBasedTies(t *testing.T) {
+	const n = 4
+	ctrl := gomock.NewController(t)
+	builders := testutil.CreateBuilders(t, ctrl, n)
+	cs := mocks.NewMockConsensus(ctrl)
+	builders[0].Register(cs, leaderrotation.NewLivenessBased(100, 20))
+	mods := builders[0].Build()
+
+	// every view committed, so all replicas have the same score
+	head := buildChain(mods.BlockChain(), n, 40)
+	cs.EXPECT().CommittedBlock().AnyTimes().Return(head)
+
+	// the candidates are the quorum of replicas with the lowest IDs
+	candidates := []hotstuff.ID{1, 2, 3}
+	for v := consensus.View(41); v < 53; v++ {
+		want := candidates[v%consensus.View(len(candidates))]
+		// the scores are collected from a map, so the leader is computed many times to catch an unstable order
+		for i := 0; i < 10; i++ {
+			if got := mods.LeaderRotation().GetLeader(v); got != want {
+				t.Fatalf("wrong leader in view %d: got: %d, want: %d", v, got, want)
+			}
+		}
+	}
+}
+
+// TestSortedReplicas checks that the canonical ordering of the replicas does not depend on the order of the configuration,
+// and that round-robin follows it when the IDs are not 1 to n, such as after a membership change.
+func TestSortedReplicas(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	replicas := make(map[hotstuff.ID]consensus.Replica)
+	for _, id := range []hotstuff.ID{9, 2, 14, 5} {
+		replicas[id] = testutil.CreateMockReplica(t, ctrl, id, nil)
+	}
+	cfg := mocks.NewMockConfiguration(ctrl)
+	cfg.EXPECT().Replicas().AnyTimes().Return(replicas)
+	cfg.EXPECT().Len().AnyTimes().Return(len(replicas))
+
+	want := []hotstuff.ID{2, 5, 9, 14}
+	// the replicas are collected from a map, so the ordering is computed many times to catch an unstable order
+	for i := 0; i < 10; i++ {
+		if got := consensus.SortedReplicas(cfg); !equalIDs(got, want) {
+			t.Fatalf("wrong ordering: got: %v, want: %v", got, want)
+		}
+	}
+
+	builder := consensus.NewBuilder(2, nil)
+	cs := mocks.NewMockConsensus(ctrl)
+	cs.EXPECT().CommittedBlock().AnyTimes().Return(consensus.GetGenesis())
+	builder.Register(cfg, cs, leaderrotation.NewRoundRobin())
+	mods := builder.Build()
+	for v := consensus.View(1); v <= 8; v++ {
+		if got := mods.LeaderRotation().GetLeader(v); got != want[v%4] {
+			t.Errorf("wrong leader in view %d: got: %d, want: %d", v, got, want[v%4])
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package leaderrotation
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"sort"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/consensus"
+)
+
+// weighted is a leader rotation that chooses the leader of each view with probability proportional to its weight.
+//
+// The replicas are ordered by ID, and the cumulative weights of the replicas are stored in a table.
+// The leader of a view is found by binary search for a number derived from the SHA-256 hash of the view.
+// Since the weights are part of the shared configuration, all replicas agree on the leader without communicating.
+type weighted struct {
+	replicas   []hotstuff.ID // the replicas with a non-zero weight, ordered by ID
+	cumulative []uint64      // cumulative[i] is the sum of the weights of replicas[0] to replicas[i]
+}
+
+// GetLeader returns the id of the leader in the given view.
+func (w weighted) GetLeader(view consensus.View) hotstuff.ID {
+	var input [8]byte
+	binary.BigEndian.PutUint64(input[:], uint64(view))
+	stream := newSeededStream(sha256.Sum256(input[:]))
+	r := stream.intn(w.cumulative[len(w.cumulative)-1])
+	i := sort.Search(len(w.cumulative), func(i int) bool { return w.cumulative[i] > r })
+	return w.replicas[i]
+}
+
+// NewWeighted returns a new leader rotation that chooses the leader of each view pseudo-randomly,
+// such that the probability of choosing a replica is proportional to its weight.
+// Replicas with a weight of 0 are never chosen. NewWeighted panics if the total weight is 0.
+func NewWeighted(weights map[hotstuff.ID]uint64) consensus.LeaderRotation {
+	ids := make([]hotstuff.ID, 0, len(weights))
+	for id, weight := range weights {
+		if weight > 0 {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		panic("leaderrotation: the total weight of the replicas is 0")
+	}
+	sortIDs(ids)
+
+	cumulative := make([]uint64, len(ids))
+	var total uint64
+	for i, id := range ids {
+		total += weights[id]
+		cumulative[i] = total
+	}
+	return weighted{replicas: ids, cumulative: cumulative}
+}
@@ -0,0 +1,46 @@
+package leaderrotation_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/leaderrotation"
+)
+
+func TestWeighted(t *testing.T) {
+	const views = 10000
+	weights := map[hotstuff.ID]uint64{1: 1, 2: 0, 3: 2, 4: 5, 5: 0}
+	rotation := leaderrotation.NewWeighted(weights)
+	other := leaderrotation.NewWeighted(weights)
+
+	counts := make(map[hotstuff.ID]int)
+	for view := consensus.View(1); view <= views; view++ {
+		leader := rotation.GetLeader(view)
+		if other.GetLeader(view) != leader {
+			t.Fatalf("replicas with the same weights disagree about the leader of view %d", view)
+		}
+		counts[leader]++
+	}
+
+	var total uint64
+	for _, weight := range weights {
+		total += weight
+	}
+	for id, weight := range weights {
+		if weight == 0 {
+			if counts[id] > 0 {
+				t.Errorf("replica %d with weight 0 was selected %d times", id, counts[id])
+			}
+			continue
+		}
+		// allow 5 standard deviations of the binomial distribution.
+		p := float64(weight) / float64(total)
+		expected := views * p
+		tolerance := 5 * math.Sqrt(views*p*(1-p))
+		if diff := math.Abs(float64(counts[id]) - expected); diff > tolerance {
+			t.Errorf("replica %d was selected %d times, expected %.0f ± %.0f", id, counts[id], expected, tolerance)
+		}
+	}
+}
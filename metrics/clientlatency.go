@@ -14,15 +14,21 @@ func init() {
 	})
 }
 
+// latencyBuckets are the upper bounds in milliseconds of the histogram buckets of the client latency,
+// from 0.5 ms to about 16 seconds.
+var latencyBuckets = ExponentialBuckets(0.5, 2, 16)
+
 // ClientLatency processes LatencyMeasurementEvents, and writes LatencyMeasurements to the metrics logger.
 type ClientLatency struct {
 	mods *modules.Modules
 	wf   Welford
+	hist *Histogram
 }
 
 // InitModule gives the module access to the other modules.
 func (lr *ClientLatency) InitModule(mods *modules.Modules) {
 	lr.mods = mods
+	lr.hist = NewHistogram(latencyBuckets...)
 
 	lr.mods.MetricsEventLoop().RegisterHandler(client.LatencyMeasurementEvent{}, func(event interface{}) {
 		latencyEvent := event.(client.LatencyMeasurementEvent)
@@ -40,16 +46,24 @@ func (lr *ClientLatency) InitModule(mods *modules.Modules) {
 func (lr *ClientLatency) addLatency(latency time.Duration) {
 	millis := float64(latency) / float64(time.Millisecond)
 	lr.wf.Update(millis)
+	lr.hist.Update(millis)
 }
 
 func (lr *ClientLatency) tick(tick types.TickEvent) {
 	mean, variance, count := lr.wf.Get()
+	buckets, p50, p90, p99 := lr.hist.Get()
 	event := &types.LatencyMeasurement{
 		Event:    types.NewClientEvent(uint32(lr.mods.ID()), time.Now()),
 		Latency:  mean,
 		Variance: variance,
 		Count:    count,
+		P50:      p50,
+		P90:      p90,
+		P99:      p99,
+		Bounds:   latencyBuckets,
+		Buckets:  buckets,
 	}
 	lr.mods.MetricsLogger().Log(event)
 	lr.wf.Reset()
+	lr.hist.Reset()
 }
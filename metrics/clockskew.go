@@ -0,0 +1,104 @@
+package metrics
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/metrics/types"
+	"github.com/relab/hotstuff/modules"
+)
+
+func init() {
+	RegisterReplicaMetric("clock-skew", func() interface{} {
+		return &ClockSkew{}
+	})
+}
+
+// proposalReceivedEvent is sent on the metrics event loop when a timestamped proposal from another replica
+// is handled by the consensus event loop.
+type proposalReceivedEvent struct {
+	id       hotstuff.ID
+	sent     time.Time // according to the sender's clock
+	received time.Time // according to our clock
+}
+
+// clockOffsets holds the offsets measured for one replica since the last tick.
+type clockOffsets struct {
+	Welford
+	min float64
+}
+
+// ClockSkew estimates the clock skew between the local replica and each of the other replicas,
+// by comparing the timestamps of their proposals with the local time at which the proposals were received.
+// The difference includes the network delay, so the smallest difference is used as the estimate of the skew.
+type ClockSkew struct {
+	mods    *modules.Modules
+	offsets map[hotstuff.ID]*clockOffsets
+}
+
+// InitConsensusModule gives the module a reference to the Modules object.
+// It also allows the module to set module options using the OptionsBuilder.
+func (cs *ClockSkew) InitConsensusModule(mods *consensus.Modules, _ *consensus.OptionsBuilder) {
+	mods.EventLoop().RegisterObserver(consensus.ProposeMsg{}, func(event interface{}) {
+		proposal := event.(consensus.ProposeMsg)
+		if proposal.ID == mods.ID() || proposal.Timestamp.IsZero() {
+			return
+		}
+		// the observer is run by the consensus event loop, so we pass the proposal on to the metrics event loop.
+		cs.mods.MetricsEventLoop().AddEvent(proposalReceivedEvent{
+			id:       proposal.ID,
+			sent:     proposal.Timestamp,
+			received: time.Now(),
+		})
+	})
+}
+
+// InitModule gives the module access to the other modules.
+func (cs *ClockSkew) InitModule(mods *modules.Modules) {
+	cs.mods = mods
+	cs.offsets = make(map[hotstuff.ID]*clockOffsets)
+	cs.mods.MetricsEventLoop().RegisterHandler(proposalReceivedEvent{}, func(event interface{}) {
+		proposal := event.(proposalReceivedEvent)
+		cs.proposalReceived(proposal.id, proposal.received.Sub(proposal.sent))
+	})
+	cs.mods.MetricsEventLoop().RegisterObserver(types.TickEvent{}, func(event interface{}) {
+		cs.tick(event.(types.TickEvent))
+	})
+	cs.mods.Logger().Info("ClockSkew metric enabled")
+}
+
+func (cs *ClockSkew) proposalReceived(id hotstuff.ID, offset time.Duration) {
+	o, ok := cs.offsets[id]
+	if !ok {
+		o = &clockOffsets{min: math.Inf(1)}
+		cs.offsets[id] = o
+	}
+	ms := float64(offset) / float64(time.Millisecond)
+	o.Update(ms)
+	o.min = math.Min(o.min, ms)
+}
+
+func (cs *ClockSkew) tick(_ types.TickEvent) {
+	now := time.Now()
+	ids := make([]hotstuff.ID, 0, len(cs.offsets))
+	for id := range cs.offsets {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	for _, id := range ids {
+		o := cs.offsets[id]
+		mean, variance, count := o.Get()
+		cs.mods.MetricsLogger().Log(&types.ClockSkew{
+			Event:     types.NewReplicaEvent(uint32(cs.mods.ID()), now),
+			Replica:   uint32(id),
+			Offset:    mean,
+			Variance:  variance,
+			MinOffset: o.min,
+			Count:     count,
+		})
+	}
+	cs.offsets = make(map[hotstuff.ID]*clockOffsets)
+}
@@ -0,0 +1,56 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/metrics/types"
+	"github.com/relab/hotstuff/modules"
+)
+
+func TestClockSkew(t *testing.T) {
+	logger := &recordingLogger{}
+	builder := modules.NewBuilder(1)
+	builder.Register(logger, &ClockSkew{})
+	mods := builder.Build()
+
+	// the clocks of the other replicas are ahead of (positive) or behind (negative) our clock
+	skews := map[hotstuff.ID]time.Duration{
+		2: 0,
+		3: 200 * time.Millisecond,
+		4: -300 * time.Millisecond,
+	}
+	delays := []time.Duration{5 * time.Millisecond, 2 * time.Millisecond, 9 * time.Millisecond}
+	start := time.Now()
+	for i, delay := range delays {
+		received := start.Add(time.Duration(i) * 100 * time.Millisecond)
+		for id, skew := range skews {
+			sent := received.Add(-delay).Add(skew)
+			mods.MetricsEventLoop().AddEvent(proposalReceivedEvent{id: id, sent: sent, received: received})
+		}
+	}
+	mods.MetricsEventLoop().AddEvent(types.TickEvent{LastTick: start})
+
+	// process the queued events
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	mods.MetricsEventLoop().Run(ctx)
+
+	if len(logger.logged) != len(skews) {
+		t.Fatalf("expected %d measurements, got %d", len(skews), len(logger.logged))
+	}
+	for _, msg := range logger.logged {
+		m := msg.(*types.ClockSkew)
+		id := hotstuff.ID(m.GetReplica())
+		if m.GetCount() != uint64(len(delays)) {
+			t.Errorf("wrong count for replica %d: got: %d, want: %d", id, m.GetCount(), len(delays))
+		}
+		// the smallest offset is the one with the shortest delay, which is 2 ms.
+		want := float64(2*time.Millisecond-skews[id]) / float64(time.Millisecond)
+		if m.GetMinOffset() != want {
+			t.Errorf("wrong minimum offset for replica %d: got: %f, want: %f", id, m.GetMinOffset(), want)
+		}
+	}
+}
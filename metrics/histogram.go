@@ -0,0 +1,99 @@
+package metrics
+
+import (
+	"math"
+	"sort"
+)
+
+// Histogram counts values in buckets with configurable upper bounds, and estimates percentiles from the counts.
+// Unlike Welford, it reveals the tail of the distribution, such as the latency experienced by the slowest requests.
+type Histogram struct {
+	bounds []float64 // the inclusive upper bound of each bucket, in ascending order
+	counts []uint64  // the number of values in each bucket; the last bucket counts the values above all bounds
+	count  uint64
+	min    float64
+	max    float64
+}
+
+// NewHistogram returns a histogram with a bucket for each of the given upper bounds,
+// and a final bucket for the values that are larger than all bounds.
+func NewHistogram(bounds ...float64) *Histogram {
+	sorted := append([]float64(nil), bounds...)
+	sort.Float64s(sorted)
+	return &Histogram{
+		bounds: sorted,
+		counts: make([]uint64, len(sorted)+1),
+	}
+}
+
+// ExponentialBuckets returns count bucket bounds, where the first bound is start,
+// and each subsequent bound is factor times the previous one.
+func ExponentialBuckets(start, factor float64, count int) []float64 {
+	bounds := make([]float64, count)
+	for i := range bounds {
+		bounds[i] = start
+		start *= factor
+	}
+	return bounds
+}
+
+// Update adds the value to the bucket that it belongs to.
+func (h *Histogram) Update(val float64) {
+	i := sort.SearchFloat64s(h.bounds, val)
+	h.counts[i]++
+	if h.count == 0 || val < h.min {
+		h.min = val
+	}
+	if h.count == 0 || val > h.max {
+		h.max = val
+	}
+	h.count++
+}
+
+// Get returns the number of values in each bucket, and the estimated 50th, 90th and 99th percentiles.
+// The last count is the number of values that are larger than all bounds.
+func (h *Histogram) Get() (counts []uint64, p50, p90, p99 float64) {
+	counts = append([]uint64(nil), h.counts...)
+	return counts, h.Quantile(0.5), h.Quantile(0.9), h.Quantile(0.99)
+}
+
+// Quantile returns an estimate of the q-quantile, for 0 <= q <= 1, or NaN if no values have been added.
+// The estimate assumes that the values are spread evenly within the bucket that contains the quantile,
+// and is limited to the smallest and largest values that were added.
+func (h *Histogram) Quantile(q float64) float64 {
+	if h.count == 0 {
+		return math.NaN()
+	}
+	rank := q * float64(h.count)
+	var cumulative uint64
+	for i, count := range h.counts {
+		if count == 0 || float64(cumulative+count) < rank {
+			cumulative += count
+			continue
+		}
+		lower, upper := h.min, h.max
+		if i > 0 && h.bounds[i-1] > lower {
+			lower = h.bounds[i-1]
+		}
+		if i < len(h.bounds) && h.bounds[i] < upper {
+			upper = h.bounds[i]
+		}
+		return lower + (upper-lower)*(rank-float64(cumulative))/float64(count)
+	}
+	return h.max
+}
+
+// Count returns the total number of values that have been added to the histogram.
+func (h *Histogram) Count() uint64 {
+	return h.count
+}
+
+// Reset removes all values from the histogram, but keeps the bucket bounds.
+func (h *Histogram) Reset() {
+	for i := range h.counts {
+		h.counts[i] = 0
+	}
+	h.count = 0
+	h.min = 0
+	h.max = 0
+}
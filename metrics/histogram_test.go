@@ -0,0 +1,95 @@
+package metrics
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/relab/hotstuff/metrics/types"
+	"github.com/relab/hotstuff/modules"
+)
+
+func TestHistogram(t *testing.T) {
+	h := NewHistogram()
+	if !math.IsNaN(h.Quantile(0.5)) {
+		t.Error("expected NaN percentile for an empty histogram")
+	}
+
+	// buckets of 10 ms up to 100 ms
+	bounds := make([]float64, 10)
+	for i := range bounds {
+		bounds[i] = float64(10 * (i + 1))
+	}
+	h = NewHistogram(bounds...)
+	// 1 to 100 ms, such that each bucket has 10 values, and a single slow value in the overflow bucket
+	for i := 1; i <= 100; i++ {
+		h.Update(float64(i))
+	}
+	h.Update(1000)
+
+	counts, p50, p90, p99 := h.Get()
+	if len(counts) != len(bounds)+1 {
+		t.Fatalf("wrong number of buckets: got %d, want %d", len(counts), len(bounds)+1)
+	}
+	for i, count := range counts[:len(bounds)] {
+		if count != 10 {
+			t.Errorf("wrong count in bucket %d: got %d, want %d", i, count, 10)
+		}
+	}
+	if counts[len(bounds)] != 1 {
+		t.Errorf("wrong count in overflow bucket: got %d, want %d", counts[len(bounds)], 1)
+	}
+
+	// with the slow value, the median is 51 ms and the 90th percentile is 91 ms.
+	for _, tc := range []struct {
+		name         string
+		got          float64
+		lower, upper float64
+	}{
+		{"p50", p50, 50, 60},
+		{"p90", p90, 90, 100},
+		{"p99", p99, 90, 100},
+		{"p100", h.Quantile(1), 1000, 1000},
+	} {
+		if tc.got < tc.lower || tc.got > tc.upper {
+			t.Errorf("%s = %f, want in [%f, %f]", tc.name, tc.got, tc.lower, tc.upper)
+		}
+	}
+
+	h.Reset()
+	if counts, _, _, _ := h.Get(); h.Count() != 0 || counts[0] != 0 {
+		t.Error("histogram not empty after reset")
+	}
+}
+
+func TestClientLatencyPercentiles(t *testing.T) {
+	logger := &recordingLogger{}
+	lr := &ClientLatency{}
+	builder := modules.NewBuilder(1)
+	builder.Register(logger, lr)
+	builder.Build()
+
+	// most requests take 2 ms, but 5 percent take 500 ms
+	for i := 0; i < 100; i++ {
+		latency := 2 * time.Millisecond
+		if i%20 == 0 {
+			latency = 500 * time.Millisecond
+		}
+		lr.addLatency(latency)
+	}
+	lr.tick(types.TickEvent{})
+
+	if len(logger.logged) != 1 {
+		t.Fatalf("expected 1 measurement, got %d", len(logger.logged))
+	}
+	m := logger.logged[0].(*types.LatencyMeasurement)
+	if m.GetP50() != 2 || m.GetP90() != 2 {
+		t.Errorf("wrong median or p90: got %f and %f, want %f", m.GetP50(), m.GetP90(), 2.0)
+	}
+	if m.GetP99() <= 256 || m.GetP99() > 500 {
+		t.Errorf("p99 = %f, want in (256, 500]", m.GetP99())
+	}
+	if len(m.GetBuckets()) != len(m.GetBounds())+1 {
+		t.Errorf("wrong number of buckets: got %d, want %d", len(m.GetBuckets()), len(m.GetBounds())+1)
+	}
+}
@@ -0,0 +1,71 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/leaderrotation"
+	"github.com/relab/hotstuff/metrics/types"
+	"github.com/relab/hotstuff/modules"
+)
+
+func init() {
+	RegisterReplicaMetric("leader-reputation", func() interface{} {
+		return &LeaderReputation{}
+	})
+}
+
+// leaderReputationEvent is sent from the consensus event loop to the metrics event loop
+// with the reputation scores computed by the leader rotation.
+type leaderReputationEvent struct {
+	scores map[hotstuff.ID]float64
+}
+
+// LeaderReputation logs the reputation score of each replica, as computed by the leader rotation.
+// The leader rotation must implement leaderrotation.Scorer; otherwise, nothing is logged.
+type LeaderReputation struct {
+	mods      *modules.Modules
+	consensus *consensus.Modules
+}
+
+// InitConsensusModule gives the module a reference to the Modules object.
+// It also allows the module to set module options using the OptionsBuilder.
+func (lr *LeaderReputation) InitConsensusModule(mods *consensus.Modules, _ *consensus.OptionsBuilder) {
+	lr.consensus = mods
+}
+
+// InitModule gives the module access to the other modules.
+func (lr *LeaderReputation) InitModule(mods *modules.Modules) {
+	lr.mods = mods
+	lr.mods.MetricsEventLoop().RegisterHandler(leaderReputationEvent{}, func(event interface{}) {
+		lr.log(event.(leaderReputationEvent).scores)
+	})
+	lr.mods.MetricsEventLoop().RegisterObserver(types.TickEvent{}, func(event interface{}) {
+		lr.tick(event.(types.TickEvent))
+	})
+	lr.mods.Logger().Info("LeaderReputation metric enabled")
+}
+
+func (lr *LeaderReputation) tick(_ types.TickEvent) {
+	scorer, ok := lr.consensus.LeaderRotation().(leaderrotation.Scorer)
+	if !ok {
+		return
+	}
+	// the scores are updated by the consensus event loop, so that is where they must be read.
+	// The metrics event loop must not wait for the consensus event loop, hence the goroutine.
+	go lr.consensus.EventLoop().AddEvent(func() {
+		lr.mods.MetricsEventLoop().AddEvent(leaderReputationEvent{scores: scorer.Scores()})
+	})
+}
+
+func (lr *LeaderReputation) log(scores map[hotstuff.ID]float64) {
+	event := &types.LeaderReputation{
+		Event:  types.NewReplicaEvent(uint32(lr.mods.ID()), time.Now()),
+		Scores: make(map[uint32]float64, len(scores)),
+	}
+	for id, score := range scores {
+		event.Scores[uint32(id)] = score
+	}
+	lr.mods.MetricsLogger().Log(event)
+}
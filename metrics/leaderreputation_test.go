@@ -0,0 +1,62 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/blockchain"
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/internal/mocks"
+	"github.com/relab/hotstuff/internal/testutil"
+	"github.com/relab/hotstuff/leaderrotation"
+	"github.com/relab/hotstuff/metrics/types"
+)
+
+func TestLeaderReputation(t *testing.T) {
+	const n = 4
+	ctrl := gomock.NewController(t)
+	logger := &recordingLogger{}
+	lr := &LeaderReputation{}
+	cfg, replicas := testutil.CreateMockConfigurationWithReplicas(t, ctrl, n)
+	sync := mocks.NewMockSynchronizer(ctrl)
+	cs := mocks.NewMockConsensus(ctrl)
+	builder := consensus.NewBuilder(1, nil)
+	builder.Register(logger, lr, cfg, sync, cs, blockchain.New(), leaderrotation.NewReputation(4, 1))
+	mods := builder.Build()
+
+	cfg.EXPECT().Replicas().AnyTimes().DoAndReturn(func() map[hotstuff.ID]consensus.Replica {
+		m := make(map[hotstuff.ID]consensus.Replica)
+		for _, replica := range replicas {
+			m[replica.ID()] = replica
+		}
+		return m
+	})
+	sync.EXPECT().View().AnyTimes().Return(consensus.View(1))
+	cs.EXPECT().CommittedBlock().AnyTimes().Return(consensus.GetGenesis())
+
+	lr.tick(types.TickEvent{})
+	deadline := time.Now().Add(time.Second)
+	for mods.EventLoop().Len() == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	mods.EventLoop().Run(ctx)
+	mods.MetricsEventLoop().Run(ctx)
+
+	if len(logger.logged) != 1 {
+		t.Fatalf("expected one measurement, got %d", len(logger.logged))
+	}
+	scores := logger.logged[0].(*types.LeaderReputation).GetScores()
+	if len(scores) != n {
+		t.Errorf("wrong number of scores: got: %d, want: %d", len(scores), n)
+	}
+	for id := hotstuff.ID(1); id <= n; id++ {
+		if _, ok := scores[uint32(id)]; !ok {
+			t.Errorf("no score for replica %d", id)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/metrics/types"
+	"github.com/relab/hotstuff/modules"
+)
+
+func init() {
+	RegisterReplicaMetric("commit-lock", func() interface{} {
+		return &CommitLockHoldTime{}
+	})
+}
+
+// CommitLockHoldTime measures the time that the consensus lock is held while committing and executing blocks.
+// It does not measure the other sections that hold the lock, which only read or update a few fields.
+type CommitLockHoldTime struct {
+	mods *modules.Modules
+	wf   Welford
+}
+
+// InitModule gives the module access to the other modules.
+func (lh *CommitLockHoldTime) InitModule(mods *modules.Modules) {
+	lh.mods = mods
+	lh.mods.MetricsEventLoop().RegisterObserver(consensus.LockHeldEvent{}, func(event interface{}) {
+		lh.addDuration(event.(consensus.LockHeldEvent).Duration)
+	})
+	lh.mods.MetricsEventLoop().RegisterObserver(types.TickEvent{}, func(event interface{}) {
+		lh.tick(event.(types.TickEvent))
+	})
+	lh.mods.Logger().Info("CommitLockHoldTime metric enabled")
+}
+
+func (lh *CommitLockHoldTime) addDuration(held time.Duration) {
+	millis := float64(held) / float64(time.Millisecond)
+	lh.wf.Update(millis)
+}
+
+func (lh *CommitLockHoldTime) tick(_ types.TickEvent) {
+	mean, variance, count := lh.wf.Get()
+	lh.mods.MetricsLogger().Log(&types.CommitLockHoldTime{
+		Event:    types.NewReplicaEvent(uint32(lh.mods.ID()), time.Now()),
+		Mean:     mean,
+		Variance: variance,
+		Count:    count,
+	})
+	lh.wf.Reset()
+}
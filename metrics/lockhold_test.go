@@ -0,0 +1,49 @@
+package metrics
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/metrics/types"
+	"github.com/relab/hotstuff/modules"
+)
+
+func TestCommitLockHoldTime(t *testing.T) {
+	logger := &recordingLogger{}
+	builder := modules.NewBuilder(1)
+	builder.Register(logger, &CommitLockHoldTime{})
+	mods := builder.Build()
+
+	// simulate load by reporting many commits between ticks
+	const commits = 50
+	for i := 1; i <= commits; i++ {
+		mods.MetricsEventLoop().AddEvent(consensus.LockHeldEvent{Duration: time.Duration(i) * time.Millisecond})
+	}
+	mods.MetricsEventLoop().AddEvent(types.TickEvent{})
+	mods.MetricsEventLoop().AddEvent(types.TickEvent{})
+
+	// process the queued events
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	mods.MetricsEventLoop().Run(ctx)
+
+	if len(logger.logged) != 2 {
+		t.Fatalf("expected two measurements, got %d", len(logger.logged))
+	}
+	m := logger.logged[0].(*types.CommitLockHoldTime)
+	if m.GetCount() != commits {
+		t.Errorf("wrong count: got: %d, want: %d", m.GetCount(), commits)
+	}
+	if want := float64(commits+1) / 2; math.Abs(m.GetMean()-want) > 1e-9 {
+		t.Errorf("wrong mean: got: %f, want: %f", m.GetMean(), want)
+	}
+	if m.GetVariance() <= 0 {
+		t.Errorf("expected a positive variance, got %f", m.GetVariance())
+	}
+	if count := logger.logged[1].(*types.CommitLockHoldTime).GetCount(); count != 0 {
+		t.Errorf("measurements were not reset after tick: %d", count)
+	}
+}
@@ -0,0 +1,68 @@
+package metrics
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/metrics/types"
+	"github.com/relab/hotstuff/modules"
+)
+
+func init() {
+	RegisterReplicaMetric("proposal-delivery", func() interface{} {
+		return &ProposalDelivery{}
+	})
+}
+
+// ProposalDelivery compares the number of proposal acknowledgments received by the leader with the number of votes.
+// If there are more acknowledgments than votes, the proposals were received, but not voted for.
+// This requires proposal acknowledgments to be enabled.
+// If abstaining is enabled, it also counts the replicas that abstained from voting for the proposals.
+type ProposalDelivery struct {
+	mods      *modules.Modules
+	consensus *consensus.Modules
+
+	// the counters are updated by the consensus event loop and read by the metrics event loop.
+	acks      uint64
+	votes     uint64
+	abstained uint64
+}
+
+// InitConsensusModule gives the module a reference to the Modules object.
+// It also allows the module to set module options using the OptionsBuilder.
+func (pd *ProposalDelivery) InitConsensusModule(mods *consensus.Modules, _ *consensus.OptionsBuilder) {
+	pd.consensus = mods
+	pd.consensus.EventLoop().RegisterObserver(consensus.ProposalAckMsg{}, func(_ interface{}) {
+		atomic.AddUint64(&pd.acks, 1)
+	})
+	pd.consensus.EventLoop().RegisterObserver(consensus.VoteMsg{}, func(event interface{}) {
+		vote := event.(consensus.VoteMsg)
+		// the leader does not acknowledge its own proposals, so we ignore its own votes.
+		// deferred votes have already been counted.
+		if vote.ID != pd.consensus.ID() && !vote.Deferred {
+			atomic.AddUint64(&pd.votes, 1)
+		}
+	})
+}
+
+// InitModule gives the module access to the other modules.
+func (pd *ProposalDelivery) InitModule(mods *modules.Modules) {
+	pd.mods = mods
+	pd.mods.MetricsEventLoop().RegisterObserver(types.TickEvent{}, func(event interface{}) {
+		pd.tick(event.(types.TickEvent))
+	})
+	pd.mods.MetricsEventLoop().RegisterObserver(consensus.AbstainEvent{}, func(_ interface{}) {
+		atomic.AddUint64(&pd.abstained, 1)
+	})
+	pd.mods.Logger().Info("ProposalDelivery metric enabled")
+}
+
+func (pd *ProposalDelivery) tick(_ types.TickEvent) {
+	pd.mods.MetricsLogger().Log(&types.ProposalDelivery{
+		Event:     types.NewReplicaEvent(uint32(pd.mods.ID()), time.Now()),
+		Delivered: atomic.SwapUint64(&pd.acks, 0),
+		Voted:     atomic.SwapUint64(&pd.votes, 0),
+		Abstained: atomic.SwapUint64(&pd.abstained, 0),
+	})
+}
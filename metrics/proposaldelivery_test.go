@@ -0,0 +1,62 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/internal/mocks"
+	"github.com/relab/hotstuff/metrics/types"
+)
+
+func TestProposalDelivery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	logger := &recordingLogger{}
+	pd := &ProposalDelivery{}
+	// the metric only observes the votes, so we pause consensus to prevent the voting machine from handling them.
+	cs := mocks.NewMockConsensus(ctrl)
+	cs.EXPECT().Paused().AnyTimes().Return(true)
+	builder := consensus.NewBuilder(1, nil)
+	builder.Register(logger, pd, cs)
+	mods := builder.Build()
+
+	hash := consensus.GetGenesis().Hash()
+	// all replicas received the proposal, but replica 4 did not vote
+	for id := hotstuff.ID(2); id <= 4; id++ {
+		mods.EventLoop().AddEvent(consensus.ProposalAckMsg{ID: id, BlockHash: hash})
+	}
+	for id := hotstuff.ID(1); id <= 3; id++ {
+		mods.EventLoop().AddEvent(consensus.VoteMsg{ID: id})
+	}
+
+	mods.MetricsEventLoop().AddEvent(consensus.AbstainEvent{ID: 4, View: 1, Abstained: 1})
+
+	// process the queued events
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	mods.EventLoop().Run(ctx)
+	mods.MetricsEventLoop().Run(ctx)
+
+	pd.tick(types.TickEvent{})
+	pd.tick(types.TickEvent{})
+
+	if len(logger.logged) != 2 {
+		t.Fatalf("expected two measurements, got %d", len(logger.logged))
+	}
+	m := logger.logged[0].(*types.ProposalDelivery)
+	if m.GetDelivered() != 3 {
+		t.Errorf("wrong number of delivered proposals: got: %d, want: %d", m.GetDelivered(), 3)
+	}
+	// the leader's own vote is not counted
+	if m.GetVoted() != 2 {
+		t.Errorf("wrong number of votes: got: %d, want: %d", m.GetVoted(), 2)
+	}
+	if m.GetAbstained() != 1 {
+		t.Errorf("wrong number of abstains: got: %d, want: %d", m.GetAbstained(), 1)
+	}
+	if m := logger.logged[1].(*types.ProposalDelivery); m.GetDelivered() != 0 || m.GetVoted() != 0 || m.GetAbstained() != 0 {
+		t.Errorf("counts were not reset after tick: %v", m)
+	}
+}
@@ -0,0 +1,76 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/metrics/types"
+	"github.com/relab/hotstuff/modules"
+)
+
+func init() {
+	RegisterReplicaMetric("qc-latency", func() interface{} {
+		return &QCLatency{}
+	})
+}
+
+// QCLatency measures the time from the leader sending a proposal until it has created the QC for the proposed block.
+// Unlike the client latency, it only includes the time spent collecting votes, and not the time spent waiting to
+// propose or execute commands. It also counts the votes that arrived after the QC was created.
+type QCLatency struct {
+	mods      *modules.Modules
+	sent      map[consensus.Hash]time.Time // the time that each of our proposals was sent
+	latency   Welford
+	lateVotes uint64
+}
+
+// InitModule gives the module access to the other modules.
+func (ql *QCLatency) InitModule(mods *modules.Modules) {
+	ql.mods = mods
+	ql.sent = make(map[consensus.Hash]time.Time)
+	// other metrics handle the same events, so we must observe them instead of replacing their handlers.
+	ql.mods.MetricsEventLoop().RegisterObserver(consensus.ProposalSentEvent{}, func(event interface{}) {
+		proposal := event.(consensus.ProposalSentEvent)
+		ql.sent[proposal.BlockHash] = proposal.Time
+	})
+	ql.mods.MetricsEventLoop().RegisterObserver(consensus.QCFormedEvent{}, func(event interface{}) {
+		qc := event.(consensus.QCFormedEvent)
+		ql.qcFormed(qc.BlockHash, qc.Time)
+	})
+	ql.mods.MetricsEventLoop().RegisterObserver(consensus.LateVoteEvent{}, func(_ interface{}) {
+		ql.lateVotes++
+	})
+	ql.mods.MetricsEventLoop().RegisterObserver(types.TickEvent{}, func(event interface{}) {
+		ql.tick(event.(types.TickEvent))
+	})
+	ql.mods.Logger().Info("QCLatency metric enabled")
+}
+
+func (ql *QCLatency) qcFormed(hash consensus.Hash, formed time.Time) {
+	sent, ok := ql.sent[hash]
+	if !ok {
+		// not one of our proposals, or the QC was already created
+		return
+	}
+	delete(ql.sent, hash)
+	ql.latency.Update(float64(formed.Sub(sent)) / float64(time.Millisecond))
+}
+
+func (ql *QCLatency) tick(tick types.TickEvent) {
+	mean, variance, count := ql.latency.Get()
+	ql.mods.MetricsLogger().Log(&types.QCLatency{
+		Event:     types.NewReplicaEvent(uint32(ql.mods.ID()), time.Now()),
+		Latency:   mean,
+		Variance:  variance,
+		Count:     count,
+		LateVotes: ql.lateVotes,
+	})
+	ql.latency.Reset()
+	ql.lateVotes = 0
+	// forget the proposals that were sent before the previous tick; they are unlikely to be certified.
+	for hash, sent := range ql.sent {
+		if sent.Before(tick.LastTick) {
+			delete(ql.sent, hash)
+		}
+	}
+}
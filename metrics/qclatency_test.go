@@ -0,0 +1,89 @@
+package metrics
+
+import (
+	"context"
+	"crypto/sha256"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/metrics/types"
+	"github.com/relab/hotstuff/modules"
+)
+
+func TestQCLatency(t *testing.T) {
+	logger := &recordingLogger{}
+	builder := modules.NewBuilder(1)
+	builder.Register(logger, &QCLatency{})
+	mods := builder.Build()
+
+	intervals := []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 30 * time.Millisecond, 40 * time.Millisecond}
+	start := time.Now()
+	for i, interval := range intervals {
+		hash := sha256.Sum256([]byte{byte(i)})
+		sent := start.Add(time.Duration(i) * 100 * time.Millisecond)
+		mods.MetricsEventLoop().AddEvent(consensus.ProposalSentEvent{BlockHash: hash, Time: sent})
+		mods.MetricsEventLoop().AddEvent(consensus.QCFormedEvent{BlockHash: hash, Time: sent.Add(interval)})
+	}
+	// a QC for a block that we did not propose should be ignored
+	mods.MetricsEventLoop().AddEvent(consensus.QCFormedEvent{BlockHash: consensus.GetGenesis().Hash(), Time: start.Add(time.Hour)})
+	mods.MetricsEventLoop().AddEvent(consensus.LateVoteEvent{ID: 2, BlockHash: sha256.Sum256([]byte{0})})
+	mods.MetricsEventLoop().AddEvent(types.TickEvent{LastTick: start})
+
+	// process the queued events
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	mods.MetricsEventLoop().Run(ctx)
+
+	if len(logger.logged) != 1 {
+		t.Fatalf("expected 1 measurement, got %d", len(logger.logged))
+	}
+	m := logger.logged[0].(*types.QCLatency)
+	if m.GetCount() != uint64(len(intervals)) {
+		t.Errorf("wrong count: got: %d, want: %d", m.GetCount(), len(intervals))
+	}
+	// the intervals are 10, 20, 30 and 40 ms, so the mean is 25 ms and the sample variance is 500/3 ms^2.
+	if m.GetLatency() != 25 {
+		t.Errorf("wrong latency: got: %f, want: %f", m.GetLatency(), 25.0)
+	}
+	if want := 500.0 / 3; math.Abs(m.GetVariance()-want) > 1e-9 {
+		t.Errorf("wrong variance: got: %f, want: %f", m.GetVariance(), want)
+	}
+	if m.GetLateVotes() != 1 {
+		t.Errorf("wrong number of late votes: got: %d, want: %d", m.GetLateVotes(), 1)
+	}
+}
+
+// TestQCLatencyWithVoteLatency checks that the QC latency and vote latency metrics both see the proposals
+// when they are enabled together.
+func TestQCLatencyWithVoteLatency(t *testing.T) {
+	logger := &recordingLogger{}
+	builder := modules.NewBuilder(1)
+	vl := &VoteLatency{}
+	ql := &QCLatency{}
+	builder.Register(logger, vl, ql)
+	mods := builder.Build()
+
+	start := time.Now()
+	hash := sha256.Sum256([]byte("foo"))
+	mods.MetricsEventLoop().AddEvent(consensus.ProposalSentEvent{BlockHash: hash, Time: start})
+	mods.MetricsEventLoop().AddEvent(voteReceivedEvent{id: 2, blockHash: hash, time: start.Add(10 * time.Millisecond)})
+	mods.MetricsEventLoop().AddEvent(consensus.QCFormedEvent{BlockHash: hash, Time: start.Add(20 * time.Millisecond)})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	mods.MetricsEventLoop().Run(ctx)
+	vl.tick(types.TickEvent{})
+	ql.tick(types.TickEvent{})
+
+	if len(logger.logged) != 2 {
+		t.Fatalf("expected two measurements, got %d", len(logger.logged))
+	}
+	if m := logger.logged[0].(*types.VoteLatency); m.GetCount() != 1 || m.GetLatency() != 10 {
+		t.Errorf("vote latency: got latency %.1f and count %d, want latency 10.0 and count 1", m.GetLatency(), m.GetCount())
+	}
+	if m := logger.logged[1].(*types.QCLatency); m.GetCount() != 1 || m.GetLatency() != 20 {
+		t.Errorf("QC latency: got latency %.1f and count %d, want latency 20.0 and count 1", m.GetLatency(), m.GetCount())
+	}
+}
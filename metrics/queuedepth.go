@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/metrics/types"
+	"github.com/relab/hotstuff/modules"
+)
+
+func init() {
+	RegisterReplicaMetric("queue-depth", func() interface{} {
+		return &ProcessingQueueDepth{}
+	})
+}
+
+// ProcessingQueueDepth measures the number of events waiting to be processed by the consensus event loop.
+// A queue that keeps growing indicates that the consensus event loop is the bottleneck.
+type ProcessingQueueDepth struct {
+	mods      *modules.Modules
+	consensus *consensus.Modules
+}
+
+// InitConsensusModule gives the module a reference to the Modules object.
+// It also allows the module to set module options using the OptionsBuilder.
+func (qd *ProcessingQueueDepth) InitConsensusModule(mods *consensus.Modules, _ *consensus.OptionsBuilder) {
+	qd.consensus = mods
+}
+
+// InitModule gives the module access to the other modules.
+func (qd *ProcessingQueueDepth) InitModule(mods *modules.Modules) {
+	qd.mods = mods
+	qd.mods.MetricsEventLoop().RegisterObserver(types.TickEvent{}, func(event interface{}) {
+		qd.tick(event.(types.TickEvent))
+	})
+	qd.mods.Logger().Info("ProcessingQueueDepth metric enabled")
+}
+
+func (qd *ProcessingQueueDepth) tick(_ types.TickEvent) {
+	qd.mods.MetricsLogger().Log(&types.ProcessingQueueDepth{
+		Event: types.NewReplicaEvent(uint32(qd.mods.ID()), time.Now()),
+		Depth: uint64(qd.consensus.EventLoop().Len()),
+	})
+}
@@ -0,0 +1,48 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/metrics/types"
+	"google.golang.org/protobuf/proto"
+)
+
+type recordingLogger struct {
+	logged []proto.Message
+}
+
+func (l *recordingLogger) Log(msg proto.Message) {
+	l.logged = append(l.logged, msg)
+}
+
+func (l *recordingLogger) Close() error {
+	return nil
+}
+
+func TestProcessingQueueDepth(t *testing.T) {
+	logger := &recordingLogger{}
+	qd := &ProcessingQueueDepth{}
+	builder := consensus.NewBuilder(1, nil)
+	builder.Register(logger, qd)
+	mods := builder.Build()
+
+	// the consensus event loop is not running, so the events will stay in the queue
+	const backlog = 5
+	for i := 0; i < backlog; i++ {
+		mods.EventLoop().AddEvent(i)
+	}
+
+	qd.tick(types.TickEvent{})
+
+	if len(logger.logged) != 1 {
+		t.Fatalf("expected one measurement, got %d", len(logger.logged))
+	}
+	m, ok := logger.logged[0].(*types.ProcessingQueueDepth)
+	if !ok {
+		t.Fatalf("wrong type of measurement: %T", logger.logged[0])
+	}
+	if m.GetDepth() != backlog {
+		t.Errorf("wrong queue depth: got: %d, want: %d", m.GetDepth(), backlog)
+	}
+}
@@ -0,0 +1,47 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/metrics/types"
+	"github.com/relab/hotstuff/modules"
+)
+
+func init() {
+	RegisterReplicaMetric("rejected-messages", func() interface{} {
+		return &RejectedMessages{}
+	})
+}
+
+// RejectedMessages counts the messages that were dropped or rejected, grouped by the reason for the rejection.
+type RejectedMessages struct {
+	mods   *modules.Modules
+	counts map[consensus.RejectReason]uint64
+}
+
+// InitModule gives the module access to the other modules.
+func (rm *RejectedMessages) InitModule(mods *modules.Modules) {
+	rm.mods = mods
+	rm.counts = make(map[consensus.RejectReason]uint64)
+	rm.mods.MetricsEventLoop().RegisterHandler(consensus.MessageRejectedEvent{}, func(event interface{}) {
+		rm.counts[event.(consensus.MessageRejectedEvent).Reason]++
+	})
+	rm.mods.MetricsEventLoop().RegisterObserver(types.TickEvent{}, func(event interface{}) {
+		rm.tick(event.(types.TickEvent))
+	})
+	rm.mods.Logger().Info("RejectedMessages metric enabled")
+}
+
+func (rm *RejectedMessages) tick(_ types.TickEvent) {
+	counts := make(map[string]uint64, len(rm.counts))
+	for reason, count := range rm.counts {
+		counts[reason.String()] = count
+	}
+	rm.mods.MetricsLogger().Log(&types.RejectedMessages{
+		Event:  types.NewReplicaEvent(uint32(rm.mods.ID()), time.Now()),
+		Counts: counts,
+	})
+	// reset counts for next tick
+	rm.counts = make(map[consensus.RejectReason]uint64)
+}
@@ -0,0 +1,57 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/metrics/types"
+	"github.com/relab/hotstuff/modules"
+)
+
+func TestRejectedMessages(t *testing.T) {
+	logger := &recordingLogger{}
+	builder := modules.NewBuilder(1)
+	builder.Register(logger, &RejectedMessages{})
+	mods := builder.Build()
+
+	rejections := []consensus.MessageRejectedEvent{
+		{Reason: consensus.RejectBadSignature, Type: "VoteMsg", Sender: 2},
+		{Reason: consensus.RejectBadSignature, Type: "TimeoutMsg", Sender: 3},
+		{Reason: consensus.RejectStaleView, Type: "ProposeMsg", Sender: 2},
+		{Reason: consensus.RejectUnknownBlock, Type: "VoteMsg", Sender: 4},
+		{Reason: consensus.RejectBadSignature, Type: "ProposeMsg", Sender: 4},
+	}
+	for _, event := range rejections {
+		mods.MetricsEventLoop().AddEvent(event)
+	}
+	mods.MetricsEventLoop().AddEvent(types.TickEvent{})
+	// a second tick should report that the counts were reset
+	mods.MetricsEventLoop().AddEvent(types.TickEvent{})
+
+	// process the queued events
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	mods.MetricsEventLoop().Run(ctx)
+
+	if len(logger.logged) != 2 {
+		t.Fatalf("expected two measurements, got %d", len(logger.logged))
+	}
+	want := map[string]uint64{
+		"bad signature": 3,
+		"stale view":    1,
+		"unknown block": 1,
+	}
+	got := logger.logged[0].(*types.RejectedMessages).GetCounts()
+	if len(got) != len(want) {
+		t.Errorf("wrong number of reasons: got: %v, want: %v", got, want)
+	}
+	for reason, count := range want {
+		if got[reason] != count {
+			t.Errorf("wrong count for %q: got: %d, want: %d", reason, got[reason], count)
+		}
+	}
+	if counts := logger.logged[1].(*types.RejectedMessages).GetCounts(); len(counts) != 0 {
+		t.Errorf("counts were not reset after tick: %v", counts)
+	}
+}
@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/metrics/types"
+	"github.com/relab/hotstuff/modules"
+)
+
+func TestThroughput(t *testing.T) {
+	logger := &recordingLogger{}
+	builder := modules.NewBuilder(1)
+	tp := &Throughput{}
+	builder.Register(logger, tp)
+	mods := builder.Build()
+
+	// the commands committed in each interval between ticks
+	intervals := [][]int{{10, 20, 30}, {5}}
+	for _, commits := range intervals {
+		for _, commands := range commits {
+			mods.MetricsEventLoop().AddEvent(consensus.CommitEvent{Commands: commands})
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		mods.MetricsEventLoop().Run(ctx)
+		tp.tick(types.TickEvent{LastTick: time.Now().Add(-2 * time.Second)})
+	}
+
+	if len(logger.logged) != len(intervals) {
+		t.Fatalf("expected %d measurements, got %d", len(intervals), len(logger.logged))
+	}
+	want := []struct {
+		commits, commands uint64
+	}{{3, 60}, {1, 5}}
+	for i, w := range want {
+		m := logger.logged[i].(*types.ThroughputMeasurement)
+		if m.GetCommits() != w.commits || m.GetCommands() != w.commands {
+			t.Errorf("tick %d: got %d commits and %d commands, want %d commits and %d commands",
+				i+1, m.GetCommits(), m.GetCommands(), w.commits, w.commands)
+		}
+		rate := float64(m.GetCommands()) / m.GetDuration().AsDuration().Seconds()
+		if wantRate := float64(w.commands) / 2; math.Abs(rate-wantRate) > 0.1*wantRate {
+			t.Errorf("tick %d: got %.2f commands per second, want %.2f", i+1, rate, wantRate)
+		}
+	}
+}
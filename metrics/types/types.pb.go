@@ -215,6 +215,15 @@ type LatencyMeasurement struct {
 	Latency  float64 `protobuf:"fixed64,2,opt,name=Latency,proto3" json:"Latency,omitempty"`
 	Variance float64 `protobuf:"fixed64,3,opt,name=Variance,proto3" json:"Variance,omitempty"`
 	Count    uint64  `protobuf:"varint,4,opt,name=Count,proto3" json:"Count,omitempty"`
+	// Estimated percentiles of the latency in milliseconds.
+	P50 float64 `protobuf:"fixed64,5,opt,name=P50,proto3" json:"P50,omitempty"`
+	P90 float64 `protobuf:"fixed64,6,opt,name=P90,proto3" json:"P90,omitempty"`
+	P99 float64 `protobuf:"fixed64,7,opt,name=P99,proto3" json:"P99,omitempty"`
+	// The upper bound of each histogram bucket in milliseconds.
+	Bounds []float64 `protobuf:"fixed64,8,rep,packed,name=Bounds,proto3" json:"Bounds,omitempty"`
+	// Number of measurements in each histogram bucket.
+	// The last bucket counts the measurements that are larger than all bounds.
+	Buckets []uint64 `protobuf:"varint,9,rep,packed,name=Buckets,proto3" json:"Buckets,omitempty"`
 }
 
 func (x *LatencyMeasurement) Reset() {
@@ -277,35 +286,626 @@ func (x *LatencyMeasurement) GetCount() uint64 {
 	return 0
 }
 
+func (x *LatencyMeasurement) GetP50() float64 {
+	if x != nil {
+		return x.P50
+	}
+	return 0
+}
+
+func (x *LatencyMeasurement) GetP90() float64 {
+	if x != nil {
+		return x.P90
+	}
+	return 0
+}
+
+func (x *LatencyMeasurement) GetP99() float64 {
+	if x != nil {
+		return x.P99
+	}
+	return 0
+}
+
+func (x *LatencyMeasurement) GetBounds() []float64 {
+	if x != nil {
+		return x.Bounds
+	}
+	return nil
+}
+
+func (x *LatencyMeasurement) GetBuckets() []uint64 {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
 type ViewTimeouts struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	Event *Event `protobuf:"bytes,1,opt,name=Event,proto3" json:"Event,omitempty"`
-	// Number of views since last reading.
-	Views uint64 `protobuf:"varint,2,opt,name=Views,proto3" json:"Views,omitempty"`
-	// Number of view timeouts.
-	Timeouts uint64 `protobuf:"varint,3,opt,name=Timeouts,proto3" json:"Timeouts,omitempty"`
+	// Number of views since last reading.
+	Views uint64 `protobuf:"varint,2,opt,name=Views,proto3" json:"Views,omitempty"`
+	// Number of view timeouts.
+	Timeouts uint64 `protobuf:"varint,3,opt,name=Timeouts,proto3" json:"Timeouts,omitempty"`
+}
+
+func (x *ViewTimeouts) Reset() {
+	*x = ViewTimeouts{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_metrics_types_types_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ViewTimeouts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ViewTimeouts) ProtoMessage() {}
+
+func (x *ViewTimeouts) ProtoReflect() protoreflect.Message {
+	mi := &file_metrics_types_types_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ViewTimeouts.ProtoReflect.Descriptor instead.
+func (*ViewTimeouts) Descriptor() ([]byte, []int) {
+	return file_metrics_types_types_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ViewTimeouts) GetEvent() *Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *ViewTimeouts) GetViews() uint64 {
+	if x != nil {
+		return x.Views
+	}
+	return 0
+}
+
+func (x *ViewTimeouts) GetTimeouts() uint64 {
+	if x != nil {
+		return x.Timeouts
+	}
+	return 0
+}
+
+type ProcessingQueueDepth struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Event *Event `protobuf:"bytes,1,opt,name=Event,proto3" json:"Event,omitempty"`
+	// Number of events waiting to be processed by the consensus event loop.
+	Depth uint64 `protobuf:"varint,2,opt,name=Depth,proto3" json:"Depth,omitempty"`
+}
+
+func (x *ProcessingQueueDepth) Reset() {
+	*x = ProcessingQueueDepth{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_metrics_types_types_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProcessingQueueDepth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProcessingQueueDepth) ProtoMessage() {}
+
+func (x *ProcessingQueueDepth) ProtoReflect() protoreflect.Message {
+	mi := &file_metrics_types_types_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProcessingQueueDepth.ProtoReflect.Descriptor instead.
+func (*ProcessingQueueDepth) Descriptor() ([]byte, []int) {
+	return file_metrics_types_types_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ProcessingQueueDepth) GetEvent() *Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *ProcessingQueueDepth) GetDepth() uint64 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+type RejectedMessages struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Event *Event `protobuf:"bytes,1,opt,name=Event,proto3" json:"Event,omitempty"`
+	// Number of rejected messages since last reading, per rejection reason.
+	Counts map[string]uint64 `protobuf:"bytes,2,rep,name=Counts,proto3" json:"Counts,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (x *RejectedMessages) Reset() {
+	*x = RejectedMessages{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_metrics_types_types_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RejectedMessages) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectedMessages) ProtoMessage() {}
+
+func (x *RejectedMessages) ProtoReflect() protoreflect.Message {
+	mi := &file_metrics_types_types_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectedMessages.ProtoReflect.Descriptor instead.
+func (*RejectedMessages) Descriptor() ([]byte, []int) {
+	return file_metrics_types_types_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RejectedMessages) GetEvent() *Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *RejectedMessages) GetCounts() map[string]uint64 {
+	if x != nil {
+		return x.Counts
+	}
+	return nil
+}
+
+type CommitLockHoldTime struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Event *Event `protobuf:"bytes,1,opt,name=Event,proto3" json:"Event,omitempty"`
+	// Mean time in milliseconds that the consensus lock was held while
+	// committing blocks.
+	Mean     float64 `protobuf:"fixed64,2,opt,name=Mean,proto3" json:"Mean,omitempty"`
+	Variance float64 `protobuf:"fixed64,3,opt,name=Variance,proto3" json:"Variance,omitempty"`
+	// Number of commits since last reading.
+	Count uint64 `protobuf:"varint,4,opt,name=Count,proto3" json:"Count,omitempty"`
+}
+
+func (x *CommitLockHoldTime) Reset() {
+	*x = CommitLockHoldTime{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_metrics_types_types_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CommitLockHoldTime) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitLockHoldTime) ProtoMessage() {}
+
+func (x *CommitLockHoldTime) ProtoReflect() protoreflect.Message {
+	mi := &file_metrics_types_types_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitLockHoldTime.ProtoReflect.Descriptor instead.
+func (*CommitLockHoldTime) Descriptor() ([]byte, []int) {
+	return file_metrics_types_types_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CommitLockHoldTime) GetEvent() *Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *CommitLockHoldTime) GetMean() float64 {
+	if x != nil {
+		return x.Mean
+	}
+	return 0
+}
+
+func (x *CommitLockHoldTime) GetVariance() float64 {
+	if x != nil {
+		return x.Variance
+	}
+	return 0
+}
+
+func (x *CommitLockHoldTime) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type ProposalDelivery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Event *Event `protobuf:"bytes,1,opt,name=Event,proto3" json:"Event,omitempty"`
+	// Number of proposal acknowledgments received since last reading.
+	Delivered uint64 `protobuf:"varint,2,opt,name=Delivered,proto3" json:"Delivered,omitempty"`
+	// Number of votes received from other replicas since last reading.
+	Voted uint64 `protobuf:"varint,3,opt,name=Voted,proto3" json:"Voted,omitempty"`
+	// Number of abstain messages recorded since last reading.
+	Abstained uint64 `protobuf:"varint,4,opt,name=Abstained,proto3" json:"Abstained,omitempty"`
+}
+
+func (x *ProposalDelivery) Reset() {
+	*x = ProposalDelivery{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_metrics_types_types_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProposalDelivery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProposalDelivery) ProtoMessage() {}
+
+func (x *ProposalDelivery) ProtoReflect() protoreflect.Message {
+	mi := &file_metrics_types_types_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProposalDelivery.ProtoReflect.Descriptor instead.
+func (*ProposalDelivery) Descriptor() ([]byte, []int) {
+	return file_metrics_types_types_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ProposalDelivery) GetEvent() *Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *ProposalDelivery) GetDelivered() uint64 {
+	if x != nil {
+		return x.Delivered
+	}
+	return 0
+}
+
+func (x *ProposalDelivery) GetVoted() uint64 {
+	if x != nil {
+		return x.Voted
+	}
+	return 0
+}
+
+func (x *ProposalDelivery) GetAbstained() uint64 {
+	if x != nil {
+		return x.Abstained
+	}
+	return 0
+}
+
+type VoteLatency struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Event *Event `protobuf:"bytes,1,opt,name=Event,proto3" json:"Event,omitempty"`
+	// The ID of the replica that sent the votes.
+	Replica uint32 `protobuf:"varint,2,opt,name=Replica,proto3" json:"Replica,omitempty"`
+	// Mean time in milliseconds from sending a proposal to receiving the vote.
+	Latency  float64 `protobuf:"fixed64,3,opt,name=Latency,proto3" json:"Latency,omitempty"`
+	Variance float64 `protobuf:"fixed64,4,opt,name=Variance,proto3" json:"Variance,omitempty"`
+	Count    uint64  `protobuf:"varint,5,opt,name=Count,proto3" json:"Count,omitempty"`
+}
+
+func (x *VoteLatency) Reset() {
+	*x = VoteLatency{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_metrics_types_types_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VoteLatency) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VoteLatency) ProtoMessage() {}
+
+func (x *VoteLatency) ProtoReflect() protoreflect.Message {
+	mi := &file_metrics_types_types_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VoteLatency.ProtoReflect.Descriptor instead.
+func (*VoteLatency) Descriptor() ([]byte, []int) {
+	return file_metrics_types_types_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *VoteLatency) GetEvent() *Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *VoteLatency) GetReplica() uint32 {
+	if x != nil {
+		return x.Replica
+	}
+	return 0
+}
+
+func (x *VoteLatency) GetLatency() float64 {
+	if x != nil {
+		return x.Latency
+	}
+	return 0
+}
+
+func (x *VoteLatency) GetVariance() float64 {
+	if x != nil {
+		return x.Variance
+	}
+	return 0
+}
+
+func (x *VoteLatency) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type ClockSkew struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Event *Event `protobuf:"bytes,1,opt,name=Event,proto3" json:"Event,omitempty"`
+	// The ID of the replica that sent the proposals.
+	Replica uint32 `protobuf:"varint,2,opt,name=Replica,proto3" json:"Replica,omitempty"`
+	// Mean difference in milliseconds between the local receipt time and the sender's timestamp of a proposal.
+	// The difference is the network delay plus the amount by which the local clock is ahead of the sender's clock.
+	Offset   float64 `protobuf:"fixed64,3,opt,name=Offset,proto3" json:"Offset,omitempty"`
+	Variance float64 `protobuf:"fixed64,4,opt,name=Variance,proto3" json:"Variance,omitempty"`
+	// The smallest difference in milliseconds, which is the best estimate of the clock skew.
+	MinOffset float64 `protobuf:"fixed64,5,opt,name=MinOffset,proto3" json:"MinOffset,omitempty"`
+	Count     uint64  `protobuf:"varint,6,opt,name=Count,proto3" json:"Count,omitempty"`
+}
+
+func (x *ClockSkew) Reset() {
+	*x = ClockSkew{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_metrics_types_types_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClockSkew) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClockSkew) ProtoMessage() {}
+
+func (x *ClockSkew) ProtoReflect() protoreflect.Message {
+	mi := &file_metrics_types_types_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClockSkew.ProtoReflect.Descriptor instead.
+func (*ClockSkew) Descriptor() ([]byte, []int) {
+	return file_metrics_types_types_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ClockSkew) GetEvent() *Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *ClockSkew) GetReplica() uint32 {
+	if x != nil {
+		return x.Replica
+	}
+	return 0
+}
+
+func (x *ClockSkew) GetOffset() float64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ClockSkew) GetVariance() float64 {
+	if x != nil {
+		return x.Variance
+	}
+	return 0
+}
+
+func (x *ClockSkew) GetMinOffset() float64 {
+	if x != nil {
+		return x.MinOffset
+	}
+	return 0
+}
+
+func (x *ClockSkew) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type UncommittedChain struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Event *Event `protobuf:"bytes,1,opt,name=Event,proto3" json:"Event,omitempty"`
+	// Number of blocks from the last committed block (exclusive) to the leaf block (inclusive).
+	Length uint64 `protobuf:"varint,2,opt,name=Length,proto3" json:"Length,omitempty"`
+}
+
+func (x *UncommittedChain) Reset() {
+	*x = UncommittedChain{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_metrics_types_types_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UncommittedChain) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UncommittedChain) ProtoMessage() {}
+
+func (x *UncommittedChain) ProtoReflect() protoreflect.Message {
+	mi := &file_metrics_types_types_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UncommittedChain.ProtoReflect.Descriptor instead.
+func (*UncommittedChain) Descriptor() ([]byte, []int) {
+	return file_metrics_types_types_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *UncommittedChain) GetEvent() *Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *UncommittedChain) GetLength() uint64 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+type ViewDuration struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Event *Event `protobuf:"bytes,1,opt,name=Event,proto3" json:"Event,omitempty"`
+	// Mean time in milliseconds from entering a view to leaving it.
+	Mean     float64 `protobuf:"fixed64,2,opt,name=Mean,proto3" json:"Mean,omitempty"`
+	Variance float64 `protobuf:"fixed64,3,opt,name=Variance,proto3" json:"Variance,omitempty"`
+	// The longest view in milliseconds since last reading.
+	Max float64 `protobuf:"fixed64,4,opt,name=Max,proto3" json:"Max,omitempty"`
+	// Number of views that ended since last reading.
+	Count uint64 `protobuf:"varint,5,opt,name=Count,proto3" json:"Count,omitempty"`
 }
 
-func (x *ViewTimeouts) Reset() {
-	*x = ViewTimeouts{}
+func (x *ViewDuration) Reset() {
+	*x = ViewDuration{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_metrics_types_types_proto_msgTypes[4]
+		mi := &file_metrics_types_types_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ViewTimeouts) String() string {
+func (x *ViewDuration) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ViewTimeouts) ProtoMessage() {}
+func (*ViewDuration) ProtoMessage() {}
 
-func (x *ViewTimeouts) ProtoReflect() protoreflect.Message {
-	mi := &file_metrics_types_types_proto_msgTypes[4]
+func (x *ViewDuration) ProtoReflect() protoreflect.Message {
+	mi := &file_metrics_types_types_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -316,28 +916,180 @@ func (x *ViewTimeouts) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ViewTimeouts.ProtoReflect.Descriptor instead.
-func (*ViewTimeouts) Descriptor() ([]byte, []int) {
-	return file_metrics_types_types_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use ViewDuration.ProtoReflect.Descriptor instead.
+func (*ViewDuration) Descriptor() ([]byte, []int) {
+	return file_metrics_types_types_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *ViewTimeouts) GetEvent() *Event {
+func (x *ViewDuration) GetEvent() *Event {
 	if x != nil {
 		return x.Event
 	}
 	return nil
 }
 
-func (x *ViewTimeouts) GetViews() uint64 {
+func (x *ViewDuration) GetMean() float64 {
 	if x != nil {
-		return x.Views
+		return x.Mean
 	}
 	return 0
 }
 
-func (x *ViewTimeouts) GetTimeouts() uint64 {
+func (x *ViewDuration) GetVariance() float64 {
 	if x != nil {
-		return x.Timeouts
+		return x.Variance
+	}
+	return 0
+}
+
+func (x *ViewDuration) GetMax() float64 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+func (x *ViewDuration) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type LeaderReputation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Event *Event `protobuf:"bytes,1,opt,name=Event,proto3" json:"Event,omitempty"`
+	// The reputation score of each replica, keyed by replica ID.
+	Scores map[uint32]float64 `protobuf:"bytes,2,rep,name=Scores,proto3" json:"Scores,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
+}
+
+func (x *LeaderReputation) Reset() {
+	*x = LeaderReputation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_metrics_types_types_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LeaderReputation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaderReputation) ProtoMessage() {}
+
+func (x *LeaderReputation) ProtoReflect() protoreflect.Message {
+	mi := &file_metrics_types_types_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaderReputation.ProtoReflect.Descriptor instead.
+func (*LeaderReputation) Descriptor() ([]byte, []int) {
+	return file_metrics_types_types_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *LeaderReputation) GetEvent() *Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *LeaderReputation) GetScores() map[uint32]float64 {
+	if x != nil {
+		return x.Scores
+	}
+	return nil
+}
+
+type QCLatency struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Event *Event `protobuf:"bytes,1,opt,name=Event,proto3" json:"Event,omitempty"`
+	// Mean time in milliseconds from sending a proposal to creating the QC for it.
+	Latency  float64 `protobuf:"fixed64,2,opt,name=Latency,proto3" json:"Latency,omitempty"`
+	Variance float64 `protobuf:"fixed64,3,opt,name=Variance,proto3" json:"Variance,omitempty"`
+	// Number of QCs created since last reading.
+	Count uint64 `protobuf:"varint,4,opt,name=Count,proto3" json:"Count,omitempty"`
+	// Number of votes received after the QC for the block was created since last reading.
+	LateVotes uint64 `protobuf:"varint,5,opt,name=LateVotes,proto3" json:"LateVotes,omitempty"`
+}
+
+func (x *QCLatency) Reset() {
+	*x = QCLatency{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_metrics_types_types_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QCLatency) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QCLatency) ProtoMessage() {}
+
+func (x *QCLatency) ProtoReflect() protoreflect.Message {
+	mi := &file_metrics_types_types_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QCLatency.ProtoReflect.Descriptor instead.
+func (*QCLatency) Descriptor() ([]byte, []int) {
+	return file_metrics_types_types_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *QCLatency) GetEvent() *Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *QCLatency) GetLatency() float64 {
+	if x != nil {
+		return x.Latency
+	}
+	return 0
+}
+
+func (x *QCLatency) GetVariance() float64 {
+	if x != nil {
+		return x.Variance
+	}
+	return 0
+}
+
+func (x *QCLatency) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *QCLatency) GetLateVotes() uint64 {
+	if x != nil {
+		return x.LateVotes
 	}
 	return 0
 }
@@ -371,7 +1123,7 @@ var file_metrics_types_types_proto_rawDesc = []byte{
 	0x61, 0x6e, 0x64, 0x73, 0x12, 0x35, 0x0a, 0x08, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
 	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x52, 0x08, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x84, 0x01, 0x0a, 0x12,
+	0x6e, 0x52, 0x08, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xec, 0x01, 0x0a, 0x12,
 	0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4d, 0x65, 0x61, 0x73, 0x75, 0x72, 0x65, 0x6d, 0x65,
 	0x6e, 0x74, 0x12, 0x22, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
 	0x0b, 0x32, 0x0c, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52,
@@ -380,16 +1132,111 @@ var file_metrics_types_types_proto_rawDesc = []byte{
 	0x12, 0x1a, 0x0a, 0x08, 0x56, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01,
 	0x28, 0x01, 0x52, 0x08, 0x56, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05,
 	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x43, 0x6f, 0x75,
-	0x6e, 0x74, 0x22, 0x64, 0x0a, 0x0c, 0x56, 0x69, 0x65, 0x77, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75,
-	0x74, 0x73, 0x12, 0x22, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x0c, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52,
-	0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x56, 0x69, 0x65, 0x77, 0x73, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x56, 0x69, 0x65, 0x77, 0x73, 0x12, 0x1a, 0x0a, 0x08,
-	0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08,
-	0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x73, 0x42, 0x29, 0x5a, 0x27, 0x67, 0x69, 0x74, 0x68,
-	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x72, 0x65, 0x6c, 0x61, 0x62, 0x2f, 0x68, 0x6f, 0x74,
-	0x73, 0x74, 0x75, 0x66, 0x66, 0x2f, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x2f, 0x74, 0x79,
-	0x70, 0x65, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6e, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x50, 0x35, 0x30, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x03, 0x50, 0x35, 0x30, 0x12, 0x10, 0x0a, 0x03, 0x50, 0x39, 0x30, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x03, 0x50, 0x39, 0x30, 0x12, 0x10, 0x0a, 0x03, 0x50, 0x39, 0x39, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x03, 0x50, 0x39, 0x39, 0x12, 0x16, 0x0a, 0x06, 0x42, 0x6f, 0x75, 0x6e,
+	0x64, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x01, 0x52, 0x06, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x73,
+	0x12, 0x18, 0x0a, 0x07, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28,
+	0x04, 0x52, 0x07, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x22, 0x64, 0x0a, 0x0c, 0x56, 0x69,
+	0x65, 0x77, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x73, 0x12, 0x22, 0x0a, 0x05, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x74, 0x79, 0x70, 0x65,
+	0x73, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x56, 0x69, 0x65, 0x77, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x56,
+	0x69, 0x65, 0x77, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x73,
+	0x22, 0x50, 0x0a, 0x14, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x51, 0x75,
+	0x65, 0x75, 0x65, 0x44, 0x65, 0x70, 0x74, 0x68, 0x12, 0x22, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05,
+	0x44, 0x65, 0x70, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x44, 0x65, 0x70,
+	0x74, 0x68, 0x22, 0xae, 0x01, 0x0a, 0x10, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x52, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x3b, 0x0a, 0x06, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x74, 0x79,
+	0x70, 0x65, 0x73, 0x2e, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x73, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x06, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x1a, 0x39, 0x0a, 0x0b, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x22, 0x7e, 0x0a, 0x12, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x4c, 0x6f, 0x63,
+	0x6b, 0x48, 0x6f, 0x6c, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x22, 0x0a, 0x05, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73,
+	0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x4d, 0x65, 0x61, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x4d, 0x65, 0x61,
+	0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x56, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x08, 0x56, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x22, 0x88, 0x01, 0x0a, 0x10, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c,
+	0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x12, 0x22, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09,
+	0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x09, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x56, 0x6f,
+	0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x56, 0x6f, 0x74, 0x65, 0x64,
+	0x12, 0x1c, 0x0a, 0x09, 0x41, 0x62, 0x73, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x64, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x09, 0x41, 0x62, 0x73, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x64, 0x22, 0x97,
+	0x01, 0x0a, 0x0b, 0x56, 0x6f, 0x74, 0x65, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x22,
+	0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e,
+	0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x05, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x07, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x12, 0x18, 0x0a, 0x07,
+	0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x4c,
+	0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x56, 0x61, 0x72, 0x69, 0x61, 0x6e,
+	0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x56, 0x61, 0x72, 0x69, 0x61, 0x6e,
+	0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x05, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0xb1, 0x01, 0x0a, 0x09, 0x43, 0x6c, 0x6f,
+	0x63, 0x6b, 0x53, 0x6b, 0x65, 0x77, 0x12, 0x22, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x52, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x52, 0x65,
+	0x70, 0x6c, 0x69, 0x63, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x52, 0x65, 0x70,
+	0x6c, 0x69, 0x63, 0x61, 0x12, 0x16, 0x0a, 0x06, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x1a, 0x0a, 0x08,
+	0x56, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08,
+	0x56, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x4d, 0x69, 0x6e, 0x4f,
+	0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x4d, 0x69, 0x6e,
+	0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x4e, 0x0a, 0x10,
+	0x55, 0x6e, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x43, 0x68, 0x61, 0x69, 0x6e,
+	0x12, 0x22, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0c, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x05, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x22, 0x8a, 0x01, 0x0a,
+	0x0c, 0x56, 0x69, 0x65, 0x77, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x22, 0x0a,
+	0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x74,
+	0x79, 0x70, 0x65, 0x73, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x05, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x4d, 0x65, 0x61, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x04, 0x4d, 0x65, 0x61, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x56, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x63,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x56, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x63,
+	0x65, 0x12, 0x10, 0x0a, 0x03, 0x4d, 0x61, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03,
+	0x4d, 0x61, 0x78, 0x12, 0x14, 0x0a, 0x05, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x05, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0xae, 0x01, 0x0a, 0x10, 0x4c, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x52, 0x65, 0x70, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x22,
+	0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e,
+	0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x05, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x12, 0x3b, 0x0a, 0x06, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x23, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x4c, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x52, 0x65, 0x70, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x53, 0x63, 0x6f, 0x72,
+	0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x1a,
+	0x39, 0x0a, 0x0b, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x99, 0x01, 0x0a, 0x09, 0x51,
+	0x43, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x22, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07,
+	0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x4c,
+	0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x56, 0x61, 0x72, 0x69, 0x61, 0x6e,
+	0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x56, 0x61, 0x72, 0x69, 0x61, 0x6e,
+	0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x05, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x4c, 0x61, 0x74, 0x65,
+	0x56, 0x6f, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x4c, 0x61, 0x74,
+	0x65, 0x56, 0x6f, 0x74, 0x65, 0x73, 0x42, 0x29, 0x5a, 0x27, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x72, 0x65, 0x6c, 0x61, 0x62, 0x2f, 0x68, 0x6f, 0x74, 0x73, 0x74,
+	0x75, 0x66, 0x66, 0x2f, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x2f, 0x74, 0x79, 0x70, 0x65,
+	0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -404,28 +1251,52 @@ func file_metrics_types_types_proto_rawDescGZIP() []byte {
 	return file_metrics_types_types_proto_rawDescData
 }
 
-var file_metrics_types_types_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_metrics_types_types_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
 var file_metrics_types_types_proto_goTypes = []interface{}{
 	(*StartEvent)(nil),            // 0: types.StartEvent
 	(*Event)(nil),                 // 1: types.Event
 	(*ThroughputMeasurement)(nil), // 2: types.ThroughputMeasurement
 	(*LatencyMeasurement)(nil),    // 3: types.LatencyMeasurement
 	(*ViewTimeouts)(nil),          // 4: types.ViewTimeouts
-	(*timestamppb.Timestamp)(nil), // 5: google.protobuf.Timestamp
-	(*durationpb.Duration)(nil),   // 6: google.protobuf.Duration
+	(*ProcessingQueueDepth)(nil),  // 5: types.ProcessingQueueDepth
+	(*RejectedMessages)(nil),      // 6: types.RejectedMessages
+	(*CommitLockHoldTime)(nil),    // 7: types.CommitLockHoldTime
+	(*ProposalDelivery)(nil),      // 8: types.ProposalDelivery
+	(*VoteLatency)(nil),           // 9: types.VoteLatency
+	(*ClockSkew)(nil),             // 10: types.ClockSkew
+	(*UncommittedChain)(nil),      // 11: types.UncommittedChain
+	(*ViewDuration)(nil),          // 12: types.ViewDuration
+	(*LeaderReputation)(nil),      // 13: types.LeaderReputation
+	(*QCLatency)(nil),             // 14: types.QCLatency
+	nil,                           // 15: types.RejectedMessages.CountsEntry
+	nil,                           // 16: types.LeaderReputation.ScoresEntry
+	(*timestamppb.Timestamp)(nil), // 17: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),   // 18: google.protobuf.Duration
 }
 var file_metrics_types_types_proto_depIdxs = []int32{
-	1, // 0: types.StartEvent.Event:type_name -> types.Event
-	5, // 1: types.Event.Timestamp:type_name -> google.protobuf.Timestamp
-	1, // 2: types.ThroughputMeasurement.Event:type_name -> types.Event
-	6, // 3: types.ThroughputMeasurement.Duration:type_name -> google.protobuf.Duration
-	1, // 4: types.LatencyMeasurement.Event:type_name -> types.Event
-	1, // 5: types.ViewTimeouts.Event:type_name -> types.Event
-	6, // [6:6] is the sub-list for method output_type
-	6, // [6:6] is the sub-list for method input_type
-	6, // [6:6] is the sub-list for extension type_name
-	6, // [6:6] is the sub-list for extension extendee
-	0, // [0:6] is the sub-list for field type_name
+	1,  // 0: types.StartEvent.Event:type_name -> types.Event
+	17, // 1: types.Event.Timestamp:type_name -> google.protobuf.Timestamp
+	1,  // 2: types.ThroughputMeasurement.Event:type_name -> types.Event
+	18, // 3: types.ThroughputMeasurement.Duration:type_name -> google.protobuf.Duration
+	1,  // 4: types.LatencyMeasurement.Event:type_name -> types.Event
+	1,  // 5: types.ViewTimeouts.Event:type_name -> types.Event
+	1,  // 6: types.ProcessingQueueDepth.Event:type_name -> types.Event
+	1,  // 7: types.RejectedMessages.Event:type_name -> types.Event
+	15, // 8: types.RejectedMessages.Counts:type_name -> types.RejectedMessages.CountsEntry
+	1,  // 9: types.CommitLockHoldTime.Event:type_name -> types.Event
+	1,  // 10: types.ProposalDelivery.Event:type_name -> types.Event
+	1,  // 11: types.VoteLatency.Event:type_name -> types.Event
+	1,  // 12: types.ClockSkew.Event:type_name -> types.Event
+	1,  // 13: types.UncommittedChain.Event:type_name -> types.Event
+	1,  // 14: types.ViewDuration.Event:type_name -> types.Event
+	1,  // 15: types.LeaderReputation.Event:type_name -> types.Event
+	16, // 16: types.LeaderReputation.Scores:type_name -> types.LeaderReputation.ScoresEntry
+	1,  // 17: types.QCLatency.Event:type_name -> types.Event
+	18, // [18:18] is the sub-list for method output_type
+	18, // [18:18] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
 }
 
 func init() { file_metrics_types_types_proto_init() }
@@ -494,6 +1365,126 @@ func file_metrics_types_types_proto_init() {
 				return nil
 			}
 		}
+		file_metrics_types_types_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProcessingQueueDepth); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_metrics_types_types_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RejectedMessages); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_metrics_types_types_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CommitLockHoldTime); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_metrics_types_types_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProposalDelivery); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_metrics_types_types_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VoteLatency); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_metrics_types_types_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClockSkew); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_metrics_types_types_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UncommittedChain); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_metrics_types_types_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ViewDuration); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_metrics_types_types_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LeaderReputation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_metrics_types_types_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QCLatency); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -501,7 +1492,7 @@ func file_metrics_types_types_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_metrics_types_types_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   17,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,77 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/metrics/types"
+	"github.com/relab/hotstuff/modules"
+)
+
+func init() {
+	RegisterReplicaMetric("uncommitted-chain", func() interface{} {
+		return &UncommittedChain{}
+	})
+}
+
+// uncommittedChainEvent is sent from the consensus event loop to the metrics event loop
+// with the length of the uncommitted chain.
+type uncommittedChainEvent struct {
+	length int
+}
+
+// UncommittedChain measures the number of blocks between the last committed block and the leaf block.
+// A chain that keeps growing indicates that blocks are proposed, but not committed,
+// which can be caused by a problem with the commit rule or by persistent timeouts.
+type UncommittedChain struct {
+	mods      *modules.Modules
+	consensus *consensus.Modules
+}
+
+// InitConsensusModule gives the module a reference to the Modules object.
+// It also allows the module to set module options using the OptionsBuilder.
+func (uc *UncommittedChain) InitConsensusModule(mods *consensus.Modules, _ *consensus.OptionsBuilder) {
+	uc.consensus = mods
+}
+
+// InitModule gives the module access to the other modules.
+func (uc *UncommittedChain) InitModule(mods *modules.Modules) {
+	uc.mods = mods
+	uc.mods.MetricsEventLoop().RegisterHandler(uncommittedChainEvent{}, func(event interface{}) {
+		uc.log(event.(uncommittedChainEvent).length)
+	})
+	uc.mods.MetricsEventLoop().RegisterObserver(types.TickEvent{}, func(event interface{}) {
+		uc.tick(event.(types.TickEvent))
+	})
+	uc.mods.Logger().Info("UncommittedChain metric enabled")
+}
+
+func (uc *UncommittedChain) tick(_ types.TickEvent) {
+	// the leaf block is updated by the consensus event loop, so that is where the chain must be measured.
+	// The metrics event loop must not wait for the consensus event loop, hence the goroutine.
+	go uc.consensus.EventLoop().AddEvent(func() {
+		uc.mods.MetricsEventLoop().AddEvent(uncommittedChainEvent{length: uc.measure()})
+	})
+}
+
+// measure returns the number of blocks from the leaf block back to the last committed block.
+func (uc *UncommittedChain) measure() (length int) {
+	committed := uc.consensus.Consensus().CommittedBlock()
+	block := uc.consensus.Synchronizer().LeafBlock()
+	for block != nil && block.View() > committed.View() {
+		length++
+		parent, ok := uc.consensus.BlockChain().LocalGet(block.Parent())
+		if !ok {
+			break
+		}
+		block = parent
+	}
+	return length
+}
+
+func (uc *UncommittedChain) log(length int) {
+	uc.mods.MetricsLogger().Log(&types.UncommittedChain{
+		Event:  types.NewReplicaEvent(uint32(uc.mods.ID()), time.Now()),
+		Length: uint64(length),
+	})
+}
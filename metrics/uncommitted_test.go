@@ -0,0 +1,68 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/relab/hotstuff/blockchain"
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/internal/mocks"
+	"github.com/relab/hotstuff/metrics/types"
+)
+
+func TestUncommittedChain(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	logger := &recordingLogger{}
+	uc := &UncommittedChain{}
+	sync := mocks.NewMockSynchronizer(ctrl)
+	cs := mocks.NewMockConsensus(ctrl)
+	builder := consensus.NewBuilder(1, nil)
+	builder.Register(logger, uc, sync, cs, blockchain.New())
+	mods := builder.Build()
+
+	leaf := consensus.GetGenesis()
+	committed := consensus.GetGenesis()
+	sync.EXPECT().LeafBlock().AnyTimes().DoAndReturn(func() *consensus.Block { return leaf })
+	cs.EXPECT().CommittedBlock().AnyTimes().DoAndReturn(func() *consensus.Block { return committed })
+
+	// measure ticks the metric and returns the measured length once the event loops have processed the tick.
+	measure := func() uint64 {
+		t.Helper()
+		n := len(logger.logged)
+		uc.tick(types.TickEvent{})
+		deadline := time.Now().Add(time.Second)
+		for mods.EventLoop().Len() == 0 && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		mods.EventLoop().Run(ctx)
+		mods.MetricsEventLoop().Run(ctx)
+		if len(logger.logged) != n+1 {
+			t.Fatalf("expected one new measurement, got %d", len(logger.logged)-n)
+		}
+		return logger.logged[n].(*types.UncommittedChain).GetLength()
+	}
+
+	if got := measure(); got != 0 {
+		t.Errorf("wrong initial length: got: %d, want: 0", got)
+	}
+
+	// each block commits its grandparent until view 4, after which the commits stall.
+	var blocks []*consensus.Block
+	for view := consensus.View(1); view <= 8; view++ {
+		block := consensus.NewBlock(leaf.Hash(), consensus.QuorumCert{}, "foo", view, 1)
+		mods.BlockChain().Store(block)
+		blocks = append(blocks, block)
+		leaf = block
+		if view >= 3 && view <= 4 {
+			committed = blocks[view-3]
+		}
+
+		if got, want := measure(), uint64(view-committed.View()); got != want {
+			t.Errorf("view %d: wrong length: got: %d, want: %d", view, got, want)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/relab/hotstuff/metrics/types"
+	"github.com/relab/hotstuff/modules"
+	"github.com/relab/hotstuff/synchronizer"
+)
+
+func init() {
+	RegisterReplicaMetric("view-duration", func() interface{} {
+		return &ViewDuration{}
+	})
+}
+
+// ViewDuration measures the time from entering a view to leaving it, whether the view succeeded or timed out.
+type ViewDuration struct {
+	mods      *modules.Modules
+	lastStart time.Time // the time when the current view was entered
+	wf        Welford
+	max       float64
+}
+
+// InitModule gives the module access to the other modules.
+func (vd *ViewDuration) InitModule(mods *modules.Modules) {
+	vd.mods = mods
+	// the timeouts metric handles the same events, so we must observe them instead of replacing its handler.
+	vd.mods.MetricsEventLoop().RegisterObserver(synchronizer.ViewChangeEvent{}, func(event interface{}) {
+		vd.viewChange(event.(synchronizer.ViewChangeEvent))
+	})
+	vd.mods.MetricsEventLoop().RegisterObserver(types.TickEvent{}, func(event interface{}) {
+		vd.tick(event.(types.TickEvent))
+	})
+	vd.mods.Logger().Info("ViewDuration metric enabled")
+}
+
+func (vd *ViewDuration) viewChange(event synchronizer.ViewChangeEvent) {
+	// the duration of the first view is unknown, since we do not know when it started.
+	if !vd.lastStart.IsZero() {
+		millis := float64(event.Time.Sub(vd.lastStart)) / float64(time.Millisecond)
+		vd.wf.Update(millis)
+		if millis > vd.max {
+			vd.max = millis
+		}
+	}
+	vd.lastStart = event.Time
+}
+
+func (vd *ViewDuration) tick(_ types.TickEvent) {
+	mean, variance, count := vd.wf.Get()
+	vd.mods.MetricsLogger().Log(&types.ViewDuration{
+		Event:    types.NewReplicaEvent(uint32(vd.mods.ID()), time.Now()),
+		Mean:     mean,
+		Variance: variance,
+		Max:      vd.max,
+		Count:    count,
+	})
+	vd.wf.Reset()
+	vd.max = 0
+}
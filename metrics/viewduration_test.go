@@ -0,0 +1,99 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/metrics/types"
+	"github.com/relab/hotstuff/modules"
+	"github.com/relab/hotstuff/synchronizer"
+)
+
+func TestViewDuration(t *testing.T) {
+	logger := &recordingLogger{}
+	builder := modules.NewBuilder(1)
+	vd := &ViewDuration{}
+	builder.Register(logger, vd)
+	mods := builder.Build()
+
+	run := func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		mods.MetricsEventLoop().Run(ctx)
+	}
+
+	start := time.Now()
+	// views 1, 2 and 3 last 100, 300 and 200 milliseconds. View 3 ends with a timeout.
+	changes := []struct {
+		offset  time.Duration
+		timeout bool
+	}{{0, false}, {100 * time.Millisecond, false}, {400 * time.Millisecond, false}, {600 * time.Millisecond, true}}
+	for i, c := range changes {
+		mods.MetricsEventLoop().AddEvent(synchronizer.ViewChangeEvent{
+			OldView: consensus.View(i),
+			View:    consensus.View(i + 1),
+			Timeout: c.timeout,
+			Time:    start.Add(c.offset),
+		})
+	}
+	run()
+	vd.tick(types.TickEvent{})
+
+	// view 4 lasts 50 milliseconds
+	mods.MetricsEventLoop().AddEvent(synchronizer.ViewChangeEvent{OldView: 4, View: 5, Time: start.Add(650 * time.Millisecond)})
+	run()
+	vd.tick(types.TickEvent{})
+
+	if len(logger.logged) != 2 {
+		t.Fatalf("expected two measurements, got %d", len(logger.logged))
+	}
+	want := []struct {
+		mean, max float64
+		count     uint64
+	}{{200, 300, 3}, {50, 50, 1}}
+	for i, w := range want {
+		m := logger.logged[i].(*types.ViewDuration)
+		if m.GetMean() != w.mean || m.GetMax() != w.max || m.GetCount() != w.count {
+			t.Errorf("tick %d: got mean %.1f, max %.1f, count %d, want mean %.1f, max %.1f, count %d",
+				i+1, m.GetMean(), m.GetMax(), m.GetCount(), w.mean, w.max, w.count)
+		}
+	}
+}
+
+// TestViewDurationWithTimeouts checks that the view duration and timeouts metrics both see the view changes
+// when they are enabled together.
+func TestViewDurationWithTimeouts(t *testing.T) {
+	logger := &recordingLogger{}
+	builder := modules.NewBuilder(1)
+	vd := &ViewDuration{}
+	vt := &ViewTimeouts{}
+	builder.Register(logger, vt, vd)
+	mods := builder.Build()
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		mods.MetricsEventLoop().AddEvent(synchronizer.ViewChangeEvent{
+			OldView: consensus.View(i),
+			View:    consensus.View(i + 1),
+			Timeout: i == 2,
+			Time:    start.Add(time.Duration(i) * 100 * time.Millisecond),
+		})
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	mods.MetricsEventLoop().Run(ctx)
+	vt.tick(types.TickEvent{})
+	vd.tick(types.TickEvent{})
+
+	if len(logger.logged) != 2 {
+		t.Fatalf("expected two measurements, got %d", len(logger.logged))
+	}
+	if m := logger.logged[0].(*types.ViewTimeouts); m.GetViews() != 3 || m.GetTimeouts() != 1 {
+		t.Errorf("got %d views and %d timeouts, want 3 views and 1 timeout", m.GetViews(), m.GetTimeouts())
+	}
+	if m := logger.logged[1].(*types.ViewDuration); m.GetCount() != 2 || m.GetMean() != 100 {
+		t.Errorf("got mean %.1f and count %d, want mean 100.0 and count 2", m.GetMean(), m.GetCount())
+	}
+}
@@ -0,0 +1,114 @@
+package metrics
+
+import (
+	"sort"
+	"time"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/metrics/types"
+	"github.com/relab/hotstuff/modules"
+)
+
+func init() {
+	RegisterReplicaMetric("vote-latency", func() interface{} {
+		return &VoteLatency{}
+	})
+}
+
+// voteReceivedEvent is sent on the metrics event loop when a vote from another replica is handled by the consensus event loop.
+type voteReceivedEvent struct {
+	id        hotstuff.ID
+	blockHash consensus.Hash
+	time      time.Time
+}
+
+// VoteLatency measures, for each replica, the time from the leader sending a proposal until it receives the replica's vote.
+// A replica with a high latency may be behind a slow link.
+type VoteLatency struct {
+	mods      *modules.Modules
+	consensus *consensus.Modules
+	sent      map[consensus.Hash]time.Time // the time that each of our proposals was sent
+	latencies map[hotstuff.ID]*Welford
+}
+
+// InitConsensusModule gives the module a reference to the Modules object.
+// It also allows the module to set module options using the OptionsBuilder.
+func (vl *VoteLatency) InitConsensusModule(mods *consensus.Modules, _ *consensus.OptionsBuilder) {
+	vl.consensus = mods
+	vl.consensus.EventLoop().RegisterObserver(consensus.VoteMsg{}, func(event interface{}) {
+		vote := event.(consensus.VoteMsg)
+		if vote.ID == vl.consensus.ID() || vote.Deferred {
+			return
+		}
+		// the observer is run by the consensus event loop, so we pass the vote on to the metrics event loop.
+		vl.mods.MetricsEventLoop().AddEvent(voteReceivedEvent{
+			id:        vote.ID,
+			blockHash: vote.PartialCert.BlockHash(),
+			time:      time.Now(),
+		})
+	})
+}
+
+// InitModule gives the module access to the other modules.
+func (vl *VoteLatency) InitModule(mods *modules.Modules) {
+	vl.mods = mods
+	vl.sent = make(map[consensus.Hash]time.Time)
+	vl.latencies = make(map[hotstuff.ID]*Welford)
+	vl.mods.MetricsEventLoop().RegisterHandler(consensus.ProposalSentEvent{}, func(event interface{}) {
+		proposal := event.(consensus.ProposalSentEvent)
+		vl.proposalSent(proposal.BlockHash, proposal.Time)
+	})
+	vl.mods.MetricsEventLoop().RegisterHandler(voteReceivedEvent{}, func(event interface{}) {
+		vote := event.(voteReceivedEvent)
+		vl.voteReceived(vote.id, vote.blockHash, vote.time)
+	})
+	vl.mods.MetricsEventLoop().RegisterObserver(types.TickEvent{}, func(event interface{}) {
+		vl.tick(event.(types.TickEvent))
+	})
+	vl.mods.Logger().Info("VoteLatency metric enabled")
+}
+
+func (vl *VoteLatency) proposalSent(hash consensus.Hash, sent time.Time) {
+	vl.sent[hash] = sent
+}
+
+func (vl *VoteLatency) voteReceived(id hotstuff.ID, hash consensus.Hash, received time.Time) {
+	sent, ok := vl.sent[hash]
+	if !ok {
+		// not one of our proposals
+		return
+	}
+	wf, ok := vl.latencies[id]
+	if !ok {
+		wf = &Welford{}
+		vl.latencies[id] = wf
+	}
+	wf.Update(float64(received.Sub(sent)) / float64(time.Millisecond))
+}
+
+func (vl *VoteLatency) tick(tick types.TickEvent) {
+	now := time.Now()
+	ids := make([]hotstuff.ID, 0, len(vl.latencies))
+	for id := range vl.latencies {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	for _, id := range ids {
+		mean, variance, count := vl.latencies[id].Get()
+		vl.mods.MetricsLogger().Log(&types.VoteLatency{
+			Event:    types.NewReplicaEvent(uint32(vl.mods.ID()), now),
+			Replica:  uint32(id),
+			Latency:  mean,
+			Variance: variance,
+			Count:    count,
+		})
+	}
+	vl.latencies = make(map[hotstuff.ID]*Welford)
+	// forget the proposals that were sent before the previous tick; late votes for them are not counted.
+	for hash, sent := range vl.sent {
+		if sent.Before(tick.LastTick) {
+			delete(vl.sent, hash)
+		}
+	}
+}
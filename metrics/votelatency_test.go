@@ -0,0 +1,64 @@
+package metrics
+
+import (
+	"context"
+	"crypto/sha256"
+	"testing"
+	"time"
+
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/metrics/types"
+	"github.com/relab/hotstuff/modules"
+)
+
+func TestVoteLatency(t *testing.T) {
+	logger := &recordingLogger{}
+	builder := modules.NewBuilder(1)
+	builder.Register(logger, &VoteLatency{})
+	mods := builder.Build()
+
+	// replica 4 is behind a slow link
+	delays := map[hotstuff.ID]time.Duration{
+		2: 5 * time.Millisecond,
+		3: 8 * time.Millisecond,
+		4: 50 * time.Millisecond,
+	}
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		hash := sha256.Sum256([]byte{byte(i)})
+		sent := start.Add(time.Duration(i) * 100 * time.Millisecond)
+		mods.MetricsEventLoop().AddEvent(consensus.ProposalSentEvent{BlockHash: hash, Time: sent})
+		for id, delay := range delays {
+			mods.MetricsEventLoop().AddEvent(voteReceivedEvent{id: id, blockHash: hash, time: sent.Add(delay)})
+		}
+	}
+	// a vote for a block that we did not propose should be ignored
+	mods.MetricsEventLoop().AddEvent(voteReceivedEvent{id: 2, blockHash: consensus.GetGenesis().Hash(), time: start.Add(time.Hour)})
+	mods.MetricsEventLoop().AddEvent(types.TickEvent{LastTick: start})
+
+	// process the queued events
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	mods.MetricsEventLoop().Run(ctx)
+
+	if len(logger.logged) != len(delays) {
+		t.Fatalf("expected %d measurements, got %d", len(delays), len(logger.logged))
+	}
+	latencies := make(map[hotstuff.ID]float64)
+	for _, msg := range logger.logged {
+		m := msg.(*types.VoteLatency)
+		if m.GetCount() != 5 {
+			t.Errorf("wrong count for replica %d: got: %d, want: %d", m.GetReplica(), m.GetCount(), 5)
+		}
+		latencies[hotstuff.ID(m.GetReplica())] = m.GetLatency()
+	}
+	for id, delay := range delays {
+		if want := float64(delay) / float64(time.Millisecond); latencies[id] != want {
+			t.Errorf("wrong latency for replica %d: got: %f, want: %f", id, latencies[id], want)
+		}
+	}
+	if latencies[4] <= latencies[2] || latencies[4] <= latencies[3] {
+		t.Errorf("the slow replica does not have the highest latency: %v", latencies)
+	}
+}
@@ -0,0 +1,70 @@
+package replica
+
+import (
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/internal/proto/clientpb"
+	"google.golang.org/protobuf/proto"
+)
+
+// batchLengthSize is the size of the length prefix of each command in the canonical batch encoding.
+const batchLengthSize = 4
+
+// batchCodec converts between batches of client commands and the commands that are proposed in blocks.
+//
+// By default, a batch is encoded as a clientpb.Batch message.
+// In the canonical encoding, the batch is encoded as the sequence of its commands in insertion order,
+// where each command is prefixed by its length as a 4 byte big-endian integer.
+// The canonical encoding does not depend on the protobuf encoding of repeated fields,
+// so every replica decodes the commands of a block in the same order as the leader added them.
+// All replicas must use the same encoding.
+type batchCodec struct {
+	canonical   bool
+	marshaler   proto.MarshalOptions
+	unmarshaler proto.UnmarshalOptions
+}
+
+func newBatchCodec(canonical bool) batchCodec {
+	return batchCodec{
+		canonical:   canonical,
+		marshaler:   proto.MarshalOptions{Deterministic: true},
+		unmarshaler: proto.UnmarshalOptions{AllowPartial: true, DiscardUnknown: true},
+	}
+}
+
+// marshal encodes the batch as a command.
+func (c batchCodec) marshal(batch *clientpb.Batch) (consensus.Command, error) {
+	if !c.canonical {
+		b, err := c.marshaler.Marshal(batch)
+		return consensus.Command(b), err
+	}
+	cmds := make([]consensus.Command, 0, len(batch.GetCommands()))
+	for _, cmd := range batch.GetCommands() {
+		data, err := c.marshaler.Marshal(cmd)
+		if err != nil {
+			return "", err
+		}
+		cmds = append(cmds, consensus.Command(data))
+	}
+	return joinBatch(cmds), nil
+}
+
+// unmarshal decodes the batch from a command.
+func (c batchCodec) unmarshal(cmd consensus.Command) (*clientpb.Batch, error) {
+	batch := new(clientpb.Batch)
+	if !c.canonical {
+		err := c.unmarshaler.Unmarshal([]byte(cmd), batch)
+		return batch, err
+	}
+	cmds, err := SplitBatch(cmd)
+	if err != nil {
+		return nil, err
+	}
+	for _, data := range cmds {
+		command := new(clientpb.Command)
+		if err := c.unmarshaler.Unmarshal([]byte(data), command); err != nil {
+			return nil, err
+		}
+		batch.Commands = append(batch.Commands, command)
+	}
+	return batch, nil
+}
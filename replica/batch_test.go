@@ -0,0 +1,70 @@
+package replica
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/internal/proto/clientpb"
+	"github.com/relab/hotstuff/modules"
+)
+
+// orderExecutor records the order in which commands are executed.
+type orderExecutor struct {
+	order []string
+}
+
+func (e *orderExecutor) Exec(cmd consensus.Command) {
+	e.order = append(e.order, string(cmd))
+}
+
+func TestCanonicalBatchExecutionOrder(t *testing.T) {
+	const numCmds = 10
+
+	codec := newBatchCodec(true)
+	batch := new(clientpb.Batch)
+	// add the commands in an order that differs from the order of the client IDs and sequence numbers.
+	for i := numCmds; i > 0; i-- {
+		batch.Commands = append(batch.Commands, &clientpb.Command{
+			ClientID:       uint32(i % 3),
+			SequenceNumber: uint64(i),
+			Data:           []byte(fmt.Sprintf("cmd%d", i)),
+		})
+	}
+	cmd, err := codec.marshal(batch)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	executors := make([]*orderExecutor, 2)
+	for i := range executors {
+		executors[i] = new(orderExecutor)
+		srv := newClientServer(Config{CanonicalBatches: true, Executor: executors[i]}, nil)
+		builder := modules.NewBuilder(1)
+		builder.Register(srv)
+		builder.Build()
+		srv.Exec(cmd)
+	}
+
+	for i, executor := range executors {
+		if len(executor.order) != numCmds {
+			t.Fatalf("replica %d executed %d commands, want %d", i+1, len(executor.order), numCmds)
+		}
+		for j, data := range executor.order {
+			if want := string(batch.GetCommands()[j].GetData()); data != want {
+				t.Errorf("replica %d executed %q as command %d, want %q", i+1, data, j, want)
+			}
+		}
+	}
+}
+
+func TestCanonicalBatchTruncated(t *testing.T) {
+	codec := newBatchCodec(true)
+	cmd, err := codec.marshal(&clientpb.Batch{Commands: []*clientpb.Command{{ClientID: 1, SequenceNumber: 1, Data: []byte("foo")}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := codec.unmarshal(cmd[:len(cmd)-1]); err == nil {
+		t.Error("expected an error when decoding a truncated batch")
+	}
+}
@@ -0,0 +1,155 @@
+package replica
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/internal/logging"
+)
+
+// BatchQueue is a CommandQueue that proposes several commands in each block.
+// Get waits for the first command, and then for up to maxBatchDelay until maxBatchSize commands are available.
+// The commands are concatenated into a single command, where each command is prefixed by its length
+// as a 4 byte big-endian integer. SplitBatch recovers the commands from a batch.
+type BatchQueue struct {
+	maxBatchSize  int
+	maxBatchDelay time.Duration
+
+	mut  sync.Mutex
+	cmds []consensus.Command
+	c    chan struct{} // notifies Get that commands were added
+}
+
+// NewBatchQueue returns a new BatchQueue.
+// A batch contains at most maxBatchSize commands, and is proposed at most maxBatchDelay after Get found the first command.
+// It returns an error if maxBatchSize is not positive, as the batches would never contain any commands.
+func NewBatchQueue(maxBatchSize int, maxBatchDelay time.Duration) (*BatchQueue, error) {
+	if maxBatchSize <= 0 {
+		return nil, fmt.Errorf("invalid max batch size: %d", maxBatchSize)
+	}
+	return &BatchQueue{
+		maxBatchSize:  maxBatchSize,
+		maxBatchDelay: maxBatchDelay,
+		c:             make(chan struct{}, 1),
+	}, nil
+}
+
+// Add adds a command to the queue.
+func (q *BatchQueue) Add(cmd consensus.Command) {
+	q.mut.Lock()
+	q.cmds = append(q.cmds, cmd)
+	q.mut.Unlock()
+	select {
+	case q.c <- struct{}{}:
+	default:
+	}
+}
+
+// Len returns the number of commands in the queue.
+func (q *BatchQueue) Len() int {
+	q.mut.Lock()
+	defer q.mut.Unlock()
+	return len(q.cmds)
+}
+
+// Get returns a batch of commands to propose. It returns false if the context is cancelled before any command is added.
+// If the context is cancelled while waiting for the batch to fill, the commands that are available are proposed.
+func (q *BatchQueue) Get(ctx context.Context) (cmd consensus.Command, ok bool) {
+	// wait for the first command.
+	for q.Len() == 0 {
+		select {
+		case <-q.c:
+		case <-ctx.Done():
+			return "", false
+		}
+	}
+
+	// wait for the batch to fill.
+	timer := time.NewTimer(q.maxBatchDelay)
+	defer timer.Stop()
+wait:
+	for q.Len() < q.maxBatchSize {
+		select {
+		case <-q.c:
+		case <-timer.C:
+			break wait
+		case <-ctx.Done():
+			break wait
+		}
+	}
+
+	q.mut.Lock()
+	n := len(q.cmds)
+	if n > q.maxBatchSize {
+		n = q.maxBatchSize
+	}
+	batch := q.cmds[:n]
+	q.cmds = q.cmds[n:]
+	q.mut.Unlock()
+
+	return joinBatch(batch), true
+}
+
+// joinBatch concatenates the commands, prefixing each command by its length.
+func joinBatch(cmds []consensus.Command) consensus.Command {
+	var b []byte
+	for _, cmd := range cmds {
+		var length [batchLengthSize]byte
+		binary.BigEndian.PutUint32(length[:], uint32(len(cmd)))
+		b = append(b, length[:]...)
+		b = append(b, cmd...)
+	}
+	return consensus.Command(b)
+}
+
+// SplitBatch returns the commands of a batch that was proposed by a BatchQueue.
+func SplitBatch(batch consensus.Command) (cmds []consensus.Command, err error) {
+	b := []byte(batch)
+	for len(b) > 0 {
+		if len(b) < batchLengthSize {
+			return nil, fmt.Errorf("truncated length prefix")
+		}
+		length := binary.BigEndian.Uint32(b)
+		b = b[batchLengthSize:]
+		if uint64(len(b)) < uint64(length) {
+			return nil, fmt.Errorf("command length %d exceeds remaining %d bytes", length, len(b))
+		}
+		cmds = append(cmds, consensus.Command(b[:length]))
+		b = b[length:]
+	}
+	return cmds, nil
+}
+
+// BatchExecutor is an Executor that executes each command of the batches proposed by a BatchQueue.
+type BatchExecutor struct {
+	executor consensus.Executor
+	logger   logging.Logger
+}
+
+// NewBatchExecutor returns an Executor that splits each batch and passes its commands to the executor, in order.
+func NewBatchExecutor(executor consensus.Executor) *BatchExecutor {
+	return &BatchExecutor{executor: executor, logger: logging.New("batch")}
+}
+
+// Exec executes the commands of the batch.
+// A batch that cannot be split is dropped, and none of its commands are executed.
+func (e *BatchExecutor) Exec(batch consensus.Command) {
+	cmds, err := SplitBatch(batch)
+	if err != nil {
+		// all replicas fail to split the same batch, so they skip it consistently.
+		e.logger.Warnf("Dropping a batch of %d bytes that could not be split: %v", len(batch), err)
+		return
+	}
+	for _, cmd := range cmds {
+		e.executor.Exec(cmd)
+	}
+}
+
+var (
+	_ consensus.CommandQueue = (*BatchQueue)(nil)
+	_ consensus.Executor     = (*BatchExecutor)(nil)
+)
@@ -0,0 +1,110 @@
+package replica
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/relab/hotstuff/consensus"
+)
+
+// newBatchQueue returns a new BatchQueue, and fails the test if the arguments are invalid.
+func newBatchQueue(t testing.TB, maxBatchSize int, maxBatchDelay time.Duration) *BatchQueue {
+	t.Helper()
+	q, err := NewBatchQueue(maxBatchSize, maxBatchDelay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return q
+}
+
+func TestBatchQueueInvalidSize(t *testing.T) {
+	for _, size := range []int{0, -1} {
+		if _, err := NewBatchQueue(size, time.Second); err == nil {
+			t.Errorf("expected an error for max batch size %d", size)
+		}
+	}
+}
+
+func TestBatchQueueFull(t *testing.T) {
+	q := newBatchQueue(t, 3, time.Hour)
+	for _, cmd := range []consensus.Command{"a", "b", "c", "d"} {
+		q.Add(cmd)
+	}
+
+	batch, ok := q.Get(context.Background())
+	if !ok {
+		t.Fatal("no batch was returned")
+	}
+	cmds, err := SplitBatch(batch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []consensus.Command{"a", "b", "c"}; !reflect.DeepEqual(cmds, want) {
+		t.Errorf("wrong batch: got %v, want %v", cmds, want)
+	}
+	if q.Len() != 1 {
+		t.Errorf("wrong number of remaining commands: got %d, want 1", q.Len())
+	}
+}
+
+func TestBatchQueueDelay(t *testing.T) {
+	const delay = 20 * time.Millisecond
+	q := newBatchQueue(t, 10, delay)
+	q.Add("a")
+	q.Add("b")
+
+	start := time.Now()
+	batch, ok := q.Get(context.Background())
+	if !ok {
+		t.Fatal("no batch was returned")
+	}
+	if elapsed := time.Since(start); elapsed < delay {
+		t.Errorf("partial batch was returned after %v, before the delay of %v", elapsed, delay)
+	}
+	cmds, err := SplitBatch(batch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []consensus.Command{"a", "b"}; !reflect.DeepEqual(cmds, want) {
+		t.Errorf("wrong batch: got %v, want %v", cmds, want)
+	}
+}
+
+func TestBatchQueueCancel(t *testing.T) {
+	q := newBatchQueue(t, 10, time.Hour)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, ok := q.Get(ctx); ok {
+		t.Error("a batch was returned from an empty queue")
+	}
+}
+
+func TestSplitBatch(t *testing.T) {
+	cmds := []consensus.Command{"foo", "", "bar\x00baz"}
+	got, err := SplitBatch(joinBatch(cmds))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, cmds) {
+		t.Errorf("wrong commands: got %q, want %q", got, cmds)
+	}
+
+	batch := joinBatch(cmds)
+	if _, err := SplitBatch(batch[:len(batch)-1]); err == nil {
+		t.Error("expected an error when splitting a truncated batch")
+	}
+
+	executor := new(orderExecutor)
+	NewBatchExecutor(executor).Exec(batch)
+	if want := []string{"foo", "", "bar\x00baz"}; !reflect.DeepEqual(executor.order, want) {
+		t.Errorf("wrong executed commands: got %q, want %q", executor.order, want)
+	}
+
+	// a batch that cannot be split is dropped as a whole.
+	NewBatchExecutor(executor).Exec(batch[:len(batch)-1])
+	if len(executor.order) != len(cmds) {
+		t.Errorf("commands from a truncated batch were executed: %q", executor.order[len(cmds):])
+	}
+}
@@ -1,19 +1,18 @@
 package replica
 
 import (
+	"context"
 	"crypto/sha256"
 	"hash"
 	"net"
 	"sync"
 
-	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/relab/gorums"
 	"github.com/relab/hotstuff/consensus"
 	"github.com/relab/hotstuff/internal/proto/clientpb"
 	"github.com/relab/hotstuff/modules"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
-	"google.golang.org/protobuf/proto"
 )
 
 // clientSrv serves a client.
@@ -22,18 +21,30 @@ type clientSrv struct {
 	mods         *modules.Modules
 	srv          *gorums.Server
 	awaitingCmds map[cmdID]chan<- error
+	awaitingAcks map[cmdID]chan<- error // clients waiting for their commands to be accepted in a proposed block
+	results      map[cmdID][]byte       // the results of executed commands that the clients are waiting for
 	cmdCache     *cmdCache
 	hash         hash.Hash
+	executor     consensus.Executor
+
+	confirmationDepth int
+	unconfirmed       [][]cmdID // the commands of the most recently executed blocks that are not yet confirmed
 }
 
 // newClientServer returns a new client server.
 func newClientServer(conf Config, srvOpts []gorums.ServerOption) (srv *clientSrv) {
 	srv = &clientSrv{
 		awaitingCmds: make(map[cmdID]chan<- error),
+		awaitingAcks: make(map[cmdID]chan<- error),
+		results:      make(map[cmdID][]byte),
 		srv:          gorums.NewServer(srvOpts...),
-		cmdCache:     newCmdCache(int(conf.BatchSize)),
+		cmdCache:     newCmdCache(conf),
 		hash:         sha256.New(),
+		executor:     conf.Executor,
+
+		confirmationDepth: int(conf.ConfirmationDepth),
 	}
+	srv.cmdCache.accepted = srv.accepted
 	clientpb.RegisterClientServer(srv.srv, srv)
 	return srv
 }
@@ -41,7 +52,6 @@ func newClientServer(conf Config, srvOpts []gorums.ServerOption) (srv *clientSrv
 // InitModule gives the module access to the other modules.
 func (srv *clientSrv) InitModule(mods *modules.Modules) {
 	srv.mods = mods
-	srv.cmdCache.InitModule(mods)
 }
 
 func (srv *clientSrv) Start(addr string) error {
@@ -66,7 +76,7 @@ func (srv *clientSrv) Stop() {
 	srv.srv.Stop()
 }
 
-func (srv *clientSrv) ExecCommand(ctx gorums.ServerCtx, cmd *clientpb.Command) (*empty.Empty, error) {
+func (srv *clientSrv) ExecCommand(ctx gorums.ServerCtx, cmd *clientpb.Command) (*clientpb.Result, error) {
 	id := cmdID{cmd.ClientID, cmd.SequenceNumber}
 
 	c := make(chan error)
@@ -77,26 +87,131 @@ func (srv *clientSrv) ExecCommand(ctx gorums.ServerCtx, cmd *clientpb.Command) (
 	srv.cmdCache.addCommand(cmd)
 	ctx.Release()
 	err := <-c
-	return &empty.Empty{}, err
+
+	srv.mut.Lock()
+	result := srv.results[id]
+	delete(srv.results, id)
+	srv.mut.Unlock()
+
+	return &clientpb.Result{CommandHash: clientpb.CommandHash(cmd), Data: result}, err
+}
+
+// AwaitProposal returns once the command has been accepted in a proposed block.
+// It must be called before ExecCommand, such that the acceptance of the command cannot be missed.
+func (srv *clientSrv) AwaitProposal(ctx gorums.ServerCtx, cmd *clientpb.Command) (*clientpb.Accepted, error) {
+	id := cmdID{cmd.ClientID, cmd.SequenceNumber}
+
+	// the channel is buffered, such that ack does not block if the client has stopped waiting.
+	c := make(chan error, 1)
+	srv.mut.Lock()
+	srv.awaitingAcks[id] = c
+	srv.mut.Unlock()
+
+	ctx.Release()
+	err := srv.waitAck(ctx, id, c)
+	return &clientpb.Accepted{CommandHash: clientpb.CommandHash(cmd)}, err
+}
+
+// waitAck waits until the command is accepted, or until the context is cancelled,
+// which happens if the client stops waiting or the server is stopped. The client no longer awaits the command once it returns.
+func (srv *clientSrv) waitAck(ctx context.Context, id cmdID, c chan error) error {
+	select {
+	case err := <-c:
+		return err
+	case <-ctx.Done():
+		srv.mut.Lock()
+		if srv.awaitingAcks[id] == c {
+			delete(srv.awaitingAcks, id)
+		}
+		srv.mut.Unlock()
+		return status.FromContextError(ctx.Err()).Err()
+	}
+}
+
+// accepted tells the clients that the commands in the batch have been accepted in a proposed block.
+func (srv *clientSrv) accepted(batch *clientpb.Batch) {
+	for _, cmd := range batch.GetCommands() {
+		srv.ack(cmdID{cmd.GetClientID(), cmd.GetSequenceNumber()}, nil)
+	}
+}
+
+// ack replies to the client that is waiting for the command to be accepted, if any.
+func (srv *clientSrv) ack(id cmdID, err error) {
+	srv.mut.Lock()
+	if done, ok := srv.awaitingAcks[id]; ok {
+		done <- err
+		delete(srv.awaitingAcks, id)
+	}
+	srv.mut.Unlock()
 }
 
 func (srv *clientSrv) Exec(cmd consensus.Command) {
-	batch := new(clientpb.Batch)
-	err := proto.UnmarshalOptions{AllowPartial: true}.Unmarshal([]byte(cmd), batch)
+	batch, err := srv.cmdCache.codec.unmarshal(cmd)
 	if err != nil {
 		srv.mods.Logger().Errorf("Failed to unmarshal command: %v", err)
 		return
 	}
 
 	srv.mods.MetricsEventLoop().AddEvent(consensus.CommitEvent{Commands: len(batch.GetCommands())})
+	srv.cmdCache.markCommitted(batch)
 
+	ids := make([]cmdID, 0, len(batch.GetCommands()))
 	for _, cmd := range batch.GetCommands() {
 		_, _ = srv.hash.Write(cmd.Data)
 		if err != nil {
 			srv.mods.Logger().Errorf("Error writing data: %v", err)
 		}
-		srv.mut.Lock()
 		id := cmdID{cmd.GetClientID(), cmd.GetSequenceNumber()}
+		ids = append(ids, id)
+		// the replica may not have accepted the block itself if it had to fetch it.
+		srv.ack(id, nil)
+		srv.execute(id, cmd)
+	}
+
+	if executor, ok := srv.executor.(AsyncExecutor); ok {
+		// the commands must be executed before the clients are told that they were executed.
+		executor.Wait()
+	}
+
+	// the commands are confirmed once enough blocks have been executed after the block that contains them.
+	srv.unconfirmed = append(srv.unconfirmed, ids)
+	for len(srv.unconfirmed) > srv.confirmationDepth {
+		srv.confirm(srv.unconfirmed[0])
+		srv.unconfirmed = srv.unconfirmed[1:]
+	}
+}
+
+// execute passes the command to the executor, if any, and saves the result if the client is waiting for it.
+func (srv *clientSrv) execute(id cmdID, cmd *clientpb.Command) {
+	switch executor := srv.executor.(type) {
+	case nil:
+	case AsyncExecutor:
+		executor.ExecAsync(consensus.Command(cmd.GetData()), func(result []byte) {
+			srv.saveResult(id, result)
+		})
+	case consensus.ExecutorWithResult:
+		srv.saveResult(id, executor.ExecWithResult(consensus.Command(cmd.GetData())))
+	default:
+		executor.Exec(consensus.Command(cmd.GetData()))
+	}
+}
+
+// saveResult saves the result of the command if the client is waiting for it.
+func (srv *clientSrv) saveResult(id cmdID, result []byte) {
+	if result == nil {
+		return
+	}
+	srv.mut.Lock()
+	if _, ok := srv.awaitingCmds[id]; ok {
+		srv.results[id] = result
+	}
+	srv.mut.Unlock()
+}
+
+// confirm tells the clients that the commands have been executed.
+func (srv *clientSrv) confirm(ids []cmdID) {
+	for _, id := range ids {
+		srv.mut.Lock()
 		if done, ok := srv.awaitingCmds[id]; ok {
 			done <- nil
 			delete(srv.awaitingCmds, id)
@@ -106,19 +221,20 @@ func (srv *clientSrv) Exec(cmd consensus.Command) {
 }
 
 func (srv *clientSrv) Fork(cmd consensus.Command) {
-	batch := new(clientpb.Batch)
-	err := proto.UnmarshalOptions{AllowPartial: true}.Unmarshal([]byte(cmd), batch)
+	batch, err := srv.cmdCache.codec.unmarshal(cmd)
 	if err != nil {
 		srv.mods.Logger().Errorf("Failed to unmarshal command: %v", err)
 		return
 	}
 
 	for _, cmd := range batch.GetCommands() {
-		srv.mut.Lock()
 		id := cmdID{cmd.GetClientID(), cmd.GetSequenceNumber()}
+		srv.ack(id, status.Error(codes.Aborted, "blockchain was forked"))
+		srv.mut.Lock()
 		if done, ok := srv.awaitingCmds[id]; ok {
 			done <- status.Error(codes.Aborted, "blockchain was forked")
 			delete(srv.awaitingCmds, id)
+			delete(srv.results, id)
 		}
 		srv.mut.Unlock()
 	}
@@ -0,0 +1,72 @@
+package replica
+
+import (
+	"context"
+	"testing"
+
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/internal/proto/clientpb"
+	"github.com/relab/hotstuff/modules"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestConfirmationDepth(t *testing.T) {
+	const depth = 2
+
+	srv := newClientServer(Config{ConfirmationDepth: depth}, nil)
+	builder := modules.NewBuilder(1)
+	builder.Register(srv)
+	builder.Build()
+
+	done := make(chan error, 1)
+	srv.awaitingCmds[cmdID{clientID: 1, sequenceNum: 1}] = done
+
+	exec := func(cmds ...*clientpb.Command) {
+		b, err := proto.Marshal(&clientpb.Batch{Commands: cmds})
+		if err != nil {
+			t.Fatal(err)
+		}
+		srv.Exec(consensus.Command(b))
+	}
+
+	exec(&clientpb.Command{ClientID: 1, SequenceNumber: 1, Data: []byte("foo")})
+	for i := 0; i < depth; i++ {
+		select {
+		case <-done:
+			t.Fatalf("client was notified after %d blocks, want %d", i, depth)
+		default:
+		}
+		exec()
+	}
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	default:
+		t.Errorf("client was not notified after %d blocks", depth)
+	}
+}
+
+// TestAwaitProposalCancelled checks that a client that stops waiting for its command to be accepted is forgotten,
+// and that the command can still be accepted afterwards.
+func TestAwaitProposalCancelled(t *testing.T) {
+	srv := newClientServer(Config{}, nil)
+	id := cmdID{clientID: 1, sequenceNum: 1}
+	c := make(chan error, 1)
+	srv.awaitingAcks[id] = c
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := srv.waitAck(ctx, id, c); status.Code(err) != codes.Canceled {
+		t.Errorf("wrong error: got: %v, want: %v", err, codes.Canceled)
+	}
+	if _, ok := srv.awaitingAcks[id]; ok {
+		t.Error("the client is still awaiting the command after it was cancelled")
+	}
+	// must not block
+	srv.ack(id, nil)
+}
@@ -7,36 +7,56 @@ import (
 
 	"github.com/relab/hotstuff/consensus"
 	"github.com/relab/hotstuff/internal/proto/clientpb"
-	"github.com/relab/hotstuff/modules"
-	"google.golang.org/protobuf/proto"
 )
 
 type cmdCache struct {
 	mut           sync.Mutex
-	mods          *modules.Modules
+	mods          *consensus.Modules
 	c             chan struct{}
 	batchSize     int
+	warmupView    consensus.View    // client commands are rejected before this view
+	byTimestamp   bool              // order commands by client timestamp instead of arrival order
 	serialNumbers map[uint32]uint64 // highest proposed serial number per client ID
+	committed     map[uint32]uint64 // highest committed serial number per client ID
 	cache         list.List
-	marshaler     proto.MarshalOptions
-	unmarshaler   proto.UnmarshalOptions
+	priorities    *priorityLevels // orders the commands by priority instead of cache, if the priority levels are set
+	codec         batchCodec
+	accepted      func(batch *clientpb.Batch) // called with each batch that is accepted. May be nil.
+
+	// reconfigurations that were authorized by the local replica, and have not yet been certified.
+	authorized map[consensus.Command]struct{}
+	reconfigs  []consensus.Command // authorized reconfigurations that the local replica has not yet proposed
 }
 
-func newCmdCache(batchSize int) *cmdCache {
-	return &cmdCache{
+func newCmdCache(conf Config) *cmdCache {
+	c := &cmdCache{
 		c:             make(chan struct{}),
-		batchSize:     batchSize,
+		batchSize:     int(conf.BatchSize),
+		warmupView:    conf.WarmupView,
+		byTimestamp:   conf.OrderByTimestamp,
 		serialNumbers: make(map[uint32]uint64),
-		marshaler:     proto.MarshalOptions{Deterministic: true},
-		unmarshaler:   proto.UnmarshalOptions{DiscardUnknown: true},
+		committed:     make(map[uint32]uint64),
+		codec:         newBatchCodec(conf.CanonicalBatches),
+		authorized:    make(map[consensus.Command]struct{}),
+	}
+	if conf.PriorityLevels > 0 {
+		levels := newPriorityLevels(int(conf.PriorityLevels), conf.PriorityAging)
+		c.priorities = &levels
 	}
+	return c
 }
 
-// InitModule gives the module access to the other modules.
-func (c *cmdCache) InitModule(mods *modules.Modules) {
+// InitConsensusModule gives the module a reference to the Modules object.
+// It also allows the module to set module options using the OptionsBuilder.
+func (c *cmdCache) InitConsensusModule(mods *consensus.Modules, _ *consensus.OptionsBuilder) {
 	c.mods = mods
 }
 
+// warmingUp returns true if the current view is before the warm-up view.
+func (c *cmdCache) warmingUp() bool {
+	return c.mods.Synchronizer().View() < c.warmupView
+}
+
 func (c *cmdCache) addCommand(cmd *clientpb.Command) {
 	c.mut.Lock()
 	defer c.mut.Unlock()
@@ -44,8 +64,15 @@ func (c *cmdCache) addCommand(cmd *clientpb.Command) {
 		// command is too old
 		return
 	}
-	c.cache.PushBack(cmd)
-	if c.cache.Len() >= c.batchSize {
+	switch {
+	case c.priorities != nil:
+		c.priorities.push(int(cmd.GetPriority()), cmd)
+	case c.byTimestamp:
+		c.insertByTimestamp(cmd)
+	default:
+		c.cache.PushBack(cmd)
+	}
+	if c.len() >= c.batchSize {
 		// notify Get that we are ready to send a new batch.
 		select {
 		case c.c <- struct{}{}:
@@ -54,32 +81,125 @@ func (c *cmdCache) addCommand(cmd *clientpb.Command) {
 	}
 }
 
+// addReconfiguration authorizes the reconfiguration, such that the replica accepts proposals of it,
+// and queues it to be proposed the next time that the replica is the leader.
+func (c *cmdCache) addReconfiguration(change consensus.Reconfiguration) {
+	cmd := change.ToCommand()
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	if _, ok := c.authorized[cmd]; ok {
+		return
+	}
+	c.authorized[cmd] = struct{}{}
+	c.reconfigs = append(c.reconfigs, cmd)
+	select {
+	case c.c <- struct{}{}:
+	default:
+	}
+}
+
+// len returns the number of commands in the cache. The caller must hold the lock.
+func (c *cmdCache) len() int {
+	if c.priorities != nil {
+		return c.priorities.len()
+	}
+	return c.cache.Len()
+}
+
+// pop removes and returns the next command in the cache. The caller must hold the lock.
+func (c *cmdCache) pop() (cmd *clientpb.Command, ok bool) {
+	if c.priorities != nil {
+		item, ok := c.priorities.pop()
+		if !ok {
+			return nil, false
+		}
+		return item.(*clientpb.Command), true
+	}
+	elem := c.cache.Front()
+	if elem == nil {
+		return nil, false
+	}
+	c.cache.Remove(elem)
+	return elem.Value.(*clientpb.Command), true
+}
+
+// insertByTimestamp inserts the command such that the cache remains sorted by timestamp and then by client ID.
+// Commands usually arrive in roughly timestamp order, so we search for the position from the back.
+func (c *cmdCache) insertByTimestamp(cmd *clientpb.Command) {
+	for elem := c.cache.Back(); elem != nil; elem = elem.Prev() {
+		if !timestampLess(cmd, elem.Value.(*clientpb.Command)) {
+			c.cache.InsertAfter(cmd, elem)
+			return
+		}
+	}
+	c.cache.PushFront(cmd)
+}
+
+// timestampLess returns true if a should be ordered before b.
+func timestampLess(a, b *clientpb.Command) bool {
+	ta, tb := a.GetTimestamp().AsTime(), b.GetTimestamp().AsTime()
+	if !ta.Equal(tb) {
+		return ta.Before(tb)
+	}
+	return a.GetClientID() < b.GetClientID()
+}
+
 // Get returns a batch of commands to propose.
 func (c *cmdCache) Get(ctx context.Context) (cmd consensus.Command, ok bool) {
-	batch := new(clientpb.Batch)
+	if c.warmingUp() {
+		// propose an empty batch until the warm-up period is over.
+		return "", true
+	}
 
 	c.mut.Lock()
-awaitBatch:
-	// wait until we can send a new batch.
-	for c.cache.Len() <= c.batchSize {
-		c.mut.Unlock()
-		select {
-		case <-c.c:
-		case <-ctx.Done():
-			return
+	defer c.mut.Unlock()
+	for {
+		// wait until we can send a new batch.
+		for c.len() <= c.batchSize && len(c.reconfigs) == 0 {
+			c.mut.Unlock()
+			select {
+			case <-c.c:
+			case <-ctx.Done():
+				c.mut.Lock()
+				return "", false
+			}
+			c.mut.Lock()
+		}
+		// if we got no (new) commands, try to wait again
+		if cmd, ok := c.next(); ok {
+			return cmd, true
 		}
-		c.mut.Lock()
+	}
+}
+
+// Poll returns a batch of the commands that are available, without waiting for more commands.
+func (c *cmdCache) Poll() (cmd consensus.Command, ok bool) {
+	if c.warmingUp() {
+		return "", true
+	}
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	return c.next()
+}
+
+// next removes the next reconfiguration or batch of commands from the cache.
+// It returns false if there are no new commands. The caller must hold the lock.
+func (c *cmdCache) next() (cmd consensus.Command, ok bool) {
+	// reconfigurations are proposed in blocks of their own, before any client commands.
+	if len(c.reconfigs) > 0 {
+		cmd = c.reconfigs[0]
+		c.reconfigs = c.reconfigs[1:]
+		return cmd, true
 	}
 
 	// Get the batch. Note that we may not be able to fill the batch, but that should be fine as long as we can send
 	// at least one command.
+	batch := new(clientpb.Batch)
 	for i := 0; i < c.batchSize; i++ {
-		elem := c.cache.Front()
-		if elem == nil {
+		cmd, ok := c.pop()
+		if !ok {
 			break
 		}
-		c.cache.Remove(elem)
-		cmd := elem.Value.(*clientpb.Command)
 		if serialNo := c.serialNumbers[cmd.GetClientID()]; serialNo >= cmd.GetSequenceNumber() {
 			// command is too old
 			i--
@@ -87,51 +207,76 @@ awaitBatch:
 		}
 		batch.Commands = append(batch.Commands, cmd)
 	}
-
-	// if we still got no (new) commands, try to wait again
 	if len(batch.Commands) == 0 {
-		goto awaitBatch
+		return "", false
 	}
 
-	defer c.mut.Unlock()
-
 	// otherwise, we should have at least one command
-	b, err := c.marshaler.Marshal(batch)
+	cmd, err := c.codec.marshal(batch)
 	if err != nil {
 		c.mods.Logger().Errorf("Failed to marshal batch: %v", err)
 		return "", false
 	}
-
-	cmd = consensus.Command(b)
 	return cmd, true
 }
 
 // Accept returns true if the replica can accept the batch.
+// A reconfiguration is only accepted if it was authorized by the local replica.
 func (c *cmdCache) Accept(cmd consensus.Command) bool {
-	batch := new(clientpb.Batch)
-	err := c.unmarshaler.Unmarshal([]byte(cmd), batch)
+	if change, ok := consensus.ReconfigurationFromCommand(cmd); ok {
+		c.mut.Lock()
+		_, authorized := c.authorized[cmd]
+		c.mut.Unlock()
+		if !authorized {
+			c.mods.Logger().Infof("Rejecting unauthorized reconfiguration: %v", change)
+		}
+		return authorized
+	}
+
+	batch, err := c.codec.unmarshal(cmd)
 	if err != nil {
 		c.mods.Logger().Errorf("Failed to unmarshal batch: %v", err)
 		return false
 	}
 
-	c.mut.Lock()
-	defer c.mut.Unlock()
+	if len(batch.GetCommands()) > 0 && c.warmingUp() {
+		c.mods.Logger().Debugf("Rejecting batch of %d commands during warm-up", len(batch.GetCommands()))
+		return false
+	}
 
+	c.mut.Lock()
 	for _, cmd := range batch.GetCommands() {
 		if serialNo := c.serialNumbers[cmd.GetClientID()]; serialNo >= cmd.GetSequenceNumber() {
 			// command is too old, can't accept
+			c.mut.Unlock()
 			return false
 		}
 	}
+	c.mut.Unlock()
 
+	if c.accepted != nil {
+		c.accepted(batch)
+	}
 	return true
 }
 
 // Proposed updates the serial numbers such that we will not accept the given batch again.
+// A reconfiguration is no longer authorized, such that it is not proposed or accepted again.
 func (c *cmdCache) Proposed(cmd consensus.Command) {
-	batch := new(clientpb.Batch)
-	err := c.unmarshaler.Unmarshal([]byte(cmd), batch)
+	if _, ok := consensus.ReconfigurationFromCommand(cmd); ok {
+		c.mut.Lock()
+		defer c.mut.Unlock()
+		delete(c.authorized, cmd)
+		for i, reconfig := range c.reconfigs {
+			if reconfig == cmd {
+				c.reconfigs = append(c.reconfigs[:i], c.reconfigs[i+1:]...)
+				break
+			}
+		}
+		return
+	}
+
+	batch, err := c.codec.unmarshal(cmd)
 	if err != nil {
 		c.mods.Logger().Errorf("Failed to unmarshal batch: %v", err)
 		return
@@ -147,4 +292,38 @@ func (c *cmdCache) Proposed(cmd consensus.Command) {
 	}
 }
 
-var _ consensus.Acceptor = (*cmdCache)(nil)
+// markCommitted updates the committed serial numbers with the commands in the batch.
+func (c *cmdCache) markCommitted(batch *clientpb.Batch) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	for _, cmd := range batch.GetCommands() {
+		if serialNo := c.committed[cmd.GetClientID()]; serialNo < cmd.GetSequenceNumber() {
+			c.committed[cmd.GetClientID()] = cmd.GetSequenceNumber()
+		}
+	}
+}
+
+// Stale returns true if the batch contains commands, and all of them have already been committed.
+func (c *cmdCache) Stale(cmd consensus.Command) bool {
+	batch, err := c.codec.unmarshal(cmd)
+	if err != nil || len(batch.GetCommands()) == 0 {
+		return false
+	}
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	for _, cmd := range batch.GetCommands() {
+		if serialNo := c.committed[cmd.GetClientID()]; serialNo < cmd.GetSequenceNumber() {
+			return false
+		}
+	}
+	return true
+}
+
+var (
+	_ consensus.Acceptor      = (*cmdCache)(nil)
+	_ consensus.StaleChecker  = (*cmdCache)(nil)
+	_ consensus.CommandPoller = (*cmdCache)(nil)
+)
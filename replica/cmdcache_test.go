@@ -0,0 +1,301 @@
+package replica
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/internal/mocks"
+	"github.com/relab/hotstuff/internal/proto/clientpb"
+	"github.com/relab/hotstuff/internal/testutil"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestCmdCacheWarmup(t *testing.T) {
+	const warmupView = 5
+
+	ctrl := gomock.NewController(t)
+	builder := testutil.TestModules(t, ctrl, 1, testutil.GenerateECDSAKey(t))
+
+	var view consensus.View
+	synchronizer := mocks.NewMockSynchronizer(ctrl)
+	synchronizer.EXPECT().View().AnyTimes().DoAndReturn(func() consensus.View { return view })
+
+	cache := newCmdCache(Config{BatchSize: 1, WarmupView: warmupView})
+	builder.Register(synchronizer, cache)
+	builder.Build()
+
+	seqNum := uint64(0)
+	newBatch := func() consensus.Command {
+		seqNum++
+		b, err := proto.Marshal(&clientpb.Batch{Commands: []*clientpb.Command{{ClientID: 1, SequenceNumber: seqNum}}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return consensus.Command(b)
+	}
+
+	for view = 1; view < warmupView; view++ {
+		if cache.Accept(newBatch()) {
+			t.Errorf("command was accepted in view %d, before the warm-up view", view)
+		}
+		if !cache.Accept("") {
+			t.Errorf("empty batch was rejected in view %d", view)
+		}
+		cmd, ok := cache.Get(context.Background())
+		if !ok || cmd != "" {
+			t.Errorf("expected an empty batch to be proposed in view %d", view)
+		}
+	}
+
+	for ; view < 2*warmupView; view++ {
+		if !cache.Accept(newBatch()) {
+			t.Errorf("command was rejected in view %d, after the warm-up view", view)
+		}
+	}
+}
+
+func TestCmdCacheOrderByTimestamp(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	builder := testutil.TestModules(t, ctrl, 1, testutil.GenerateECDSAKey(t))
+	synchronizer := mocks.NewMockSynchronizer(ctrl)
+	synchronizer.EXPECT().View().AnyTimes().Return(consensus.View(1))
+
+	cache := newCmdCache(Config{BatchSize: 4, OrderByTimestamp: true})
+	builder.Register(synchronizer, cache)
+	builder.Build()
+
+	start := time.Now()
+	at := func(ms int) *timestamppb.Timestamp {
+		return timestamppb.New(start.Add(time.Duration(ms) * time.Millisecond))
+	}
+
+	// commands arrive in a different order than they were created in
+	arrivals := []*clientpb.Command{
+		{ClientID: 2, SequenceNumber: 1, Timestamp: at(30)},
+		{ClientID: 1, SequenceNumber: 1, Timestamp: at(10)},
+		{ClientID: 3, SequenceNumber: 1, Timestamp: at(20)},
+		{ClientID: 1, SequenceNumber: 2, Timestamp: at(30)},
+		{ClientID: 3, SequenceNumber: 2, Timestamp: at(50)},
+	}
+	for _, cmd := range arrivals {
+		cache.addCommand(cmd)
+	}
+
+	cmd, ok := cache.Get(context.Background())
+	if !ok {
+		t.Fatal("failed to get a batch")
+	}
+	batch := new(clientpb.Batch)
+	if err := proto.Unmarshal([]byte(cmd), batch); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		clientID uint32
+		seqNum   uint64
+	}{{1, 1}, {3, 1}, {1, 2}, {2, 1}}
+	if len(batch.GetCommands()) != len(want) {
+		t.Fatalf("wrong batch size: got: %d, want: %d", len(batch.GetCommands()), len(want))
+	}
+	for i, cmd := range batch.GetCommands() {
+		if cmd.GetClientID() != want[i].clientID || cmd.GetSequenceNumber() != want[i].seqNum {
+			t.Errorf("command %d: got: (client %d, seq %d), want: (client %d, seq %d)",
+				i, cmd.GetClientID(), cmd.GetSequenceNumber(), want[i].clientID, want[i].seqNum)
+		}
+	}
+}
+
+func TestCmdCachePriority(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	builder := testutil.TestModules(t, ctrl, 1, testutil.GenerateECDSAKey(t))
+	synchronizer := mocks.NewMockSynchronizer(ctrl)
+	synchronizer.EXPECT().View().AnyTimes().Return(consensus.View(1))
+
+	cache := newCmdCache(Config{BatchSize: 2, PriorityLevels: 2})
+	builder.Register(synchronizer, cache)
+	builder.Build()
+
+	// the latency-sensitive commands of client 2 arrive after the commands of client 1
+	arrivals := []*clientpb.Command{
+		{ClientID: 1, SequenceNumber: 1},
+		{ClientID: 1, SequenceNumber: 2},
+		{ClientID: 2, SequenceNumber: 1, Priority: 1},
+		{ClientID: 2, SequenceNumber: 2, Priority: 1},
+		{ClientID: 1, SequenceNumber: 3},
+	}
+	for _, cmd := range arrivals {
+		cache.addCommand(cmd)
+	}
+
+	cmd, ok := cache.Get(context.Background())
+	if !ok {
+		t.Fatal("failed to get a batch")
+	}
+	batch := new(clientpb.Batch)
+	if err := proto.Unmarshal([]byte(cmd), batch); err != nil {
+		t.Fatal(err)
+	}
+	if len(batch.GetCommands()) != 2 {
+		t.Fatalf("wrong batch size: got: %d, want: %d", len(batch.GetCommands()), 2)
+	}
+	for i, cmd := range batch.GetCommands() {
+		if cmd.GetClientID() != 2 || cmd.GetSequenceNumber() != uint64(i+1) {
+			t.Errorf("command %d: got: (client %d, seq %d), want: (client 2, seq %d)",
+				i, cmd.GetClientID(), cmd.GetSequenceNumber(), i+1)
+		}
+	}
+}
+
+func TestCmdCacheStale(t *testing.T) {
+	cache := newCmdCache(Config{BatchSize: 1})
+	batch := func(seqNums ...uint64) consensus.Command {
+		b := new(clientpb.Batch)
+		for _, seqNum := range seqNums {
+			b.Commands = append(b.Commands, &clientpb.Command{ClientID: 1, SequenceNumber: seqNum})
+		}
+		cmd, err := cache.codec.marshal(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return cmd
+	}
+
+	cache.markCommitted(&clientpb.Batch{Commands: []*clientpb.Command{{ClientID: 1, SequenceNumber: 2}}})
+
+	if !cache.Stale(batch(1, 2)) {
+		t.Error("batch of committed commands was not stale")
+	}
+	if cache.Stale(batch(2, 3)) {
+		t.Error("batch with an uncommitted command was stale")
+	}
+	if cache.Stale(batch()) {
+		t.Error("empty batch was stale")
+	}
+}
+
+// TestCmdCacheGetCancel checks that Get returns promptly when the context is cancelled while it waits for commands.
+func TestCmdCacheGetCancel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	builder := testutil.TestModules(t, ctrl, 1, testutil.GenerateECDSAKey(t))
+	synchronizer := mocks.NewMockSynchronizer(ctrl)
+	synchronizer.EXPECT().View().AnyTimes().Return(consensus.View(1))
+	cache := newCmdCache(Config{BatchSize: 1})
+	builder.Register(synchronizer, cache)
+	builder.Build()
+
+	queues := map[string]consensus.CommandQueue{
+		"cmdCache":   cache,
+		"BatchQueue": newBatchQueue(t, 1, time.Second),
+	}
+	for name, queue := range queues {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			type result struct {
+				cmd consensus.Command
+				ok  bool
+			}
+			c := make(chan result)
+			go func() {
+				cmd, ok := queue.Get(ctx)
+				c <- result{cmd, ok}
+			}()
+
+			// give Get time to block before cancelling.
+			time.Sleep(10 * time.Millisecond)
+			cancel()
+
+			select {
+			case r := <-c:
+				if r.ok || r.cmd != "" {
+					t.Errorf("Get returned (%q, %v) after the context was cancelled, want (\"\", false)", r.cmd, r.ok)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("Get did not return after the context was cancelled")
+			}
+		})
+	}
+}
+
+// TestCmdCachePoll checks that Poll returns the commands that are available, even if Get would wait for more commands.
+func TestCmdCachePoll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	builder := testutil.TestModules(t, ctrl, 1, testutil.GenerateECDSAKey(t))
+	synchronizer := mocks.NewMockSynchronizer(ctrl)
+	synchronizer.EXPECT().View().AnyTimes().Return(consensus.View(1))
+	cache := newCmdCache(Config{BatchSize: 2})
+	builder.Register(synchronizer, cache)
+	builder.Build()
+
+	if cmd, ok := cache.Poll(); ok {
+		t.Errorf("Poll returned %q from an empty cache", cmd)
+	}
+
+	cache.addCommand(&clientpb.Command{ClientID: 1, SequenceNumber: 1})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, ok := cache.Get(ctx); ok {
+		t.Fatal("Get returned a batch without waiting for a full batch")
+	}
+	cmd, ok := cache.Poll()
+	if !ok {
+		t.Fatal("Poll did not return the available command")
+	}
+	batch, err := cache.codec.unmarshal(cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(batch.GetCommands()) != 1 || batch.GetCommands()[0].GetSequenceNumber() != 1 {
+		t.Errorf("Poll returned the wrong batch: %v", batch)
+	}
+	if cmd, ok := cache.Poll(); ok {
+		t.Errorf("Poll returned %q after the cache was emptied", cmd)
+	}
+}
+
+// TestCmdCacheReconfiguration checks that a reconfiguration is only accepted if it was authorized by the local replica,
+// that it is proposed before client commands, and that it is not accepted again once it is certified.
+func TestCmdCacheReconfiguration(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	builder := testutil.TestModules(t, ctrl, 1, testutil.GenerateECDSAKey(t))
+	synchronizer := mocks.NewMockSynchronizer(ctrl)
+	synchronizer.EXPECT().View().AnyTimes().Return(consensus.View(1))
+
+	cache := newCmdCache(Config{BatchSize: 1})
+	builder.Register(synchronizer, cache)
+	builder.Build()
+
+	change := consensus.Reconfiguration{Remove: true, ID: 4}
+	if cache.Accept(change.ToCommand()) {
+		t.Error("an unauthorized reconfiguration was accepted")
+	}
+
+	cache.addCommand(&clientpb.Command{ClientID: 1, SequenceNumber: 1})
+	cache.addCommand(&clientpb.Command{ClientID: 1, SequenceNumber: 2})
+	cache.addReconfiguration(change)
+	if !cache.Accept(change.ToCommand()) {
+		t.Error("an authorized reconfiguration was rejected")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	cmd, _ := cache.Get(ctx)
+	if got, ok := consensus.ReconfigurationFromCommand(cmd); !ok || got.ID != change.ID || !got.Remove {
+		t.Fatalf("expected the reconfiguration to be proposed first, got: %q", cmd)
+	}
+
+	cache.Proposed(cmd)
+	if cache.Accept(change.ToCommand()) {
+		t.Error("a certified reconfiguration was accepted again")
+	}
+	cmd, ok := cache.Get(ctx)
+	if !ok {
+		t.Fatal("expected a batch of client commands to be proposed")
+	}
+	if _, ok := consensus.ReconfigurationFromCommand(cmd); ok {
+		t.Error("a certified reconfiguration was proposed again")
+	}
+}
@@ -0,0 +1,132 @@
+package replica
+
+import (
+	"sync"
+
+	"github.com/relab/hotstuff/consensus"
+)
+
+// AsyncExecutor is an Executor that may return from Exec before the command has been executed.
+// The replica waits for the commands in a block to be executed before it tells the clients that they were executed.
+type AsyncExecutor interface {
+	consensus.Executor
+	// ExecAsync starts executing the command, and calls done with the result once the command has been executed.
+	// The result is nil if the executor does not return results.
+	ExecAsync(cmd consensus.Command, done func(result []byte))
+	// Wait waits until all commands that have been started are executed.
+	Wait()
+}
+
+// ParallelExecutor is an Executor that executes committed commands concurrently with the earlier commands
+// that they do not conflict with. Two commands conflict if the conflicts function returns true for them.
+// A command is only executed after all earlier commands that it conflicts with have been executed,
+// such that the commands appear to have been executed in the total order to any application
+// whose conflicts function reports all commands that do not commute.
+//
+// The replica waits for all commands in a block to be executed before it handles the next block.
+// If the executor implements consensus.ExecutorWithResult or consensus.CommitHook, so does the ParallelExecutor,
+// such that the results are sent to the clients, and the hook is called with each committed block.
+type ParallelExecutor struct {
+	executor  consensus.Executor
+	conflicts func(a, b consensus.Command) bool
+	sem       chan struct{} // limits the number of commands that are executed concurrently. May be nil.
+
+	mut     sync.Mutex
+	pending []*pendingCmd // commands that have been started, but have not finished
+	wg      sync.WaitGroup
+}
+
+type pendingCmd struct {
+	cmd  consensus.Command
+	done chan struct{}
+}
+
+// NewParallelExecutor returns a ParallelExecutor that executes the commands with the given executor.
+// At most maxParallel commands are executed concurrently. If maxParallel is 0, there is no limit.
+func NewParallelExecutor(executor consensus.Executor, conflicts func(a, b consensus.Command) bool, maxParallel int) *ParallelExecutor {
+	e := &ParallelExecutor{
+		executor:  executor,
+		conflicts: conflicts,
+	}
+	if maxParallel > 0 {
+		e.sem = make(chan struct{}, maxParallel)
+	}
+	return e
+}
+
+// Exec starts executing the command once all earlier commands that it conflicts with have been executed.
+// It does not wait for the command to be executed.
+func (e *ParallelExecutor) Exec(cmd consensus.Command) {
+	e.ExecAsync(cmd, nil)
+}
+
+// ExecAsync starts executing the command once all earlier commands that it conflicts with have been executed,
+// and calls done with the result once the command has been executed. done may be nil.
+// The result is nil if the executor does not implement consensus.ExecutorWithResult.
+func (e *ParallelExecutor) ExecAsync(cmd consensus.Command, done func(result []byte)) {
+	e.mut.Lock()
+	var deps []chan struct{}
+	for _, p := range e.pending {
+		if e.conflicts(p.cmd, cmd) {
+			deps = append(deps, p.done)
+		}
+	}
+	p := &pendingCmd{cmd: cmd, done: make(chan struct{})}
+	e.pending = append(e.pending, p)
+	e.wg.Add(1)
+	e.mut.Unlock()
+
+	go func() {
+		defer e.wg.Done()
+		for _, dep := range deps {
+			<-dep
+		}
+		if e.sem != nil {
+			e.sem <- struct{}{}
+		}
+		var result []byte
+		if executor, ok := e.executor.(consensus.ExecutorWithResult); ok {
+			result = executor.ExecWithResult(cmd)
+		} else {
+			e.executor.Exec(cmd)
+		}
+		if e.sem != nil {
+			<-e.sem
+		}
+		e.finish(p)
+		if done != nil {
+			done(result)
+		}
+	}()
+}
+
+// finish removes the command from the pending commands and notifies the commands that depend on it.
+func (e *ParallelExecutor) finish(p *pendingCmd) {
+	e.mut.Lock()
+	for i, q := range e.pending {
+		if q == p {
+			e.pending = append(e.pending[:i], e.pending[i+1:]...)
+			break
+		}
+	}
+	e.mut.Unlock()
+	close(p.done)
+}
+
+// Wait waits until all commands that have been started are executed.
+func (e *ParallelExecutor) Wait() {
+	e.wg.Wait()
+}
+
+// PreExecCommit calls the CommitHook of the executor, if it implements consensus.CommitHook.
+func (e *ParallelExecutor) PreExecCommit(block *consensus.Block) error {
+	if hook, ok := e.executor.(consensus.CommitHook); ok {
+		return hook.PreExecCommit(block)
+	}
+	return nil
+}
+
+var (
+	_ AsyncExecutor        = (*ParallelExecutor)(nil)
+	_ consensus.CommitHook = (*ParallelExecutor)(nil)
+)
@@ -0,0 +1,184 @@
+package replica
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/relab/hotstuff/consensus"
+)
+
+// blockingExecutor records the order in which commands start and finish,
+// and blocks each command until it is released.
+type blockingExecutor struct {
+	mut      sync.Mutex
+	started  []consensus.Command
+	finished []consensus.Command
+	start    chan consensus.Command
+	release  map[consensus.Command]chan struct{}
+}
+
+func newBlockingExecutor(cmds ...consensus.Command) *blockingExecutor {
+	e := &blockingExecutor{
+		start:   make(chan consensus.Command, len(cmds)),
+		release: make(map[consensus.Command]chan struct{}),
+	}
+	for _, cmd := range cmds {
+		e.release[cmd] = make(chan struct{})
+	}
+	return e
+}
+
+func (e *blockingExecutor) Exec(cmd consensus.Command) {
+	e.mut.Lock()
+	e.started = append(e.started, cmd)
+	e.mut.Unlock()
+	e.start <- cmd
+	<-e.release[cmd]
+	e.mut.Lock()
+	e.finished = append(e.finished, cmd)
+	e.mut.Unlock()
+}
+
+// sameKey reports a conflict between commands that start with the same character.
+func sameKey(a, b consensus.Command) bool {
+	return a[0] == b[0]
+}
+
+// awaitStart waits for the executor to start a command, or returns false after a timeout.
+func (e *blockingExecutor) awaitStart(t *testing.T) (consensus.Command, bool) {
+	t.Helper()
+	select {
+	case cmd := <-e.start:
+		return cmd, true
+	case <-time.After(100 * time.Millisecond):
+		return "", false
+	}
+}
+
+func TestParallelExecutor(t *testing.T) {
+	cmds := []consensus.Command{"a1", "b1", "a2"}
+	executor := newBlockingExecutor(cmds...)
+	parallel := NewParallelExecutor(executor, sameKey, 0)
+
+	for _, cmd := range cmds {
+		parallel.Exec(cmd)
+	}
+
+	// the independent commands run concurrently.
+	started := make(map[consensus.Command]bool)
+	for i := 0; i < 2; i++ {
+		cmd, ok := executor.awaitStart(t)
+		if !ok {
+			t.Fatal("independent commands were not executed concurrently")
+		}
+		started[cmd] = true
+	}
+	if !started["a1"] || !started["b1"] {
+		t.Fatalf("wrong commands started: %v", started)
+	}
+
+	// the conflicting command waits for the earlier command.
+	if cmd, ok := executor.awaitStart(t); ok {
+		t.Fatalf("command %s started before the conflicting command a1 finished", cmd)
+	}
+	close(executor.release["a1"])
+	if cmd, ok := executor.awaitStart(t); !ok || cmd != "a2" {
+		t.Fatalf("expected a2 to start after a1 finished, got %q", cmd)
+	}
+
+	close(executor.release["b1"])
+	close(executor.release["a2"])
+	parallel.Wait()
+
+	var order []consensus.Command
+	for _, cmd := range executor.finished {
+		if cmd[0] == 'a' {
+			order = append(order, cmd)
+		}
+	}
+	if len(order) != 2 || order[0] != "a1" || order[1] != "a2" {
+		t.Errorf("conflicting commands finished in the wrong order: %v", order)
+	}
+}
+
+func TestParallelExecutorLimit(t *testing.T) {
+	cmds := []consensus.Command{"a1", "b1"}
+	executor := newBlockingExecutor(cmds...)
+	parallel := NewParallelExecutor(executor, sameKey, 1)
+
+	for _, cmd := range cmds {
+		parallel.Exec(cmd)
+	}
+
+	first, ok := executor.awaitStart(t)
+	if !ok {
+		t.Fatal("no command was started")
+	}
+	if cmd, ok := executor.awaitStart(t); ok {
+		t.Fatalf("command %s started while %s was running", cmd, first)
+	}
+	close(executor.release[first])
+	if _, ok := executor.awaitStart(t); !ok {
+		t.Fatal("second command was not started")
+	}
+	for _, cmd := range cmds {
+		if cmd != first {
+			close(executor.release[cmd])
+		}
+	}
+	parallel.Wait()
+}
+
+// resultExecutor is an ExecutorWithResult and a CommitHook that returns the command as the result.
+type resultExecutor struct {
+	hooked []*consensus.Block
+}
+
+func (e *resultExecutor) Exec(_ consensus.Command) {}
+
+func (e *resultExecutor) ExecWithResult(cmd consensus.Command) []byte {
+	return []byte(cmd)
+}
+
+func (e *resultExecutor) PreExecCommit(block *consensus.Block) error {
+	e.hooked = append(e.hooked, block)
+	return nil
+}
+
+// TestParallelExecutorForwarding checks that the results and the commit hook of the executor are forwarded.
+func TestParallelExecutorForwarding(t *testing.T) {
+	executor := &resultExecutor{}
+	parallel := NewParallelExecutor(executor, sameKey, 0)
+
+	var (
+		mut     sync.Mutex
+		results = make(map[string]string)
+	)
+	for _, cmd := range []consensus.Command{"a1", "b1"} {
+		cmd := cmd
+		parallel.ExecAsync(cmd, func(result []byte) {
+			mut.Lock()
+			results[string(cmd)] = string(result)
+			mut.Unlock()
+		})
+	}
+	parallel.Wait()
+	if len(results) != 2 || results["a1"] != "a1" || results["b1"] != "b1" {
+		t.Errorf("wrong results: %v", results)
+	}
+
+	block := consensus.GetGenesis()
+	if err := parallel.PreExecCommit(block); err != nil {
+		t.Fatal(err)
+	}
+	if len(executor.hooked) != 1 || executor.hooked[0] != block {
+		t.Error("the commit hook of the executor was not called")
+	}
+
+	// an executor without results or a commit hook
+	plain := NewParallelExecutor(newBlockingExecutor(), sameKey, 0)
+	if err := plain.PreExecCommit(block); err != nil {
+		t.Errorf("unexpected error from an executor without a commit hook: %v", err)
+	}
+}
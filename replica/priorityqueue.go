@@ -0,0 +1,154 @@
+package replica
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/relab/hotstuff/consensus"
+)
+
+// PriorityQueue is a CommandQueue that serves commands by priority.
+// The first byte of each command that is added is its priority, where higher values are served first.
+// Commands with the same priority are served in the order that they were added.
+// To prevent starvation, a command that has waited for at least the aging threshold is served before
+// commands with a higher priority, starting with the command that has waited the longest.
+// The priority byte is removed from the commands that are returned by Get.
+//
+// The replica orders the commands from clients in the same way if Config.PriorityLevels is set.
+type PriorityQueue struct {
+	mut sync.Mutex
+	priorityLevels
+	c chan struct{} // notifies Get that commands were added
+}
+
+// NewPriorityQueue returns a new PriorityQueue with the given number of priority levels.
+// Priorities that are not below levels are served at the highest level.
+// If aging is 0, commands are served strictly by priority, and low priority commands may starve.
+func NewPriorityQueue(levels int, aging time.Duration) *PriorityQueue {
+	return &PriorityQueue{
+		priorityLevels: newPriorityLevels(levels, aging),
+		c:              make(chan struct{}, 1),
+	}
+}
+
+// WithPriority returns the command tagged with the priority, such that it can be added to a PriorityQueue.
+func WithPriority(priority byte, cmd consensus.Command) consensus.Command {
+	return consensus.Command(string(priority) + string(cmd))
+}
+
+// Add adds a command that is tagged with its priority to the queue.
+// An empty command has the lowest priority.
+func (q *PriorityQueue) Add(cmd consensus.Command) {
+	level := 0
+	if len(cmd) > 0 {
+		level = int(cmd[0])
+		cmd = cmd[1:]
+	}
+	q.mut.Lock()
+	q.push(level, cmd)
+	q.mut.Unlock()
+	select {
+	case q.c <- struct{}{}:
+	default:
+	}
+}
+
+// Len returns the number of commands in the queue.
+func (q *PriorityQueue) Len() int {
+	q.mut.Lock()
+	defer q.mut.Unlock()
+	return q.len()
+}
+
+// Get returns the next command to propose. It returns false if the context is cancelled before any command is added.
+func (q *PriorityQueue) Get(ctx context.Context) (cmd consensus.Command, ok bool) {
+	for {
+		q.mut.Lock()
+		item, ok := q.pop()
+		q.mut.Unlock()
+		if ok {
+			return item.(consensus.Command), true
+		}
+		select {
+		case <-q.c:
+		case <-ctx.Done():
+			return "", false
+		}
+	}
+}
+
+// priorityLevels is a FIFO queue for each priority level, which serves the items by priority and aging.
+// It is not safe for concurrent use.
+type priorityLevels struct {
+	aging  time.Duration
+	now    func() time.Time
+	levels [][]prioritized
+	n      int // the number of items in all levels
+}
+
+type prioritized struct {
+	item  interface{}
+	added time.Time
+}
+
+func newPriorityLevels(levels int, aging time.Duration) priorityLevels {
+	if levels < 1 {
+		levels = 1
+	}
+	return priorityLevels{
+		aging:  aging,
+		now:    time.Now,
+		levels: make([][]prioritized, levels),
+	}
+}
+
+// push adds the item at the level. Levels that are not below the number of levels are added at the highest level.
+func (p *priorityLevels) push(level int, item interface{}) {
+	if level >= len(p.levels) {
+		level = len(p.levels) - 1
+	}
+	p.levels[level] = append(p.levels[level], prioritized{item: item, added: p.now()})
+	p.n++
+}
+
+// len returns the number of items in all levels.
+func (p *priorityLevels) len() int {
+	return p.n
+}
+
+// pop removes and returns the next item to serve, if any.
+func (p *priorityLevels) pop() (item interface{}, ok bool) {
+	next := -1
+	for level := len(p.levels) - 1; level >= 0; level-- {
+		if len(p.levels[level]) > 0 {
+			next = level
+			break
+		}
+	}
+	if next < 0 {
+		return nil, false
+	}
+
+	// serve the item that has waited the longest, if it has reached the aging threshold.
+	if p.aging > 0 {
+		now := p.now()
+		for level := next - 1; level >= 0; level-- {
+			if len(p.levels[level]) == 0 {
+				continue
+			}
+			head := p.levels[level][0]
+			if now.Sub(head.added) >= p.aging && head.added.Before(p.levels[next][0].added) {
+				next = level
+			}
+		}
+	}
+
+	item = p.levels[next][0].item
+	p.levels[next][0] = prioritized{}
+	p.levels[next] = p.levels[next][1:]
+	p.n--
+	return item, true
+}
+
+var _ consensus.CommandQueue = (*PriorityQueue)(nil)
@@ -0,0 +1,101 @@
+package replica
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/relab/hotstuff/consensus"
+)
+
+// get returns the next n commands that are served by the queue.
+func get(t *testing.T, q *PriorityQueue, n int) (cmds []consensus.Command) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		cmd, ok := q.Get(context.Background())
+		if !ok {
+			t.Fatal("no command was returned")
+		}
+		cmds = append(cmds, cmd)
+	}
+	return cmds
+}
+
+func TestPriorityQueueOrder(t *testing.T) {
+	q := NewPriorityQueue(3, 0)
+	q.Add(WithPriority(0, "low1"))
+	q.Add(WithPriority(2, "high1"))
+	q.Add(WithPriority(1, "mid"))
+	q.Add(WithPriority(0, "low2"))
+	q.Add(WithPriority(9, "high2")) // priorities above the highest level are served at the highest level
+
+	want := []consensus.Command{"high1", "high2", "mid", "low1", "low2"}
+	if got := get(t, q, len(want)); !equalCommands(got, want) {
+		t.Errorf("wrong order: got %q, want %q", got, want)
+	}
+	if q.Len() != 0 {
+		t.Errorf("wrong number of remaining commands: got %d, want 0", q.Len())
+	}
+}
+
+func TestPriorityQueueAging(t *testing.T) {
+	const aging = time.Second
+	now := time.Now()
+	q := NewPriorityQueue(2, aging)
+	q.now = func() time.Time { return now }
+
+	q.Add(WithPriority(0, "low1"))
+	now = now.Add(time.Millisecond)
+	q.Add(WithPriority(0, "low2"))
+	for _, cmd := range []consensus.Command{"high1", "high2", "high3", "high4"} {
+		q.Add(WithPriority(1, cmd))
+	}
+
+	// before the aging threshold, the low priority commands wait.
+	if got, want := get(t, q, 1), []consensus.Command{"high1"}; !equalCommands(got, want) {
+		t.Errorf("wrong command before the aging threshold: got %q, want %q", got, want)
+	}
+
+	// low1 has reached the aging threshold, and is served before the remaining high priority commands.
+	now = now.Add(aging - time.Millisecond)
+	if got, want := get(t, q, 2), []consensus.Command{"low1", "high2"}; !equalCommands(got, want) {
+		t.Errorf("wrong commands after low1 aged: got %q, want %q", got, want)
+	}
+
+	// low2 has also reached the aging threshold, but the high priority commands have waited as long, so they are served first.
+	now = now.Add(time.Millisecond)
+	if got, want := get(t, q, 3), []consensus.Command{"high3", "high4", "low2"}; !equalCommands(got, want) {
+		t.Errorf("wrong commands after low2 aged: got %q, want %q", got, want)
+	}
+}
+
+func TestPriorityQueueWait(t *testing.T) {
+	q := NewPriorityQueue(2, 0)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, ok := q.Get(ctx); ok {
+		t.Error("a command was returned from an empty queue")
+	}
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		q.Add(WithPriority(1, "foo"))
+	}()
+	ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if cmd, ok := q.Get(ctx); !ok || cmd != "foo" {
+		t.Errorf("wrong command: got %q, %v, want %q", cmd, ok, "foo")
+	}
+}
+
+func equalCommands(a, b []consensus.Command) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
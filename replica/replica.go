@@ -7,6 +7,7 @@ import (
 	"crypto/x509"
 	"net"
 	"strconv"
+	"time"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/relab/gorums"
@@ -17,6 +18,7 @@ import (
 	"github.com/relab/hotstuff/internal/logging"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/protobuf/proto"
 )
 
 // cmdID is a unique identifier for a command
@@ -39,6 +41,30 @@ type Config struct {
 	RootCAs *x509.CertPool
 	// The number of client commands that should be batched together in a block.
 	BatchSize uint32
+	// The first view in which client commands are accepted. Before this view, only empty blocks are proposed.
+	WarmupView consensus.View
+	// Controls whether client commands are proposed in the order of their timestamps instead of the order of arrival.
+	OrderByTimestamp bool
+	// The number of priority levels that client commands are ordered by. If it is set, the commands with the highest
+	// priority are proposed first, and commands with the same priority are proposed in the order of arrival.
+	// It takes precedence over OrderByTimestamp.
+	PriorityLevels uint32
+	// The time after which a client command is proposed before commands with a higher priority.
+	// If it is 0, commands with a low priority may starve.
+	PriorityAging time.Duration
+	// Controls whether batches are encoded in the canonical encoding, which lists the commands in insertion order,
+	// each prefixed by its length. All replicas must use the same encoding.
+	CanonicalBatches bool
+	// The number of blocks that must be committed on top of the block containing a command
+	// before the client is told that the command was executed.
+	ConfirmationDepth uint32
+	// Executes the data of each client command once it is committed.
+	// If it implements consensus.ExecutorWithResult, the results are sent to the clients.
+	// If it implements consensus.CommitHook, it is called with each committed block before the block is executed.
+	// If it implements AsyncExecutor, the replica waits for the commands in each block to be executed.
+	// Use a ParallelExecutor to execute commands that do not conflict concurrently.
+	// May be nil.
+	Executor consensus.Executor
 	// Options for the client server.
 	ClientServerOptions []gorums.ServerOption
 	// Options for the replica server.
@@ -112,11 +138,20 @@ func New(conf Config, builder consensus.Builder) (replica *Replica) {
 		srv.clientSrv.cmdCache, // acceptor and command queue
 		logging.New("hs"+strconv.Itoa(int(conf.ID))),
 	)
+	if hook, ok := conf.Executor.(consensus.CommitHook); ok {
+		// the executor must not be registered directly, as it would replace the client server as the executor.
+		builder.Register(commitHook{hook})
+	}
 	srv.hs = builder.Build()
 
 	return srv
 }
 
+// commitHook hides all methods of the wrapped CommitHook except PreExecCommit.
+type commitHook struct {
+	consensus.CommitHook
+}
+
 // StartServers starts the client and replica servers.
 func (srv *Replica) StartServers(replicaListen, clientListen net.Listener) {
 	srv.hsSrv.StartOnListener(replicaListen)
@@ -142,6 +177,7 @@ func (srv *Replica) Start() {
 func (srv *Replica) Stop() {
 	srv.cancel()
 	<-srv.done
+	srv.hs.FlushRejections()
 	srv.Close()
 }
 
@@ -158,6 +194,34 @@ func (srv *Replica) Close() {
 	srv.hsSrv.Stop()
 }
 
+// RegisterMessageHandler registers a handler for custom messages with the same type as msg.
+// It must be called before the servers are started.
+func (srv *Replica) RegisterMessageHandler(msg proto.Message, handler backend.MessageHandler) {
+	srv.hsSrv.RegisterMessageHandler(msg, handler)
+}
+
+// SendMessage sends a custom message to the replica with the given ID.
+func (srv *Replica) SendMessage(id hotstuff.ID, msg proto.Message) error {
+	return srv.cfg.SendMessage(id, msg)
+}
+
+// Broadcast sends a custom message to all other replicas.
+func (srv *Replica) Broadcast(msg proto.Message) error {
+	return srv.cfg.Broadcast(msg)
+}
+
+// Reconfigure authorizes the change to the set of replicas, and proposes it the next time that the replica is the leader.
+// Replicas only vote for reconfigurations that they have authorized themselves,
+// so the change must be requested at a quorum of the replicas before it can be committed.
+func (srv *Replica) Reconfigure(change consensus.Reconfiguration) {
+	srv.clientSrv.cmdCache.addReconfiguration(change)
+}
+
+// Genesis returns the genesis block that the replica's blockchain starts from.
+func (srv *Replica) Genesis() *consensus.Block {
+	return srv.hs.Genesis()
+}
+
 // GetHash returns the hash of all executed commands.
 func (srv *Replica) GetHash() (b []byte) {
 	return srv.clientSrv.hash.Sum(b)
@@ -0,0 +1,308 @@
+package replica
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/relab/gorums"
+	"github.com/relab/hotstuff"
+	"github.com/relab/hotstuff/blockchain"
+	"github.com/relab/hotstuff/config"
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/consensus/chainedhotstuff"
+	"github.com/relab/hotstuff/crypto"
+	"github.com/relab/hotstuff/internal/proto/clientpb"
+	"github.com/relab/hotstuff/internal/testutil"
+	"github.com/relab/hotstuff/leaderrotation"
+	"github.com/relab/hotstuff/synchronizer"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// startReplicas starts n connected replicas that use the trusted signer.
+// The ID and private key of each replica is set in a copy of conf.
+// It returns the replicas and a configuration containing the addresses of their client servers.
+func startReplicas(t *testing.T, n int, conf Config) ([]*Replica, *config.ReplicaConfig) {
+	t.Helper()
+
+	replicaListeners := make([]net.Listener, n)
+	clientListeners := make([]net.Listener, n)
+	keys := make([]consensus.PrivateKey, n)
+	replicaCfg := config.NewConfig(0, nil, nil, 0)
+	clientCfg := config.NewConfig(0, nil, nil, 0)
+	for i := 0; i < n; i++ {
+		id := hotstuff.ID(i + 1)
+		replicaListeners[i] = testutil.CreateTCPListener(t)
+		clientListeners[i] = testutil.CreateTCPListener(t)
+		keys[i] = testutil.GenerateECDSAKey(t)
+		replicaCfg.Replicas[id] = &config.ReplicaInfo{
+			ID:      id,
+			Address: replicaListeners[i].Addr().String(),
+			PubKey:  keys[i].Public(),
+		}
+		clientCfg.Replicas[id] = &config.ReplicaInfo{
+			ID:      id,
+			Address: clientListeners[i].Addr().String(),
+			PubKey:  keys[i].Public(),
+		}
+	}
+
+	replicas := make([]*Replica, n)
+	for i := range replicas {
+		id := hotstuff.ID(i + 1)
+		builder := consensus.NewBuilder(id, keys[i])
+		builder.Register(
+			consensus.New(chainedhotstuff.New()),
+			crypto.New(testutil.NewTrustedSigner(t)),
+			leaderrotation.NewRoundRobin(),
+			synchronizer.New(testutil.FixedTimeout(500)),
+			blockchain.New(),
+		)
+		replicaConf := conf
+		replicaConf.ID = id
+		replicaConf.PrivateKey = keys[i]
+		replicaConf.ManagerOptions = []gorums.ManagerOption{gorums.WithDialTimeout(time.Second)}
+		replicas[i] = New(replicaConf, builder)
+		replicas[i].StartServers(replicaListeners[i], clientListeners[i])
+	}
+
+	for i, replica := range replicas {
+		cfg := *replicaCfg
+		cfg.ID = hotstuff.ID(i + 1)
+		cfg.PrivateKey = keys[i]
+		if err := replica.Connect(&cfg); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, replica := range replicas {
+		replica.Start()
+	}
+	t.Cleanup(func() {
+		for _, replica := range replicas {
+			replica.Stop()
+		}
+	})
+	return replicas, clientCfg
+}
+
+// TestTrustedSignerManyReplicas checks that consensus makes progress in a large configuration of replicas
+// that run on a single host and use the trusted signer instead of real signatures.
+func TestTrustedSignerManyReplicas(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping large configuration in short mode")
+	}
+	const (
+		n         = 31
+		minCommit = consensus.View(10)
+	)
+
+	replicas, _ := startReplicas(t, n, Config{
+		BatchSize: 1,
+		// propose empty blocks, such that no clients are needed.
+		WarmupView: consensus.View(1 << 30),
+	})
+
+	deadline := time.Now().Add(20 * time.Second)
+	for time.Now().Before(deadline) {
+		if replicas[0].hs.Consensus().CommittedBlock().View() >= minCommit {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Errorf("no block after view %d was committed: last committed block: %v", minCommit, replicas[0].hs.Consensus().CommittedBlock())
+}
+
+// upperExecutor returns the data of each command in upper case.
+type upperExecutor struct{}
+
+func (upperExecutor) Exec(_ consensus.Command) {}
+
+func (upperExecutor) ExecWithResult(cmd consensus.Command) []byte {
+	return []byte(strings.ToUpper(string(cmd)))
+}
+
+// resultQSpec returns the first result once f+1 replicas have replied.
+type resultQSpec struct {
+	faulty int
+}
+
+func (q resultQSpec) ExecCommandQF(_ *clientpb.Command, replies map[uint32]*clientpb.Result) (*clientpb.Result, bool) {
+	if len(replies) < q.faulty+1 {
+		return nil, false
+	}
+	for _, reply := range replies {
+		return reply, true
+	}
+	return nil, false
+}
+
+func (q resultQSpec) AwaitProposalQF(_ *clientpb.Command, replies map[uint32]*clientpb.Accepted) (*clientpb.Accepted, bool) {
+	if len(replies) < q.faulty+1 {
+		return nil, false
+	}
+	for _, reply := range replies {
+		return reply, true
+	}
+	return nil, false
+}
+
+// connectClient returns a client configuration for the client servers of the replicas.
+func connectClient(t *testing.T, clientCfg *config.ReplicaConfig) *clientpb.Configuration {
+	t.Helper()
+	mgr := clientpb.NewManager(
+		gorums.WithDialTimeout(time.Second),
+		gorums.WithGrpcDialOptions(grpc.WithInsecure(), grpc.WithBlock()),
+	)
+	t.Cleanup(mgr.Close)
+	nodes := make(map[string]uint32, len(clientCfg.Replicas))
+	for id, r := range clientCfg.Replicas {
+		nodes[r.Address] = uint32(id)
+	}
+	cfg, err := mgr.NewConfiguration(resultQSpec{faulty: hotstuff.NumFaulty(len(clientCfg.Replicas))}, gorums.WithNodeMap(nodes))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cfg
+}
+
+// TestExecutionResult checks that the results of executing the commands are delivered to the client that sent them.
+func TestExecutionResult(t *testing.T) {
+	const n = 4
+	_, clientCfg := startReplicas(t, n, Config{
+		BatchSize: 1,
+		Executor:  upperExecutor{},
+	})
+	cfg := connectClient(t, clientCfg)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	inputs := []string{"foo", "bar", "baz"}
+	cmds := make([]*clientpb.Command, len(inputs))
+	promises := make([]*clientpb.AsyncResult, len(inputs))
+	for i, data := range inputs {
+		cmds[i] = &clientpb.Command{ClientID: 1, SequenceNumber: uint64(i + 1), Data: []byte(data), Timestamp: timestamppb.Now()}
+		promises[i] = cfg.ExecCommand(ctx, cmds[i])
+	}
+	// new blocks are only proposed when there are commands, and a block is only committed once enough blocks
+	// have been proposed on top of it, so we send some empty commands after the ones we are waiting for.
+	for i := len(inputs); i < len(inputs)+10; i++ {
+		cfg.ExecCommand(ctx, &clientpb.Command{ClientID: 1, SequenceNumber: uint64(i + 1), Timestamp: timestamppb.Now()})
+	}
+
+	for i, promise := range promises {
+		result, err := promise.Get()
+		if err != nil {
+			t.Fatalf("command %q failed: %v", inputs[i], err)
+		}
+		if !bytes.Equal(result.GetCommandHash(), clientpb.CommandHash(cmds[i])) {
+			t.Errorf("command %q: result has the wrong command hash", inputs[i])
+		}
+		if got, want := string(result.GetData()), strings.ToUpper(inputs[i]); got != want {
+			t.Errorf("command %q: wrong result: got: %q, want: %q", inputs[i], got, want)
+		}
+	}
+}
+
+// chanExecutor sends the data of each executed command on the channel.
+type chanExecutor chan<- string
+
+func (e chanExecutor) Exec(cmd consensus.Command) {
+	e <- string(cmd)
+}
+
+// TestAcceptedBeforeExecuted checks that a client is told when its command is accepted in a proposed block,
+// before it is told that the command was executed.
+func TestAcceptedBeforeExecuted(t *testing.T) {
+	const (
+		n       = 4
+		numCmds = 12
+	)
+	// each replica executes each command at most once, so the executor never blocks.
+	executed := make(chan string, n*numCmds)
+	_, clientCfg := startReplicas(t, n, Config{BatchSize: 1, Executor: chanExecutor(executed)})
+	cfg := connectClient(t, clientCfg)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	// the requests are delivered to each replica in the order they are sent,
+	// so the replicas await the acceptance of the command before it is added to the queue.
+	cmd := &clientpb.Command{ClientID: 1, SequenceNumber: 1, Data: []byte("foo"), Timestamp: timestamppb.Now()}
+	accepted := cfg.AwaitProposal(ctx, cmd)
+	result := cfg.ExecCommand(ctx, cmd)
+	// the replicas propose a command once another command is waiting behind it.
+	cfg.ExecCommand(ctx, &clientpb.Command{ClientID: 1, SequenceNumber: 2, Timestamp: timestamppb.Now()})
+
+	ack, err := accepted.Get()
+	if err != nil {
+		t.Fatalf("command was not accepted: %v", err)
+	}
+	if !bytes.Equal(ack.GetCommandHash(), clientpb.CommandHash(cmd)) {
+		t.Error("acknowledgment has the wrong command hash")
+	}
+	// no blocks have been proposed on top of the block with the command, so it cannot have been committed.
+	select {
+	case <-executed:
+		t.Error("command was executed before more blocks were proposed")
+	default:
+	}
+
+	for i := 3; i <= numCmds; i++ {
+		cfg.ExecCommand(ctx, &clientpb.Command{ClientID: 1, SequenceNumber: uint64(i), Timestamp: timestamppb.Now()})
+	}
+	for data := ""; data != "foo"; {
+		select {
+		case data = <-executed:
+		case <-ctx.Done():
+			t.Fatal("command was not executed")
+		}
+	}
+	res, err := result.Get()
+	if err != nil {
+		t.Fatalf("client was not told that the command was executed: %v", err)
+	}
+	if !bytes.Equal(res.GetCommandHash(), clientpb.CommandHash(cmd)) {
+		t.Error("result has the wrong command hash")
+	}
+}
+
+// TestReconfigure checks that a replica is removed from the configuration once a quorum of the replicas
+// have authorized its removal.
+func TestReconfigure(t *testing.T) {
+	const n = 4
+	replicas, clientCfg := startReplicas(t, n, Config{BatchSize: 1})
+	cfg := connectClient(t, clientCfg)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	// the replica that is removed does not authorize its own removal.
+	change := consensus.Reconfiguration{Remove: true, ID: n}
+	for _, replica := range replicas[:n-1] {
+		replica.Reconfigure(change)
+	}
+
+	// new blocks are only proposed when there are commands, so we keep sending commands until the change takes effect.
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+	for seqNo := uint64(1); ; seqNo++ {
+		if _, ok := replicas[0].cfg.Replica(n); !ok {
+			break
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			t.Fatal("the replica was not removed")
+		}
+		cfg.ExecCommand(ctx, &clientpb.Command{ClientID: 1, SequenceNumber: seqNo, Timestamp: timestamppb.Now()})
+	}
+	if got, want := replicas[0].cfg.QuorumSize(), hotstuff.QuorumSize(n-1); got != want {
+		t.Errorf("wrong quorum size after the replica was removed: got: %d, want: %d", got, want)
+	}
+}
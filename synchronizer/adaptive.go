@@ -0,0 +1,96 @@
+package synchronizer
+
+import (
+	"math"
+	"time"
+
+	"github.com/relab/hotstuff/internal/stats"
+)
+
+// NewAdaptive returns a ViewDuration that adapts the view duration to the durations of recent successful views.
+// The duration of the next view is mean + k*stddev of the recent view durations.
+// The statistics are computed over the last complete period of window successful views,
+// or over all successful views until the first period is complete.
+// Until two views have succeeded, the duration is the initial duration.
+// Each time a view times out, the duration is doubled until a view succeeds.
+// The duration is capped at max. If max is 0, there is no cap, other than the largest time.Duration.
+func NewAdaptive(window uint64, initial time.Duration, k float64, max time.Duration) ViewDuration {
+	return &adaptive{
+		window:  window,
+		initial: initial,
+		k:       k,
+		max:     max,
+		now:     time.Now,
+	}
+}
+
+// adaptive is a ViewDuration that sets the view duration based on the statistics of previous view durations.
+type adaptive struct {
+	window   uint64        // the number of measurements in each period
+	initial  time.Duration // the view duration before enough views have been measured
+	k        float64       // the number of standard deviations to add to the mean
+	max      time.Duration // upper bound on the view duration, or 0 if there is no bound
+	timeouts uint          // the number of consecutive view timeouts
+	now      func() time.Time
+
+	startTime time.Time
+	cur       stats.Welford // the measurements of the current period
+	prev      stats.Welford // the measurements of the last complete period
+}
+
+// ViewStarted records the start time of a view.
+func (a *adaptive) ViewStarted() {
+	a.startTime = a.now()
+}
+
+// ViewSucceeded records the duration of the view, and resets the timeout backoff.
+func (a *adaptive) ViewSucceeded() {
+	a.timeouts = 0
+	if a.startTime.IsZero() {
+		return
+	}
+	a.record(a.now().Sub(a.startTime))
+}
+
+// record adds the duration of a successful view to the statistics.
+// The measurements are discarded one period at a time, such that the statistics follow changes in the network.
+func (a *adaptive) record(d time.Duration) {
+	a.cur.Update(float64(d))
+	if a.cur.Count() >= a.window {
+		a.prev = a.cur
+		a.cur.Reset()
+	}
+}
+
+// ViewTimeout doubles the duration of the next view, unless the duration has reached the cap.
+func (a *adaptive) ViewTimeout() {
+	if a.Duration() < a.limit() {
+		a.timeouts++
+	}
+}
+
+// limit returns the upper bound on the view duration.
+func (a *adaptive) limit() time.Duration {
+	if a.max > 0 {
+		return a.max
+	}
+	return math.MaxInt64
+}
+
+// Duration returns the duration that the next view should last.
+func (a *adaptive) Duration() time.Duration {
+	w := &a.prev
+	if w.Count() == 0 {
+		w = &a.cur
+	}
+	duration := float64(a.initial)
+	if mean, variance, count := w.Get(); count > 1 {
+		duration = mean + a.k*math.Sqrt(variance)
+	}
+	duration *= math.Pow(2, float64(a.timeouts))
+	// the duration is clamped before it is converted, as the conversion of a value that is too large overflows.
+	if limit := a.limit(); duration >= float64(limit) {
+		return limit
+	}
+	return time.Duration(duration)
+}
@@ -0,0 +1,76 @@
+package synchronizer
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// feed passes the view durations to the ViewDuration using a synthetic clock.
+func feed(a *adaptive, durations ...time.Duration) {
+	now := time.Unix(0, 0)
+	a.now = func() time.Time { return now }
+	for _, d := range durations {
+		a.ViewStarted()
+		now = now.Add(d)
+		a.ViewSucceeded()
+	}
+}
+
+func TestAdaptive(t *testing.T) {
+	const ms = time.Millisecond
+	a := NewAdaptive(4, 500*ms, 2, time.Second).(*adaptive)
+
+	if got := a.Duration(); got != 500*ms {
+		t.Errorf("wrong initial duration: got %v, want %v", got, 500*ms)
+	}
+
+	// mean 20ms, sample standard deviation 10ms
+	feed(a, 10*ms, 20*ms, 30*ms)
+	if got, want := a.Duration(), 40*ms; got != want {
+		t.Errorf("wrong duration after three views: got %v, want %v", got, want)
+	}
+
+	// complete the first period, followed by a period with mean 100ms and sample standard deviation 0
+	feed(a, 100*ms)
+	feed(a, 100*ms, 100*ms, 100*ms, 100*ms)
+	if got, want := a.Duration(), 100*ms; got != want {
+		t.Errorf("wrong duration after a complete period: got %v, want %v", got, want)
+	}
+
+	a.ViewTimeout()
+	a.ViewTimeout()
+	if got, want := a.Duration(), 400*ms; got != want {
+		t.Errorf("wrong duration after two timeouts: got %v, want %v", got, want)
+	}
+	a.ViewTimeout()
+	a.ViewTimeout()
+	if got, want := a.Duration(), time.Second; got != want {
+		t.Errorf("wrong duration after four timeouts: got %v, want %v", got, want)
+	}
+
+	feed(a, 100*ms)
+	if got, want := a.Duration(), 100*ms; got != want {
+		t.Errorf("wrong duration after a successful view: got %v, want %v", got, want)
+	}
+}
+
+// TestAdaptiveNoCap checks that the duration saturates at the largest time.Duration without a cap,
+// and that the timeouts are no longer counted once the duration is saturated.
+func TestAdaptiveNoCap(t *testing.T) {
+	a := NewAdaptive(4, 100*time.Millisecond, 2, 0).(*adaptive)
+	for i := 0; i < 1000; i++ {
+		a.ViewTimeout()
+	}
+	if got, want := a.Duration(), time.Duration(math.MaxInt64); got != want {
+		t.Errorf("wrong duration after 1000 timeouts: got %v, want %v", got, want)
+	}
+	if a.timeouts >= 1000 {
+		t.Errorf("the timeouts were counted after the duration was saturated: %d", a.timeouts)
+	}
+
+	feed(a, 100*time.Millisecond)
+	if got, want := a.Duration(), 100*time.Millisecond; got != want {
+		t.Errorf("wrong duration after a successful view: got %v, want %v", got, want)
+	}
+}
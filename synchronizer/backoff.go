@@ -0,0 +1,67 @@
+package synchronizer
+
+import (
+	"math"
+	"time"
+
+	"github.com/relab/hotstuff/consensus"
+)
+
+// NewSynchronizer returns a synchronizer whose view timeout starts at base and doubles each time a view times out,
+// up to maxMultiplier times the base duration. The timeout returns to base once a view succeeds with a QC.
+func NewSynchronizer(base time.Duration, maxMultiplier uint) consensus.Synchronizer {
+	return New(NewBackoff(base, 2, maxMultiplier))
+}
+
+// NewBackoff returns a ViewDuration that implements exponential backoff.
+// The duration starts at base, and is multiplied by multiplier each time a view times out.
+// The duration is capped at maxMultiplier times the base duration. If maxMultiplier is 0, there is no cap,
+// other than the largest time.Duration.
+// The duration is reset to base when a view succeeds.
+func NewBackoff(base time.Duration, multiplier float64, maxMultiplier uint) ViewDuration {
+	var max time.Duration
+	if maxMultiplier > 0 {
+		max = base * time.Duration(maxMultiplier)
+	}
+	return &backoff{
+		base:    base,
+		current: base,
+		max:     max,
+		mul:     multiplier,
+	}
+}
+
+// backoff is a ViewDuration that increases the view duration exponentially after consecutive timeouts.
+type backoff struct {
+	base    time.Duration // the duration of a view after a successful view
+	current time.Duration // the duration of the next view
+	max     time.Duration // upper bound on the view duration, or 0 if there is no bound
+	mul     float64       // on failed views, multiply the current duration by this number (should be > 1)
+}
+
+// Duration returns the duration that the next view should last.
+func (b *backoff) Duration() time.Duration {
+	return b.current
+}
+
+// ViewStarted does nothing, as the backoff does not measure the views.
+func (b *backoff) ViewStarted() {}
+
+// ViewSucceeded resets the duration to the base duration.
+func (b *backoff) ViewSucceeded() {
+	b.current = b.base
+}
+
+// ViewTimeout multiplies the duration by the multiplier, up to the maximum duration.
+// Without a maximum duration, the duration saturates at the largest time.Duration instead of overflowing.
+func (b *backoff) ViewTimeout() {
+	next := float64(b.current) * b.mul
+	if next >= math.MaxInt64 {
+		b.current = math.MaxInt64
+	} else {
+		b.current = time.Duration(next)
+	}
+	if b.max > 0 && b.current > b.max {
+		b.current = b.max
+	}
+}
@@ -0,0 +1,56 @@
+package synchronizer
+
+import "time"
+
+// Clock provides the current time and timers to the synchronizer.
+// Tests can replace the real clock to control when views time out, without waiting for the timeouts.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// NewTimer returns a timer that sends the current time on its channel after the duration d.
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer is a timer created by a Clock. It behaves like time.Timer.
+type Timer interface {
+	// C returns the channel on which the time is delivered when the timer expires.
+	C() <-chan time.Time
+	// Stop prevents the timer from firing. It returns false if the timer has already expired or been stopped.
+	Stop() bool
+	// Reset changes the timer to expire after the duration d. It returns true if the timer had been active.
+	Reset(d time.Duration) bool
+}
+
+// WithClock makes the synchronizer use the given clock instead of the real time.
+func WithClock(clock Clock) Option {
+	return func(s *Synchronizer) {
+		s.clock = clock
+	}
+}
+
+// realClock is a Clock that uses the time package.
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) NewTimer(d time.Duration) Timer {
+	return realTimer{time.NewTimer(d)}
+}
+
+type realTimer struct {
+	timer *time.Timer
+}
+
+func (t realTimer) C() <-chan time.Time {
+	return t.timer.C
+}
+
+func (t realTimer) Stop() bool {
+	return t.timer.Stop()
+}
+
+func (t realTimer) Reset(d time.Duration) bool {
+	return t.timer.Reset(d)
+}
@@ -0,0 +1,150 @@
+package synchronizer_test
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/relab/hotstuff/consensus"
+	"github.com/relab/hotstuff/internal/mocks"
+	"github.com/relab/hotstuff/internal/testutil"
+	. "github.com/relab/hotstuff/synchronizer"
+)
+
+// mockClock is a Clock whose time only passes when Advance is called.
+type mockClock struct {
+	mut    sync.Mutex
+	cond   *sync.Cond // signalled when a timer is started
+	now    time.Time
+	timers []*mockTimer
+}
+
+func newMockClock() *mockClock {
+	c := &mockClock{now: time.Unix(0, 0)}
+	c.cond = sync.NewCond(&c.mut)
+	return c
+}
+
+func (c *mockClock) Now() time.Time {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	return c.now
+}
+
+func (c *mockClock) NewTimer(d time.Duration) Timer {
+	t := &mockTimer{clock: c, c: make(chan time.Time, 1)}
+	c.mut.Lock()
+	c.timers = append(c.timers, t)
+	c.mut.Unlock()
+	t.Reset(d)
+	return t
+}
+
+// Advance moves the time forward by d, and fires the timers that expire.
+func (c *mockClock) Advance(d time.Duration) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	c.now = c.now.Add(d)
+	for _, t := range c.timers {
+		if t.active && !t.deadline.After(c.now) {
+			t.active = false
+			select {
+			case t.c <- c.now:
+			default:
+			}
+		}
+	}
+}
+
+// waitActive blocks until a timer is running, such that the time is not advanced before the timer is started.
+func (c *mockClock) waitActive() {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	for {
+		for _, t := range c.timers {
+			if t.active {
+				return
+			}
+		}
+		c.cond.Wait()
+	}
+}
+
+type mockTimer struct {
+	clock    *mockClock
+	c        chan time.Time
+	deadline time.Time
+	active   bool
+}
+
+func (t *mockTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *mockTimer) Stop() bool {
+	t.clock.mut.Lock()
+	defer t.clock.mut.Unlock()
+	wasActive := t.active
+	t.active = false
+	return wasActive
+}
+
+func (t *mockTimer) Reset(d time.Duration) bool {
+	t.clock.mut.Lock()
+	defer t.clock.mut.Unlock()
+	wasActive := t.active
+	t.active = true
+	t.deadline = t.clock.now.Add(d)
+	t.clock.cond.Broadcast()
+	return wasActive
+}
+
+// TestMockClockTimeouts checks that the view times out when the clock passes the view duration,
+// and that the timeout is sent again if the view times out a second time.
+func TestMockClockTimeouts(t *testing.T) {
+	const duration = 500 * time.Millisecond
+	ctrl := gomock.NewController(t)
+	builder := testutil.TestModules(t, ctrl, 2, testutil.GenerateECDSAKey(t))
+	hs := mocks.NewMockConsensus(ctrl)
+	clock := newMockClock()
+	s := New(testutil.FixedTimeout(500), WithClock(clock))
+	builder.Register(hs, s)
+	mods := builder.Build()
+	cfg := mods.Configuration().(*mocks.MockConfiguration)
+	leader := testutil.CreateMockReplica(t, ctrl, 1, testutil.GenerateECDSAKey(t))
+	testutil.ConfigAddReplica(t, cfg, leader)
+
+	timeouts := make(chan consensus.TimeoutMsg, 2)
+	hs.EXPECT().StopVoting(consensus.View(1)).AnyTimes()
+	cfg.EXPECT().Timeout(gomock.AssignableToTypeOf(consensus.TimeoutMsg{})).
+		Do(func(msg consensus.TimeoutMsg) { timeouts <- msg }).
+		Times(2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.Start(ctx)
+	go mods.Run(ctx)
+
+	clock.Advance(duration - time.Millisecond)
+	select {
+	case <-timeouts:
+		t.Fatal("view timed out before the view duration had passed")
+	default:
+	}
+	clock.Advance(time.Millisecond)
+	first := <-timeouts
+	if first.View != 1 {
+		t.Errorf("wrong view. got: %v, want: %v", first.View, 1)
+	}
+
+	// the timer is restarted after the timeout has been handled.
+	clock.waitActive()
+	clock.Advance(duration)
+	second := <-timeouts
+	if second.View != 1 || !bytes.Equal(second.ViewSignature.ToBytes(), first.ViewSignature.ToBytes()) {
+		t.Errorf("the second timeout is not a copy of the first: got %v, want %v", second, first)
+	}
+}
@@ -1,6 +1,7 @@
 package synchronizer
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"time"
@@ -14,6 +15,7 @@ type Synchronizer struct {
 	mods *consensus.Modules
 
 	currentView consensus.View
+	leader      hotstuff.ID // the leader of the current view, as chosen when the view started
 	highTC      consensus.TimeoutCert
 	highQC      consensus.QuorumCert
 	leafBlock   *consensus.Block
@@ -24,7 +26,12 @@ type Synchronizer struct {
 	lastTimeout *consensus.TimeoutMsg
 
 	duration ViewDuration
-	timer    *time.Timer
+	clock    Clock
+	timer    Timer
+
+	pacing    time.Duration  // the minimum duration of a view that succeeds with a QC, or 0 to advance immediately
+	viewStart time.Time      // the time that the current view started
+	paced     consensus.View // the last view for which advancing was delayed by the pacing
 
 	viewCtx   context.Context // a context that is cancelled at the end of the current view
 	cancelCtx context.CancelFunc
@@ -55,11 +62,15 @@ func (s *Synchronizer) InitConsensusModule(mods *consensus.Modules, opts *consen
 		s.OnRemoteTimeout(timeoutMsg)
 	})
 
+	s.mods.EventLoop().RegisterHandler(consensus.StepDownEvent{}, func(event interface{}) {
+		s.onStepDown(event.(consensus.StepDownEvent))
+	})
+
+	// the genesis QC is always valid, so it does not need to be created by the crypto module,
+	// which may not have been initialized yet.
+	s.leafBlock = mods.Genesis()
+	s.highQC = consensus.NewQuorumCert(nil, 0, mods.Genesis().Hash())
 	var err error
-	s.highQC, err = s.mods.Crypto().CreateQuorumCert(consensus.GetGenesis(), []consensus.PartialCert{})
-	if err != nil {
-		panic(fmt.Errorf("unable to create empty quorum cert for genesis block: %v", err))
-	}
 	s.highTC, err = s.mods.Crypto().CreateTimeoutCert(consensus.View(0), []consensus.TimeoutMsg{})
 	if err != nil {
 		panic(fmt.Errorf("unable to create empty timeout cert for view 0: %v", err))
@@ -67,10 +78,25 @@ func (s *Synchronizer) InitConsensusModule(mods *consensus.Modules, opts *consen
 
 }
 
+// Option configures the Synchronizer.
+type Option func(*Synchronizer)
+
+// WithPacing disables optimistic responsiveness: a QC for the current view only advances to the next view
+// once at least delta has passed since the view started, instead of as soon as the QC is received.
+// Views that do not produce a QC still end at the view timeout, and QCs and TCs for later views,
+// which the replica needs to catch up, still advance the view immediately.
+//
+// By default, the synchronizer is optimistically responsive, such that the views progress at the speed of the network.
+func WithPacing(delta time.Duration) Option {
+	return func(s *Synchronizer) {
+		s.pacing = delta
+	}
+}
+
 // New creates a new Synchronizer.
-func New(viewDuration ViewDuration) consensus.Synchronizer {
+func New(viewDuration ViewDuration, opts ...Option) consensus.Synchronizer {
 	ctx, cancel := context.WithCancel(context.Background())
-	return &Synchronizer{
+	s := &Synchronizer{
 		leafBlock:   consensus.GetGenesis(),
 		currentView: 1,
 
@@ -80,27 +106,42 @@ func New(viewDuration ViewDuration) consensus.Synchronizer {
 		nextLeader: 0,
 
 		duration: viewDuration,
-		timer:    time.AfterFunc(0, func() {}), // dummy timer that will be replaced after start() is called
+		clock:    realClock{},
 
 		timeouts: make(map[consensus.View]map[hotstuff.ID]consensus.TimeoutMsg),
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	// dummy timer that will be replaced after Start() is called
+	s.timer = s.clock.NewTimer(time.Hour)
+	s.timer.Stop()
+	return s
 }
 
 // Start starts the synchronizer with the given context.
 func (s *Synchronizer) Start(ctx context.Context) {
-	s.timer = time.AfterFunc(s.duration.Duration(), func() {
-		// The event loop will execute onLocalTimeout for us.
-		s.cancelCtx()
-		s.mods.EventLoop().AddEvent(s.onLocalTimeout)
-	})
+	s.viewStart = s.clock.Now()
+	timer := s.clock.NewTimer(s.duration.Duration())
+	s.timer = timer
 
 	go func() {
-		<-ctx.Done()
-		s.timer.Stop()
+		for {
+			select {
+			case <-timer.C():
+				// The event loop will execute onLocalTimeout for us.
+				s.cancelCtx()
+				s.mods.EventLoop().AddEvent(s.onLocalTimeout)
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			}
+		}
 	}()
 
 	// start the initial proposal
-	if s.currentView == 1 && s.mods.LeaderRotation().GetLeader(s.currentView) == s.mods.ID() {
+	s.leader = s.mods.LeaderRotation().GetLeader(s.currentView)
+	if s.currentView == 1 && s.leader == s.mods.ID() {
 		s.mods.Consensus().Propose(s.SyncInfo())
 	}
 }
@@ -110,6 +151,15 @@ func (s *Synchronizer) HighQC() consensus.QuorumCert {
 	return s.highQC
 }
 
+// TimedOutView returns the most recent view that was abandoned because of a local timeout.
+// ok is false if no local timeout has happened.
+func (s *Synchronizer) TimedOutView() (view consensus.View, ok bool) {
+	if s.lastTimeout == nil {
+		return 0, false
+	}
+	return s.lastTimeout.View, true
+}
+
 // LeafBlock returns the current leaf block.
 func (s *Synchronizer) LeafBlock() *consensus.Block {
 	return s.leafBlock
@@ -170,25 +220,26 @@ func (s *Synchronizer) onLocalTimeout() {
 	view := s.currentView
 	s.mods.Logger().Debugf("OnLocalTimeout: %v", view)
 
-	sig, err := s.mods.Crypto().Sign(view.ToHash())
-	if err != nil {
-		s.mods.Logger().Warnf("Failed to sign view: %v", err)
+	if s.mods.Options().IsWitness() {
+		// witnesses do not take part in forming timeout certificates, so they wait for the other replicas.
 		return
 	}
+
 	timeoutMsg := consensus.TimeoutMsg{
-		ID:            s.mods.ID(),
-		View:          view,
-		SyncInfo:      s.SyncInfo(),
-		ViewSignature: sig,
+		ID:       s.mods.ID(),
+		View:     view,
+		SyncInfo: s.SyncInfo(),
 	}
+	// the signature covers both the view and the highQC, such that the TC can prove which QCs the replicas had.
+	sig, err := s.mods.Crypto().Sign(timeoutMsg.Hash())
+	if err != nil {
+		s.mods.Logger().Warnf("Failed to sign timeout message: %v", err)
+		return
+	}
+	timeoutMsg.ViewSignature = sig
 
 	if s.mods.Options().ShouldUseAggQC() {
-		// generate a second signature that will become part of the aggregateQC
-		sig, err := s.mods.Crypto().Sign(timeoutMsg.Hash())
-		if err != nil {
-			s.mods.Logger().Warnf("Failed to sign timeout message: %v", err)
-			return
-		}
+		// the aggregateQC covers the same message as the TC, so the signature can be reused.
 		timeoutMsg.MsgSignature = sig
 	}
 	s.lastTimeout = &timeoutMsg
@@ -199,6 +250,19 @@ func (s *Synchronizer) onLocalTimeout() {
 	s.OnRemoteTimeout(timeoutMsg)
 }
 
+// onStepDown times out the view that the local replica stepped down from as the leader,
+// instead of waiting for the view timer to expire.
+func (s *Synchronizer) onStepDown(event consensus.StepDownEvent) {
+	if event.View != s.currentView {
+		// we have already moved on to another view
+		return
+	}
+	s.mods.Logger().Debugf("OnStepDown: %v", event.View)
+	s.timer.Stop()
+	s.cancelCtx()
+	s.onLocalTimeout()
+}
+
 // OnRemoteTimeout handles an incoming timeout from a remote replica.
 func (s *Synchronizer) OnRemoteTimeout(timeout consensus.TimeoutMsg) {
 	defer func() {
@@ -211,21 +275,40 @@ func (s *Synchronizer) OnRemoteTimeout(timeout consensus.TimeoutMsg) {
 	}()
 
 	verifier := s.mods.Crypto()
-	if !verifier.Verify(timeout.ViewSignature, timeout.View.ToHash()) {
+	if !verifier.Verify(timeout.ViewSignature, timeout.Hash()) {
+		s.mods.ReportRejection(consensus.MessageRejectedEvent{
+			Reason: consensus.RejectBadSignature,
+			Type:   "TimeoutMsg",
+			Sender: timeout.ID,
+		}, "OnRemoteTimeout: invalid signature from replica %d", timeout.ID)
+		return
+	}
+	// a timeout with an invalid QC would prevent the creation of a TC, as the TC must include the highest QC.
+	if qc, ok := timeout.SyncInfo.QC(); ok && (qc.View() >= timeout.View || !verifier.VerifyQuorumCert(qc)) {
+		s.mods.ReportRejection(consensus.MessageRejectedEvent{
+			Reason: consensus.RejectBadSignature,
+			Type:   "TimeoutMsg",
+			Sender: timeout.ID,
+		}, "OnRemoteTimeout: invalid QC from replica %d", timeout.ID)
 		return
 	}
 	s.mods.Logger().Debug("OnRemoteTimeout: ", timeout)
 
 	s.AdvanceView(timeout.SyncInfo)
 
-	timeouts, ok := s.timeouts[timeout.View]
-	if !ok {
-		timeouts = make(map[hotstuff.ID]consensus.TimeoutMsg)
-		s.timeouts[timeout.View] = timeouts
+	if replica, ok := s.mods.Configuration().Replica(timeout.ID); ok && consensus.IsWitness(replica) {
+		s.mods.ReportRejection(consensus.MessageRejectedEvent{
+			Reason: consensus.RejectWitness,
+			Type:   "TimeoutMsg",
+			Sender: timeout.ID,
+		}, "OnRemoteTimeout: ignoring timeout from witness %d", timeout.ID)
+		return
 	}
 
-	if _, ok := timeouts[timeout.ID]; !ok {
-		timeouts[timeout.ID] = timeout
+	timeouts, ok := s.bufferTimeout(timeout)
+	if !ok {
+		s.rejectTimeout(timeout)
+		return
 	}
 
 	if len(timeouts) < s.mods.Configuration().QuorumSize() {
@@ -261,6 +344,56 @@ func (s *Synchronizer) OnRemoteTimeout(timeout consensus.TimeoutMsg) {
 	s.AdvanceView(si)
 }
 
+// bufferTimeout adds the timeout message to the timeouts collected for its view, and returns those timeouts.
+// ok is false if the message was dropped because of the MaxTimeoutViews or MaxTimeoutsPerView bounds.
+func (s *Synchronizer) bufferTimeout(timeout consensus.TimeoutMsg) (timeouts map[hotstuff.ID]consensus.TimeoutMsg, ok bool) {
+	timeouts, ok = s.timeouts[timeout.View]
+	if !ok {
+		if max := s.mods.Options().MaxTimeoutViews(); max > 0 && len(s.timeouts) >= max {
+			// the views that are furthest ahead are the least likely to be legitimate,
+			// so they are dropped to make room for the earlier views.
+			highest := timeout.View
+			for view := range s.timeouts {
+				if view > highest {
+					highest = view
+				}
+			}
+			if highest == timeout.View {
+				return nil, false
+			}
+			for _, t := range s.timeouts[highest] {
+				s.rejectTimeout(t)
+			}
+			delete(s.timeouts, highest)
+		}
+		timeouts = make(map[hotstuff.ID]consensus.TimeoutMsg)
+		s.timeouts[timeout.View] = timeouts
+	}
+
+	if _, ok := timeouts[timeout.ID]; ok {
+		return timeouts, true
+	}
+	max := s.mods.Options().MaxTimeoutsPerView()
+	if quorum := s.mods.Configuration().QuorumSize(); max > 0 && max < quorum {
+		max = quorum
+	}
+	if max > 0 && len(timeouts) >= max {
+		return nil, false
+	}
+	timeouts[timeout.ID] = timeout
+	return timeouts, true
+}
+
+// rejectTimeout reports that the timeout message was dropped because the buffer was full.
+func (s *Synchronizer) rejectTimeout(timeout consensus.TimeoutMsg) {
+	s.mods.Logger().Debugf("Dropped timeout from replica %d for view %d: buffer full", timeout.ID, timeout.View)
+	s.mods.ReportRejection(consensus.MessageRejectedEvent{
+		Reason: consensus.RejectBufferFull,
+		Type:   "TimeoutMsg",
+		Sender: timeout.ID,
+	}, "")
+}
+
 // OnNewView handles an incoming consensus.NewViewMsg
 func (s *Synchronizer) OnNewView(newView consensus.NewViewMsg) {
 	s.AdvanceView(newView.SyncInfo)
@@ -271,7 +404,17 @@ func (s *Synchronizer) OnNewView(newView consensus.NewViewMsg) {
 func (s *Synchronizer) AdvanceView(syncInfo consensus.SyncInfo) {
 	var v consensus.View
 	timeout := false
-	if tc, ok := syncInfo.TC(); ok {
+	tc, hasTC := syncInfo.TC()
+	if hasTC && s.mods.Options().StrictTimeoutCertView() && tc.View() < s.currentView {
+		// a stale TC must not advance the view or update the high QC, but a TC for a later view lets us catch up.
+		s.mods.Logger().Infof("Timeout Certificate for view %d is older than the current view %d!", tc.View(), s.currentView)
+		hasTC = false
+	}
+	if hasTC {
+		if s.tooFarAhead(tc.View()) {
+			s.mods.Logger().Infof("Timeout Certificate for view %d is too far ahead of the current view!", tc.View())
+			return
+		}
 		if !s.mods.Crypto().VerifyTimeoutCert(tc) {
 			s.mods.Logger().Info("Timeout Certificate could not be verified!")
 			return
@@ -281,13 +424,28 @@ func (s *Synchronizer) AdvanceView(syncInfo consensus.SyncInfo) {
 			s.highTC = tc
 		}
 		timeout = true
+		// the TC includes the highest QC of the replicas that timed out,
+		// and the sender of a NewView includes its highQC with the TC, such that the leader can propose on it.
+		if qc, ok := tc.HighQC(); ok {
+			s.UpdateHighQC(qc)
+		}
+		if qc, ok := syncInfo.QC(); ok {
+			s.UpdateHighQC(qc)
+		}
 	} else if qc, ok := syncInfo.QC(); ok {
+		if s.tooFarAhead(qc.View()) {
+			s.mods.Logger().Infof("Quorum Certificate for view %d is too far ahead of the current view!", qc.View())
+			return
+		}
 		if !s.mods.Crypto().VerifyQuorumCert(qc) {
 			s.mods.Logger().Info("Quorum Certificate could not be verified!")
 			return
 		}
 		s.UpdateHighQC(qc)
 		v = qc.View()
+		if s.delayAdvance(v, syncInfo) {
+			return
+		}
 		s.duration.ViewSucceeded()
 	}
 
@@ -295,31 +453,91 @@ func (s *Synchronizer) AdvanceView(syncInfo consensus.SyncInfo) {
 		return
 	}
 
+	// the leader is not recomputed, as the leader rotation may choose a different leader for the view by now.
+	// The leader of a view that we did not take part in is unknown.
+	if timeout && v == s.currentView {
+		if observer, ok := s.mods.LeaderRotation().(consensus.TimeoutObserver); ok {
+			observer.ViewTimedOut(v, s.leader)
+		}
+	}
+
 	s.timer.Stop()
 
+	oldView := s.currentView
 	s.currentView = v + 1
 	s.lastTimeout = nil
 	s.duration.ViewStarted()
+	s.viewStart = s.clock.Now()
+
+	// apply the reconfigurations that take effect in the new view before its leader is chosen
+	if cfg, ok := s.mods.Configuration().(consensus.Reconfigurable); ok {
+		cfg.EnterView(s.currentView)
+	}
 
 	// cancel the old view context and set up the next one
 	s.newCtx()
 
 	s.timer.Reset(s.duration.Duration())
 
-	s.mods.MetricsEventLoop().AddEvent(ViewChangeEvent{View: s.currentView, Timeout: timeout})
+	s.mods.MetricsEventLoop().AddEvent(ViewChangeEvent{OldView: oldView, View: s.currentView, Timeout: timeout, Time: s.clock.Now()})
 
-	leader := s.mods.LeaderRotation().GetLeader(s.currentView)
+	s.leader = s.mods.LeaderRotation().GetLeader(s.currentView)
+	syncInfo = s.withHighQC(syncInfo)
 
-	if leader == s.mods.ID() {
+	if s.leader == s.mods.ID() {
 		s.mods.Consensus().Propose(syncInfo)
-	} else if replica, ok := s.mods.Configuration().Replica(leader); ok {
+	} else if replica, ok := s.mods.Configuration().Replica(s.leader); ok {
 		replica.NewView(syncInfo)
 	}
 }
 
+// delayAdvance returns true if advancing from the view with the QC must wait for the pacing delay.
+// In that case, the syncInfo is handled again once the pacing delay has passed since the view started.
+func (s *Synchronizer) delayAdvance(view consensus.View, syncInfo consensus.SyncInfo) bool {
+	if s.pacing <= 0 || view != s.currentView || s.viewStart.IsZero() {
+		return false
+	}
+	wait := s.pacing - s.clock.Now().Sub(s.viewStart)
+	if wait <= 0 {
+		return false
+	}
+	if s.paced == view {
+		// we are already waiting to advance from this view.
+		return true
+	}
+	s.paced = view
+	timer := s.clock.NewTimer(wait)
+	go func() {
+		<-timer.C()
+		s.mods.EventLoop().AddEvent(func() {
+			if s.currentView == view {
+				s.AdvanceView(syncInfo)
+			}
+		})
+	}()
+	return true
+}
+
+// withHighQC returns the syncInfo with the local highQC, unless the syncInfo already carries a QC that is at least as high.
+// This ensures that the leader of the next view learns of the highest certified block,
+// even if the view was advanced by a TC.
+func (s *Synchronizer) withHighQC(syncInfo consensus.SyncInfo) consensus.SyncInfo {
+	if qc, ok := syncInfo.QC(); ok && qc.View() >= s.highQC.View() {
+		return syncInfo
+	}
+	return syncInfo.WithQC(s.highQC)
+}
+
 // UpdateHighQC updates HighQC if the given qc is higher than the old HighQC.
+// If the QCs reference different blocks in the same view, which can only happen if a quorum of replicas
+// is Byzantine, the QC for the block with the lexicographically smallest hash is chosen.
+// This ensures that honest replicas that know of the same QCs choose the same HighQC.
 func (s *Synchronizer) UpdateHighQC(qc consensus.QuorumCert) {
 	s.mods.Logger().Debugf("updateHighQC: %v", qc)
+	if s.tooFarAhead(qc.View()) {
+		s.mods.Logger().Info("updateHighQC: QC is too far ahead of the current view!")
+		return
+	}
 	if !s.mods.Crypto().VerifyQuorumCert(qc) {
 		s.mods.Logger().Info("updateHighQC: QC could not be verified!")
 		return
@@ -336,13 +554,26 @@ func (s *Synchronizer) UpdateHighQC(qc consensus.QuorumCert) {
 		s.mods.Logger().Panic("Block from the old highQC missing from chain")
 	}
 
-	if newBlock.View() > oldBlock.View() {
+	if newBlock.View() > oldBlock.View() || (newBlock.View() == oldBlock.View() && forkChoice(newBlock, oldBlock)) {
 		s.mods.Logger().Debug("HighQC updated")
 		s.highQC = qc
 		s.leafBlock = newBlock
 	}
 }
 
+// forkChoice returns true if block a should be preferred over block b, where both blocks are from the same view.
+func forkChoice(a, b *consensus.Block) bool {
+	ha, hb := a.Hash(), b.Hash()
+	return bytes.Compare(ha[:], hb[:]) < 0
+}
+
+// tooFarAhead returns true if the view is further ahead of the current view than the MaxFutureView option allows.
+// Such certificates are unlikely to be legitimate, so they are rejected before spending time on verification.
+func (s *Synchronizer) tooFarAhead(view consensus.View) bool {
+	bound := s.mods.Options().MaxFutureView()
+	return bound > 0 && view > s.currentView+bound
+}
+
 func (s *Synchronizer) newCtx() {
 	s.cancelCtx()
 	s.viewCtx, s.cancelCtx = context.WithTimeout(context.Background(), s.duration.Duration())
@@ -352,6 +583,8 @@ var _ consensus.Synchronizer = (*Synchronizer)(nil)
 
 // ViewChangeEvent is sent on the metrics event loop whenever a view change occurs.
 type ViewChangeEvent struct {
-	View    consensus.View
-	Timeout bool
+	OldView consensus.View // The view that was left.
+	View    consensus.View // The view that was entered.
+	Timeout bool           // Whether the view was left because of a timeout.
+	Time    time.Time      // The time of the view change.
 }
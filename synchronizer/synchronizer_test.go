@@ -3,9 +3,12 @@ package synchronizer_test
 import (
 	"bytes"
 	"context"
+	"math"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/relab/hotstuff"
 	"github.com/relab/hotstuff/consensus"
 	"github.com/relab/hotstuff/internal/mocks"
 	"github.com/relab/hotstuff/internal/testutil"
@@ -39,7 +42,7 @@ func TestLocalTimeout(t *testing.T) {
 			if msgQC, ok := msg.SyncInfo.QC(); ok && !bytes.Equal(msgQC.ToBytes(), qc.ToBytes()) {
 				t.Errorf("wrong QC. got: %v, want: %v", msgQC, qc)
 			}
-			if !mods.Crypto().Verify(msg.ViewSignature, msg.View.ToHash()) {
+			if !mods.Crypto().Verify(msg.ViewSignature, msg.Hash()) {
 				t.Error("failed to verify signature")
 			}
 			close(c)
@@ -106,6 +109,405 @@ func TestAdvanceViewTC(t *testing.T) {
 	}
 }
 
+func TestAdvanceViewStrictTC(t *testing.T) {
+	const n = 4
+	ctrl := gomock.NewController(t)
+	builders := testutil.CreateBuilders(t, ctrl, n)
+	builders[0].Options().SetStrictTimeoutCertView()
+	s := New(testutil.FixedTimeout(1000))
+	hs := mocks.NewMockConsensus(ctrl)
+	builders[0].Register(s, hs)
+
+	hl := builders.Build()
+	signers := hl.Signers()
+
+	// the TC for the current view advances the view
+	hs.EXPECT().Propose(gomock.AssignableToTypeOf(consensus.NewSyncInfo()))
+	s.AdvanceView(consensus.NewSyncInfo().WithTC(testutil.CreateTC(t, 1, signers)))
+	if s.View() != 2 {
+		t.Fatalf("wrong view: expected: %v, got: %v", 2, s.View())
+	}
+
+	// a stale TC does not
+	s.AdvanceView(consensus.NewSyncInfo().WithTC(testutil.CreateTC(t, 1, signers)))
+	if s.View() != 2 {
+		t.Errorf("wrong view after stale TC: expected: %v, got: %v", 2, s.View())
+	}
+
+	// a TC for a later view lets us catch up
+	hs.EXPECT().Propose(gomock.AssignableToTypeOf(consensus.NewSyncInfo()))
+	s.AdvanceView(consensus.NewSyncInfo().WithTC(testutil.CreateTC(t, 5, signers)))
+	if s.View() != 6 {
+		t.Errorf("wrong view after TC for a later view: expected: %v, got: %v", 6, s.View())
+	}
+}
+
+// timeoutRecorder is a leader rotation that records the views that timed out, and their leaders.
+type timeoutRecorder struct {
+	leader   hotstuff.ID
+	timeouts map[consensus.View]hotstuff.ID
+}
+
+func (r *timeoutRecorder) GetLeader(consensus.View) hotstuff.ID { return r.leader }
+
+func (r *timeoutRecorder) ViewTimedOut(view consensus.View, leader hotstuff.ID) {
+	r.timeouts[view] = leader
+}
+
+// TestAdvanceViewTCObserver checks that the leader rotation is told about a timed out view
+// with the leader that was chosen when the view started, even if the rotation has changed its mind since.
+func TestAdvanceViewTCObserver(t *testing.T) {
+	const n = 4
+	ctrl := gomock.NewController(t)
+	builders := testutil.CreateBuilders(t, ctrl, n)
+	s := New(testutil.FixedTimeout(1000))
+	hs := mocks.NewMockConsensus(ctrl)
+	rotation := &timeoutRecorder{leader: 1, timeouts: make(map[consensus.View]hotstuff.ID)}
+	builders[0].Register(s, hs, rotation)
+
+	hl := builders.Build()
+	signers := hl.Signers()
+
+	hs.EXPECT().Propose(gomock.AssignableToTypeOf(consensus.NewSyncInfo()))
+	s.AdvanceView(consensus.NewSyncInfo().WithTC(testutil.CreateTC(t, 1, signers)))
+
+	rotation.leader = 3
+	replica, _ := hl[0].Configuration().Replica(3)
+	replica.(*mocks.MockReplica).EXPECT().NewView(gomock.Any()).Times(2)
+	s.AdvanceView(consensus.NewSyncInfo().WithTC(testutil.CreateTC(t, 2, signers)))
+	if leader, ok := rotation.timeouts[2]; !ok || leader != 1 {
+		t.Errorf("wrong leader of the timed out view: got: %d, want: 1", leader)
+	}
+
+	// we did not take part in view 5, so its leader is unknown.
+	s.AdvanceView(consensus.NewSyncInfo().WithTC(testutil.CreateTC(t, 5, signers)))
+	if s.View() != 6 {
+		t.Fatalf("wrong view: expected: %v, got: %v", 6, s.View())
+	}
+	if leader, ok := rotation.timeouts[5]; ok {
+		t.Errorf("the rotation was told that replica %d led view 5", leader)
+	}
+}
+
+func TestAdvanceViewFutureQC(t *testing.T) {
+	const n = 4
+	ctrl := gomock.NewController(t)
+	builders := testutil.CreateBuilders(t, ctrl, n)
+	builders[0].Options().SetMaxFutureView(10)
+	s := New(testutil.FixedTimeout(1000))
+	hs := mocks.NewMockConsensus(ctrl)
+	builders[0].Register(s, hs)
+
+	hl := builders.Build()
+	signers := hl.Signers()
+
+	createQC := func(view consensus.View) consensus.QuorumCert {
+		block := consensus.NewBlock(
+			consensus.GetGenesis().Hash(),
+			consensus.NewQuorumCert(nil, 0, consensus.GetGenesis().Hash()),
+			"foo",
+			view,
+			2,
+		)
+		hl[0].BlockChain().Store(block)
+		return testutil.CreateQC(t, block, signers)
+	}
+
+	// a QC that is absurdly far ahead should be rejected
+	s.AdvanceView(consensus.NewSyncInfo().WithQC(createQC(1000000)))
+	if s.View() != 1 {
+		t.Errorf("wrong view after QC beyond the bound: expected: %v, got: %v", 1, s.View())
+	}
+
+	// a QC within the bound should let us catch up
+	hs.EXPECT().Propose(gomock.AssignableToTypeOf(consensus.NewSyncInfo()))
+	s.AdvanceView(consensus.NewSyncInfo().WithQC(createQC(5)))
+	if s.View() != 6 {
+		t.Errorf("wrong view after QC within the bound: expected: %v, got: %v", 6, s.View())
+	}
+}
+
+func TestInstallQCFromVotesThreeChain(t *testing.T) {
+	const n = 4
+	ctrl := gomock.NewController(t)
+	builders := testutil.CreateBuilders(t, ctrl, n)
+	s := New(testutil.FixedTimeout(1000))
+	hs := mocks.NewMockConsensus(ctrl)
+	builders[0].Register(s, hs)
+
+	hl := builders.Build()
+	signers := hl.Signers()
+
+	hs.EXPECT().Propose(gomock.AssignableToTypeOf(consensus.NewSyncInfo())).Times(3)
+
+	parent := consensus.GetGenesis()
+	qc := s.HighQC()
+	var chain []*consensus.Block
+	for view := consensus.View(1); view <= 3; view++ {
+		block := consensus.NewBlock(parent.Hash(), qc, "foo", view, 1)
+		var err error
+		qc, err = testutil.InstallQCFromVotes(hl[0], block, testutil.CreatePCs(t, block, signers))
+		if err != nil {
+			t.Fatal(err)
+		}
+		chain = append(chain, block)
+		parent = block
+	}
+
+	if s.View() != 4 {
+		t.Errorf("wrong view: expected: %v, got: %v", 4, s.View())
+	}
+	if s.HighQC().BlockHash() != chain[2].Hash() {
+		t.Error("highQC does not reference the last block of the chain")
+	}
+	if s.LeafBlock() != chain[2] {
+		t.Error("leaf block is not the last block of the chain")
+	}
+	for i := 1; i < len(chain); i++ {
+		if chain[i].QuorumCert().BlockHash() != chain[i-1].Hash() {
+			t.Errorf("block %d does not certify its parent", i+1)
+		}
+	}
+}
+
+// TestUpdateHighQCForkChoice checks that replicas choose the same HighQC among QCs from the same view,
+// regardless of the order in which they learn of them.
+func TestUpdateHighQCForkChoice(t *testing.T) {
+	const n = 4
+	ctrl := gomock.NewController(t)
+	builders := testutil.CreateBuilders(t, ctrl, n)
+	syncs := []consensus.Synchronizer{New(testutil.FixedTimeout(1000)), New(testutil.FixedTimeout(1000))}
+	builders[0].Register(syncs[0])
+	builders[1].Register(syncs[1])
+	hl := builders.Build()
+	signers := hl.Signers()
+
+	genesisQC := consensus.NewQuorumCert(nil, 0, consensus.GetGenesis().Hash())
+	a := consensus.NewBlock(consensus.GetGenesis().Hash(), genesisQC, "foo", 1, 1)
+	b := consensus.NewBlock(consensus.GetGenesis().Hash(), genesisQC, "bar", 1, 1)
+	want := a
+	if ha, hb := a.Hash(), b.Hash(); bytes.Compare(hb[:], ha[:]) < 0 {
+		want = b
+	}
+	for _, hs := range hl[:2] {
+		hs.BlockChain().Store(a)
+		hs.BlockChain().Store(b)
+	}
+	qcA := testutil.CreateQC(t, a, signers)
+	qcB := testutil.CreateQC(t, b, signers)
+
+	syncs[0].UpdateHighQC(qcA)
+	syncs[0].UpdateHighQC(qcB)
+	syncs[1].UpdateHighQC(qcB)
+	syncs[1].UpdateHighQC(qcA)
+
+	for i, s := range syncs {
+		if s.HighQC().BlockHash() != want.Hash() {
+			t.Errorf("replica %d chose the wrong highQC", i+1)
+		}
+		if s.LeafBlock() != want {
+			t.Errorf("replica %d chose the wrong leaf block", i+1)
+		}
+	}
+}
+
+// TestRelayQC checks that a replica that is only connected to the leader learns new QCs from the leader.
+func TestRelayQC(t *testing.T) {
+	const n = 4
+	ctrl := gomock.NewController(t)
+	builders := testutil.CreateBuilders(t, ctrl, n)
+	builders[0].Options().SetShouldRelayQC()
+	leaderSync := mocks.NewMockSynchronizer(ctrl)
+	leaderSync.EXPECT().LeafBlock().AnyTimes().Return(consensus.GetGenesis())
+	builders[0].Register(leaderSync)
+	// the last replica does not receive the votes, and does not take part in the next proposal
+	s := New(testutil.FixedTimeout(1000))
+	builders[3].Register(s)
+
+	hl := builders.Build()
+	leader, laggard := hl[0], hl[3]
+
+	relayed := make(chan consensus.SyncInfo, 1)
+	for id, replica := range leader.Configuration().Replicas() {
+		mock := replica.(*mocks.MockReplica)
+		if id == laggard.ID() {
+			mock.EXPECT().NewView(gomock.Any()).Do(func(syncInfo consensus.SyncInfo) {
+				relayed <- syncInfo
+			})
+			continue
+		}
+		mock.EXPECT().NewView(gomock.Any()).AnyTimes()
+	}
+
+	block := consensus.NewBlock(
+		consensus.GetGenesis().Hash(),
+		consensus.NewQuorumCert(nil, 0, consensus.GetGenesis().Hash()),
+		"foo",
+		1,
+		1,
+	)
+	leader.BlockChain().Store(block)
+	laggard.BlockChain().Store(block)
+
+	for i, signer := range hl.Signers()[:3] {
+		pc := testutil.CreatePC(t, block, signer)
+		leader.EventLoop().AddEvent(consensus.VoteMsg{ID: hl[i].ID(), PartialCert: pc})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	go leader.EventLoop().Run(ctx)
+
+	var syncInfo consensus.SyncInfo
+	select {
+	case syncInfo = <-relayed:
+	case <-ctx.Done():
+		t.Fatal("the leader did not relay the QC")
+	}
+
+	laggard.EventLoop().AddEvent(consensus.NewViewMsg{ID: leader.ID(), SyncInfo: syncInfo})
+	drained, stop := context.WithCancel(context.Background())
+	stop()
+	laggard.EventLoop().Run(drained)
+
+	if s.View() != 2 {
+		t.Errorf("wrong view: expected: %v, got: %v", 2, s.View())
+	}
+	if s.HighQC().BlockHash() != block.Hash() {
+		t.Error("highQC does not reference the certified block")
+	}
+}
+
+func TestAdvanceViewSendsHighQC(t *testing.T) {
+	const n = 4
+	ctrl := gomock.NewController(t)
+	builders := testutil.CreateBuilders(t, ctrl, n)
+	leaderSync := New(testutil.FixedTimeout(1000))
+	leaderCS := mocks.NewMockConsensus(ctrl)
+	builders[0].Register(leaderSync, leaderCS)
+	s := New(testutil.FixedTimeout(1000))
+	builders[1].Register(s)
+
+	hl := builders.Build()
+	leader, replica := hl[0], hl[1]
+	signers := hl.Signers()
+
+	block := consensus.NewBlock(
+		consensus.GetGenesis().Hash(),
+		consensus.NewQuorumCert(nil, 0, consensus.GetGenesis().Hash()),
+		"foo",
+		1,
+		1,
+	)
+	leader.BlockChain().Store(block)
+	replica.BlockChain().Store(block)
+	qc := testutil.CreateQC(t, block, signers)
+	s.UpdateHighQC(qc)
+
+	var sent consensus.SyncInfo
+	leaderReplica, _ := replica.Configuration().Replica(leader.ID())
+	leaderReplica.(*mocks.MockReplica).EXPECT().NewView(gomock.Any()).Do(func(syncInfo consensus.SyncInfo) {
+		sent = syncInfo
+	})
+
+	// the view is advanced by a TC that does not carry the replica's highQC.
+	tc := testutil.CreateTC(t, 1, signers)
+	s.AdvanceView(consensus.NewSyncInfo().WithTC(tc))
+
+	if s.View() != 2 {
+		t.Fatalf("wrong view: expected: %v, got: %v", 2, s.View())
+	}
+	if sentTC, ok := sent.TC(); !ok || sentTC.View() != tc.View() {
+		t.Error("the NewView message does not carry the TC")
+	}
+	if sentQC, ok := sent.QC(); !ok || sentQC.BlockHash() != block.Hash() {
+		t.Error("the NewView message does not carry the highQC")
+	}
+
+	// the leader learns of the highQC from the NewView message, and proposes on it.
+	leaderCS.EXPECT().Propose(gomock.Any()).Do(func(syncInfo consensus.SyncInfo) {
+		if proposeQC, ok := syncInfo.QC(); !ok || proposeQC.BlockHash() != block.Hash() {
+			t.Error("the leader did not propose with the highQC")
+		}
+	})
+	leaderSync.AdvanceView(sent)
+	if leaderSync.HighQC().BlockHash() != block.Hash() {
+		t.Error("the leader did not update its highQC")
+	}
+}
+
+// TestAdvanceViewTCHighQC checks that a TC includes the highest QC of the timeout messages,
+// and that the replicas that advance the view using the TC adopt that QC.
+func TestAdvanceViewTCHighQC(t *testing.T) {
+	const n = 4
+	ctrl := gomock.NewController(t)
+	builders := testutil.CreateBuilders(t, ctrl, n)
+	s := New(testutil.FixedTimeout(1000))
+	hs := mocks.NewMockConsensus(ctrl)
+	builders[0].Register(s, hs)
+
+	hl := builders.Build()
+	signers := hl.Signers()
+
+	// a chain of certified blocks in views 1 to 3
+	var qcs []consensus.QuorumCert
+	parent := consensus.GetGenesis()
+	for view := consensus.View(1); view <= 3; view++ {
+		block := consensus.NewBlock(parent.Hash(), consensus.NewQuorumCert(nil, 0, parent.Hash()), "foo", view, 1)
+		hl[0].BlockChain().Store(block)
+		qcs = append(qcs, testutil.CreateQC(t, block, signers))
+		parent = block
+	}
+
+	// the replicas time out in view 4 with highQCs of different heights
+	const view = 4
+	highQCs := []consensus.QuorumCert{qcs[0], qcs[2], qcs[1]}
+	timeouts := make([]consensus.TimeoutMsg, 0, len(highQCs))
+	for i, qc := range highQCs {
+		timeout := consensus.TimeoutMsg{
+			ID:       hl[i].ID(),
+			View:     view,
+			SyncInfo: consensus.NewSyncInfo().WithQC(qc),
+		}
+		timeout.ViewSignature = testutil.Sign(t, timeout.Hash(), signers[i])
+		timeouts = append(timeouts, timeout)
+	}
+	tc, err := hl[0].Crypto().CreateTimeoutCert(view, timeouts)
+	if err != nil {
+		t.Fatalf("Failed to create TC: %v", err)
+	}
+	if highQC, ok := tc.HighQC(); !ok || highQC.BlockHash() != qcs[2].BlockHash() {
+		t.Fatalf("the TC does not include the highest QC")
+	}
+	if !hl[0].Crypto().VerifyTimeoutCert(tc) {
+		t.Fatal("failed to verify the TC")
+	}
+	forged := consensus.NewQuorumCert(qcs[0].Signature(), 3, qcs[2].BlockHash())
+	if hl[0].Crypto().VerifyTimeoutCert(tc.WithHighQC(forged)) {
+		t.Error("verified a TC with an invalid highQC")
+	}
+	if hl[0].Crypto().VerifyTimeoutCert(tc.WithHighQC(qcs[1])) {
+		t.Error("verified a TC with a valid highQC that is lower than the highest QC of the timeouts")
+	}
+	lowered := make(map[hotstuff.ID]consensus.HighQCRef)
+	for id := range tc.HighQCRefs() {
+		lowered[id] = consensus.HighQCRef{View: qcs[1].View(), Hash: qcs[1].BlockHash()}
+	}
+	if hl[0].Crypto().VerifyTimeoutCert(tc.WithHighQCRefs(lowered).WithHighQC(qcs[1])) {
+		t.Error("verified a TC whose QC references were not signed by the timeouts")
+	}
+
+	hs.EXPECT().Propose(gomock.AssignableToTypeOf(consensus.NewSyncInfo()))
+	s.AdvanceView(consensus.NewSyncInfo().WithTC(tc))
+
+	if s.View() != view+1 {
+		t.Errorf("wrong view: expected: %v, got: %v", view+1, s.View())
+	}
+	if s.HighQC().BlockHash() != qcs[2].BlockHash() {
+		t.Error("the highest QC from the TC was not adopted")
+	}
+}
+
 // func TestRemoteTimeout(t *testing.T) {
 // 	const n = 4
 // 	ctrl := gomock.NewController(t)
@@ -130,3 +532,164 @@ func TestAdvanceViewTC(t *testing.T) {
 // 		t.Errorf("wrong view: expected: %v, got: %v", 2, s.View())
 // 	}
 // }
+
+func TestTimeoutBufferBounds(t *testing.T) {
+	const (
+		n         = 4
+		maxViews  = 4
+		bogusView = consensus.View(100)
+		numBogus  = 20
+	)
+	ctrl := gomock.NewController(t)
+	builders := testutil.CreateBuilders(t, ctrl, n)
+	builders[0].Options().SetMaxTimeoutViews(maxViews)
+	// smaller than the quorum size, so it must be raised to allow a TC to form.
+	builders[0].Options().SetMaxTimeoutsPerView(1)
+	s := New(testutil.FixedTimeout(1000))
+	hs := mocks.NewMockConsensus(ctrl)
+	builders[0].Register(s, hs)
+
+	hl := builders.Build()
+	signers := hl.Signers()
+
+	var rejected int
+	hl[0].MetricsEventLoop().RegisterHandler(consensus.MessageRejectedEvent{}, func(event interface{}) {
+		if e := event.(consensus.MessageRejectedEvent); e.Reason == consensus.RejectBufferFull && e.Type == "TimeoutMsg" {
+			rejected++
+		}
+	})
+
+	// a faulty replica floods timeouts for views far ahead of the current view.
+	for view := bogusView; view < bogusView+numBogus; view++ {
+		hl[0].EventLoop().AddEvent(testutil.CreateTimeouts(t, view, signers[3:])[0])
+	}
+
+	// synchronizer should tell hotstuff to propose
+	hs.EXPECT().Propose(gomock.AssignableToTypeOf(consensus.NewSyncInfo()))
+
+	for _, timeout := range testutil.CreateTimeouts(t, 1, signers[1:]) {
+		hl[0].EventLoop().AddEvent(timeout)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	hl[0].EventLoop().Run(ctx)
+	hl[0].MetricsEventLoop().Run(ctx)
+
+	if s.View() != 2 {
+		t.Errorf("wrong view: expected: %v, got: %v", 2, s.View())
+	}
+	// all but the first bogus views are dropped, and then the highest buffered view makes room for the current view.
+	if want := numBogus - maxViews + 1; rejected != want {
+		t.Errorf("wrong number of dropped timeouts: got: %d, want: %d", rejected, want)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	const base = 100 * time.Millisecond
+	d := NewBackoff(base, 2, 8)
+
+	want := []time.Duration{base, 2 * base, 4 * base, 8 * base, 8 * base}
+	for i, w := range want {
+		if i > 0 {
+			d.ViewTimeout()
+		}
+		if got := d.Duration(); got != w {
+			t.Errorf("wrong duration after %d timeouts: got %v, want %v", i, got, w)
+		}
+	}
+
+	d.ViewStarted()
+	d.ViewSucceeded()
+	if got := d.Duration(); got != base {
+		t.Errorf("wrong duration after a successful view: got %v, want %v", got, base)
+	}
+	d.ViewTimeout()
+	if got := d.Duration(); got != 2*base {
+		t.Errorf("wrong duration after a timeout following a successful view: got %v, want %v", got, 2*base)
+	}
+}
+
+func TestBackoffNoCap(t *testing.T) {
+	const base = 100 * time.Millisecond
+	d := NewBackoff(base, 2, 0)
+	for i := 0; i < 10; i++ {
+		d.ViewTimeout()
+	}
+	if got, want := d.Duration(), base<<10; got != want {
+		t.Errorf("wrong duration after 10 timeouts: got %v, want %v", got, want)
+	}
+	// the duration would overflow after about 36 timeouts.
+	for i := 0; i < 100; i++ {
+		d.ViewTimeout()
+	}
+	if got, want := d.Duration(), time.Duration(math.MaxInt64); got != want {
+		t.Errorf("wrong duration after 110 timeouts: got %v, want %v", got, want)
+	}
+}
+
+// TestPacing checks that a QC for the current view advances the view immediately by default,
+// and only after the pacing delay when optimistic responsiveness is disabled.
+func TestPacing(t *testing.T) {
+	const pacing = 100 * time.Millisecond
+
+	// advanceTime returns the time that passes on the clock from handling the QC for view 2
+	// until the synchronizer proposes in view 3.
+	advanceTime := func(opts ...Option) time.Duration {
+		const n = 4
+		ctrl := gomock.NewController(t)
+		builders := testutil.CreateBuilders(t, ctrl, n)
+		clock := newMockClock()
+		s := New(testutil.FixedTimeout(1000), append(opts, WithClock(clock))...)
+		hs := mocks.NewMockConsensus(ctrl)
+		builders[0].Register(s, hs)
+		hl := builders.Build()
+		signers := hl.Signers()
+
+		genesisQC := consensus.NewQuorumCert(nil, 0, consensus.GetGenesis().Hash())
+		b1 := consensus.NewBlock(consensus.GetGenesis().Hash(), genesisQC, "foo", 1, 1)
+		hl[0].BlockChain().Store(b1)
+		qc1 := testutil.CreateQC(t, b1, signers)
+		b2 := consensus.NewBlock(b1.Hash(), qc1, "bar", 2, 1)
+		hl[0].BlockChain().Store(b2)
+		qc2 := testutil.CreateQC(t, b2, signers)
+
+		// the view has not been started by the timer, so the first QC advances the view immediately.
+		hs.EXPECT().Propose(gomock.Any())
+		s.AdvanceView(consensus.NewSyncInfo().WithQC(qc1))
+		if s.View() != 2 {
+			t.Fatalf("wrong view: expected: %v, got: %v", 2, s.View())
+		}
+
+		proposed := make(chan time.Time, 1)
+		hs.EXPECT().Propose(gomock.Any()).Do(func(_ consensus.SyncInfo) { proposed <- clock.Now() })
+		start := clock.Now()
+		s.AdvanceView(consensus.NewSyncInfo().WithQC(qc2))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go hl[0].EventLoop().Run(ctx)
+		// the pacing timer cannot fire before the pacing delay has passed on the clock.
+		clock.Advance(pacing - time.Millisecond)
+		select {
+		case end := <-proposed:
+			return end.Sub(start)
+		default:
+		}
+		clock.Advance(time.Millisecond)
+		select {
+		case end := <-proposed:
+			return end.Sub(start)
+		case <-time.After(time.Second):
+			t.Fatal("the view was not advanced")
+			return 0
+		}
+	}
+
+	if d := advanceTime(); d != 0 {
+		t.Errorf("optimistically responsive view took %v, want %v", d, 0)
+	}
+	if d := advanceTime(WithPacing(pacing)); d != pacing {
+		t.Errorf("paced view took %v, want %v", d, pacing)
+	}
+}